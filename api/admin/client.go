@@ -28,6 +28,7 @@ type Client interface {
 	SetLoggerLevel(ctx context.Context, loggerName, logLevel, displayLevel string, options ...rpc.Option) error
 	GetLoggerLevel(ctx context.Context, loggerName string, options ...rpc.Option) (map[string]LogAndDisplayLevels, error)
 	GetConfig(ctx context.Context, options ...rpc.Option) (interface{}, error)
+	CreateDBCheckpoint(ctx context.Context, path string, options ...rpc.Option) (string, error)
 }
 
 // Client implementation for the Avalanche Platform Info API Endpoint
@@ -138,3 +139,11 @@ func (c *client) GetConfig(ctx context.Context, options ...rpc.Option) (interfac
 	err := c.requester.SendRequest(ctx, "admin.getConfig", struct{}{}, &res, options...)
 	return res, err
 }
+
+func (c *client) CreateDBCheckpoint(ctx context.Context, path string, options ...rpc.Option) (string, error) {
+	res := &CreateDBCheckpointReply{}
+	err := c.requester.SendRequest(ctx, "admin.createDBCheckpoint", &CreateDBCheckpointArgs{
+		Path: path,
+	}, res, options...)
+	return res.Path, err
+}
@@ -66,6 +66,9 @@ func (mc *mockClient) SendRequest(_ context.Context, _ string, _ interface{}, re
 	case *GetLoggerLevelReply:
 		response := mc.response.(*GetLoggerLevelReply)
 		*p = *response
+	case *CreateDBCheckpointReply:
+		response := mc.response.(*CreateDBCheckpointReply)
+		*p = *response
 	case *interface{}:
 		response := mc.response.(*interface{})
 		*p = *response
@@ -353,3 +356,24 @@ func TestGetConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateDBCheckpoint(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		require := require.New(t)
+
+		expectedPath := "checkpoint/v1.0.0"
+		mockClient := client{requester: NewMockClient(&CreateDBCheckpointReply{
+			Path: expectedPath,
+		}, nil)}
+
+		path, err := mockClient.CreateDBCheckpoint(context.Background(), "checkpoint")
+		require.NoError(err)
+		require.Equal(expectedPath, path)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&CreateDBCheckpointReply{}, errTest)}
+		_, err := mockClient.CreateDBCheckpoint(context.Background(), "checkpoint")
+		require.ErrorIs(t, err, errTest)
+	})
+}
@@ -5,8 +5,13 @@ package admin
 
 import (
 	"errors"
+	"fmt"
+	"io/fs"
 	"net/http"
+	"os"
 	"path"
+	"path/filepath"
+	"time"
 
 	"github.com/gorilla/rpc/v2"
 
@@ -15,6 +20,8 @@ import (
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/api/server"
 	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/manager"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/utils"
@@ -23,6 +30,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/perms"
 	"github.com/ava-labs/avalanchego/utils/profiler"
+	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/vms"
 	"github.com/ava-labs/avalanchego/vms/registry"
 )
@@ -32,11 +40,19 @@ const (
 
 	// Name of file that stacktraces are written to
 	stacktraceFile = "stacktrace.txt"
+
+	// checkpointBatchSize is the number of bytes that are buffered before
+	// being written to a database checkpoint.
+	checkpointBatchSize = units.MiB
 )
 
 var (
 	errAliasTooLong = errors.New("alias length is too long")
 	errNoLogLevel   = errors.New("need to specify either displayLevel or logLevel")
+
+	errNoCheckpointPath   = errors.New("need to specify a checkpoint path")
+	errCheckpointExists   = errors.New("checkpoint already exists")
+	errNoCheckpointSource = errors.New("database checkpoints are not supported by this node")
 )
 
 type Config struct {
@@ -48,6 +64,10 @@ type Config struct {
 	HTTPServer   server.PathAdderWithReadLock
 	VMRegistry   registry.VMRegistry
 	VMManager    vms.Manager
+	DBManager    manager.Manager
+	// NewCheckpointDB creates the database that a checkpoint of the current
+	// database is written into.
+	NewCheckpointDB func(path string) (database.Database, error)
 }
 
 // Admin is the API service for node admin management
@@ -345,3 +365,103 @@ func (a *Admin) LoadVMs(r *http.Request, _ *struct{}, reply *LoadVMsReply) error
 	reply.NewVMs, err = ids.GetRelevantAliases(a.VMManager, loadedVMs)
 	return err
 }
+
+// CreateDBCheckpointArgs are the arguments for calling CreateDBCheckpoint
+type CreateDBCheckpointArgs struct {
+	// Path is the directory that the checkpoint is written into. The
+	// checkpoint is placed in a sub-directory named after the database
+	// version, so [Path] can be used as the database directory of a node.
+	Path string `json:"path"`
+}
+
+// CreateDBCheckpointReply is the response from calling CreateDBCheckpoint
+type CreateDBCheckpointReply struct {
+	// Path is the directory that contains the checkpoint.
+	Path string `json:"path"`
+}
+
+// CreateDBCheckpoint writes a consistent, point-in-time copy of the current
+// database into a new database while the node keeps running.
+func (a *Admin) CreateDBCheckpoint(_ *http.Request, args *CreateDBCheckpointArgs, reply *CreateDBCheckpointReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "createDBCheckpoint"),
+		logging.UserString("path", args.Path),
+	)
+
+	if len(args.Path) == 0 {
+		return errNoCheckpointPath
+	}
+	if a.DBManager == nil || a.NewCheckpointDB == nil {
+		return errNoCheckpointSource
+	}
+
+	current := a.DBManager.Current()
+	checkpointPath := filepath.Join(args.Path, current.Version.String())
+	switch _, err := os.Stat(checkpointPath); {
+	case err == nil:
+		return fmt.Errorf("%w at %s", errCheckpointExists, checkpointPath)
+	case !errors.Is(err, fs.ErrNotExist):
+		return err
+	}
+
+	startTime := time.Now()
+	if err := a.createDBCheckpoint(current.Database, checkpointPath); err != nil {
+		// Drop any removal error to report the original error
+		_ = os.RemoveAll(checkpointPath)
+		return err
+	}
+
+	a.Log.Info("created database checkpoint",
+		zap.String("path", checkpointPath),
+		zap.Duration("duration", time.Since(startTime)),
+	)
+	reply.Path = checkpointPath
+	return nil
+}
+
+func (a *Admin) createDBCheckpoint(db database.Database, checkpointPath string) error {
+	checkpointDB, err := a.NewCheckpointDB(checkpointPath)
+	if err != nil {
+		return err
+	}
+
+	w := &batchWriter{
+		batch:     checkpointDB.NewBatch(),
+		writeSize: checkpointBatchSize,
+	}
+	if err := database.WriteSnapshot(db, w); err != nil {
+		_ = checkpointDB.Close()
+		return err
+	}
+	if err := w.batch.Write(); err != nil {
+		_ = checkpointDB.Close()
+		return err
+	}
+	return checkpointDB.Close()
+}
+
+// batchWriter writes key-value pairs into [batch], writing [batch] whenever it
+// reaches [writeSize].
+type batchWriter struct {
+	batch     database.Batch
+	writeSize int
+}
+
+func (w *batchWriter) Put(key, value []byte) error {
+	if err := w.batch.Put(key, value); err != nil {
+		return err
+	}
+	return w.maybeWrite()
+}
+
+func (w *batchWriter) maybeWrite() error {
+	if w.batch.Size() < w.writeSize {
+		return nil
+	}
+	if err := w.batch.Write(); err != nil {
+		return err
+	}
+	w.batch.Reset()
+	return nil
+}
@@ -5,14 +5,21 @@ package admin
 
 import (
 	"net/http"
+	"path/filepath"
 	"testing"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/stretchr/testify/require"
 
 	"go.uber.org/mock/gomock"
 
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/leveldb"
+	"github.com/ava-labs/avalanchego/database/manager"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms"
 	"github.com/ava-labs/avalanchego/vms/registry"
 )
@@ -117,3 +124,55 @@ func TestLoadVMsGetAliasesFails(t *testing.T) {
 	err := resources.admin.LoadVMs(&http.Request{}, nil, &reply)
 	require.ErrorIs(err, errTest)
 }
+
+func TestCreateDBCheckpointSuccess(t *testing.T) {
+	require := require.New(t)
+
+	dbManager := manager.NewMemDB(version.Semantic1_0_0)
+	currentDB := dbManager.Current().Database
+
+	key := []byte("hello")
+	value := []byte("world")
+	require.NoError(currentDB.Put(key, value))
+
+	newDB := func(path string) (database.Database, error) {
+		return leveldb.New(path, nil, logging.NoLog{}, "", prometheus.NewRegistry())
+	}
+	admin := &Admin{Config: Config{
+		Log:             logging.NoLog{},
+		DBManager:       dbManager,
+		NewCheckpointDB: newDB,
+	}}
+
+	dir := t.TempDir()
+	args := &CreateDBCheckpointArgs{
+		Path: dir,
+	}
+	reply := CreateDBCheckpointReply{}
+	require.NoError(admin.CreateDBCheckpoint(&http.Request{}, args, &reply))
+	require.Equal(filepath.Join(dir, version.Semantic1_0_0.String()), reply.Path)
+
+	// Writing a second checkpoint to the same path must not overwrite the
+	// first checkpoint.
+	err := admin.CreateDBCheckpoint(&http.Request{}, args, &CreateDBCheckpointReply{})
+	require.ErrorIs(err, errCheckpointExists)
+
+	checkpointDB, err := newDB(reply.Path)
+	require.NoError(err)
+
+	got, err := checkpointDB.Get(key)
+	require.NoError(err)
+	require.Equal(value, got)
+	require.NoError(checkpointDB.Close())
+}
+
+func TestCreateDBCheckpointNoPath(t *testing.T) {
+	admin := &Admin{Config: Config{
+		Log:       logging.NoLog{},
+		DBManager: manager.NewMemDB(version.Semantic1_0_0),
+	}}
+
+	reply := CreateDBCheckpointReply{}
+	err := admin.CreateDBCheckpoint(&http.Request{}, &CreateDBCheckpointArgs{}, &reply)
+	require.ErrorIs(t, err, errNoCheckpointPath)
+}
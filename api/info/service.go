@@ -18,6 +18,7 @@ import (
 	"github.com/ava-labs/avalanchego/network/peer"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
+	"github.com/ava-labs/avalanchego/snow/networking/reputation"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/ips"
@@ -40,6 +41,7 @@ type Info struct {
 	vmManager    vms.Manager
 	validators   validators.Set
 	benchlist    benchlist.Manager
+	reputation   reputation.Tracker
 }
 
 type Parameters struct {
@@ -69,6 +71,7 @@ func NewService(
 	network network.Network,
 	validators validators.Set,
 	benchlist benchlist.Manager,
+	reputation reputation.Tracker,
 ) (*common.HTTPHandler, error) {
 	newServer := rpc.NewServer()
 	codec := json.NewCodec()
@@ -83,6 +86,7 @@ func NewService(
 		networking:   network,
 		validators:   validators,
 		benchlist:    benchlist,
+		reputation:   reputation,
 	}, "info"); err != nil {
 		return nil, err
 	}
@@ -217,7 +221,8 @@ type PeersArgs struct {
 type Peer struct {
 	peer.Info
 
-	Benched []ids.ID `json:"benched"`
+	Benched    []ids.ID     `json:"benched"`
+	Reputation json.Float64 `json:"reputation"`
 }
 
 // PeersReply are the results from calling Peers
@@ -239,8 +244,9 @@ func (i *Info) Peers(_ *http.Request, args *PeersArgs, reply *PeersReply) error
 	peerInfo := make([]Peer, len(peers))
 	for index, peer := range peers {
 		peerInfo[index] = Peer{
-			Info:    peer,
-			Benched: i.benchlist.GetBenched(peer.ID),
+			Info:       peer,
+			Benched:    i.benchlist.GetBenched(peer.ID),
+			Reputation: json.Float64(i.reputation.Score(peer.ID)),
 		}
 	}
 
@@ -3,6 +3,8 @@
 
 package cache
 
+import "time"
+
 // Cacher acts as a best effort key value store.
 type Cacher[K comparable, V any] interface {
 	// Put inserts an element into the cache. If space is required, elements will
@@ -26,6 +28,20 @@ type Cacher[K comparable, V any] interface {
 	PortionFilled() float64
 }
 
+// TTLCacher is a Cacher whose elements expire.
+type TTLCacher[K comparable, V any] interface {
+	Cacher[K, V]
+
+	// PutWithTTL inserts an element into the cache that expires after [ttl].
+	// If [ttl] isn't positive, the element doesn't expire. If space is
+	// required, elements will be evicted.
+	PutWithTTL(key K, value V, ttl time.Duration)
+
+	// NumExpired returns the number of elements that have expired from the
+	// cache.
+	NumExpired() uint64
+}
+
 // Evictable allows the object to be notified when it is evicted
 type Evictable[K comparable] interface {
 	Key() K
@@ -0,0 +1,66 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+const (
+	hitRateCacheSize = 1024
+	// hitRateHotSize is the number of elements that are accessed repeatedly.
+	hitRateHotSize = hitRateCacheSize / 2
+	// hitRateScanPeriod is the number of hot accesses between scans.
+	hitRateScanPeriod = 4 * hitRateCacheSize
+)
+
+// BenchmarkCacheHitRate reports the hit rate of the hot set of elements under
+// a workload that accesses them repeatedly, interleaved with scans of elements
+// that are never accessed again. Elements are inserted when they miss, as the
+// state caches do.
+func BenchmarkCacheHitRate(b *testing.B) {
+	for _, policy := range []Policy{LRUPolicy, TwoQueuePolicy} {
+		for _, scanLen := range []int{0, hitRateCacheSize / 2, 2 * hitRateCacheSize} {
+			b.Run(fmt.Sprintf("%s_scan_%d", policy, scanLen), func(b *testing.B) {
+				cache, err := NewCacher[int, int](policy, hitRateCacheSize)
+				if err != nil {
+					b.Fatal(err)
+				}
+				benchmarkHitRate(b, cache, scanLen)
+			})
+		}
+	}
+}
+
+func benchmarkHitRate(b *testing.B, cache Cacher[int, int], scanLen int) {
+	var (
+		source  = rand.New(rand.NewSource(0)) //#nosec G404
+		nextKey = hitRateHotSize
+		hits    int
+	)
+	access := func(key int) bool {
+		if _, ok := cache.Get(key); ok {
+			return true
+		}
+		cache.Put(key, key)
+		return false
+	}
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if access(source.Intn(hitRateHotSize)) {
+			hits++
+		}
+		if n%hitRateScanPeriod != hitRateScanPeriod-1 {
+			continue
+		}
+		for i := 0; i < scanLen; i++ {
+			access(nextKey)
+			nextKey++
+		}
+	}
+	b.ReportMetric(float64(hits)/float64(b.N), "hits/op")
+}
@@ -5,6 +5,7 @@ package metercacher
 
 import (
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -33,6 +34,24 @@ func TestInterface(t *testing.T) {
 				return cache.NewSizedLRU[ids.ID, int64](size*cache.TestIntSize, cache.TestIntSizeFunc)
 			},
 		},
+		{
+			description: "sized cache TTL",
+			setup: func(size int) cache.Cacher[ids.ID, int64] {
+				return cache.NewSizedTTL[ids.ID, int64](size*cache.TestIntSize, cache.TestIntSizeFunc, time.Hour)
+			},
+		},
+		{
+			description: "cache 2Q",
+			setup: func(size int) cache.Cacher[ids.ID, int64] {
+				return cache.NewTwoQueue[ids.ID, int64](size)
+			},
+		},
+		{
+			description: "sized cache 2Q",
+			setup: func(size int) cache.Cacher[ids.ID, int64] {
+				return cache.NewSizedTwoQueue[ids.ID, int64](size*cache.TestIntSize, cache.TestIntSizeFunc)
+			},
+		},
 	}
 
 	for _, scenario := range scenarios {
@@ -44,3 +63,28 @@ func TestInterface(t *testing.T) {
 		}
 	}
 }
+
+func TestTTLExpiredMetric(t *testing.T) {
+	require := require.New(t)
+
+	registry := prometheus.NewRegistry()
+	c, err := NewTTL("", registry, cache.NewSizedTTL[ids.ID, int64](cache.TestIntSize, cache.TestIntSizeFunc, time.Hour))
+	require.NoError(err)
+
+	id := ids.ID{1}
+	c.PutWithTTL(id, 1, time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	_, found := c.Get(id)
+	require.False(found)
+	require.Equal(uint64(1), c.NumExpired())
+
+	metrics, err := registry.Gather()
+	require.NoError(err)
+	for _, metric := range metrics {
+		if metric.GetName() == "expired" {
+			require.Equal(float64(1), metric.GetMetric()[0].GetCounter().GetValue())
+			return
+		}
+	}
+	require.FailNow("expired metric not found")
+}
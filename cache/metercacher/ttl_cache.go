@@ -0,0 +1,61 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package metercacher
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/cache"
+)
+
+var _ cache.TTLCacher[struct{}, struct{}] = (*TTLCache[struct{}, struct{}])(nil)
+
+type TTLCache[K comparable, V any] struct {
+	Cache[K, V]
+
+	ttlCacher cache.TTLCacher[K, V]
+}
+
+// NewTTL is like [New] and additionally reports the number of expired
+// elements.
+func NewTTL[K comparable, V any](
+	namespace string,
+	registerer prometheus.Registerer,
+	ttlCacher cache.TTLCacher[K, V],
+) (cache.TTLCacher[K, V], error) {
+	meterCache := &TTLCache[K, V]{
+		Cache:     Cache[K, V]{Cacher: ttlCacher},
+		ttlCacher: ttlCacher,
+	}
+	if err := meterCache.metrics.Initialize(namespace, registerer); err != nil {
+		return nil, err
+	}
+
+	expired := prometheus.NewCounterFunc(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "expired",
+			Help:      "# of entries that expired",
+		},
+		func() float64 {
+			return float64(ttlCacher.NumExpired())
+		},
+	)
+	return meterCache, registerer.Register(expired)
+}
+
+func (c *TTLCache[K, V]) PutWithTTL(key K, value V, ttl time.Duration) {
+	start := c.clock.Time()
+	c.ttlCacher.PutWithTTL(key, value, ttl)
+	end := c.clock.Time()
+	c.put.Observe(float64(end.Sub(start)))
+	c.len.Set(float64(c.ttlCacher.Len()))
+	c.portionFilled.Set(c.ttlCacher.PortionFilled())
+}
+
+func (c *TTLCache[_, _]) NumExpired() uint64 {
+	return c.ttlCacher.NumExpired()
+}
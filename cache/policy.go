@@ -0,0 +1,58 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// LRUPolicy evicts the least recently used element.
+	LRUPolicy Policy = "lru"
+	// TwoQueuePolicy evicts elements following the 2Q policy, which prevents
+	// scans from evicting the elements that are accessed repeatedly.
+	TwoQueuePolicy Policy = "2q"
+)
+
+var ErrUnknownPolicy = errors.New("unknown cache policy")
+
+// Policy selects how a cache evicts its elements. The empty policy is
+// equivalent to [LRUPolicy].
+type Policy string
+
+func (p Policy) Verify() error {
+	switch p {
+	case "", LRUPolicy, TwoQueuePolicy:
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownPolicy, p)
+	}
+}
+
+// NewCacher returns a cache following [policy] that holds at most [size]
+// elements.
+func NewCacher[K comparable, V any](policy Policy, size int) (Cacher[K, V], error) {
+	switch policy {
+	case "", LRUPolicy:
+		return &LRU[K, V]{Size: size}, nil
+	case TwoQueuePolicy:
+		return NewTwoQueue[K, V](size), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownPolicy, policy)
+	}
+}
+
+// NewSizedCacher returns a cache following [policy] whose elements' sizes, as
+// returned by [size], add up to at most [maxSize].
+func NewSizedCacher[K comparable, V any](policy Policy, maxSize int, size func(K, V) int) (Cacher[K, V], error) {
+	switch policy {
+	case "", LRUPolicy:
+		return NewSizedLRU[K, V](maxSize, size), nil
+	case TwoQueuePolicy:
+		return NewSizedTwoQueue[K, V](maxSize, size), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownPolicy, policy)
+	}
+}
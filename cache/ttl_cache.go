@@ -0,0 +1,212 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/heap"
+	"github.com/ava-labs/avalanchego/utils/linkedhashmap"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+var _ TTLCacher[struct{}, any] = (*sizedTTL[struct{}, any])(nil)
+
+type ttlEntry[K comparable, V any] struct {
+	key   K
+	value V
+	// expiry is the zero time if the entry doesn't expire.
+	expiry time.Time
+}
+
+// sizedTTL is a key value store with bounded size whose elements expire. If
+// the size is attempted to be exceeded, then elements are removed from the
+// cache until the bound is honored, based on evicting the least recently used
+// value. Expired elements are removed before any element is evicted.
+type sizedTTL[K comparable, V any] struct {
+	lock        sync.Mutex
+	elements    linkedhashmap.LinkedHashmap[K, *ttlEntry[K, V]]
+	maxSize     int
+	currentSize int
+	size        func(K, V) int
+	defaultTTL  time.Duration
+	numExpired  uint64
+
+	// expiries orders the entries that expire by their expiry. It may contain
+	// entries that were since replaced or evicted, which are skipped.
+	expiries heap.Queue[*ttlEntry[K, V]]
+
+	clock mockable.Clock
+}
+
+// NewSizedTTL returns a cache whose elements are evicted by size and expire
+// after [defaultTTL] unless they're inserted with [TTLCacher.PutWithTTL]. If
+// [defaultTTL] isn't positive, elements inserted with [Cacher.Put] don't
+// expire.
+func NewSizedTTL[K comparable, V any](maxSize int, size func(K, V) int, defaultTTL time.Duration) TTLCacher[K, V] {
+	return &sizedTTL[K, V]{
+		elements:   linkedhashmap.New[K, *ttlEntry[K, V]](),
+		maxSize:    maxSize,
+		size:       size,
+		defaultTTL: defaultTTL,
+		expiries:   heap.NewQueue[*ttlEntry[K, V]](expiresBefore[K, V]),
+	}
+}
+
+func (c *sizedTTL[K, V]) Put(key K, value V) {
+	c.PutWithTTL(key, value, c.defaultTTL)
+}
+
+func (c *sizedTTL[K, V]) PutWithTTL(key K, value V, ttl time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.put(key, value, ttl)
+}
+
+func (c *sizedTTL[K, V]) Get(key K) (V, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.get(key)
+}
+
+func (c *sizedTTL[K, V]) Evict(key K) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.evict(key)
+}
+
+func (c *sizedTTL[K, V]) Flush() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.flush()
+}
+
+func (c *sizedTTL[_, _]) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.removeExpired()
+	return c.elements.Len()
+}
+
+func (c *sizedTTL[_, _]) PortionFilled() float64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.removeExpired()
+	return float64(c.currentSize) / float64(c.maxSize)
+}
+
+func (c *sizedTTL[_, _]) NumExpired() uint64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.numExpired
+}
+
+func (c *sizedTTL[K, V]) put(key K, value V, ttl time.Duration) {
+	c.removeExpired()
+
+	newEntrySize := c.size(key, value)
+	if newEntrySize > c.maxSize {
+		c.flush()
+		return
+	}
+
+	c.evict(key)
+
+	// Remove elements until the size of elements in the cache <= [c.maxSize].
+	for c.currentSize > c.maxSize-newEntrySize {
+		oldestKey, _, _ := c.elements.Oldest()
+		c.evict(oldestKey)
+	}
+
+	entry := &ttlEntry[K, V]{
+		key:   key,
+		value: value,
+	}
+	if ttl > 0 {
+		entry.expiry = c.clock.Time().Add(ttl)
+		c.expiries.Push(entry)
+	}
+	c.elements.Put(key, entry)
+	c.currentSize += newEntrySize
+
+	// Don't let the replaced and evicted entries accumulate in [c.expiries].
+	if c.expiries.Len() > 2*c.elements.Len()+1 {
+		c.rebuildExpiries()
+	}
+}
+
+func (c *sizedTTL[K, V]) get(key K) (V, bool) {
+	entry, ok := c.elements.Get(key)
+	if !ok {
+		return utils.Zero[V](), false
+	}
+	if c.isExpired(entry) {
+		c.evict(key)
+		c.numExpired++
+		return utils.Zero[V](), false
+	}
+
+	c.elements.Put(key, entry) // Mark [k] as MRU.
+	return entry.value, true
+}
+
+func (c *sizedTTL[K, _]) evict(key K) {
+	if entry, ok := c.elements.Get(key); ok {
+		c.elements.Delete(key)
+		c.currentSize -= c.size(key, entry.value)
+	}
+}
+
+func (c *sizedTTL[K, V]) flush() {
+	c.elements = linkedhashmap.New[K, *ttlEntry[K, V]]()
+	c.expiries = heap.NewQueue[*ttlEntry[K, V]](expiresBefore[K, V])
+	c.currentSize = 0
+}
+
+// removeExpired removes the expired elements from the cache.
+func (c *sizedTTL[K, V]) removeExpired() {
+	for {
+		entry, ok := c.expiries.Peek()
+		if !ok || !c.isExpired(entry) {
+			return
+		}
+		_, _ = c.expiries.Pop()
+
+		// Skip entries that were replaced or evicted.
+		if current, ok := c.elements.Get(entry.key); ok && current == entry {
+			c.evict(entry.key)
+			c.numExpired++
+		}
+	}
+}
+
+// rebuildExpiries removes the entries that were replaced or evicted from
+// [c.expiries].
+func (c *sizedTTL[K, V]) rebuildExpiries() {
+	entries := make([]*ttlEntry[K, V], 0, c.elements.Len())
+	iter := c.elements.NewIterator()
+	for iter.Next() {
+		if entry := iter.Value(); !entry.expiry.IsZero() {
+			entries = append(entries, entry)
+		}
+	}
+	c.expiries = heap.QueueOf(expiresBefore[K, V], entries...)
+}
+
+func (c *sizedTTL[K, V]) isExpired(entry *ttlEntry[K, V]) bool {
+	return !entry.expiry.IsZero() && !c.clock.Time().Before(entry.expiry)
+}
+
+func expiresBefore[K comparable, V any](a, b *ttlEntry[K, V]) bool {
+	return a.expiry.Before(b.expiry)
+}
@@ -0,0 +1,147 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestSizedTTL(t *testing.T) {
+	cache := NewSizedTTL[ids.ID, int64](TestIntSize, TestIntSizeFunc, time.Hour)
+
+	TestBasic(t, cache)
+}
+
+func TestSizedTTLEviction(t *testing.T) {
+	cache := NewSizedTTL[ids.ID, int64](2*TestIntSize, TestIntSizeFunc, time.Hour)
+
+	TestEviction(t, cache)
+}
+
+func TestSizedTTLExpiry(t *testing.T) {
+	require := require.New(t)
+
+	cache := NewSizedTTL[ids.ID, int64](3*TestIntSize, TestIntSizeFunc, time.Minute).(*sizedTTL[ids.ID, int64])
+	now := time.Now()
+	cache.clock.Set(now)
+
+	id1 := ids.ID{1}
+	id2 := ids.ID{2}
+	id3 := ids.ID{3}
+	cache.Put(id1, 1)
+	cache.PutWithTTL(id2, 2, 2*time.Minute)
+	cache.PutWithTTL(id3, 3, 0)
+	require.Equal(3, cache.Len())
+
+	// [id1] expires after the default TTL.
+	cache.clock.Set(now.Add(time.Minute))
+	_, found := cache.Get(id1)
+	require.False(found)
+	value, found := cache.Get(id2)
+	require.True(found)
+	require.Equal(int64(2), value)
+	require.Equal(2, cache.Len())
+	require.Equal(uint64(1), cache.NumExpired())
+
+	// Expired elements are removed without being accessed.
+	cache.clock.Set(now.Add(2 * time.Minute))
+	require.Equal(1, cache.Len())
+	require.Equal(float64(1)/3, cache.PortionFilled())
+	require.Equal(uint64(2), cache.NumExpired())
+
+	// [id3] doesn't expire.
+	cache.clock.Set(now.Add(time.Hour))
+	value, found = cache.Get(id3)
+	require.True(found)
+	require.Equal(int64(3), value)
+	require.Equal(uint64(2), cache.NumExpired())
+}
+
+func TestSizedTTLPutResetsExpiry(t *testing.T) {
+	require := require.New(t)
+
+	cache := NewSizedTTL[ids.ID, int64](TestIntSize, TestIntSizeFunc, time.Minute).(*sizedTTL[ids.ID, int64])
+	now := time.Now()
+	cache.clock.Set(now)
+
+	id1 := ids.ID{1}
+	cache.Put(id1, 1)
+	cache.clock.Set(now.Add(30 * time.Second))
+	cache.Put(id1, 2)
+
+	// The first expiry of [id1] doesn't remove the replaced element.
+	cache.clock.Set(now.Add(time.Minute))
+	value, found := cache.Get(id1)
+	require.True(found)
+	require.Equal(int64(2), value)
+	require.Zero(cache.NumExpired())
+
+	cache.clock.Set(now.Add(90 * time.Second))
+	_, found = cache.Get(id1)
+	require.False(found)
+	require.Equal(uint64(1), cache.NumExpired())
+}
+
+func TestSizedTTLEvictsExpiredFirst(t *testing.T) {
+	require := require.New(t)
+
+	cache := NewSizedTTL[ids.ID, int64](2*TestIntSize, TestIntSizeFunc, 0).(*sizedTTL[ids.ID, int64])
+	now := time.Now()
+	cache.clock.Set(now)
+
+	id1 := ids.ID{1}
+	id2 := ids.ID{2}
+	id3 := ids.ID{3}
+	cache.Put(id1, 1)
+	cache.PutWithTTL(id2, 2, time.Minute)
+
+	// [id2] expired, so the least recently used [id1] isn't evicted.
+	cache.clock.Set(now.Add(time.Minute))
+	cache.Put(id3, 3)
+
+	_, found := cache.Get(id1)
+	require.True(found)
+	_, found = cache.Get(id2)
+	require.False(found)
+	_, found = cache.Get(id3)
+	require.True(found)
+	require.Equal(uint64(1), cache.NumExpired())
+}
+
+func TestSizedTTLStaleExpiriesBounded(t *testing.T) {
+	require := require.New(t)
+
+	cache := NewSizedTTL[ids.ID, int64](TestIntSize, TestIntSizeFunc, time.Hour).(*sizedTTL[ids.ID, int64])
+	for i := 0; i < 100; i++ {
+		cache.Put(ids.ID{byte(i)}, int64(i))
+	}
+	require.Equal(1, cache.Len())
+	require.LessOrEqual(cache.expiries.Len(), 3)
+}
+
+func TestSizedTTLConcurrent(t *testing.T) {
+	cache := NewSizedTTL[ids.ID, int64](10*TestIntSize, TestIntSizeFunc, time.Millisecond)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				id := ids.ID{byte(i), byte(j)}
+				cache.Put(id, int64(j))
+				_, _ = cache.Get(id)
+				_ = cache.Len()
+				cache.Evict(id)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
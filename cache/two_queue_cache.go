@@ -0,0 +1,214 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import (
+	"sync"
+
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/linkedhashmap"
+)
+
+const (
+	// twoQueueRecentRatio is the portion of the cache's size that is reserved
+	// for the elements that were only accessed once recently.
+	twoQueueRecentRatio = 0.25
+	// twoQueueGhostRatio is the portion of the cache's size of elements whose
+	// keys are remembered after being evicted from the recent elements.
+	twoQueueGhostRatio = 0.5
+)
+
+var _ Cacher[struct{}, any] = (*twoQueue[struct{}, any])(nil)
+
+// twoQueue is a key value store with bounded size that implements the 2Q
+// eviction policy, which is resistant to scans.
+//
+// New elements are inserted into a FIFO queue of recent elements. When they're
+// evicted from it, their keys are remembered in a FIFO ghost queue. Elements
+// that are accessed again while they're recent, or that are inserted again
+// while their key is remembered, are moved to the frequent elements, which are
+// evicted in LRU order. Elements that are only accessed once, such as during a
+// scan, never evict frequent elements while the recent elements take up more
+// than their reserved portion of the cache.
+type twoQueue[K comparable, V any] struct {
+	lock    sync.Mutex
+	maxSize int
+	size    func(K, V) int
+
+	recent        linkedhashmap.LinkedHashmap[K, V]
+	recentSize    int
+	maxRecentSize int
+	ghost         linkedhashmap.LinkedHashmap[K, int]
+	ghostSize     int
+	maxGhostSize  int
+	frequent      linkedhashmap.LinkedHashmap[K, V]
+	frequentSize  int
+}
+
+// NewTwoQueue returns a 2Q cache that holds at most [size] elements.
+func NewTwoQueue[K comparable, V any](size int) Cacher[K, V] {
+	return NewSizedTwoQueue[K, V](size, func(K, V) int {
+		return 1
+	})
+}
+
+// NewSizedTwoQueue returns a 2Q cache whose elements' sizes, as returned by
+// [size], add up to at most [maxSize].
+func NewSizedTwoQueue[K comparable, V any](maxSize int, size func(K, V) int) Cacher[K, V] {
+	if maxSize <= 0 {
+		maxSize = 1
+	}
+	return &twoQueue[K, V]{
+		maxSize:       maxSize,
+		size:          size,
+		recent:        linkedhashmap.New[K, V](),
+		maxRecentSize: int(float64(maxSize) * twoQueueRecentRatio),
+		ghost:         linkedhashmap.New[K, int](),
+		maxGhostSize:  int(float64(maxSize) * twoQueueGhostRatio),
+		frequent:      linkedhashmap.New[K, V](),
+	}
+}
+
+func (c *twoQueue[K, V]) Put(key K, value V) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.put(key, value)
+}
+
+func (c *twoQueue[K, V]) Get(key K) (V, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.get(key)
+}
+
+func (c *twoQueue[K, V]) Evict(key K) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.evict(key)
+	if ghostSize, ok := c.ghost.Get(key); ok {
+		c.ghost.Delete(key)
+		c.ghostSize -= ghostSize
+	}
+}
+
+func (c *twoQueue[K, V]) Flush() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.flush()
+}
+
+func (c *twoQueue[_, _]) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.recent.Len() + c.frequent.Len()
+}
+
+func (c *twoQueue[_, _]) PortionFilled() float64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return float64(c.recentSize+c.frequentSize) / float64(c.maxSize)
+}
+
+func (c *twoQueue[K, V]) put(key K, value V) {
+	newEntrySize := c.size(key, value)
+	if newEntrySize > c.maxSize {
+		c.flush()
+		return
+	}
+
+	// Elements that are inserted again while they're cached or while their
+	// key is remembered are accessed repeatedly.
+	isFrequent := c.evict(key)
+	if ghostSize, ok := c.ghost.Get(key); ok {
+		c.ghost.Delete(key)
+		c.ghostSize -= ghostSize
+		isFrequent = true
+	}
+
+	// Remove elements until the size of elements in the cache <= [c.maxSize].
+	for c.recentSize+c.frequentSize > c.maxSize-newEntrySize {
+		if c.recent.Len() > 0 && (c.recentSize > c.maxRecentSize || c.frequent.Len() == 0) {
+			c.evictOldestRecent()
+		} else {
+			oldestKey, oldestValue, _ := c.frequent.Oldest()
+			c.frequent.Delete(oldestKey)
+			c.frequentSize -= c.size(oldestKey, oldestValue)
+		}
+	}
+
+	if isFrequent {
+		c.frequent.Put(key, value)
+		c.frequentSize += newEntrySize
+	} else {
+		c.recent.Put(key, value)
+		c.recentSize += newEntrySize
+	}
+}
+
+func (c *twoQueue[K, V]) get(key K) (V, bool) {
+	if value, ok := c.frequent.Get(key); ok {
+		c.frequent.Put(key, value) // Mark [k] as MRU.
+		return value, true
+	}
+
+	value, ok := c.recent.Get(key)
+	if !ok {
+		return utils.Zero[V](), false
+	}
+
+	// [key] was accessed again, so it's moved to the frequent elements.
+	size := c.size(key, value)
+	c.recent.Delete(key)
+	c.recentSize -= size
+	c.frequent.Put(key, value)
+	c.frequentSize += size
+	return value, true
+}
+
+// evictOldestRecent evicts the oldest recent element and remembers its key.
+func (c *twoQueue[K, V]) evictOldestRecent() {
+	oldestKey, oldestValue, _ := c.recent.Oldest()
+	oldestSize := c.size(oldestKey, oldestValue)
+	c.recent.Delete(oldestKey)
+	c.recentSize -= oldestSize
+
+	c.ghost.Put(oldestKey, oldestSize)
+	c.ghostSize += oldestSize
+	for c.ghostSize > c.maxGhostSize {
+		ghostKey, ghostSize, _ := c.ghost.Oldest()
+		c.ghost.Delete(ghostKey)
+		c.ghostSize -= ghostSize
+	}
+}
+
+// evict removes [key] from the recent and frequent elements and returns true
+// if it was cached.
+func (c *twoQueue[K, _]) evict(key K) bool {
+	if value, ok := c.recent.Get(key); ok {
+		c.recent.Delete(key)
+		c.recentSize -= c.size(key, value)
+		return true
+	}
+	if value, ok := c.frequent.Get(key); ok {
+		c.frequent.Delete(key)
+		c.frequentSize -= c.size(key, value)
+		return true
+	}
+	return false
+}
+
+func (c *twoQueue[K, V]) flush() {
+	c.recent = linkedhashmap.New[K, V]()
+	c.recentSize = 0
+	c.ghost = linkedhashmap.New[K, int]()
+	c.ghostSize = 0
+	c.frequent = linkedhashmap.New[K, V]()
+	c.frequentSize = 0
+}
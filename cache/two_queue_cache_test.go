@@ -0,0 +1,148 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestTwoQueue(t *testing.T) {
+	cache := NewTwoQueue[ids.ID, int64](1)
+
+	TestBasic(t, cache)
+}
+
+func TestSizedTwoQueue(t *testing.T) {
+	cache := NewSizedTwoQueue[ids.ID, int64](TestIntSize, TestIntSizeFunc)
+
+	TestBasic(t, cache)
+}
+
+func TestTwoQueueEviction(t *testing.T) {
+	cache := NewTwoQueue[ids.ID, int64](2)
+
+	TestEviction(t, cache)
+}
+
+func TestSizedTwoQueueEviction(t *testing.T) {
+	cache := NewSizedTwoQueue[ids.ID, int64](2*TestIntSize, TestIntSizeFunc)
+
+	TestEviction(t, cache)
+}
+
+func TestTwoQueueGhost(t *testing.T) {
+	require := require.New(t)
+
+	cache := NewTwoQueue[int, int](4)
+
+	// Elements that aren't accessed again are evicted in FIFO order.
+	for i := 0; i < 5; i++ {
+		cache.Put(i, i)
+	}
+	require.Equal(4, cache.Len())
+	require.Equal(float64(1), cache.PortionFilled())
+	_, found := cache.Get(0)
+	require.False(found)
+
+	// [0] is remembered, so it's inserted into the frequent elements and
+	// outlives the recent elements.
+	cache.Put(0, 0)
+	for i := 5; i < 10; i++ {
+		cache.Put(i, i)
+	}
+	value, found := cache.Get(0)
+	require.True(found)
+	require.Equal(0, value)
+
+	// Evicted elements aren't remembered.
+	cache.Evict(0)
+	_, found = cache.Get(0)
+	require.False(found)
+	require.Equal(3, cache.Len())
+
+	cache.Flush()
+	require.Zero(cache.Len())
+	require.Zero(cache.PortionFilled())
+	for i := 0; i < 10; i++ {
+		_, found := cache.Get(i)
+		require.False(found)
+	}
+}
+
+func TestTwoQueueScanResistance(t *testing.T) {
+	require := require.New(t)
+
+	const (
+		size    = 100
+		hotSize = 50
+	)
+	cache := NewTwoQueue[int, int](size)
+
+	// Make the hot elements frequently accessed.
+	for i := 0; i < hotSize; i++ {
+		cache.Put(i, i)
+	}
+	for i := 0; i < hotSize; i++ {
+		_, found := cache.Get(i)
+		require.True(found)
+	}
+
+	// A scan of elements that aren't accessed again doesn't evict them.
+	for i := hotSize; i < 10*size; i++ {
+		cache.Put(i, i)
+	}
+	for i := 0; i < hotSize; i++ {
+		value, found := cache.Get(i)
+		require.True(found)
+		require.Equal(i, value)
+	}
+	require.Equal(size, cache.Len())
+}
+
+func TestSizedTwoQueueOversizedElement(t *testing.T) {
+	require := require.New(t)
+
+	cache := NewSizedTwoQueue[string, struct{}](
+		3,
+		func(key string, _ struct{}) int {
+			return len(key)
+		},
+	)
+
+	cache.Put("a", struct{}{})
+	cache.Put("bb", struct{}{})
+	require.Equal(2, cache.Len())
+	require.Equal(float64(1), cache.PortionFilled())
+
+	cache.Put("cccc", struct{}{})
+	require.Zero(cache.Len())
+	require.Zero(cache.PortionFilled())
+}
+
+func TestNewCacher(t *testing.T) {
+	require := require.New(t)
+
+	for _, policy := range []Policy{"", LRUPolicy, TwoQueuePolicy} {
+		require.NoError(policy.Verify())
+
+		cache, err := NewCacher[ids.ID, int64](policy, 1)
+		require.NoError(err)
+		TestBasic(t, cache)
+
+		cache, err = NewSizedCacher[ids.ID, int64](policy, TestIntSize, TestIntSizeFunc)
+		require.NoError(err)
+		TestBasic(t, cache)
+	}
+
+	const unknownPolicy Policy = "arc"
+	require.ErrorIs(unknownPolicy.Verify(), ErrUnknownPolicy)
+	_, err := NewCacher[ids.ID, int64](unknownPolicy, 1)
+	require.ErrorIs(err, ErrUnknownPolicy)
+	_, err = NewSizedCacher[ids.ID, int64](unknownPolicy, TestIntSize, TestIntSizeFunc)
+	require.ErrorIs(err, ErrUnknownPolicy)
+}
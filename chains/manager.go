@@ -23,6 +23,7 @@ import (
 	"github.com/ava-labs/avalanchego/api/metrics"
 	"github.com/ava-labs/avalanchego/api/server"
 	"github.com/ava-labs/avalanchego/chains/atomic"
+	"github.com/ava-labs/avalanchego/database/compressdb"
 	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
@@ -37,6 +38,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/syncer"
 	"github.com/ava-labs/avalanchego/snow/networking/handler"
+	"github.com/ava-labs/avalanchego/snow/networking/reputation"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/sender"
 	"github.com/ava-labs/avalanchego/snow/networking/timeout"
@@ -162,9 +164,11 @@ type chain struct {
 // ChainConfig is configuration settings for the current execution.
 // [Config] is the user-provided config blob for the chain.
 // [Upgrade] is a chain-specific blob for coordinating upgrades.
+// [Database] is the user-provided config blob for the chain's database.
 type ChainConfig struct {
-	Config  []byte
-	Upgrade []byte
+	Config   []byte
+	Upgrade  []byte
+	Database []byte
 }
 
 type ManagerConfig struct {
@@ -224,6 +228,12 @@ type ManagerConfig struct {
 	// Tracks CPU/disk usage caused by each peer.
 	ResourceTracker timetracker.ResourceTracker
 
+	// Tracks the reputation of each peer.
+	ReputationTracker reputation.Tracker
+	// If true, consensus polls are biased towards validators with higher
+	// reputation scores.
+	ReputationSamplingEnabled bool
+
 	StateSyncBeacons []ids.NodeID
 
 	ChainDataDir string
@@ -607,11 +617,10 @@ func (m *manager) createAvalancheChain(
 		State: snow.Initializing,
 	})
 
-	meterDBManager, err := m.DBManager.NewMeterDBManager("db", ctx.Registerer)
+	prefixDBManager, err := m.newChainDBManager(ctx)
 	if err != nil {
 		return nil, err
 	}
-	prefixDBManager := meterDBManager.NewPrefixDBManager(ctx.ChainID[:])
 	vmDBManager := prefixDBManager.NewPrefixDBManager(vmDBPrefix)
 
 	db := prefixDBManager.Current()
@@ -757,16 +766,19 @@ func (m *manager) createAvalancheChain(
 	var (
 		minBlockDelay       = proposervm.DefaultMinBlockDelay
 		numHistoricalBlocks = proposervm.DefaultNumHistoricalBlocks
+		cachePolicy         = proposervm.DefaultCachePolicy
 	)
 	if subnetCfg, ok := m.SubnetConfigs[ctx.SubnetID]; ok {
 		minBlockDelay = subnetCfg.ProposerMinBlockDelay
 		numHistoricalBlocks = subnetCfg.ProposerNumHistoricalBlocks
+		cachePolicy = subnetCfg.ProposerCachePolicy
 	}
 	m.Log.Info("creating proposervm wrapper",
 		zap.Time("activationTime", m.ApricotPhase4Time),
 		zap.Uint64("minPChainHeight", m.ApricotPhase4MinPChainHeight),
 		zap.Duration("minBlockDelay", minBlockDelay),
 		zap.Uint64("numHistoricalBlocks", numHistoricalBlocks),
+		zap.String("cachePolicy", string(cachePolicy)),
 	)
 
 	chainAlias := m.PrimaryAliasOrDefault(ctx.ChainID)
@@ -787,6 +799,7 @@ func (m *manager) createAvalancheChain(
 		m.ApricotPhase4MinPChainHeight,
 		minBlockDelay,
 		numHistoricalBlocks,
+		cachePolicy,
 		m.stakingSigner,
 		m.stakingCert,
 	)
@@ -883,7 +896,7 @@ func (m *manager) createAvalancheChain(
 		AllGetsServer: snowGetHandler,
 		VM:            vmWrappingProposerVM,
 		Sender:        snowmanCommonCfg.Sender,
-		Validators:    vdrs,
+		Validators:    m.pollValidators(vdrs),
 		Params:        consensusParams,
 		Consensus:     snowmanConsensus,
 	}
@@ -1012,11 +1025,10 @@ func (m *manager) createSnowmanChain(
 		State: snow.Initializing,
 	})
 
-	meterDBManager, err := m.DBManager.NewMeterDBManager("db", ctx.Registerer)
+	prefixDBManager, err := m.newChainDBManager(ctx)
 	if err != nil {
 		return nil, err
 	}
-	prefixDBManager := meterDBManager.NewPrefixDBManager(ctx.ChainID[:])
 	vmDBManager := prefixDBManager.NewPrefixDBManager(vmDBPrefix)
 
 	db := prefixDBManager.Current()
@@ -1112,16 +1124,19 @@ func (m *manager) createSnowmanChain(
 	var (
 		minBlockDelay       = proposervm.DefaultMinBlockDelay
 		numHistoricalBlocks = proposervm.DefaultNumHistoricalBlocks
+		cachePolicy         = proposervm.DefaultCachePolicy
 	)
 	if subnetCfg, ok := m.SubnetConfigs[ctx.SubnetID]; ok {
 		minBlockDelay = subnetCfg.ProposerMinBlockDelay
 		numHistoricalBlocks = subnetCfg.ProposerNumHistoricalBlocks
+		cachePolicy = subnetCfg.ProposerCachePolicy
 	}
 	m.Log.Info("creating proposervm wrapper",
 		zap.Time("activationTime", m.ApricotPhase4Time),
 		zap.Uint64("minPChainHeight", m.ApricotPhase4MinPChainHeight),
 		zap.Duration("minBlockDelay", minBlockDelay),
 		zap.Uint64("numHistoricalBlocks", numHistoricalBlocks),
+		zap.String("cachePolicy", string(cachePolicy)),
 	)
 
 	chainAlias := m.PrimaryAliasOrDefault(ctx.ChainID)
@@ -1135,6 +1150,7 @@ func (m *manager) createSnowmanChain(
 		m.ApricotPhase4MinPChainHeight,
 		minBlockDelay,
 		numHistoricalBlocks,
+		cachePolicy,
 		m.stakingSigner,
 		m.stakingCert,
 	)
@@ -1232,7 +1248,7 @@ func (m *manager) createSnowmanChain(
 		AllGetsServer: snowGetHandler,
 		VM:            vm,
 		Sender:        commonCfg.Sender,
-		Validators:    vdrs,
+		Validators:    m.pollValidators(vdrs),
 		Params:        consensusParams,
 		Consensus:     consensus,
 		PartialSync:   m.PartialSyncPrimaryNetwork && commonCfg.Ctx.ChainID == constants.PlatformChainID,
@@ -1446,6 +1462,36 @@ func (m *manager) notifyRegistrants(name string, ctx *snow.ConsensusContext, vm
 	}
 }
 
+// newChainDBManager returns the database manager of the chain described by
+// [ctx], with all of its keys prefixed by the chain's ID.
+//
+// If the chain's database config enables compression, values written by the
+// chain are compressed.
+func (m *manager) newChainDBManager(ctx *snow.ConsensusContext) (dbManager.Manager, error) {
+	meterDBManager, err := m.DBManager.NewMeterDBManager("db", ctx.Registerer)
+	if err != nil {
+		return nil, err
+	}
+
+	chainConfig, err := m.getChainConfig(ctx.ChainID)
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching chain config: %w", err)
+	}
+	compressConfig, err := compressdb.ParseConfig(chainConfig.Database)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse chain database config: %w", err)
+	}
+	// Compression is applied on top of the chain's prefix so that whether the
+	// chain's values are compressed is tracked per chain. The keys written by
+	// the chain are the same regardless of compression.
+	chainDBManager := meterDBManager.NewPrefixDBManager(ctx.ChainID[:])
+	compressDBManager, err := chainDBManager.NewCompressDBManager(compressConfig)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't enable compression: %w", err)
+	}
+	return compressDBManager, nil
+}
+
 // getChainConfig returns value of a entry by looking at ID key and alias key
 // it first searches ID key, then falls back to it's corresponding primary alias
 func (m *manager) getChainConfig(id ids.ID) (ChainConfig, error) {
@@ -1464,3 +1510,12 @@ func (m *manager) getChainConfig(id ids.ID) (ChainConfig, error) {
 
 	return ChainConfig{}, nil
 }
+
+// pollValidators returns the validators of [vdrs] as they should be sampled by
+// a consensus engine.
+func (m *manager) pollValidators(vdrs validators.Set) validators.Set {
+	if !m.ReputationSamplingEnabled {
+		return vdrs
+	}
+	return reputation.Validators(vdrs, m.ReputationTracker)
+}
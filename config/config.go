@@ -28,10 +28,13 @@ import (
 	"github.com/ava-labs/avalanchego/nat"
 	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/network/dialer"
+	"github.com/ava-labs/avalanchego/network/peer"
 	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/node"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
+	"github.com/ava-labs/avalanchego/snow/networking/capture"
+	"github.com/ava-labs/avalanchego/snow/networking/reputation"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/staking"
@@ -55,10 +58,11 @@ import (
 )
 
 const (
-	chainConfigFileName  = "config"
-	chainUpgradeFileName = "upgrade"
-	subnetConfigFileExt  = ".json"
-	ipResolutionTimeout  = 30 * time.Second
+	chainConfigFileName   = "config"
+	chainUpgradeFileName  = "upgrade"
+	chainDatabaseFileName = "database"
+	subnetConfigFileExt   = ".json"
+	ipResolutionTimeout   = 30 * time.Second
 )
 
 var (
@@ -413,6 +417,24 @@ func getNetworkConfig(
 		RequireValidatorToConnect: v.GetBool(NetworkRequireValidatorToConnectKey),
 		PeerReadBufferSize:        int(v.GetUint(NetworkPeerReadBufferSizeKey)),
 		PeerWriteBufferSize:       int(v.GetUint(NetworkPeerWriteBufferSizeKey)),
+
+		OutboundMessageQueueConfig: peer.PrioritizedMessageQueueConfig{
+			StrictPriority: v.GetBool(NetworkOutboundQueueStrictPriorityKey),
+			Consensus: peer.MessageClassConfig{
+				Weight:   v.GetUint64(NetworkOutboundQueueConsensusWeightKey),
+				MaxBytes: v.GetUint64(NetworkOutboundQueueConsensusMaxBytesKey),
+			},
+			Bootstrapping: peer.MessageClassConfig{
+				Weight:   v.GetUint64(NetworkOutboundQueueBootstrappingWeightKey),
+				MaxBytes: v.GetUint64(NetworkOutboundQueueBootstrappingMaxBytesKey),
+			},
+			Application: peer.MessageClassConfig{
+				Weight:   v.GetUint64(NetworkOutboundQueueApplicationWeightKey),
+				MaxBytes: v.GetUint64(NetworkOutboundQueueApplicationMaxBytesKey),
+			},
+		},
+
+		MinInboundReputation: v.GetFloat64(ReputationMinInboundScoreKey),
 	}
 
 	switch {
@@ -448,6 +470,8 @@ func getNetworkConfig(
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkReadHandshakeTimeoutKey)
 	case config.MaxClockDifference < 0:
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkMaxClockDifferenceKey)
+	case config.MinInboundReputation < 0 || config.MinInboundReputation > 1:
+		return network.Config{}, fmt.Errorf("%s must be in [0,1]", ReputationMinInboundScoreKey)
 	}
 	return config, nil
 }
@@ -473,6 +497,33 @@ func getBenchlistConfig(v *viper.Viper, consensusParameters snowball.Parameters)
 	return config, nil
 }
 
+func getReputationConfig(v *viper.Viper) (reputation.Config, error) {
+	config := reputation.Config{
+		Halflife:                v.GetDuration(ReputationHalflifeKey),
+		TimeoutPenalty:          v.GetFloat64(ReputationTimeoutPenaltyKey),
+		InvalidMessagePenalty:   v.GetFloat64(ReputationInvalidMessagePenaltyKey),
+		BandwidthAbusePenalty:   v.GetFloat64(ReputationBandwidthAbusePenaltyKey),
+		HandshakeFailurePenalty: v.GetFloat64(ReputationHandshakeFailurePenaltyKey),
+		ResponseReward:          v.GetFloat64(ReputationResponseRewardKey),
+		SamplingEnabled:         v.GetBool(ReputationSamplingEnabledKey),
+	}
+	switch {
+	case config.Halflife <= 0:
+		return reputation.Config{}, fmt.Errorf("%q must be > 0", ReputationHalflifeKey)
+	case config.TimeoutPenalty < 0:
+		return reputation.Config{}, fmt.Errorf("%q must be >= 0", ReputationTimeoutPenaltyKey)
+	case config.InvalidMessagePenalty < 0:
+		return reputation.Config{}, fmt.Errorf("%q must be >= 0", ReputationInvalidMessagePenaltyKey)
+	case config.BandwidthAbusePenalty < 0:
+		return reputation.Config{}, fmt.Errorf("%q must be >= 0", ReputationBandwidthAbusePenaltyKey)
+	case config.HandshakeFailurePenalty < 0:
+		return reputation.Config{}, fmt.Errorf("%q must be >= 0", ReputationHandshakeFailurePenaltyKey)
+	case config.ResponseReward < 0:
+		return reputation.Config{}, fmt.Errorf("%q must be >= 0", ReputationResponseRewardKey)
+	}
+	return config, nil
+}
+
 func getStateSyncConfig(v *viper.Viper) (node.StateSyncConfig, error) {
 	var (
 		config       = node.StateSyncConfig{}
@@ -641,6 +692,22 @@ func getIPConfig(v *viper.Viper) (node.IPConfig, error) {
 	return ipConfig, nil
 }
 
+func getCaptureConfig(v *viper.Viper) (capture.Config, error) {
+	config := capture.Config{
+		Enabled:     v.GetBool(NetworkCaptureEnabledKey),
+		Directory:   GetExpandedArg(v, NetworkCaptureDirKey),
+		MaxFileSize: v.GetInt(NetworkCaptureMaxFileSizeKey),
+		MaxFiles:    v.GetInt(NetworkCaptureMaxFilesKey),
+	}
+	switch {
+	case config.MaxFileSize <= 0:
+		return capture.Config{}, fmt.Errorf("%s must be > 0", NetworkCaptureMaxFileSizeKey)
+	case config.MaxFiles < 0:
+		return capture.Config{}, fmt.Errorf("%s must be >= 0", NetworkCaptureMaxFilesKey)
+	}
+	return config, nil
+}
+
 func getProfilerConfig(v *viper.Viper) (profiler.Config, error) {
 	config := profiler.Config{
 		Dir:         GetExpandedArg(v, ProfileDirKey),
@@ -1073,9 +1140,16 @@ func readChainConfigPath(chainConfigPath string) (map[string]chains.ChainConfig,
 			return chainConfigMap, err
 		}
 
+		// chainconfigdir/chainId/database.*
+		databaseData, err := storage.ReadFileWithName(chainDir, chainDatabaseFileName)
+		if err != nil {
+			return chainConfigMap, err
+		}
+
 		chainConfigMap[dirInfo.Name()] = chains.ChainConfig{
-			Config:  configData,
-			Upgrade: upgradeData,
+			Config:   configData,
+			Upgrade:  upgradeData,
+			Database: databaseData,
 		}
 	}
 	return chainConfigMap, nil
@@ -1186,6 +1260,7 @@ func getDefaultSubnetConfig(v *viper.Viper) subnets.Config {
 		GossipConfig:                getGossipConfig(v),
 		ProposerMinBlockDelay:       proposervm.DefaultMinBlockDelay,
 		ProposerNumHistoricalBlocks: proposervm.DefaultNumHistoricalBlocks,
+		ProposerCachePolicy:         proposervm.DefaultCachePolicy,
 	}
 }
 
@@ -1426,6 +1501,12 @@ func GetNodeConfig(v *viper.Viper) (node.Config, error) {
 		return node.Config{}, err
 	}
 
+	// Reputation
+	nodeConfig.ReputationConfig, err = getReputationConfig(v)
+	if err != nil {
+		return node.Config{}, err
+	}
+
 	// File Descriptor Limit
 	nodeConfig.FdLimit = v.GetUint64(FdLimitKey)
 
@@ -1463,6 +1544,12 @@ func GetNodeConfig(v *viper.Viper) (node.Config, error) {
 		return node.Config{}, err
 	}
 
+	// Message capture
+	nodeConfig.CaptureConfig, err = getCaptureConfig(v)
+	if err != nil {
+		return node.Config{}, err
+	}
+
 	// VM Aliases
 	nodeConfig.VMAliaser, err = getVMAliaser(v)
 	if err != nil {
@@ -25,9 +25,10 @@ import (
 
 func TestGetChainConfigsFromFiles(t *testing.T) {
 	tests := map[string]struct {
-		configs  map[string]string
-		upgrades map[string]string
-		expected map[string]chains.ChainConfig
+		configs   map[string]string
+		upgrades  map[string]string
+		databases map[string]string
+		expected  map[string]chains.ChainConfig
 	}{
 		"no chain configs": {
 			configs:  map[string]string{},
@@ -51,12 +52,13 @@ func TestGetChainConfigsFromFiles(t *testing.T) {
 			}(),
 		},
 		"valid alias": {
-			configs:  map[string]string{"C": "hello", "X": "world"},
-			upgrades: map[string]string{"C": "upgradess"},
+			configs:   map[string]string{"C": "hello", "X": "world"},
+			upgrades:  map[string]string{"C": "upgradess"},
+			databases: map[string]string{"X": "compressed"},
 			expected: func() map[string]chains.ChainConfig {
 				m := map[string]chains.ChainConfig{}
 				m["C"] = chains.ChainConfig{Config: []byte("hello"), Upgrade: []byte("upgradess")}
-				m["X"] = chains.ChainConfig{Config: []byte("world"), Upgrade: []byte(nil)}
+				m["X"] = chains.ChainConfig{Config: []byte("world"), Upgrade: []byte(nil), Database: []byte("compressed")}
 
 				return m
 			}(),
@@ -79,6 +81,10 @@ func TestGetChainConfigsFromFiles(t *testing.T) {
 				chainDir := filepath.Join(chainsDir, key)
 				setupFile(t, chainDir, chainUpgradeFileName+".ex", value)
 			}
+			for key, value := range test.databases {
+				chainDir := filepath.Join(chainsDir, key)
+				setupFile(t, chainDir, chainDatabaseFileName+".ex", value)
+			}
 
 			v := setupViper(configFile)
 
@@ -15,6 +15,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/database/leveldb"
 	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/pebbledb"
 	"github.com/ava-labs/avalanchego/genesis"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
 	"github.com/ava-labs/avalanchego/trace"
@@ -40,6 +41,7 @@ var (
 	defaultDBDir                = filepath.Join(defaultUnexpandedDataDir, "db")
 	defaultLogDir               = filepath.Join(defaultUnexpandedDataDir, "logs")
 	defaultProfileDir           = filepath.Join(defaultUnexpandedDataDir, "profiles")
+	defaultCaptureDir           = filepath.Join(defaultUnexpandedDataDir, "captures")
 	defaultStakingPath          = filepath.Join(defaultUnexpandedDataDir, "staking")
 	defaultStakingTLSKeyPath    = filepath.Join(defaultStakingPath, "staker.key")
 	defaultStakingCertPath      = filepath.Join(defaultStakingPath, "staker.crt")
@@ -103,7 +105,7 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Uint64(AddSubnetDelegatorFeeKey, genesis.LocalParams.AddSubnetDelegatorFee, "Transaction fee, in nAVAX, for transactions that add new subnet delegators")
 
 	// Database
-	fs.String(DBTypeKey, leveldb.Name, fmt.Sprintf("Database type to use. Should be one of {%s, %s}", leveldb.Name, memdb.Name))
+	fs.String(DBTypeKey, leveldb.Name, fmt.Sprintf("Database type to use. Should be one of {%s, %s, %s}", leveldb.Name, memdb.Name, pebbledb.Name))
 	fs.String(DBPathKey, defaultDBDir, "Path to database directory")
 	fs.String(DBConfigFileKey, "", fmt.Sprintf("Path to database config file. Ignored if %s is specified", DBConfigContentKey))
 	fs.String(DBConfigContentKey, "", "Specifies base64 encoded database config content")
@@ -158,6 +160,13 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Bool(NetworkRequireValidatorToConnectKey, constants.DefaultNetworkRequireValidatorToConnect, "If true, this node will only maintain a connection with another node if this node is a validator, the other node is a validator, or the other node is a beacon")
 	fs.Uint(NetworkPeerReadBufferSizeKey, constants.DefaultNetworkPeerReadBufferSize, "Size, in bytes, of the buffer that we read peer messages into (there is one buffer per peer)")
 	fs.Uint(NetworkPeerWriteBufferSizeKey, constants.DefaultNetworkPeerWriteBufferSize, "Size, in bytes, of the buffer that we write peer messages into (there is one buffer per peer)")
+	fs.Bool(NetworkOutboundQueueStrictPriorityKey, constants.DefaultNetworkOutboundQueueStrictPriority, "If true, messages sent to a peer are sent strictly in the order of their class' priority: consensus, bootstrapping, then application. Otherwise, the bandwidth to each peer is shared between the classes by their weight")
+	fs.Uint64(NetworkOutboundQueueConsensusWeightKey, constants.DefaultNetworkOutboundQueueConsensusWeight, "Relative share of the bandwidth to each peer given to consensus messages. A weight of 0 is treated as 1")
+	fs.Uint64(NetworkOutboundQueueConsensusMaxBytesKey, constants.DefaultNetworkOutboundQueueConsensusMaxBytes, "Max number of bytes of consensus messages queued to be sent to each peer. If 0, there is no limit")
+	fs.Uint64(NetworkOutboundQueueBootstrappingWeightKey, constants.DefaultNetworkOutboundQueueBootstrappingWeight, "Relative share of the bandwidth to each peer given to bootstrapping and state sync messages. A weight of 0 is treated as 1")
+	fs.Uint64(NetworkOutboundQueueBootstrappingMaxBytesKey, constants.DefaultNetworkOutboundQueueBootstrappingMaxBytes, "Max number of bytes of bootstrapping and state sync messages queued to be sent to each peer. If 0, there is no limit")
+	fs.Uint64(NetworkOutboundQueueApplicationWeightKey, constants.DefaultNetworkOutboundQueueApplicationWeight, "Relative share of the bandwidth to each peer given to application messages. A weight of 0 is treated as 1")
+	fs.Uint64(NetworkOutboundQueueApplicationMaxBytesKey, constants.DefaultNetworkOutboundQueueApplicationMaxBytes, "Max number of bytes of application messages queued to be sent to each peer. If 0, there is no limit")
 
 	fs.Bool(NetworkTCPProxyEnabledKey, constants.DefaultNetworkTCPProxyEnabled, "Require all P2P connections to be initiated with a TCP proxy header")
 	// The PROXY protocol specification recommends setting this value to be at
@@ -174,6 +183,16 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Duration(BenchlistDurationKey, constants.DefaultBenchlistDuration, "Max amount of time a peer is benchlisted after surpassing the threshold")
 	fs.Duration(BenchlistMinFailingDurationKey, constants.DefaultBenchlistMinFailingDuration, "Minimum amount of time messages to a peer must be failing before the peer is benched")
 
+	// Reputation
+	fs.Duration(ReputationHalflifeKey, constants.DefaultReputationHalflife, "Time it takes for the reputation penalty of a peer to decay by half")
+	fs.Float64(ReputationTimeoutPenaltyKey, constants.DefaultReputationTimeoutPenalty, "Reputation penalty of a peer each time it fails to respond to a request in time")
+	fs.Float64(ReputationInvalidMessagePenaltyKey, constants.DefaultReputationInvalidMessagePenalty, "Reputation penalty of a peer each time it sends an invalid message")
+	fs.Float64(ReputationBandwidthAbusePenaltyKey, constants.DefaultReputationBandwidthAbusePenalty, "Reputation penalty of a peer each time it exceeds its inbound bandwidth allocation")
+	fs.Float64(ReputationHandshakeFailurePenaltyKey, constants.DefaultReputationHandshakeFailurePenalty, "Reputation penalty of a peer each time the handshake with it fails")
+	fs.Float64(ReputationResponseRewardKey, constants.DefaultReputationResponseReward, "Reduction of the reputation penalty of a peer each time it responds to a request in time")
+	fs.Float64(ReputationMinInboundScoreKey, constants.DefaultReputationMinInboundScore, "Reputation score, in [0, 1], below which inbound connections from a peer are rejected. If 0, no inbound connections are rejected")
+	fs.Bool(ReputationSamplingEnabledKey, constants.DefaultReputationSamplingEnabled, "If true, consensus polls are biased towards validators with higher reputation scores")
+
 	// Router
 	fs.Duration(ConsensusAcceptedFrontierGossipFrequencyKey, constants.DefaultAcceptedFrontierGossipFrequency, "Frequency of gossiping accepted frontiers")
 	fs.Uint(ConsensusAppConcurrencyKey, constants.DefaultConsensusAppConcurrency, "Maximum number of goroutines to use when handling App messages on a chain")
@@ -341,6 +360,12 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Duration(ProfileContinuousFreqKey, 15*time.Minute, "How frequently to rotate performance profiles")
 	fs.Int(ProfileContinuousMaxFilesKey, 5, "Maximum number of historical profiles to keep")
 
+	// Message capture
+	fs.Bool(NetworkCaptureEnabledKey, false, "Whether the consensus messages exchanged with peers should be captured to disk, to be replayed when debugging")
+	fs.String(NetworkCaptureDirKey, defaultCaptureDir, "Path to the message capture directory")
+	fs.Int(NetworkCaptureMaxFileSizeKey, 64, "Size, in megabytes, a message capture file grows to before it is rotated")
+	fs.Int(NetworkCaptureMaxFilesKey, 5, "Maximum number of rotated message capture files to keep")
+
 	// Aliasing
 	fs.String(VMAliasesFileKey, defaultVMAliasFilePath, fmt.Sprintf("Specifies a JSON file that maps vmIDs with custom aliases. Ignored if %s is specified", VMAliasesContentKey))
 	fs.String(VMAliasesContentKey, "", "Specifies base64 encoded maps vmIDs with custom aliases")
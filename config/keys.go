@@ -102,6 +102,13 @@ const (
 	NetworkRequireValidatorToConnectKey                = "network-require-validator-to-connect"
 	NetworkPeerReadBufferSizeKey                       = "network-peer-read-buffer-size"
 	NetworkPeerWriteBufferSizeKey                      = "network-peer-write-buffer-size"
+	NetworkOutboundQueueStrictPriorityKey              = "network-outbound-queue-strict-priority"
+	NetworkOutboundQueueConsensusWeightKey             = "network-outbound-queue-consensus-weight"
+	NetworkOutboundQueueConsensusMaxBytesKey           = "network-outbound-queue-consensus-max-bytes"
+	NetworkOutboundQueueBootstrappingWeightKey         = "network-outbound-queue-bootstrapping-weight"
+	NetworkOutboundQueueBootstrappingMaxBytesKey       = "network-outbound-queue-bootstrapping-max-bytes"
+	NetworkOutboundQueueApplicationWeightKey           = "network-outbound-queue-application-weight"
+	NetworkOutboundQueueApplicationMaxBytesKey         = "network-outbound-queue-application-max-bytes"
 	NetworkTCPProxyEnabledKey                          = "network-tcp-proxy-enabled"
 	NetworkTCPProxyReadTimeoutKey                      = "network-tcp-proxy-read-timeout"
 	NetworkTLSKeyLogFileKey                            = "network-tls-key-log-file-unsafe"
@@ -112,6 +119,14 @@ const (
 	BenchlistFailThresholdKey                          = "benchlist-fail-threshold"
 	BenchlistDurationKey                               = "benchlist-duration"
 	BenchlistMinFailingDurationKey                     = "benchlist-min-failing-duration"
+	ReputationHalflifeKey                              = "reputation-halflife"
+	ReputationTimeoutPenaltyKey                        = "reputation-timeout-penalty"
+	ReputationInvalidMessagePenaltyKey                 = "reputation-invalid-message-penalty"
+	ReputationBandwidthAbusePenaltyKey                 = "reputation-bandwidth-abuse-penalty"
+	ReputationHandshakeFailurePenaltyKey               = "reputation-handshake-failure-penalty"
+	ReputationResponseRewardKey                        = "reputation-response-reward"
+	ReputationMinInboundScoreKey                       = "reputation-min-inbound-score"
+	ReputationSamplingEnabledKey                       = "reputation-sampling-enabled"
 	LogsDirKey                                         = "log-dir"
 	LogLevelKey                                        = "log-level"
 	LogDisplayLevelKey                                 = "log-display-level"
@@ -178,6 +193,10 @@ const (
 	ProfileContinuousEnabledKey                        = "profile-continuous-enabled"
 	ProfileContinuousFreqKey                           = "profile-continuous-freq"
 	ProfileContinuousMaxFilesKey                       = "profile-continuous-max-files"
+	NetworkCaptureEnabledKey                           = "network-capture-enabled"
+	NetworkCaptureDirKey                               = "network-capture-dir"
+	NetworkCaptureMaxFileSizeKey                       = "network-capture-max-file-size"
+	NetworkCaptureMaxFilesKey                          = "network-capture-max-files"
 	InboundThrottlerAtLargeAllocSizeKey                = "throttler-inbound-at-large-alloc-size"
 	InboundThrottlerVdrAllocSizeKey                    = "throttler-inbound-validator-alloc-size"
 	InboundThrottlerNodeMaxAtLargeBytesKey             = "throttler-inbound-node-max-at-large-bytes"
@@ -13,6 +13,7 @@ import "golang.org/x/exp/slices"
 // when Write is called. A batch cannot be used concurrently.
 type Batch interface {
 	KeyValueWriterDeleter
+	KeyValueRangeDeleter
 
 	// Size retrieves the amount of data queued up for writing, this includes
 	// the keys, values, and deleted keys.
@@ -26,6 +27,9 @@ type Batch interface {
 
 	// Replay replays the batch contents in the same order they were written
 	// to the batch.
+	//
+	// If the batch contains a range deletion and [w] doesn't implement
+	// KeyValueRangeDeleter, ErrRangeDeleteNotSupported is returned.
 	Replay(w KeyValueWriterDeleter) error
 
 	// Inner returns a Batch writing to the inner database, if one exists. If
@@ -45,6 +49,10 @@ type BatchOp struct {
 	Key    []byte
 	Value  []byte
 	Delete bool
+
+	// If DeleteRange is true, all keys in the range [Key, Limit) are deleted.
+	DeleteRange bool
+	Limit       []byte
 }
 
 type BatchOps struct {
@@ -70,6 +78,16 @@ func (b *BatchOps) Delete(key []byte) error {
 	return nil
 }
 
+func (b *BatchOps) DeleteRange(start, limit []byte) error {
+	b.Ops = append(b.Ops, BatchOp{
+		Key:         slices.Clone(start),
+		DeleteRange: true,
+		Limit:       slices.Clone(limit),
+	})
+	b.size += len(start) + len(limit)
+	return nil
+}
+
 func (b *BatchOps) Size() int {
 	return b.size
 }
@@ -81,11 +99,16 @@ func (b *BatchOps) Reset() {
 
 func (b *BatchOps) Replay(w KeyValueWriterDeleter) error {
 	for _, op := range b.Ops {
-		if op.Delete {
-			if err := w.Delete(op.Key); err != nil {
-				return err
-			}
-		} else if err := w.Put(op.Key, op.Value); err != nil {
+		var err error
+		switch {
+		case op.DeleteRange:
+			err = DeleteRange(w, op.Key, op.Limit)
+		case op.Delete:
+			err = w.Delete(op.Key)
+		default:
+			err = w.Put(op.Key, op.Value)
+		}
+		if err != nil {
 			return err
 		}
 	}
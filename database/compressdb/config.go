@@ -0,0 +1,93 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compressdb
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils/compression"
+)
+
+var (
+	// markerKey is written to a database when it is first wrapped by [Wrap].
+	// It is shorter than the prefix of a prefixdb, so it can't collide with
+	// keys written through a prefixdb.
+	markerKey = []byte("compressdb")
+
+	errUnknownCompressionType = errors.New("unknown compression type")
+	errUncompressedDatabase   = errors.New("database contains values written without compressdb")
+)
+
+// Config describes whether, and how, the values of a database are compressed.
+//
+// Because every value is tagged with a header byte, compression must be
+// enabled before any values are written to the database. Enabling compression
+// on a database that already contains values is not supported.
+type Config struct {
+	// Compression is the algorithm used to compress values. If unset, new
+	// values are not compressed, and the database is only wrapped if
+	// compression was previously enabled on it.
+	Compression compression.Type `json:"compression"`
+	// Threshold is the minimum size of a value, in bytes, for the value to be
+	// compressed.
+	Threshold int `json:"threshold"`
+}
+
+// ParseConfig parses [configBytes] into a Config. If [configBytes] is empty,
+// compression is disabled.
+func ParseConfig(configBytes []byte) (Config, error) {
+	config := Config{
+		Threshold: DefaultThreshold,
+	}
+	if len(configBytes) == 0 {
+		return config, nil
+	}
+	err := json.Unmarshal(configBytes, &config)
+	return config, err
+}
+
+// Wrap returns [db] wrapped with a compressdb as described by [config].
+//
+// The first time compression is enabled on [db], a marker is written to it,
+// and an error is returned if [db] already contains values. Once the marker
+// has been written, [db] is always wrapped, even if compression is disabled,
+// so that the values written with compression can still be read. If
+// compression is disabled and the marker was never written, [db] is returned
+// unmodified.
+func Wrap(config Config, db database.Database) (database.Database, error) {
+	wrapped, err := db.Has(markerKey)
+	if err != nil {
+		return nil, err
+	}
+
+	typ := config.Compression
+	if typ == 0 {
+		if !wrapped {
+			return db, nil
+		}
+		typ = compression.TypeNone
+	}
+
+	compressDB, err := New(typ, config.Threshold, db)
+	if err != nil {
+		return nil, err
+	}
+	if wrapped {
+		return compressDB, nil
+	}
+
+	isEmpty, err := database.IsEmpty(db)
+	if err != nil {
+		return nil, err
+	}
+	if !isEmpty {
+		return nil, fmt.Errorf("%w: compression can only be enabled on an empty database", errUncompressedDatabase)
+	}
+	// The marker is written as a compressdb value so that it can be read
+	// through the returned database.
+	return compressDB, compressDB.Put(markerKey, nil)
+}
@@ -0,0 +1,469 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compressdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils/compression"
+)
+
+const (
+	// DefaultThreshold is the default minimum size of a value, in bytes, for
+	// the value to be compressed.
+	DefaultThreshold = 1024
+
+	// maxValueSize is the maximum size of a value that will be compressed.
+	// Larger values are stored uncompressed.
+	maxValueSize = math.MaxInt32
+)
+
+var (
+	_ database.Database      = (*Database)(nil)
+	_ database.RangeIteratee = (*Database)(nil)
+	_ database.Snapshotter   = (*Database)(nil)
+	_ database.Snapshot      = (*dbSnapshot)(nil)
+	_ database.RangeIteratee = (*dbSnapshot)(nil)
+	_ database.Batch         = (*batch)(nil)
+	_ database.Iterator      = (*iterator)(nil)
+
+	errMissingHeader = errors.New("value is missing the compression header")
+)
+
+// Database compresses all values that are provided, whose size is at least the
+// threshold.
+//
+// Each value written to the underlying database is prefixed with a header byte
+// that denotes the compression.Type used to compress it. This allows values to
+// be read after the compression type of the database has been changed.
+// However, values written to the underlying database by anything other than a
+// compressdb can't be read.
+type Database struct {
+	lock      sync.RWMutex
+	db        database.Database
+	typ       compression.Type
+	threshold int
+	closed    bool
+
+	compressorsLock sync.Mutex
+	compressors     map[compression.Type]compression.Compressor
+}
+
+// New returns a new database that compresses values with [typ] whose size is
+// at least [threshold] bytes.
+func New(typ compression.Type, threshold int, db database.Database) (*Database, error) {
+	compressDB := &Database{
+		db:          db,
+		typ:         typ,
+		threshold:   threshold,
+		compressors: make(map[compression.Type]compression.Compressor),
+	}
+	// Make sure that [typ] is supported.
+	_, err := compressDB.compressor(typ)
+	return compressDB, err
+}
+
+func (db *Database) Has(key []byte) (bool, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return false, database.ErrClosed
+	}
+	return db.db.Has(key)
+}
+
+func (db *Database) Get(key []byte) ([]byte, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return nil, database.ErrClosed
+	}
+	compressedValue, err := db.db.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	return db.decompress(compressedValue)
+}
+
+func (db *Database) Put(key, value []byte) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+
+	compressedValue, err := db.compress(value)
+	if err != nil {
+		return err
+	}
+	return db.db.Put(key, compressedValue)
+}
+
+func (db *Database) Delete(key []byte) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+	return db.db.Delete(key)
+}
+
+func (db *Database) DeleteRange(start, limit []byte) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+	return db.db.DeleteRange(start, limit)
+}
+
+func (db *Database) NewBatch() database.Batch {
+	return &batch{
+		Batch: db.db.NewBatch(),
+		db:    db,
+	}
+}
+
+func (db *Database) NewIterator() database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (db *Database) NewIteratorWithStart(start []byte) database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (db *Database) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return db.NewIteratorWithOptions(database.IteratorOptions{
+		Start:  start,
+		Prefix: prefix,
+	})
+}
+
+func (db *Database) NewIteratorWithOptions(opts database.IteratorOptions) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return &database.IteratorError{
+			Err: database.ErrClosed,
+		}
+	}
+	return &iterator{
+		Iterator: database.NewIteratorWithOptions(db.db, opts),
+		db:       db,
+	}
+}
+
+// NewSnapshot returns a read-only view of this database. The underlying
+// database must implement database.Snapshotter.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return nil, database.ErrClosed
+	}
+	snapshot, err := database.NewSnapshot(db.db)
+	if err != nil {
+		return nil, err
+	}
+	return &dbSnapshot{
+		Snapshot: snapshot,
+		db:       db,
+	}, nil
+}
+
+func (db *Database) Compact(start, limit []byte) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+	return db.db.Compact(start, limit)
+}
+
+func (db *Database) Close() error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+	db.closed = true
+	return nil
+}
+
+func (db *Database) isClosed() bool {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	return db.closed
+}
+
+func (db *Database) HealthCheck(ctx context.Context) (interface{}, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return nil, database.ErrClosed
+	}
+	return db.db.HealthCheck(ctx)
+}
+
+// compress returns [value] prefixed with the compression header. [value] is
+// only compressed if it is at least [db.threshold] bytes and compressing it
+// reduces its size.
+func (db *Database) compress(value []byte) ([]byte, error) {
+	if db.typ != compression.TypeNone && len(value) >= db.threshold && len(value) <= maxValueSize {
+		compressor, err := db.compressor(db.typ)
+		if err != nil {
+			return nil, err
+		}
+		compressedValue, err := compressor.Compress(value)
+		if err != nil {
+			return nil, err
+		}
+		if len(compressedValue) < len(value) {
+			return withHeader(db.typ, compressedValue), nil
+		}
+	}
+	return withHeader(compression.TypeNone, value), nil
+}
+
+// decompress returns the original value of a value returned by [compress].
+func (db *Database) decompress(compressedValue []byte) ([]byte, error) {
+	if len(compressedValue) == 0 {
+		return nil, errMissingHeader
+	}
+
+	typ := compression.Type(compressedValue[0])
+	value := compressedValue[1:]
+	if typ == compression.TypeNone {
+		return value, nil
+	}
+
+	compressor, err := db.compressor(typ)
+	if err != nil {
+		return nil, err
+	}
+	return compressor.Decompress(value)
+}
+
+// compressor returns the compressor for [typ], creating it if needed.
+func (db *Database) compressor(typ compression.Type) (compression.Compressor, error) {
+	db.compressorsLock.Lock()
+	defer db.compressorsLock.Unlock()
+
+	if compressor, ok := db.compressors[typ]; ok {
+		return compressor, nil
+	}
+
+	var (
+		compressor compression.Compressor
+		err        error
+	)
+	switch typ {
+	case compression.TypeNone:
+		compressor = compression.NewNoCompressor()
+	case compression.TypeGzip:
+		compressor, err = compression.NewGzipCompressor(maxValueSize)
+	case compression.TypeZstd:
+		compressor, err = compression.NewZstdCompressor(maxValueSize)
+	default:
+		err = fmt.Errorf("%w: %d", errUnknownCompressionType, typ)
+	}
+	if err != nil {
+		return nil, err
+	}
+	db.compressors[typ] = compressor
+	return compressor, nil
+}
+
+func withHeader(typ compression.Type, value []byte) []byte {
+	valueWithHeader := make([]byte, len(value)+1)
+	valueWithHeader[0] = byte(typ)
+	copy(valueWithHeader[1:], value)
+	return valueWithHeader
+}
+
+type batch struct {
+	database.Batch
+
+	db  *Database
+	ops []database.BatchOp
+}
+
+func (b *batch) Put(key, value []byte) error {
+	b.ops = append(b.ops, database.BatchOp{
+		Key:   slices.Clone(key),
+		Value: slices.Clone(value),
+	})
+	compressedValue, err := b.db.compress(value)
+	if err != nil {
+		return err
+	}
+	return b.Batch.Put(key, compressedValue)
+}
+
+func (b *batch) Delete(key []byte) error {
+	b.ops = append(b.ops, database.BatchOp{
+		Key:    slices.Clone(key),
+		Delete: true,
+	})
+	return b.Batch.Delete(key)
+}
+
+func (b *batch) DeleteRange(start, limit []byte) error {
+	b.ops = append(b.ops, database.BatchOp{
+		Key:         slices.Clone(start),
+		DeleteRange: true,
+		Limit:       slices.Clone(limit),
+	})
+	return b.Batch.DeleteRange(start, limit)
+}
+
+func (b *batch) Write() error {
+	b.db.lock.RLock()
+	defer b.db.lock.RUnlock()
+
+	if b.db.closed {
+		return database.ErrClosed
+	}
+	return b.Batch.Write()
+}
+
+// Reset resets the batch for reuse.
+func (b *batch) Reset() {
+	if cap(b.ops) > len(b.ops)*database.MaxExcessCapacityFactor {
+		b.ops = make([]database.BatchOp, 0, cap(b.ops)/database.CapacityReductionFactor)
+	} else {
+		b.ops = b.ops[:0]
+	}
+	b.Batch.Reset()
+}
+
+// Replay replays the batch contents.
+func (b *batch) Replay(w database.KeyValueWriterDeleter) error {
+	for _, op := range b.ops {
+		var err error
+		switch {
+		case op.DeleteRange:
+			err = database.DeleteRange(w, op.Key, op.Limit)
+		case op.Delete:
+			err = w.Delete(op.Key)
+		default:
+			err = w.Put(op.Key, op.Value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dbSnapshot decompresses all values read from the snapshot of the underlying
+// database.
+type dbSnapshot struct {
+	database.Snapshot
+	db *Database
+}
+
+func (s *dbSnapshot) Get(key []byte) ([]byte, error) {
+	compressedValue, err := s.Snapshot.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	return s.db.decompress(compressedValue)
+}
+
+func (s *dbSnapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *dbSnapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *dbSnapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (s *dbSnapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return s.NewIteratorWithOptions(database.IteratorOptions{
+		Start:  start,
+		Prefix: prefix,
+	})
+}
+
+func (s *dbSnapshot) NewIteratorWithOptions(opts database.IteratorOptions) database.Iterator {
+	return &iterator{
+		Iterator: database.NewIteratorWithOptions(s.Snapshot, opts),
+		db:       s.db,
+	}
+}
+
+type iterator struct {
+	database.Iterator
+	db *Database
+
+	key, val []byte
+	err      error
+}
+
+func (it *iterator) Next() bool {
+	// Short-circuit and set an error if the underlying database has been closed.
+	if it.db.isClosed() {
+		it.key = nil
+		it.val = nil
+		it.err = database.ErrClosed
+		return false
+	}
+
+	next := it.Iterator.Next()
+	if next {
+		val, err := it.db.decompress(it.Iterator.Value())
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.key = it.Iterator.Key()
+		it.val = val
+	} else {
+		it.key = nil
+		it.val = nil
+	}
+	return next
+}
+
+func (it *iterator) Error() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.Iterator.Error()
+}
+
+func (it *iterator) Key() []byte {
+	return it.key
+}
+
+func (it *iterator) Value() []byte {
+	return it.val
+}
@@ -0,0 +1,201 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compressdb
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/utils/compression"
+)
+
+var testTypes = []compression.Type{
+	compression.TypeNone,
+	compression.TypeGzip,
+	compression.TypeZstd,
+}
+
+func TestInterface(t *testing.T) {
+	for _, typ := range testTypes {
+		for _, test := range database.Tests {
+			// A threshold of 0 makes sure that every value is compressed.
+			db, err := New(typ, 0, memdb.New())
+			require.NoError(t, err)
+
+			test(t, db)
+		}
+	}
+}
+
+func TestCompression(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db, err := New(compression.TypeZstd, 16, baseDB)
+	require.NoError(err)
+
+	smallKey := []byte("small")
+	smallValue := []byte("tiny")
+	largeKey := []byte("large")
+	largeValue := bytes.Repeat([]byte("compressible"), 100)
+
+	require.NoError(db.Put(smallKey, smallValue))
+	require.NoError(db.Put(largeKey, largeValue))
+
+	// Values below the threshold are only tagged with the header.
+	rawSmallValue, err := baseDB.Get(smallKey)
+	require.NoError(err)
+	require.Equal(append([]byte{byte(compression.TypeNone)}, smallValue...), rawSmallValue)
+
+	rawLargeValue, err := baseDB.Get(largeKey)
+	require.NoError(err)
+	require.Equal(byte(compression.TypeZstd), rawLargeValue[0])
+	require.Less(len(rawLargeValue), len(largeValue))
+
+	value, err := db.Get(largeKey)
+	require.NoError(err)
+	require.Equal(largeValue, value)
+
+	// Values compressed with a different type should still be readable.
+	gzipDB, err := New(compression.TypeGzip, 16, baseDB)
+	require.NoError(err)
+
+	value, err = gzipDB.Get(largeKey)
+	require.NoError(err)
+	require.Equal(largeValue, value)
+
+	// Values not written by a compressdb can't be read.
+	require.NoError(baseDB.Put(smallKey, nil))
+	_, err = db.Get(smallKey)
+	require.ErrorIs(err, errMissingHeader)
+}
+
+func TestNewUnknownType(t *testing.T) {
+	_, err := New(compression.Type(0), DefaultThreshold, memdb.New())
+	require.ErrorIs(t, err, errUnknownCompressionType)
+}
+
+func TestWrap(t *testing.T) {
+	require := require.New(t)
+
+	config, err := ParseConfig(nil)
+	require.NoError(err)
+
+	baseDB := memdb.New()
+	db, err := Wrap(config, baseDB)
+	require.NoError(err)
+	require.Equal(baseDB, db)
+
+	config, err = ParseConfig([]byte(`{"compression":"zstd","threshold":512}`))
+	require.NoError(err)
+	require.Equal(
+		Config{
+			Compression: compression.TypeZstd,
+			Threshold:   512,
+		},
+		config,
+	)
+
+	db, err = Wrap(config, baseDB)
+	require.NoError(err)
+	require.IsType(&Database{}, db)
+}
+
+func TestWrapUncompressedDatabase(t *testing.T) {
+	require := require.New(t)
+
+	// The value starts with the header byte of an uncompressed value, so it
+	// would be silently truncated if it were read through a compressdb.
+	key := []byte("key")
+	value := []byte{byte(compression.TypeNone), 2, 3}
+	baseDB := memdb.New()
+	require.NoError(baseDB.Put(key, value))
+
+	config := Config{
+		Compression: compression.TypeZstd,
+		Threshold:   DefaultThreshold,
+	}
+	_, err := Wrap(config, baseDB)
+	require.ErrorIs(err, errUncompressedDatabase)
+
+	// The database is left untouched.
+	has, err := baseDB.Has(markerKey)
+	require.NoError(err)
+	require.False(has)
+
+	gotValue, err := baseDB.Get(key)
+	require.NoError(err)
+	require.Equal(value, gotValue)
+}
+
+func TestWrapDisableCompression(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db, err := Wrap(
+		Config{
+			Compression: compression.TypeZstd,
+			Threshold:   0,
+		},
+		baseDB,
+	)
+	require.NoError(err)
+
+	key := []byte("key")
+	value := bytes.Repeat([]byte("compressible"), 100)
+	require.NoError(db.Put(key, value))
+
+	// Re-wrapping the database is allowed because it was written by a
+	// compressdb.
+	_, err = Wrap(
+		Config{
+			Compression: compression.TypeGzip,
+			Threshold:   0,
+		},
+		baseDB,
+	)
+	require.NoError(err)
+
+	// Once compression has been enabled, the database stays wrapped so that
+	// compressed values can still be read.
+	config, err := ParseConfig(nil)
+	require.NoError(err)
+	db, err = Wrap(config, baseDB)
+	require.NoError(err)
+	require.IsType(&Database{}, db)
+
+	gotValue, err := db.Get(key)
+	require.NoError(err)
+	require.Equal(value, gotValue)
+}
+
+func FuzzKeyValue(f *testing.F) {
+	db, err := New(compression.TypeZstd, 0, memdb.New())
+	require.NoError(f, err)
+	database.FuzzKeyValue(f, db)
+}
+
+func FuzzNewIteratorWithPrefix(f *testing.F) {
+	db, err := New(compression.TypeZstd, 0, memdb.New())
+	require.NoError(f, err)
+	database.FuzzNewIteratorWithPrefix(f, db)
+}
+
+func BenchmarkInterface(b *testing.B) {
+	for _, size := range database.BenchmarkSizes {
+		keys, values := database.SetupBenchmark(b, size[0], size[1], size[2])
+		for _, typ := range testTypes {
+			for _, bench := range database.Benchmarks {
+				db, err := New(typ, DefaultThreshold, memdb.New())
+				require.NoError(b, err)
+				bench(b, db, fmt.Sprintf("compressdb_%s", typ), keys, values)
+			}
+		}
+	}
+}
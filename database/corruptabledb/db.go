@@ -12,8 +12,12 @@ import (
 )
 
 var (
-	_ database.Database = (*Database)(nil)
-	_ database.Batch    = (*batch)(nil)
+	_ database.Database      = (*Database)(nil)
+	_ database.RangeIteratee = (*Database)(nil)
+	_ database.Snapshotter   = (*Database)(nil)
+	_ database.Snapshot      = (*dbSnapshot)(nil)
+	_ database.RangeIteratee = (*dbSnapshot)(nil)
+	_ database.Batch         = (*batch)(nil)
 )
 
 // CorruptableDB is a wrapper around Database
@@ -67,10 +71,35 @@ func (db *Database) Delete(key []byte) error {
 	return db.handleError(db.Database.Delete(key))
 }
 
+// DeleteRange removes all keys in the range [start, limit) from the database
+func (db *Database) DeleteRange(start, limit []byte) error {
+	if err := db.corrupted(); err != nil {
+		return err
+	}
+	return db.handleError(db.Database.DeleteRange(start, limit))
+}
+
 func (db *Database) Compact(start []byte, limit []byte) error {
 	return db.handleError(db.Database.Compact(start, limit))
 }
 
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	if err := db.corrupted(); err != nil {
+		return nil, err
+	}
+	snapshot, err := database.NewSnapshot(db.Database)
+	if err == database.ErrSnapshotNotSupported {
+		return nil, err
+	}
+	if err := db.handleError(err); err != nil {
+		return nil, err
+	}
+	return &dbSnapshot{
+		Snapshot: snapshot,
+		db:       db,
+	}, nil
+}
+
 func (db *Database) Close() error {
 	return db.handleError(db.Database.Close())
 }
@@ -111,8 +140,15 @@ func (db *Database) NewIteratorWithPrefix(prefix []byte) database.Iterator {
 }
 
 func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return db.NewIteratorWithOptions(database.IteratorOptions{
+		Start:  start,
+		Prefix: prefix,
+	})
+}
+
+func (db *Database) NewIteratorWithOptions(opts database.IteratorOptions) database.Iterator {
 	return &iterator{
-		Iterator: db.Database.NewIteratorWithStartAndPrefix(start, prefix),
+		Iterator: database.NewIteratorWithOptions(db.Database, opts),
 		db:       db,
 	}
 }
@@ -156,6 +192,63 @@ func (b *batch) Write() error {
 	return b.db.handleError(b.Batch.Write())
 }
 
+// dbSnapshot is a wrapper around a snapshot of the underlying database.
+type dbSnapshot struct {
+	database.Snapshot
+	db *Database
+}
+
+func (s *dbSnapshot) Has(key []byte) (bool, error) {
+	if err := s.db.corrupted(); err != nil {
+		return false, err
+	}
+	has, err := s.Snapshot.Has(key)
+	return has, s.db.handleError(err)
+}
+
+func (s *dbSnapshot) Get(key []byte) ([]byte, error) {
+	if err := s.db.corrupted(); err != nil {
+		return nil, err
+	}
+	value, err := s.Snapshot.Get(key)
+	return value, s.db.handleError(err)
+}
+
+func (s *dbSnapshot) NewIterator() database.Iterator {
+	return &iterator{
+		Iterator: s.Snapshot.NewIterator(),
+		db:       s.db,
+	}
+}
+
+func (s *dbSnapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return &iterator{
+		Iterator: s.Snapshot.NewIteratorWithStart(start),
+		db:       s.db,
+	}
+}
+
+func (s *dbSnapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return &iterator{
+		Iterator: s.Snapshot.NewIteratorWithPrefix(prefix),
+		db:       s.db,
+	}
+}
+
+func (s *dbSnapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return s.NewIteratorWithOptions(database.IteratorOptions{
+		Start:  start,
+		Prefix: prefix,
+	})
+}
+
+func (s *dbSnapshot) NewIteratorWithOptions(opts database.IteratorOptions) database.Iterator {
+	return &iterator{
+		Iterator: database.NewIteratorWithOptions(s.Snapshot, opts),
+		db:       s.db,
+	}
+}
+
 type iterator struct {
 	database.Iterator
 	db *Database
@@ -49,6 +49,26 @@ type KeyValueDeleter interface {
 	Delete(key []byte) error
 }
 
+// KeyValueRangeDeleter wraps the DeleteRange method of a backing data store.
+type KeyValueRangeDeleter interface {
+	// DeleteRange removes all keys in the range [start, limit) from the
+	// key-value data store.
+	//
+	// A nil start is treated as a key before all keys in the data store.
+	// And a nil, or empty, limit is treated as a key after all keys in the
+	// data store. Therefore if both are nil then all keys are removed.
+	//
+	// DeleteRange isn't guaranteed to be atomic. Some implementations, such
+	// as leveldb, delete a large range in multiple writes, so if an error is
+	// returned, or the process stops, only some of the keys in the range may
+	// have been removed. Callers that need the range to be removed atomically
+	// should add it to a Batch instead.
+	//
+	// Note: [start] and [limit] are safe to modify and read after calling
+	// DeleteRange.
+	DeleteRange(start []byte, limit []byte) error
+}
+
 // KeyValueReaderWriter allows read/write acccess to a backing data store.
 type KeyValueReaderWriter interface {
 	KeyValueReader
@@ -84,10 +104,33 @@ type Compacter interface {
 	Compact(start []byte, limit []byte) error
 }
 
+// Snapshot is a read-only view of a backing data store, frozen at the time the
+// snapshot was created. Writes to the data store after the snapshot was created
+// are not visible through the snapshot.
+type Snapshot interface {
+	KeyValueReader
+	Iteratee
+
+	// Release releases the resources held by the snapshot. Release should
+	// always succeed and can be called multiple times without causing error.
+	// After Release is called, reads from the snapshot return ErrClosed.
+	Release()
+}
+
+// Snapshotter wraps the NewSnapshot method of a backing data store.
+type Snapshotter interface {
+	// NewSnapshot creates a consistent, read-only view of the data store at
+	// the time of the call.
+	//
+	// The returned snapshot must be released after use.
+	NewSnapshot() (Snapshot, error)
+}
+
 // Database contains all the methods required to allow handling different
 // key-value data stores backing the database.
 type Database interface {
 	KeyValueReaderWriterDeleter
+	KeyValueRangeDeleter
 	Batcher
 	Iteratee
 	Compacter
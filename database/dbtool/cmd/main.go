@@ -0,0 +1,358 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/dbtool"
+	"github.com/ava-labs/avalanchego/database/leveldb"
+	"github.com/ava-labs/avalanchego/database/manager"
+	"github.com/ava-labs/avalanchego/database/migration"
+	"github.com/ava-labs/avalanchego/database/pebbledb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+const (
+	// readOnlyConfig opens the database without modifying it and without
+	// polling metrics.
+	readOnlyConfig = `{"readOnly":true,"metricUpdateFrequency":0}`
+	// readWriteConfig opens the database without polling metrics.
+	readWriteConfig = `{"metricUpdateFrequency":0}`
+)
+
+var (
+	errDBDirRequired = errors.New("--db-dir is required")
+	errUnknownDBType = errors.New("unknown db-type")
+)
+
+func main() {
+	var (
+		dbDir    string
+		dbType   string
+		chainIDs []string
+	)
+	rootCmd := &cobra.Command{
+		Use:   "dbtool",
+		Short: "Inspects and maintains the database of a stopped node",
+	}
+	rootFlags := rootCmd.PersistentFlags()
+	rootFlags.StringVar(&dbDir, "db-dir", "", "Path to the database directory of the node, including the network name")
+	rootFlags.StringVar(&dbType, "db-type", leveldb.Name, fmt.Sprintf("Database type. Should be one of {%s, %s}", leveldb.Name, pebbledb.Name))
+	rootFlags.StringSliceVar(&chainIDs, "chain-id", nil, "IDs of the chains whose prefixes should be labeled or whose state should be migrated. The P-chain is always included. Migrating requires every chain in the previous database")
+
+	// withManager opens the versioned databases described by the root flags
+	// with [dbConfig], passes them to [f], and closes them once [f] returns.
+	withManager := func(dbConfig string, f func(dbManager manager.Manager) error) error {
+		if len(dbDir) == 0 {
+			return errDBDirRequired
+		}
+
+		var newManager func(string, []byte, logging.Logger, *version.Semantic, string, prometheus.Registerer) (manager.Manager, error)
+		switch dbType {
+		case leveldb.Name:
+			newManager = manager.NewLevelDB
+		case pebbledb.Name:
+			newManager = manager.NewPebbleDB
+		default:
+			return fmt.Errorf("%w: %q", errUnknownDBType, dbType)
+		}
+
+		dbManager, err := newManager(
+			dbDir,
+			[]byte(dbConfig),
+			logging.NoLog{},
+			version.CurrentDatabase,
+			"",
+			prometheus.NewRegistry(),
+		)
+		if err != nil {
+			return err
+		}
+
+		err = f(dbManager)
+		if closeErr := dbManager.Close(); err == nil {
+			err = closeErr
+		}
+		return err
+	}
+
+	// withDB opens the current database and the labels described by the root
+	// flags, passes them to [f], and closes the database once [f] returns.
+	withDB := func(f func(db database.Database, labels *dbtool.Labels) error) error {
+		labels, err := newLabels(chainIDs)
+		if err != nil {
+			return err
+		}
+		return withManager(readOnlyConfig, func(dbManager manager.Manager) error {
+			return f(dbManager.Current().Database, labels)
+		})
+	}
+
+	prefixesCmd := &cobra.Command{
+		Use:   "prefixes",
+		Short: "Lists the prefixes in the database",
+		RunE: func(*cobra.Command, []string) error {
+			return withDB(func(db database.Database, labels *dbtool.Labels) error {
+				stats, err := dbtool.Stats(db, labels)
+				if err != nil {
+					return err
+				}
+
+				w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+				fmt.Fprintln(w, "PREFIX\tNAME\tKEYS")
+				for _, s := range stats {
+					fmt.Fprintf(w, "%s\t%s\t%d\n", formatPrefix(s.Prefix), s.Name, s.NumKeys)
+				}
+				return w.Flush()
+			})
+		},
+	}
+	rootCmd.AddCommand(prefixesCmd)
+
+	sizesCmd := &cobra.Command{
+		Use:   "sizes",
+		Short: "Reports the number of bytes stored under each prefix",
+		RunE: func(*cobra.Command, []string) error {
+			return withDB(func(db database.Database, labels *dbtool.Labels) error {
+				stats, err := dbtool.Stats(db, labels)
+				if err != nil {
+					return err
+				}
+
+				w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+				fmt.Fprintln(w, "PREFIX\tNAME\tKEYS\tKEY BYTES\tVALUE BYTES\tTOTAL BYTES")
+				var total dbtool.PrefixStats
+				for _, s := range stats {
+					fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t%d\n", formatPrefix(s.Prefix), s.Name, s.NumKeys, s.KeySize, s.ValueSize, s.Size())
+					total.NumKeys += s.NumKeys
+					total.KeySize += s.KeySize
+					total.ValueSize += s.ValueSize
+				}
+				fmt.Fprintf(w, "\ttotal\t%d\t%d\t%d\t%d\n", total.NumKeys, total.KeySize, total.ValueSize, total.Size())
+				return w.Flush()
+			})
+		},
+	}
+	rootCmd.AddCommand(sizesCmd)
+
+	var countPrefix string
+	countCmd := &cobra.Command{
+		Use:   "count",
+		Short: "Counts the keys in the database",
+		RunE: func(*cobra.Command, []string) error {
+			return withDB(func(db database.Database, labels *dbtool.Labels) error {
+				prefix, err := parsePrefix(labels, countPrefix)
+				if err != nil {
+					return err
+				}
+
+				count, err := dbtool.Count(db, prefix)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(os.Stdout, count)
+				return nil
+			})
+		},
+	}
+	countCmd.Flags().StringVar(&countPrefix, "prefix", "", "Only count keys that start with this prefix. Either a name reported by the prefixes command or hex")
+	rootCmd.AddCommand(countCmd)
+
+	var (
+		dumpPrefix string
+		dumpStart  string
+		dumpEnd    string
+		dumpLimit  int
+		dumpFormat string
+	)
+	dumpCmd := &cobra.Command{
+		Use:   "dump",
+		Short: "Dumps a range of key-value pairs in the database",
+		RunE: func(*cobra.Command, []string) error {
+			return withDB(func(db database.Database, labels *dbtool.Labels) error {
+				prefix, err := parsePrefix(labels, dumpPrefix)
+				if err != nil {
+					return err
+				}
+				start, err := hex.DecodeString(dumpStart)
+				if err != nil {
+					return fmt.Errorf("invalid --start: %w", err)
+				}
+				end, err := hex.DecodeString(dumpEnd)
+				if err != nil {
+					return fmt.Errorf("invalid --end: %w", err)
+				}
+
+				_, err = dbtool.Dump(
+					os.Stdout,
+					db,
+					dbtool.Range{
+						Prefix: prefix,
+						Start:  start,
+						End:    end,
+						Limit:  dumpLimit,
+					},
+					dbtool.Format(dumpFormat),
+				)
+				return err
+			})
+		},
+	}
+	dumpFlags := dumpCmd.Flags()
+	dumpFlags.StringVar(&dumpPrefix, "prefix", "", "Only dump keys that start with this prefix. Either a name reported by the prefixes command or hex")
+	dumpFlags.StringVar(&dumpStart, "start", "", "Hex encoded key to start dumping from, inclusive")
+	dumpFlags.StringVar(&dumpEnd, "end", "", "Hex encoded key to stop dumping at, exclusive")
+	dumpFlags.IntVar(&dumpLimit, "limit", 100, "Maximum number of key-value pairs to dump. If <= 0, there is no limit")
+	dumpFlags.StringVar(&dumpFormat, "format", string(dbtool.Hex), fmt.Sprintf("Output format. Should be one of {%s, %s}", dbtool.Hex, dbtool.JSON))
+	rootCmd.AddCommand(dumpCmd)
+
+	versionsCmd := &cobra.Command{
+		Use:   "versions",
+		Short: "Lists the versioned databases and whether they can be deleted",
+		RunE: func(*cobra.Command, []string) error {
+			return withManager(readOnlyConfig, func(dbManager manager.Manager) error {
+				deletable, err := migration.Deletable(dbManager)
+				if err != nil {
+					return err
+				}
+				isDeletable := make(map[string]bool, len(deletable))
+				for _, v := range deletable {
+					isDeletable[v.String()] = true
+				}
+
+				currentVersion := dbManager.Current().Version
+				w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+				fmt.Fprintln(w, "VERSION\tCURRENT\tDELETABLE")
+				for _, db := range dbManager.GetDatabases() {
+					v := db.Version.String()
+					fmt.Fprintf(w, "%s\t%t\t%t\n", v, v == currentVersion.String(), isDeletable[v])
+				}
+				return w.Flush()
+			})
+		},
+	}
+	rootCmd.AddCommand(versionsCmd)
+
+	var migrateBatchSize int
+	migrateCmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Copies the state of the node, of the P-chain and of each --chain-id from the previous database into the current database",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			parsedChainIDs, err := parseChainIDs(chainIDs)
+			if err != nil {
+				return err
+			}
+			return withManager(readWriteConfig, func(dbManager manager.Manager) error {
+				if err := dbtool.Migrate(cmd.Context(), logging.NoLog{}, dbManager, parsedChainIDs, migrateBatchSize); err != nil {
+					return err
+				}
+
+				deletable, err := migration.Deletable(dbManager)
+				if err != nil {
+					return err
+				}
+				if len(deletable) == 0 {
+					fmt.Fprintln(os.Stdout, "no previous database was migrated")
+					return nil
+				}
+				for _, v := range deletable {
+					fmt.Fprintf(os.Stdout, "migrated %s\n", v)
+				}
+				return nil
+			})
+		},
+	}
+	migrateCmd.Flags().IntVar(&migrateBatchSize, "batch-size", 4*units.MiB, "Number of bytes to write between checkpoints")
+	rootCmd.AddCommand(migrateCmd)
+
+	var dryRun bool
+	deletePreviousCmd := &cobra.Command{
+		Use:   "delete-previous",
+		Short: "Deletes the previous versioned databases that have been migrated into the current database",
+		RunE: func(*cobra.Command, []string) error {
+			var deletable []*version.Semantic
+			err := withManager(readOnlyConfig, func(dbManager manager.Manager) error {
+				var err error
+				deletable, err = migration.Deletable(dbManager)
+				return err
+			})
+			if err != nil {
+				return err
+			}
+			if len(deletable) == 0 {
+				fmt.Fprintln(os.Stdout, "no previous databases can be deleted")
+				return nil
+			}
+
+			if dryRun {
+				for _, v := range deletable {
+					fmt.Fprintf(os.Stdout, "would delete %s\n", filepath.Join(dbDir, v.String()))
+				}
+				return nil
+			}
+
+			// The databases must be closed before they are deleted.
+			deleted, err := dbtool.DeletePrevious(dbDir, deletable)
+			for _, path := range deleted {
+				fmt.Fprintf(os.Stdout, "deleted %s\n", path)
+			}
+			return err
+		},
+	}
+	deletePreviousCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only report the databases that would be deleted")
+	rootCmd.AddCommand(deletePreviousCmd)
+
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Fprintf(os.Stderr, "dbtool failed %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func newLabels(chainIDStrs []string) (*dbtool.Labels, error) {
+	chainIDs, err := parseChainIDs(chainIDStrs)
+	if err != nil {
+		return nil, err
+	}
+	return dbtool.NewLabels(chainIDs), nil
+}
+
+func parseChainIDs(chainIDStrs []string) ([]ids.ID, error) {
+	chainIDs := make([]ids.ID, len(chainIDStrs))
+	for i, chainIDStr := range chainIDStrs {
+		chainID, err := ids.FromString(chainIDStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --chain-id %q: %w", chainIDStr, err)
+		}
+		chainIDs[i] = chainID
+	}
+	return chainIDs, nil
+}
+
+func parsePrefix(labels *dbtool.Labels, prefix string) ([]byte, error) {
+	if len(prefix) == 0 {
+		return nil, nil
+	}
+	return labels.Prefix(prefix)
+}
+
+func formatPrefix(prefix []byte) string {
+	if prefix == nil {
+		return "-"
+	}
+	return hex.EncodeToString(prefix)
+}
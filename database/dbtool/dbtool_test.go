@@ -0,0 +1,250 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dbtool
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database/manager"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/migration"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+func TestStats(t *testing.T) {
+	require := require.New(t)
+
+	chainID := ids.GenerateTestID()
+	labels := NewLabels([]ids.ID{chainID})
+
+	// Mirror the prefixes used by the chain manager.
+	db := memdb.New()
+	chainDB := prefixdb.New(chainID[:], db)
+	vmDB := prefixdb.New([]byte("vm"), chainDB)
+	unknownDB := prefixdb.New([]byte("unknown"), db)
+
+	require.NoError(db.Put([]byte("genesisID"), []byte{1, 2}))
+	require.NoError(vmDB.Put([]byte{1}, []byte{1, 2, 3}))
+	require.NoError(vmDB.Put([]byte{2}, []byte{1}))
+	require.NoError(unknownDB.Put([]byte{3}, nil))
+
+	stats, err := Stats(db, labels)
+	require.NoError(err)
+
+	vmPrefix, err := labels.Prefix(fmt.Sprintf("chain/%s/vm", chainID))
+	require.NoError(err)
+
+	expected := map[string]*PrefixStats{
+		"": {
+			NumKeys:   1,
+			KeySize:   9,
+			ValueSize: 2,
+		},
+		string(vmPrefix): {
+			Prefix:    vmPrefix,
+			Name:      fmt.Sprintf("chain/%s/vm", chainID),
+			NumKeys:   2,
+			KeySize:   2 * (PrefixLen + 1),
+			ValueSize: 4,
+		},
+		string(prefixdb.MakePrefix([]byte("unknown"))): {
+			Prefix:  prefixdb.MakePrefix([]byte("unknown")),
+			NumKeys: 1,
+			KeySize: PrefixLen + 1,
+		},
+	}
+	require.Len(stats, len(expected))
+	require.Nil(stats[0].Prefix)
+	for i, s := range stats {
+		require.Equal(expected[string(s.Prefix)], s)
+		if i > 0 {
+			require.Negative(bytes.Compare(stats[i-1].Prefix, s.Prefix))
+		}
+	}
+
+	count, err := Count(db, vmPrefix)
+	require.NoError(err)
+	require.Equal(uint64(2), count)
+}
+
+func TestLabelsPrefix(t *testing.T) {
+	require := require.New(t)
+
+	labels := NewLabels(nil)
+
+	prefix, err := labels.Prefix("node/keystore")
+	require.NoError(err)
+	require.Equal(prefixdb.MakePrefix([]byte("keystore")), prefix)
+
+	name, ok := labels.Name(prefix)
+	require.True(ok)
+	require.Equal("node/keystore", name)
+
+	prefix, err = labels.Prefix("0a0b")
+	require.NoError(err)
+	require.Equal([]byte{0x0a, 0x0b}, prefix)
+
+	_, err = labels.Prefix("not a prefix")
+	require.ErrorIs(err, errInvalidPrefix)
+}
+
+func TestDump(t *testing.T) {
+	db := memdb.New()
+	for i := byte(0); i < 5; i++ {
+		require.NoError(t, db.Put([]byte{0x01, i}, []byte{i}))
+	}
+	require.NoError(t, db.Put([]byte{0x02}, []byte{0xff}))
+
+	tests := []struct {
+		name        string
+		r           Range
+		format      Format
+		expected    string
+		expectedErr error
+	}{
+		{
+			name:     "everything",
+			format:   Hex,
+			expected: "0100 00\n0101 01\n0102 02\n0103 03\n0104 04\n02 ff\n",
+		},
+		{
+			name: "prefix with limit",
+			r: Range{
+				Prefix: []byte{0x01},
+				Limit:  2,
+			},
+			format:   Hex,
+			expected: "0100 00\n0101 01\n",
+		},
+		{
+			name: "start and end",
+			r: Range{
+				Start: []byte{0x01, 0x03},
+				End:   []byte{0x02},
+			},
+			format:   JSON,
+			expected: "{\"key\":\"0103\",\"value\":\"03\"}\n{\"key\":\"0104\",\"value\":\"04\"}\n",
+		},
+		{
+			name:        "unknown format",
+			format:      "xml",
+			expectedErr: errUnknownFormat,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			w := &bytes.Buffer{}
+			_, err := Dump(w, db, test.r, test.format)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expected, w.String())
+		})
+	}
+}
+
+func TestMigrateAndDeletePrevious(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		dbDir           = t.TempDir()
+		previousVersion = &version.Semantic{Major: 1}
+		currentVersion  = &version.Semantic{Major: 2}
+		chainID         = ids.GenerateTestID()
+	)
+	newManager := func(v *version.Semantic) manager.Manager {
+		dbManager, err := manager.NewLevelDB(dbDir, nil, logging.NoLog{}, v, "", prometheus.NewRegistry())
+		require.NoError(err)
+		return dbManager
+	}
+
+	// Write the state of the node and of the chains into the previous version.
+	dbManager := newManager(previousVersion)
+	previousDB := dbManager.Current().Database
+	require.NoError(previousDB.Put(genesisKey, []byte{0}))
+	require.NoError(prefixdb.New(nodePrefixes["shared memory"], previousDB).Put([]byte{3}, []byte{3}))
+	require.NoError(prefixdb.New(nodePrefixes["migration"], previousDB).Put([]byte{4}, []byte{4}))
+	require.NoError(prefixdb.New(constants.PlatformChainID[:], previousDB).Put([]byte{1}, []byte{1}))
+	require.NoError(prefixdb.New(chainID[:], previousDB).Put([]byte{2}, []byte{2}))
+	require.NoError(dbManager.Close())
+
+	dbManager = newManager(currentVersion)
+	require.Len(dbManager.GetDatabases(), 2)
+
+	deletable, err := migration.Deletable(dbManager)
+	require.NoError(err)
+	require.Empty(deletable)
+
+	// Every chain must be migrated, so that no state is lost when the previous
+	// version is deleted.
+	err = Migrate(context.Background(), logging.NoLog{}, dbManager, nil, 1)
+	require.ErrorIs(err, errUnmigratedKey)
+
+	require.NoError(Migrate(context.Background(), logging.NoLog{}, dbManager, []ids.ID{chainID}, 1))
+
+	deletable, err = migration.Deletable(dbManager)
+	require.NoError(err)
+	require.Equal([]*version.Semantic{previousVersion}, deletable)
+	require.NoError(dbManager.Close())
+
+	deleted, err := DeletePrevious(dbDir, deletable)
+	require.NoError(err)
+	require.Equal([]string{filepath.Join(dbDir, previousVersion.String())}, deleted)
+
+	// Only the current version remains, and it holds the migrated state.
+	dbManager = newManager(currentVersion)
+	require.Len(dbManager.GetDatabases(), 1)
+	currentDB := dbManager.Current().Database
+
+	value, err := prefixdb.New(constants.PlatformChainID[:], currentDB).Get([]byte{1})
+	require.NoError(err)
+	require.Equal([]byte{1}, value)
+
+	value, err = prefixdb.New(chainID[:], currentDB).Get([]byte{2})
+	require.NoError(err)
+	require.Equal([]byte{2}, value)
+
+	value, err = currentDB.Get(genesisKey)
+	require.NoError(err)
+	require.Equal([]byte{0}, value)
+
+	value, err = prefixdb.New(nodePrefixes["shared memory"], currentDB).Get([]byte{3})
+	require.NoError(err)
+	require.Equal([]byte{3}, value)
+
+	// The migration metadata of the previous version isn't copied.
+	has, err := prefixdb.New(nodePrefixes["migration"], currentDB).Has([]byte{4})
+	require.NoError(err)
+	require.False(has)
+	require.NoError(dbManager.Close())
+}
+
+func TestNodePrefixesMigratedOrExcluded(t *testing.T) {
+	require := require.New(t)
+
+	names := make(map[string]bool, len(nodePrefixes))
+	for _, name := range migratedNodePrefixes {
+		names[name] = true
+	}
+	for _, name := range excludedNodePrefixes {
+		require.False(names[name], "%q is both migrated and excluded", name)
+		names[name] = true
+	}
+	for name := range nodePrefixes {
+		require.True(names[name], "%q is neither migrated nor excluded", name)
+	}
+	require.Len(names, len(nodePrefixes))
+}
@@ -0,0 +1,82 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dbtool
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ava-labs/avalanchego/database"
+)
+
+var errUnknownFormat = errors.New("unknown format")
+
+// Format is the encoding used to dump key-value pairs.
+type Format string
+
+const (
+	// Hex writes each key-value pair on its own line as the hex encoded key
+	// and value separated by a space.
+	Hex Format = "hex"
+	// JSON writes each key-value pair on its own line as a JSON object.
+	JSON Format = "json"
+)
+
+// Range describes the key-value pairs to dump.
+type Range struct {
+	// Prefix, if non-empty, only includes keys that start with Prefix.
+	Prefix []byte
+	// Start, if non-empty, only includes keys >= Start.
+	Start []byte
+	// End, if non-empty, only includes keys < End.
+	End []byte
+	// Limit, if > 0, is the maximum number of key-value pairs to include.
+	Limit int
+}
+
+type jsonKeyValue struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Dump writes the key-value pairs of [db] in [r] to [w], encoded with
+// [format]. Returns the number of key-value pairs written.
+func Dump(w io.Writer, db database.Iteratee, r Range, format Format) (int, error) {
+	if format != Hex && format != JSON {
+		return 0, fmt.Errorf("%w: %q", errUnknownFormat, format)
+	}
+
+	it := db.NewIteratorWithStartAndPrefix(r.Start, r.Prefix)
+	defer it.Release()
+
+	encoder := json.NewEncoder(w)
+	numDumped := 0
+	for (r.Limit <= 0 || numDumped < r.Limit) && it.Next() {
+		key := it.Key()
+		if len(r.End) > 0 && bytes.Compare(key, r.End) >= 0 {
+			break
+		}
+
+		value := it.Value()
+		var err error
+		switch format {
+		case Hex:
+			_, err = fmt.Fprintf(w, "%x %x\n", key, value)
+		case JSON:
+			err = encoder.Encode(jsonKeyValue{
+				Key:   hex.EncodeToString(key),
+				Value: hex.EncodeToString(value),
+			})
+		}
+		if err != nil {
+			return numDumped, err
+		}
+		numDumped++
+	}
+	return numDumped, it.Error()
+}
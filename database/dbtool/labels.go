@@ -0,0 +1,101 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dbtool
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+// PrefixLen is the length of the prefix that a prefixed database prepends to
+// every key.
+const PrefixLen = hashing.HashLen
+
+var (
+	errInvalidPrefix = errors.New("invalid prefix")
+
+	// Prefixes used by the node on its database. Each prefix must be either
+	// in [migratedNodePrefixes] or in [excludedNodePrefixes].
+	//
+	// Must be kept in sync with node/node.go and database/migration.
+	nodePrefixes = map[string][]byte{
+		"indexer":       {0x00},
+		"keystore":      []byte("keystore"),
+		"migration":     []byte("migration"),
+		"shared memory": []byte("shared memory"),
+	}
+
+	// Prefixes used by the chain manager on the database of each chain.
+	//
+	// Must be kept in sync with chains/manager.go.
+	chainPrefixes = map[string][]byte{
+		"vm":        []byte("vm"),
+		"vertex":    []byte("vertex"),
+		"vertex_bs": []byte("vertex_bs"),
+		"tx_bs":     []byte("tx_bs"),
+		"block_bs":  []byte("block_bs"),
+		"bs":        []byte("bs"),
+	}
+)
+
+// Labels maps the prefixes written to disk by the node to human readable
+// names.
+type Labels struct {
+	prefixToName map[string]string
+	nameToPrefix map[string][]byte
+}
+
+// NewLabels returns the labels of the prefixes used by the node, and of the
+// prefixes used by the chain manager for each of [chainIDs]. The P-chain is
+// always included.
+func NewLabels(chainIDs []ids.ID) *Labels {
+	l := &Labels{
+		prefixToName: make(map[string]string),
+		nameToPrefix: make(map[string][]byte),
+	}
+	for name, prefix := range nodePrefixes {
+		l.add("node/"+name, prefixdb.MakePrefix(prefix))
+	}
+
+	chainIDs = append([]ids.ID{constants.PlatformChainID}, chainIDs...)
+	for _, chainID := range chainIDs {
+		chainPrefix := prefixdb.MakePrefix(chainID[:])
+		chainName := fmt.Sprintf("chain/%s", chainID)
+		l.add(chainName, chainPrefix)
+		for name, prefix := range chainPrefixes {
+			l.add(chainName+"/"+name, prefixdb.JoinPrefixes(chainPrefix, prefix))
+		}
+	}
+	return l
+}
+
+func (l *Labels) add(name string, prefix []byte) {
+	l.prefixToName[string(prefix)] = name
+	l.nameToPrefix[name] = prefix
+}
+
+// Name returns the name of [prefix], if it is known.
+func (l *Labels) Name(prefix []byte) (string, bool) {
+	name, ok := l.prefixToName[string(prefix)]
+	return name, ok
+}
+
+// Prefix parses [s] as either the name of a known prefix or a hex encoded
+// prefix.
+func (l *Labels) Prefix(s string) ([]byte, error) {
+	if prefix, ok := l.nameToPrefix[s]; ok {
+		return prefix, nil
+	}
+	prefix, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q is neither a known prefix nor hex: %w", errInvalidPrefix, s, err)
+	}
+	return prefix, nil
+}
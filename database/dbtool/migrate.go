@@ -0,0 +1,172 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dbtool
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/manager"
+	"github.com/ava-labs/avalanchego/database/migration"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+// copyStepName is the name of the step that copies the state of a chain. It is
+// persisted with the progress of the step, so it must never change.
+const copyStepName = "copy"
+
+var (
+	errUnmigratedKey = errors.New("key wouldn't be migrated")
+
+	// migratedNodePrefixes are the names of the [nodePrefixes] that are copied
+	// by [Migrate]. The names are also the names of the steps that copy them,
+	// so they must never change.
+	migratedNodePrefixes = []string{"indexer", "keystore", "shared memory"}
+
+	// excludedNodePrefixes are the names of the [nodePrefixes] that aren't
+	// copied by [Migrate]. The migration metadata describes the database it
+	// is written in, so it is never copied.
+	excludedNodePrefixes = []string{"migration"}
+
+	// genesisKey is the unprefixed key that the node records the hash of its
+	// genesis under.
+	//
+	// Must be kept in sync with node/node.go.
+	genesisKey = []byte("genesisID")
+)
+
+// genesisStepName is the name of the step that copies [genesisKey]. It is
+// persisted with the progress of the step, so it must never change.
+const genesisStepName = "genesis"
+
+// Migrate copies the state of the node, of the P-chain and of each of
+// [chainIDs] from the previous database of [dbManager] into its current
+// database. A checkpoint is saved every time [batchSize] bytes have been
+// written, so an interrupted migration resumes where it left off.
+//
+// Before anything is copied, the previous database is checked to only have
+// state that is either copied or explicitly excluded, so that deleting it
+// doesn't lose any state. This requires the ID of every chain in the previous
+// database to be in [chainIDs].
+//
+// Once everything has been copied and verified, the previous database is
+// recorded as migrated and can be deleted with [DeletePrevious].
+func Migrate(
+	ctx context.Context,
+	log logging.Logger,
+	dbManager manager.Manager,
+	chainIDs []ids.ID,
+	batchSize int,
+) error {
+	m := migration.New(log)
+	prefixes := set.Set[string]{}
+	for _, name := range migratedNodePrefixes {
+		prefix := prefixdb.MakePrefix(nodePrefixes[name])
+		if err := m.RegisterNode(migration.NewCopyStep(name, prefix, batchSize)); err != nil {
+			return err
+		}
+		prefixes.Add(string(prefix))
+	}
+	for _, name := range excludedNodePrefixes {
+		prefixes.Add(string(prefixdb.MakePrefix(nodePrefixes[name])))
+	}
+	if err := m.RegisterNode(migration.NewCopyStep(genesisStepName, genesisKey, batchSize)); err != nil {
+		return err
+	}
+
+	registered := set.Set[ids.ID]{}
+	for _, chainID := range append([]ids.ID{constants.PlatformChainID}, chainIDs...) {
+		if registered.Contains(chainID) {
+			continue
+		}
+		registered.Add(chainID)
+
+		if err := m.Register(chainID, migration.NewCopyStep(copyStepName, nil, batchSize)); err != nil {
+			return err
+		}
+		prefixes.Add(string(prefixdb.MakePrefix(chainID[:])))
+	}
+
+	if previous, ok := dbManager.Previous(); ok {
+		if err := checkMigrated(previous.Database, prefixes); err != nil {
+			return err
+		}
+	}
+	return m.Run(ctx, dbManager)
+}
+
+// checkMigrated returns an error if [db] has a key, other than [genesisKey],
+// that doesn't start with one of [prefixes].
+func checkMigrated(db database.Iteratee, prefixes set.Set[string]) error {
+	var start []byte
+	for {
+		key, ok, err := firstKey(db, start)
+		if err != nil || !ok {
+			return err
+		}
+
+		switch {
+		case bytes.Equal(key, genesisKey):
+			start = append(key, 0x00)
+		case len(key) >= PrefixLen && prefixes.Contains(string(key[:PrefixLen])):
+			// Skip the rest of the keys with the prefix.
+			start = prefixUpperBound(key[:PrefixLen])
+			if start == nil {
+				return nil
+			}
+		default:
+			return fmt.Errorf("%w: 0x%x", errUnmigratedKey, key)
+		}
+	}
+}
+
+// firstKey returns the first key in [db] that is at least [start].
+func firstKey(db database.Iteratee, start []byte) ([]byte, bool, error) {
+	it := db.NewIteratorWithStart(start)
+	defer it.Release()
+
+	if !it.Next() {
+		return nil, false, it.Error()
+	}
+	return slices.Clone(it.Key()), true, nil
+}
+
+// prefixUpperBound returns the smallest key that is greater than every key
+// with [prefix]. If no such key exists, nil is returned.
+func prefixUpperBound(prefix []byte) []byte {
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if prefix[i] != 0xFF {
+			upperBound := slices.Clone(prefix[:i+1])
+			upperBound[i]++
+			return upperBound
+		}
+	}
+	return nil
+}
+
+// DeletePrevious deletes the directories of the databases with [versions] from
+// [dbDir] and returns the deleted paths. The databases must be closed.
+func DeletePrevious(dbDir string, versions []*version.Semantic) ([]string, error) {
+	paths := make([]string, 0, len(versions))
+	for _, v := range versions {
+		path := filepath.Join(dbDir, v.String())
+		if err := os.RemoveAll(path); err != nil {
+			return paths, fmt.Errorf("couldn't delete %s: %w", path, err)
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
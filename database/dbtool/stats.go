@@ -0,0 +1,102 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dbtool
+
+import (
+	"bytes"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/avalanchego/database"
+)
+
+// PrefixStats summarizes the keys that start with [Prefix].
+type PrefixStats struct {
+	// Prefix is nil for keys that are shorter than [PrefixLen].
+	Prefix []byte
+	// Name is empty if the prefix isn't known.
+	Name      string
+	NumKeys   uint64
+	KeySize   uint64
+	ValueSize uint64
+}
+
+// Size returns the total number of bytes of the keys and values that start with
+// the prefix.
+func (s *PrefixStats) Size() uint64 {
+	return s.KeySize + s.ValueSize
+}
+
+// Stats iterates over every key in [db] and groups the keys by their first
+// [PrefixLen] bytes. The returned stats are sorted by prefix.
+func Stats(db database.Iteratee, labels *Labels) ([]*PrefixStats, error) {
+	it := db.NewIterator()
+	defer it.Release()
+
+	var (
+		stats   []*PrefixStats
+		current *PrefixStats
+	)
+	for it.Next() {
+		key := it.Key()
+		var prefix []byte
+		if len(key) >= PrefixLen {
+			prefix = key[:PrefixLen]
+		}
+
+		if current == nil || !bytes.Equal(current.Prefix, prefix) {
+			current = &PrefixStats{
+				Prefix: slices.Clone(prefix),
+			}
+			current.Name, _ = labels.Name(prefix)
+			stats = append(stats, current)
+		}
+		current.NumKeys++
+		current.KeySize += uint64(len(key))
+		current.ValueSize += uint64(len(it.Value()))
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+
+	// Keys shorter than [PrefixLen] may be interleaved with prefixed keys, so
+	// they are merged into a single entry.
+	return mergeUnprefixed(stats), nil
+}
+
+func mergeUnprefixed(stats []*PrefixStats) []*PrefixStats {
+	var (
+		merged     = stats[:0]
+		unprefixed *PrefixStats
+	)
+	for _, s := range stats {
+		if s.Prefix != nil {
+			merged = append(merged, s)
+			continue
+		}
+		if unprefixed == nil {
+			unprefixed = s
+			continue
+		}
+		unprefixed.NumKeys += s.NumKeys
+		unprefixed.KeySize += s.KeySize
+		unprefixed.ValueSize += s.ValueSize
+	}
+	if unprefixed != nil {
+		merged = append([]*PrefixStats{unprefixed}, merged...)
+	}
+	return merged
+}
+
+// Count returns the number of keys in [db] that start with [prefix].
+func Count(db database.Iteratee, prefix []byte) (uint64, error) {
+	it := db.NewIteratorWithPrefix(prefix)
+	defer it.Release()
+
+	var count uint64
+	for it.Next() {
+		count++
+	}
+	return count, it.Error()
+}
@@ -24,9 +24,13 @@ const (
 )
 
 var (
-	_ database.Database = (*Database)(nil)
-	_ database.Batch    = (*batch)(nil)
-	_ database.Iterator = (*iterator)(nil)
+	_ database.Database      = (*Database)(nil)
+	_ database.RangeIteratee = (*Database)(nil)
+	_ database.Snapshotter   = (*Database)(nil)
+	_ database.Snapshot      = (*dbSnapshot)(nil)
+	_ database.RangeIteratee = (*dbSnapshot)(nil)
+	_ database.Batch         = (*batch)(nil)
+	_ database.Iterator      = (*iterator)(nil)
 )
 
 // Database encrypts all values that are provided
@@ -103,6 +107,16 @@ func (db *Database) Delete(key []byte) error {
 	return db.db.Delete(key)
 }
 
+func (db *Database) DeleteRange(start, limit []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+	return db.db.DeleteRange(start, limit)
+}
+
 func (db *Database) NewBatch() database.Batch {
 	return &batch{
 		Batch: db.db.NewBatch(),
@@ -123,6 +137,13 @@ func (db *Database) NewIteratorWithPrefix(prefix []byte) database.Iterator {
 }
 
 func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return db.NewIteratorWithOptions(database.IteratorOptions{
+		Start:  start,
+		Prefix: prefix,
+	})
+}
+
+func (db *Database) NewIteratorWithOptions(opts database.IteratorOptions) database.Iterator {
 	db.lock.RLock()
 	defer db.lock.RUnlock()
 
@@ -132,11 +153,30 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 		}
 	}
 	return &iterator{
-		Iterator: db.db.NewIteratorWithStartAndPrefix(start, prefix),
+		Iterator: database.NewIteratorWithOptions(db.db, opts),
 		db:       db,
 	}
 }
 
+// NewSnapshot returns a read-only view of this database. The underlying
+// database must implement database.Snapshotter.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return nil, database.ErrClosed
+	}
+	snapshot, err := database.NewSnapshot(db.db)
+	if err != nil {
+		return nil, err
+	}
+	return &dbSnapshot{
+		Snapshot: snapshot,
+		db:       db,
+	}, nil
+}
+
 func (db *Database) Compact(start, limit []byte) error {
 	db.lock.Lock()
 	defer db.lock.Unlock()
@@ -202,6 +242,15 @@ func (b *batch) Delete(key []byte) error {
 	return b.Batch.Delete(key)
 }
 
+func (b *batch) DeleteRange(start, limit []byte) error {
+	b.ops = append(b.ops, database.BatchOp{
+		Key:         slices.Clone(start),
+		DeleteRange: true,
+		Limit:       slices.Clone(limit),
+	})
+	return b.Batch.DeleteRange(start, limit)
+}
+
 func (b *batch) Write() error {
 	b.db.lock.Lock()
 	defer b.db.lock.Unlock()
@@ -226,17 +275,63 @@ func (b *batch) Reset() {
 // Replay replays the batch contents.
 func (b *batch) Replay(w database.KeyValueWriterDeleter) error {
 	for _, op := range b.ops {
-		if op.Delete {
-			if err := w.Delete(op.Key); err != nil {
-				return err
-			}
-		} else if err := w.Put(op.Key, op.Value); err != nil {
+		var err error
+		switch {
+		case op.DeleteRange:
+			err = database.DeleteRange(w, op.Key, op.Limit)
+		case op.Delete:
+			err = w.Delete(op.Key)
+		default:
+			err = w.Put(op.Key, op.Value)
+		}
+		if err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// dbSnapshot decrypts all values read from the snapshot of the underlying
+// database.
+type dbSnapshot struct {
+	database.Snapshot
+	db *Database
+}
+
+func (s *dbSnapshot) Get(key []byte) ([]byte, error) {
+	encVal, err := s.Snapshot.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	return s.db.decrypt(encVal)
+}
+
+func (s *dbSnapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *dbSnapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *dbSnapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (s *dbSnapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return s.NewIteratorWithOptions(database.IteratorOptions{
+		Start:  start,
+		Prefix: prefix,
+	})
+}
+
+func (s *dbSnapshot) NewIteratorWithOptions(opts database.IteratorOptions) database.Iterator {
+	return &iterator{
+		Iterator: database.NewIteratorWithOptions(s.Snapshot, opts),
+		db:       s.db,
+	}
+}
+
 type iterator struct {
 	database.Iterator
 	db *Database
@@ -9,4 +9,7 @@ import "errors"
 var (
 	ErrClosed   = errors.New("closed")
 	ErrNotFound = errors.New("not found")
+
+	ErrSnapshotNotSupported    = errors.New("snapshot not supported")
+	ErrRangeDeleteNotSupported = errors.New("range delete not supported")
 )
@@ -4,6 +4,7 @@
 package database
 
 import (
+	"bytes"
 	"encoding/binary"
 	"errors"
 	"fmt"
@@ -170,6 +171,53 @@ func IsEmpty(db Iteratee) (bool, error) {
 	return !iterator.Next(), iterator.Error()
 }
 
+// NewSnapshot returns a snapshot of [db]. If [db] doesn't implement
+// Snapshotter, ErrSnapshotNotSupported is returned.
+func NewSnapshot(db Database) (Snapshot, error) {
+	snapshotter, ok := db.(Snapshotter)
+	if !ok {
+		return nil, ErrSnapshotNotSupported
+	}
+	return snapshotter.NewSnapshot()
+}
+
+// WriteSnapshot writes every key-value pair of a snapshot of [db] to [w].
+func WriteSnapshot(db Database, w KeyValueWriter) error {
+	snapshot, err := NewSnapshot(db)
+	if err != nil {
+		return err
+	}
+	defer snapshot.Release()
+
+	it := snapshot.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		if err := w.Put(it.Key(), it.Value()); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
+// DeleteRange removes all keys in the range [start, limit) from [w]. If [w]
+// doesn't implement KeyValueRangeDeleter, ErrRangeDeleteNotSupported is
+// returned.
+func DeleteRange(w KeyValueWriterDeleter, start, limit []byte) error {
+	rangeDeleter, ok := w.(KeyValueRangeDeleter)
+	if !ok {
+		return ErrRangeDeleteNotSupported
+	}
+	return rangeDeleter.DeleteRange(start, limit)
+}
+
+// InRange returns true if [key] is in the range [start, limit). A nil, or
+// empty, [limit] is treated as a key after all keys.
+func InRange(key, start, limit []byte) bool {
+	return bytes.Compare(key, start) >= 0 &&
+		(len(limit) == 0 || bytes.Compare(key, limit) < 0)
+}
+
 func AtomicClear(readerDB Iteratee, deleterDB KeyValueDeleter) error {
 	return AtomicClearPrefix(readerDB, deleterDB, nil)
 }
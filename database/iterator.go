@@ -7,7 +7,17 @@
 
 package database
 
-var _ Iterator = (*IteratorError)(nil)
+import (
+	"bytes"
+
+	"golang.org/x/exp/slices"
+)
+
+var (
+	_ Iterator = (*IteratorError)(nil)
+	_ Iterator = (*boundedIterator)(nil)
+	_ Iterator = (*reverseIterator)(nil)
+)
 
 // Iterator iterates over a database's key/value pairs.
 //
@@ -65,6 +75,163 @@ type Iteratee interface {
 	NewIteratorWithStartAndPrefix(start, prefix []byte) Iterator
 }
 
+// IteratorOptions describes the keys, and the order of the keys, that an
+// iterator returns.
+type IteratorOptions struct {
+	// Start is the inclusive lower bound of the iteration. If nil, iteration
+	// is not bounded from below.
+	Start []byte
+	// End is the exclusive upper bound of the iteration. If nil or empty,
+	// iteration is not bounded from above.
+	End []byte
+	// Prefix restricts the iteration to keys that start with Prefix.
+	Prefix []byte
+	// Reverse iterates over the keys in descending order. The bounds are
+	// unchanged, so the first key returned is the largest key below End.
+	Reverse bool
+}
+
+// Bounds returns the inclusive lower bound and the exclusive upper bound of
+// the keys covered by [o]. A nil upper bound means the keys are not bounded
+// from above.
+func (o IteratorOptions) Bounds() ([]byte, []byte) {
+	lower := o.Prefix
+	if bytes.Compare(o.Start, lower) > 0 {
+		lower = o.Start
+	}
+	upper := prefixUpperBound(o.Prefix)
+	if len(o.End) > 0 && (upper == nil || bytes.Compare(o.End, upper) < 0) {
+		upper = o.End
+	}
+	return lower, upper
+}
+
+// RangeIteratee is implemented by data stores that can natively iterate over
+// bounded ranges in either direction.
+type RangeIteratee interface {
+	// NewIteratorWithOptions creates an iterator over the keys described by
+	// [opts].
+	NewIteratorWithOptions(opts IteratorOptions) Iterator
+}
+
+// NewIteratorWithOptions returns an iterator over the keys of [db] described
+// by [opts]. If [db] does not implement RangeIteratee, bounded iteration is
+// emulated on top of a forward iterator and reverse iteration reads the whole
+// range into memory.
+func NewIteratorWithOptions(db Iteratee, opts IteratorOptions) Iterator {
+	if db, ok := db.(RangeIteratee); ok {
+		return db.NewIteratorWithOptions(opts)
+	}
+
+	_, upper := opts.Bounds()
+	var it Iterator = &boundedIterator{
+		Iterator: db.NewIteratorWithStartAndPrefix(opts.Start, opts.Prefix),
+		limit:    slices.Clone(upper),
+	}
+	if !opts.Reverse {
+		return it
+	}
+	defer it.Release()
+
+	reverseIt := &reverseIterator{}
+	for it.Next() {
+		reverseIt.keys = append(reverseIt.keys, slices.Clone(it.Key()))
+		reverseIt.values = append(reverseIt.values, slices.Clone(it.Value()))
+	}
+	if err := it.Error(); err != nil {
+		return &IteratorError{
+			Err: err,
+		}
+	}
+	return reverseIt
+}
+
+// prefixUpperBound returns the smallest key that is greater than every key
+// with the provided [prefix]. If no such key exists, nil is returned.
+func prefixUpperBound(prefix []byte) []byte {
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if prefix[i] != 0xFF {
+			upperBound := slices.Clone(prefix[:i+1])
+			upperBound[i]++
+			return upperBound
+		}
+	}
+	return nil
+}
+
+// boundedIterator stops iterating once the wrapped iterator reaches [limit].
+type boundedIterator struct {
+	Iterator
+	limit    []byte
+	finished bool
+}
+
+func (it *boundedIterator) Next() bool {
+	if it.finished {
+		return false
+	}
+	if it.Iterator.Next() && (it.limit == nil || bytes.Compare(it.Iterator.Key(), it.limit) < 0) {
+		return true
+	}
+	it.finished = true
+	return false
+}
+
+func (it *boundedIterator) Key() []byte {
+	if it.finished {
+		return nil
+	}
+	return it.Iterator.Key()
+}
+
+func (it *boundedIterator) Value() []byte {
+	if it.finished {
+		return nil
+	}
+	return it.Iterator.Value()
+}
+
+// reverseIterator iterates over the provided key/value pairs from the last
+// pair to the first.
+type reverseIterator struct {
+	initialized  bool
+	keys, values [][]byte
+}
+
+func (it *reverseIterator) Next() bool {
+	switch {
+	case !it.initialized:
+		it.initialized = true
+	case len(it.keys) > 0:
+		it.keys = it.keys[:len(it.keys)-1]
+		it.values = it.values[:len(it.values)-1]
+	}
+	return len(it.keys) > 0
+}
+
+func (*reverseIterator) Error() error {
+	return nil
+}
+
+func (it *reverseIterator) Key() []byte {
+	if !it.initialized || len(it.keys) == 0 {
+		return nil
+	}
+	return it.keys[len(it.keys)-1]
+}
+
+func (it *reverseIterator) Value() []byte {
+	if !it.initialized || len(it.values) == 0 {
+		return nil
+	}
+	return it.values[len(it.values)-1]
+}
+
+func (it *reverseIterator) Release() {
+	it.keys = nil
+	it.values = nil
+}
+
 // IteratorError does nothing and returns the provided error
 type IteratorError struct {
 	Err error
@@ -28,6 +28,7 @@ import (
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
 )
 
 const (
@@ -63,12 +64,20 @@ const (
 	// levelDBByteOverhead is the number of bytes of constant overhead that
 	// should be added to a batch size per operation.
 	levelDBByteOverhead = 8
+
+	// defaultDeleteRangeBatchSize is the maximum number of bytes written at
+	// once by DeleteRange.
+	defaultDeleteRangeBatchSize = opt.MiB
 )
 
 var (
-	_ database.Database = (*Database)(nil)
-	_ database.Batch    = (*batch)(nil)
-	_ database.Iterator = (*iter)(nil)
+	_ database.Database      = (*Database)(nil)
+	_ database.RangeIteratee = (*Database)(nil)
+	_ database.Snapshotter   = (*Database)(nil)
+	_ database.Snapshot      = (*dbSnapshot)(nil)
+	_ database.RangeIteratee = (*dbSnapshot)(nil)
+	_ database.Batch         = (*batch)(nil)
+	_ database.Iterator      = (*iter)(nil)
 
 	ErrInvalidConfig = errors.New("invalid config")
 	ErrCouldNotOpen  = errors.New("could not open")
@@ -81,9 +90,16 @@ type Database struct {
 	*leveldb.DB
 	// metrics is only initialized and used when [MetricUpdateFrequency] is >= 0
 	// in the config
-	metrics   metrics
-	closed    utils.Atomic[bool]
-	closeOnce sync.Once
+	metrics metrics
+	closed  utils.Atomic[bool]
+	// writeLock is held exclusively while range deletions are resolved into
+	// the keys they delete and written, so that no key written concurrently
+	// can be missed. Every other write holds it in shared mode.
+	writeLock sync.RWMutex
+	// deleteRangeBatchSize is the maximum number of bytes written at once by
+	// DeleteRange.
+	deleteRangeBatchSize int
+	closeOnce            sync.Once
 	// closeCh is closed when Close() is called.
 	closeCh chan struct{}
 	// closeWg is used to wait for all goroutines created by New() to exit.
@@ -186,6 +202,12 @@ type config struct {
 	// MetricUpdateFrequency is the frequency to poll LevelDB metrics.
 	// If <= 0, LevelDB metrics aren't polled.
 	MetricUpdateFrequency time.Duration `json:"metricUpdateFrequency"`
+
+	// ReadOnly opens the database in read-only mode. All writes to a read-only
+	// database will fail, and a corrupted database will not be recovered.
+	//
+	// The default is false.
+	ReadOnly bool `json:"readOnly"`
 }
 
 // New returns a wrapped LevelDB object.
@@ -226,8 +248,9 @@ func New(file string, configBytes []byte, log logging.Logger, namespace string,
 		WriteBuffer:                   parsedConfig.WriteBuffer,
 		Filter:                        filter.NewBloomFilter(parsedConfig.FilterBitsPerKey),
 		MaxManifestFileSize:           parsedConfig.MaxManifestFileSize,
+		ReadOnly:                      parsedConfig.ReadOnly,
 	})
-	if _, corrupted := err.(*errors.ErrCorrupted); corrupted {
+	if _, corrupted := err.(*errors.ErrCorrupted); corrupted && !parsedConfig.ReadOnly {
 		db, err = leveldb.RecoverFile(file, nil)
 	}
 	if err != nil {
@@ -235,8 +258,9 @@ func New(file string, configBytes []byte, log logging.Logger, namespace string,
 	}
 
 	wrappedDB := &Database{
-		DB:      db,
-		closeCh: make(chan struct{}),
+		DB:                   db,
+		deleteRangeBatchSize: defaultDeleteRangeBatchSize,
+		closeCh:              make(chan struct{}),
 	}
 	if parsedConfig.MetricUpdateFrequency > 0 {
 		metrics, err := newMetrics(namespace, reg)
@@ -286,14 +310,63 @@ func (db *Database) Get(key []byte) ([]byte, error) {
 
 // Put sets the value of the provided key to the provided value
 func (db *Database) Put(key []byte, value []byte) error {
+	db.writeLock.RLock()
+	defer db.writeLock.RUnlock()
+
 	return updateError(db.DB.Put(key, value, nil))
 }
 
 // Delete removes the key from the database
 func (db *Database) Delete(key []byte) error {
+	db.writeLock.RLock()
+	defer db.writeLock.RUnlock()
+
 	return updateError(db.DB.Delete(key, nil))
 }
 
+// DeleteRange removes all keys in the range [start, limit) from the database.
+//
+// LevelDB doesn't support range deletions natively, so every key in the range
+// is deleted individually, in writes of bounded size. Other writes are blocked
+// until every key in the range has been deleted. However, the deletion isn't
+// atomic: if an error is returned, only some of the keys may have been
+// deleted.
+func (db *Database) DeleteRange(start, limit []byte) error {
+	db.writeLock.Lock()
+	defer db.writeLock.Unlock()
+
+	// Other writes are blocked, so the keys in the range can't change while
+	// they are iterated over.
+	it := db.DB.NewIterator(newRange(start, limit), nil)
+	defer it.Release()
+
+	var (
+		b    leveldb.Batch
+		size int
+	)
+	for it.Next() {
+		key := it.Key()
+		b.Delete(key)
+		size += len(key) + levelDBByteOverhead
+		if size < db.deleteRangeBatchSize {
+			continue
+		}
+
+		if err := db.DB.Write(&b, nil); err != nil {
+			return updateError(err)
+		}
+		b.Reset()
+		size = 0
+	}
+	if err := it.Error(); err != nil {
+		return updateError(err)
+	}
+	if b.Len() == 0 {
+		return nil
+	}
+	return updateError(db.DB.Write(&b, nil))
+}
+
 // NewBatch creates a write/delete-only buffer that is atomically committed to
 // the database when write is called
 func (db *Database) NewBatch() database.Batch {
@@ -340,6 +413,16 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	}
 }
 
+// NewIteratorWithOptions creates a lexicographically ordered iterator over
+// the keys of the database described by [opts]
+func (db *Database) NewIteratorWithOptions(opts database.IteratorOptions) database.Iterator {
+	return &iter{
+		db:       db,
+		Iterator: db.DB.NewIterator(iteratorRange(opts), nil),
+		reverse:  opts.Reverse,
+	}
+}
+
 // This comment is basically copy pasted from the underlying levelDB library:
 
 // Compact the underlying DB for the given key range.
@@ -355,6 +438,19 @@ func (db *Database) Compact(start []byte, limit []byte) error {
 	return updateError(db.DB.CompactRange(util.Range{Start: start, Limit: limit}))
 }
 
+// NewSnapshot returns a read-only view of the database as of the time
+// NewSnapshot is called.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	snapshot, err := db.DB.GetSnapshot()
+	if err != nil {
+		return nil, updateError(err)
+	}
+	return &dbSnapshot{
+		db:       db,
+		snapshot: snapshot,
+	}, nil
+}
+
 func (db *Database) Close() error {
 	db.closed.Set(true)
 	db.closeOnce.Do(func() {
@@ -376,6 +472,18 @@ type batch struct {
 	leveldb.Batch
 	db   *Database
 	size int
+
+	// rangeDeletes are the range deletions added to the batch, in the order
+	// they were added. Because LevelDB doesn't support range deletions, they
+	// are resolved into individual deletions when the batch is written.
+	rangeDeletes []rangeDelete
+}
+
+type rangeDelete struct {
+	// index is the number of records in [batch.Batch] when the range deletion
+	// was added.
+	index        int
+	start, limit []byte
 }
 
 // Put the value into the batch for later writing
@@ -392,29 +500,82 @@ func (b *batch) Delete(key []byte) error {
 	return nil
 }
 
+// DeleteRange removes all keys in the range [start, limit) during writing.
+//
+// The range is resolved into a deletion of each of its keys when the batch is
+// written, and the whole batch is written at once. Large ranges should be
+// deleted with Database.DeleteRange instead.
+func (b *batch) DeleteRange(start, limit []byte) error {
+	b.rangeDeletes = append(b.rangeDeletes, rangeDelete{
+		index: b.Batch.Len(),
+		start: slices.Clone(start),
+		limit: slices.Clone(limit),
+	})
+	b.size += len(start) + len(limit) + levelDBByteOverhead
+	return nil
+}
+
 // Size retrieves the amount of data queued up for writing.
 func (b *batch) Size() int {
 	return b.size
 }
 
 // Write flushes any accumulated data to disk.
+//
+// If the batch contains range deletions, other writes are blocked from when
+// the range deletions are resolved until the batch is written, so the batch
+// deletes every key in its ranges.
 func (b *batch) Write() error {
-	return updateError(b.db.DB.Write(&b.Batch, nil))
+	if len(b.rangeDeletes) == 0 {
+		b.db.writeLock.RLock()
+		defer b.db.writeLock.RUnlock()
+
+		return updateError(b.db.DB.Write(&b.Batch, nil))
+	}
+
+	b.db.writeLock.Lock()
+	defer b.db.writeLock.Unlock()
+
+	snapshot, err := b.db.DB.GetSnapshot()
+	if err != nil {
+		return updateError(err)
+	}
+	defer snapshot.Release()
+
+	resolver := &rangeResolver{
+		snapshot: snapshot,
+		keys:     set.Set[string]{},
+	}
+	if err := b.replay(resolver); err != nil {
+		return updateError(err)
+	}
+	return updateError(b.db.DB.Write(&resolver.batch, nil))
 }
 
 // Reset resets the batch for reuse.
 func (b *batch) Reset() {
 	b.Batch.Reset()
+	b.rangeDeletes = nil
 	b.size = 0
 }
 
 // Replay the batch contents.
 func (b *batch) Replay(w database.KeyValueWriterDeleter) error {
-	replay := &replayer{writerDeleter: w}
+	return b.replay(w)
+}
+
+// replay replays the records of [b.Batch] to [w], with the range deletions
+// interleaved in the order they were added.
+func (b *batch) replay(w database.KeyValueWriterDeleter) error {
+	replay := &replayer{
+		writerDeleter: w,
+		rangeDeletes:  b.rangeDeletes,
+	}
 	if err := b.Batch.Replay(replay); err != nil {
 		// Never actually returns an error, because Replay just returns nil
 		return err
 	}
+	replay.deleteRanges(math.MaxInt)
 	return replay.err
 }
 
@@ -425,10 +586,14 @@ func (b *batch) Inner() database.Batch {
 
 type replayer struct {
 	writerDeleter database.KeyValueWriterDeleter
+	rangeDeletes  []rangeDelete
+	index         int
 	err           error
 }
 
 func (r *replayer) Put(key, value []byte) {
+	r.deleteRanges(r.index)
+	r.index++
 	if r.err != nil {
 		return
 	}
@@ -436,16 +601,86 @@ func (r *replayer) Put(key, value []byte) {
 }
 
 func (r *replayer) Delete(key []byte) {
+	r.deleteRanges(r.index)
+	r.index++
 	if r.err != nil {
 		return
 	}
 	r.err = r.writerDeleter.Delete(key)
 }
 
+// deleteRanges replays all the remaining range deletions that were added
+// before the record at [index].
+func (r *replayer) deleteRanges(index int) {
+	for len(r.rangeDeletes) > 0 && r.rangeDeletes[0].index <= index {
+		rangeDelete := r.rangeDeletes[0]
+		r.rangeDeletes = r.rangeDeletes[1:]
+		if r.err != nil {
+			continue
+		}
+		r.err = database.DeleteRange(r.writerDeleter, rangeDelete.start, rangeDelete.limit)
+	}
+}
+
+// rangeResolver builds a LevelDB batch where every range deletion is replaced
+// by deletions of the keys in the range. The keys in the range are the keys in
+// [snapshot] along with the keys previously put into the batch.
+type rangeResolver struct {
+	batch    leveldb.Batch
+	snapshot *leveldb.Snapshot
+	keys     set.Set[string]
+}
+
+func (r *rangeResolver) Put(key, value []byte) error {
+	r.batch.Put(key, value)
+	r.keys.Add(string(key))
+	return nil
+}
+
+func (r *rangeResolver) Delete(key []byte) error {
+	r.batch.Delete(key)
+	r.keys.Remove(string(key))
+	return nil
+}
+
+func (r *rangeResolver) DeleteRange(start, limit []byte) error {
+	it := r.snapshot.NewIterator(newRange(start, limit), nil)
+	defer it.Release()
+
+	for it.Next() {
+		r.batch.Delete(it.Key())
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+
+	for key := range r.keys {
+		if database.InRange([]byte(key), start, limit) {
+			r.batch.Delete([]byte(key))
+			r.keys.Remove(key)
+		}
+	}
+	return nil
+}
+
+// newRange returns the range [start, limit). If [limit] is empty, the range has
+// no upper bound.
+func newRange(start, limit []byte) *util.Range {
+	keyRange := &util.Range{Start: start}
+	if len(limit) > 0 {
+		keyRange.Limit = limit
+	}
+	return keyRange
+}
+
 type iter struct {
 	db *Database
 	iterator.Iterator
 
+	// reverse iterates from the last key to the first key.
+	reverse     bool
+	initialized bool
+
 	key, val []byte
 	err      error
 }
@@ -459,7 +694,17 @@ func (it *iter) Next() bool {
 		return false
 	}
 
-	hasNext := it.Iterator.Next()
+	var hasNext bool
+	switch {
+	case !it.reverse:
+		hasNext = it.Iterator.Next()
+	case !it.initialized:
+		hasNext = it.Iterator.Last()
+	default:
+		hasNext = it.Iterator.Prev()
+	}
+	it.initialized = true
+
 	if hasNext {
 		it.key = slices.Clone(it.Iterator.Key())
 		it.val = slices.Clone(it.Iterator.Value())
@@ -485,9 +730,17 @@ func (it *iter) Value() []byte {
 	return it.val
 }
 
+func iteratorRange(opts database.IteratorOptions) *util.Range {
+	lower, upper := opts.Bounds()
+	return &util.Range{
+		Start: lower,
+		Limit: upper,
+	}
+}
+
 func updateError(err error) error {
 	switch err {
-	case leveldb.ErrClosed:
+	case leveldb.ErrClosed, leveldb.ErrSnapshotReleased:
 		return database.ErrClosed
 	case leveldb.ErrNotFound:
 		return database.ErrNotFound
@@ -6,6 +6,8 @@ package leveldb
 import (
 	"testing"
 
+	"github.com/syndtr/goleveldb/leveldb"
+
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/stretchr/testify/require"
@@ -26,6 +28,59 @@ func TestInterface(t *testing.T) {
 	}
 }
 
+func TestReadOnly(t *testing.T) {
+	require := require.New(t)
+
+	key := []byte("hello")
+	value := []byte("world")
+
+	folder := t.TempDir()
+	db, err := New(folder, nil, logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+	require.NoError(db.Put(key, value))
+	require.NoError(db.Close())
+
+	db, err = New(folder, []byte(`{"readOnly":true}`), logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+
+	gotValue, err := db.Get(key)
+	require.NoError(err)
+	require.Equal(value, gotValue)
+
+	err = db.Put(key, value)
+	require.ErrorIs(err, leveldb.ErrReadOnly)
+	require.NoError(db.Close())
+}
+
+func TestDeleteRangeBatches(t *testing.T) {
+	require := require.New(t)
+
+	db, err := New(t.TempDir(), nil, logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+	require.IsType(&Database{}, db)
+	levelDB := db.(*Database)
+	// Force every key to be deleted in its own write.
+	levelDB.deleteRangeBatchSize = 1
+
+	for i := byte(0); i < 10; i++ {
+		require.NoError(db.Put([]byte{i}, []byte{i}))
+	}
+	require.NoError(db.DeleteRange([]byte{2}, []byte{8}))
+
+	for i := byte(0); i < 10; i++ {
+		has, err := db.Has([]byte{i})
+		require.NoError(err)
+		require.Equal(i < 2 || i >= 8, has)
+	}
+
+	// An empty limit deletes every key after the start.
+	require.NoError(db.DeleteRange([]byte{9}, nil))
+	has, err := db.Has([]byte{9})
+	require.NoError(err)
+	require.False(has)
+	require.NoError(db.Close())
+}
+
 func FuzzKeyValue(f *testing.F) {
 	folder := f.TempDir()
 	db, err := New(folder, nil, logging.NoLog{}, "", prometheus.NewRegistry())
@@ -0,0 +1,86 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package leveldb
+
+import (
+	"bytes"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+
+	"github.com/ava-labs/avalanchego/database"
+)
+
+// dbSnapshot is a wrapper around a levelDB snapshot.
+type dbSnapshot struct {
+	db       *Database
+	snapshot *leveldb.Snapshot
+}
+
+// Has returns if the key is set in the snapshot
+func (s *dbSnapshot) Has(key []byte) (bool, error) {
+	has, err := s.snapshot.Has(key, nil)
+	return has, updateError(err)
+}
+
+// Get returns the value the key maps to in the snapshot
+func (s *dbSnapshot) Get(key []byte) ([]byte, error) {
+	value, err := s.snapshot.Get(key, nil)
+	return value, updateError(err)
+}
+
+// NewIterator creates a lexicographically ordered iterator over the snapshot
+func (s *dbSnapshot) NewIterator() database.Iterator {
+	return &iter{
+		db:       s.db,
+		Iterator: s.snapshot.NewIterator(new(util.Range), nil),
+	}
+}
+
+// NewIteratorWithStart creates a lexicographically ordered iterator over the
+// snapshot starting at the provided key
+func (s *dbSnapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return &iter{
+		db:       s.db,
+		Iterator: s.snapshot.NewIterator(&util.Range{Start: start}, nil),
+	}
+}
+
+// NewIteratorWithPrefix creates a lexicographically ordered iterator over the
+// snapshot ignoring keys that do not start with the provided prefix
+func (s *dbSnapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return &iter{
+		db:       s.db,
+		Iterator: s.snapshot.NewIterator(util.BytesPrefix(prefix), nil),
+	}
+}
+
+// NewIteratorWithStartAndPrefix creates a lexicographically ordered iterator
+// over the snapshot starting at start and ignoring keys that do not start with
+// the provided prefix
+func (s *dbSnapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	iterRange := util.BytesPrefix(prefix)
+	if bytes.Compare(start, prefix) == 1 {
+		iterRange.Start = start
+	}
+	return &iter{
+		db:       s.db,
+		Iterator: s.snapshot.NewIterator(iterRange, nil),
+	}
+}
+
+// NewIteratorWithOptions creates a lexicographically ordered iterator over
+// the keys of the snapshot described by [opts]
+func (s *dbSnapshot) NewIteratorWithOptions(opts database.IteratorOptions) database.Iterator {
+	return &iter{
+		db:       s.db,
+		Iterator: s.snapshot.NewIterator(iteratorRange(opts), nil),
+		reverse:  opts.Reverse,
+	}
+}
+
+// Release releases the snapshot. It is safe to call Release multiple times.
+func (s *dbSnapshot) Release() {
+	s.snapshot.Release()
+}
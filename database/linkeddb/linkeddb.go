@@ -4,6 +4,7 @@
 package linkeddb
 
 import (
+	"bytes"
 	"sync"
 
 	"golang.org/x/exp/maps"
@@ -19,6 +20,7 @@ const (
 
 var (
 	headKey = []byte{0x01}
+	tailKey = []byte{0x02}
 
 	_ LinkedDB          = (*linkedDB)(nil)
 	_ database.Iterator = (*iterator)(nil)
@@ -34,6 +36,7 @@ type LinkedDB interface {
 
 	NewIterator() database.Iterator
 	NewIteratorWithStart(start []byte) database.Iterator
+	NewIteratorWithOptions(opts database.IteratorOptions) database.Iterator
 }
 
 type linkedDB struct {
@@ -44,6 +47,9 @@ type linkedDB struct {
 	// these variables provide caching for the head key.
 	headKeyIsSynced, headKeyExists, headKeyIsUpdated, updatedHeadKeyExists bool
 	headKey, updatedHeadKey                                                []byte
+	// these variables provide caching for the tail key.
+	tailKeyIsSynced, tailKeyExists, tailKeyIsUpdated, updatedTailKeyExists bool
+	tailKey, updatedTailKey                                                []byte
 	// these variables provide caching for the nodes.
 	nodeCache    cache.Cacher[string, *node] // key -> *node
 	updatedNodes map[string]*node
@@ -125,7 +131,12 @@ func (ldb *linkedDB) Put(key, value []byte) error {
 
 		newHead.HasNext = true
 		newHead.Next = headKey
-	} else if err != database.ErrNotFound {
+	} else if err == database.ErrNotFound {
+		// The list is currently empty, so the new head is also the tail.
+		if err := ldb.putTailKey(key); err != nil {
+			return err
+		}
+	} else {
 		return err
 	}
 	if err := ldb.putNode(key, newHead); err != nil {
@@ -168,7 +179,12 @@ func (ldb *linkedDB) Delete(key []byte) error {
 		if err := ldb.putNode(currentNode.Previous, previousNode); err != nil {
 			return err
 		}
-		if currentNode.HasNext {
+		if !currentNode.HasNext {
+			// The previous node will be the new tail.
+			if err := ldb.putTailKey(currentNode.Previous); err != nil {
+				return err
+			}
+		} else {
 			// We aren't modifying the tail.
 			nextNode, err := ldb.getNode(currentNode.Next)
 			if err != nil {
@@ -181,10 +197,13 @@ func (ldb *linkedDB) Delete(key []byte) error {
 			}
 		}
 	case !currentNode.HasNext:
-		// This is the only node, so we don't have a head anymore.
+		// This is the only node, so we don't have a head or a tail anymore.
 		if err := ldb.deleteHeadKey(); err != nil {
 			return err
 		}
+		if err := ldb.deleteTailKey(); err != nil {
+			return err
+		}
 	default:
 		// The next node will be the new head.
 		if err := ldb.putHeadKey(currentNode.Next); err != nil {
@@ -253,6 +272,24 @@ func (ldb *linkedDB) NewIteratorWithStart(start []byte) database.Iterator {
 	return ldb.NewIterator()
 }
 
+// NewIteratorWithOptions returns an iterator over the keys within the bounds
+// of [opts].
+//
+// As with the other iterators, keys are returned in list order rather than in
+// lexicographic order: from the head of the list, or from the tail if
+// [opts.Reverse] is set. The bounds of [opts] are compared against the keys as
+// usual, so the same keys are returned as by any other database. Because the
+// list isn't sorted, the whole list is walked regardless of the bounds.
+func (ldb *linkedDB) NewIteratorWithOptions(opts database.IteratorOptions) database.Iterator {
+	return &iterator{
+		ldb:     ldb,
+		reverse: opts.Reverse,
+		start:   slices.Clone(opts.Start),
+		end:     slices.Clone(opts.End),
+		prefix:  slices.Clone(opts.Prefix),
+	}
+}
+
 func (ldb *linkedDB) getHeadKey() ([]byte, error) {
 	// If the ldb read lock is held, then there needs to be additional
 	// synchronization here to avoid racy behavior.
@@ -293,6 +330,79 @@ func (ldb *linkedDB) deleteHeadKey() error {
 	return ldb.batch.Delete(headKey)
 }
 
+func (ldb *linkedDB) getTailKey() ([]byte, error) {
+	// If the ldb read lock is held, then there needs to be additional
+	// synchronization here to avoid racy behavior.
+	ldb.cacheLock.Lock()
+	defer ldb.cacheLock.Unlock()
+
+	if ldb.tailKeyIsSynced {
+		if ldb.tailKeyExists {
+			return ldb.tailKey, nil
+		}
+		return nil, database.ErrNotFound
+	}
+	tailKey, err := ldb.db.Get(tailKey)
+	if err == nil {
+		ldb.tailKeyIsSynced = true
+		ldb.tailKeyExists = true
+		ldb.tailKey = tailKey
+		return tailKey, nil
+	}
+	if err != database.ErrNotFound {
+		return nil, err
+	}
+
+	// Lists written before the tail key was tracked don't have a tail key, so
+	// it is found by walking the list. The tail key is written the next time
+	// the tail of the list is modified.
+	ldb.cacheLock.Unlock()
+	tailKey, err = ldb.findTailKey()
+	ldb.cacheLock.Lock()
+	if err != nil && err != database.ErrNotFound {
+		return nil, err
+	}
+	if !ldb.tailKeyIsSynced {
+		ldb.tailKeyIsSynced = true
+		ldb.tailKeyExists = err == nil
+		ldb.tailKey = tailKey
+	}
+	return tailKey, err
+}
+
+// findTailKey walks the list from the head to find its tail.
+//
+// Assumes [ldb.cacheLock] is not held.
+func (ldb *linkedDB) findTailKey() ([]byte, error) {
+	key, err := ldb.getHeadKey()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		n, err := ldb.getNode(key)
+		if err != nil {
+			return nil, err
+		}
+		if !n.HasNext {
+			return key, nil
+		}
+		key = n.Next
+	}
+}
+
+func (ldb *linkedDB) putTailKey(key []byte) error {
+	ldb.tailKeyIsUpdated = true
+	ldb.updatedTailKeyExists = true
+	ldb.updatedTailKey = key
+	return ldb.batch.Put(tailKey, key)
+}
+
+func (ldb *linkedDB) deleteTailKey() error {
+	ldb.tailKeyIsUpdated = true
+	ldb.updatedTailKeyExists = false
+	return ldb.batch.Delete(tailKey)
+}
+
 func (ldb *linkedDB) getNode(key []byte) (node, error) {
 	// If the ldb read lock is held, then there needs to be additional
 	// synchronization here to avoid racy behavior.
@@ -339,6 +449,7 @@ func (ldb *linkedDB) deleteNode(key []byte) error {
 
 func (ldb *linkedDB) resetBatch() {
 	ldb.headKeyIsUpdated = false
+	ldb.tailKeyIsUpdated = false
 	maps.Clear(ldb.updatedNodes)
 	ldb.batch.Reset()
 }
@@ -352,6 +463,11 @@ func (ldb *linkedDB) writeBatch() error {
 		ldb.headKeyExists = ldb.updatedHeadKeyExists
 		ldb.headKey = ldb.updatedHeadKey
 	}
+	if ldb.tailKeyIsUpdated {
+		ldb.tailKeyIsSynced = true
+		ldb.tailKeyExists = ldb.updatedTailKeyExists
+		ldb.tailKey = ldb.updatedTailKey
+	}
 	for key, n := range ldb.updatedNodes {
 		ldb.nodeCache.Put(key, n)
 	}
@@ -363,6 +479,11 @@ type iterator struct {
 	initialized, exhausted bool
 	key, value, nextKey    []byte
 	err                    error
+
+	// reverse follows the list from the tail to the head.
+	reverse bool
+	// Keys outside of [start, end) or without [prefix] are skipped.
+	start, end, prefix []byte
 }
 
 func (it *iterator) Next() bool {
@@ -379,7 +500,15 @@ func (it *iterator) Next() bool {
 	// If the iterator was not yet initialized, do it now.
 	if !it.initialized {
 		it.initialized = true
-		headKey, err := it.ldb.getHeadKey()
+		var (
+			firstKey []byte
+			err      error
+		)
+		if it.reverse {
+			firstKey, err = it.ldb.getTailKey()
+		} else {
+			firstKey, err = it.ldb.getHeadKey()
+		}
 		if err == database.ErrNotFound {
 			it.exhausted = true
 			it.key = nil
@@ -393,28 +522,42 @@ func (it *iterator) Next() bool {
 			it.err = err
 			return false
 		}
-		it.nextKey = headKey
+		it.nextKey = firstKey
 	}
 
-	nextNode, err := it.ldb.getNode(it.nextKey)
-	if err == database.ErrNotFound {
-		it.exhausted = true
-		it.key = nil
-		it.value = nil
-		return false
-	}
-	if err != nil {
-		it.exhausted = true
-		it.key = nil
-		it.value = nil
-		it.err = err
-		return false
+	for {
+		nextNode, err := it.ldb.getNode(it.nextKey)
+		if err == database.ErrNotFound {
+			it.exhausted = true
+			it.key = nil
+			it.value = nil
+			return false
+		}
+		if err != nil {
+			it.exhausted = true
+			it.key = nil
+			it.value = nil
+			it.err = err
+			return false
+		}
+		it.key = it.nextKey
+		it.value = nextNode.Value
+		if it.reverse {
+			it.nextKey = nextNode.Previous
+			it.exhausted = !nextNode.HasPrevious
+		} else {
+			it.nextKey = nextNode.Next
+			it.exhausted = !nextNode.HasNext
+		}
+		if bytes.HasPrefix(it.key, it.prefix) && database.InRange(it.key, it.start, it.end) {
+			return true
+		}
+		if it.exhausted {
+			it.key = nil
+			it.value = nil
+			return false
+		}
 	}
-	it.key = it.nextKey
-	it.value = nextNode.Value
-	it.nextKey = nextNode.Next
-	it.exhausted = !nextNode.HasNext
-	return true
 }
 
 func (it *iterator) Error() error {
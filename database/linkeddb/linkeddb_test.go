@@ -460,3 +460,167 @@ func TestLinkedDBHead(t *testing.T) {
 	require.Equal(key0, headKey)
 	require.Equal(value0, headVal)
 }
+
+func TestLinkedDBIteratorWithOptions(t *testing.T) {
+	keys := [][]byte{
+		[]byte("a0"),
+		[]byte("b1"),
+		[]byte("a2"),
+		[]byte("a3"),
+		[]byte("a4"),
+	}
+
+	tests := []struct {
+		name         string
+		opts         database.IteratorOptions
+		expectedKeys [][]byte
+	}{
+		{
+			name: "forward",
+			opts: database.IteratorOptions{},
+			expectedKeys: [][]byte{
+				[]byte("a4"),
+				[]byte("a3"),
+				[]byte("a2"),
+				[]byte("b1"),
+				[]byte("a0"),
+			},
+		},
+		{
+			name: "reverse",
+			opts: database.IteratorOptions{
+				Reverse: true,
+			},
+			expectedKeys: [][]byte{
+				[]byte("a0"),
+				[]byte("b1"),
+				[]byte("a2"),
+				[]byte("a3"),
+				[]byte("a4"),
+			},
+		},
+		{
+			name: "start and end",
+			opts: database.IteratorOptions{
+				Start: []byte("a1"),
+				End:   []byte("a4"),
+			},
+			expectedKeys: [][]byte{
+				[]byte("a3"),
+				[]byte("a2"),
+			},
+		},
+		{
+			name: "reverse with start and end",
+			opts: database.IteratorOptions{
+				Start:   []byte("a2"),
+				End:     []byte("b1"),
+				Reverse: true,
+			},
+			expectedKeys: [][]byte{
+				[]byte("a2"),
+				[]byte("a3"),
+				[]byte("a4"),
+			},
+		},
+		{
+			name: "bounds that aren't in the list",
+			opts: database.IteratorOptions{
+				Start: []byte("a"),
+				End:   []byte("b"),
+			},
+			expectedKeys: [][]byte{
+				[]byte("a4"),
+				[]byte("a3"),
+				[]byte("a2"),
+				[]byte("a0"),
+			},
+		},
+		{
+			name: "prefix",
+			opts: database.IteratorOptions{
+				Start:  []byte("a3"),
+				Prefix: []byte("a"),
+			},
+			expectedKeys: [][]byte{
+				[]byte("a4"),
+				[]byte("a3"),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			ldb := NewDefault(memdb.New())
+			for _, key := range keys {
+				require.NoError(ldb.Put(key, key))
+			}
+
+			iterator := ldb.NewIteratorWithOptions(test.opts)
+			for _, expectedKey := range test.expectedKeys {
+				require.True(iterator.Next())
+				require.Equal(expectedKey, iterator.Key())
+				require.Equal(expectedKey, iterator.Value())
+			}
+			require.False(iterator.Next())
+			require.Nil(iterator.Key())
+			require.Nil(iterator.Value())
+			require.NoError(iterator.Error())
+			iterator.Release()
+		})
+	}
+}
+
+func TestLinkedDBReverseIteratorTail(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	ldb := NewDefault(db)
+
+	key0 := []byte("hello0")
+	key1 := []byte("hello1")
+	key2 := []byte("hello2")
+
+	require.NoError(ldb.Put(key0, key0))
+	require.NoError(ldb.Put(key1, key1))
+	require.NoError(ldb.Put(key2, key2))
+
+	// Lists written without a tail key should still be iterable in reverse.
+	require.NoError(db.Delete(tailKey))
+	ldb = NewDefault(db)
+
+	iterator := ldb.NewIteratorWithOptions(database.IteratorOptions{
+		Reverse: true,
+	})
+	require.True(iterator.Next())
+	require.Equal(key0, iterator.Key())
+	iterator.Release()
+
+	// Removing the tail should update the tail key.
+	require.NoError(ldb.Delete(key0))
+
+	ldb = NewDefault(db)
+	tail, err := db.Get(tailKey)
+	require.NoError(err)
+	require.Equal(key1, tail)
+
+	iterator = ldb.NewIteratorWithOptions(database.IteratorOptions{
+		Reverse: true,
+	})
+	require.True(iterator.Next())
+	require.Equal(key1, iterator.Key())
+	require.True(iterator.Next())
+	require.Equal(key2, iterator.Key())
+	require.False(iterator.Next())
+	require.NoError(iterator.Error())
+	iterator.Release()
+
+	// Removing every key should remove the tail key.
+	require.NoError(ldb.Delete(key1))
+	require.NoError(ldb.Delete(key2))
+
+	has, err := db.Has(tailKey)
+	require.NoError(err)
+	require.False(has)
+}
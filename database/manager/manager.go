@@ -13,10 +13,12 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/compressdb"
 	"github.com/ava-labs/avalanchego/database/corruptabledb"
 	"github.com/ava-labs/avalanchego/database/leveldb"
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/database/meterdb"
+	"github.com/ava-labs/avalanchego/database/pebbledb"
 	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/logging"
@@ -64,6 +66,12 @@ type Manager interface {
 	// Note: calling this more than once with the same [namespace] will cause a
 	// conflict error for the [registerer].
 	NewCompleteMeterDBManager(namespace string, registerer prometheus.Registerer) (Manager, error)
+
+	// NewCompressDBManager returns a new database manager with the current
+	// database wrapped with a compressdb instance as described by [config].
+	// Returns an error if compression is enabled on a current database that
+	// already contains uncompressed values.
+	NewCompressDBManager(config compressdb.Config) (Manager, error)
 }
 
 type manager struct {
@@ -96,6 +104,28 @@ func NewLevelDB(
 	)
 }
 
+// NewPebbleDB creates a database manager of pebbleDBs at [filePath] by
+// creating a database instance from each directory with a version <=
+// [currentVersion].
+func NewPebbleDB(
+	dbDirPath string,
+	dbConfig []byte,
+	log logging.Logger,
+	currentVersion *version.Semantic,
+	namespace string,
+	reg prometheus.Registerer,
+) (Manager, error) {
+	return new(
+		pebbledb.New,
+		dbDirPath,
+		dbConfig,
+		log,
+		currentVersion,
+		namespace,
+		reg,
+	)
+}
+
 // new creates a database manager at [filePath] by creating a database instance
 // from each directory with a version <= [currentVersion]. If
 // [includePreviousVersions], opens previous database versions and includes them
@@ -303,6 +333,28 @@ func (m *manager) NewCompleteMeterDBManager(namespace string, registerer prometh
 	})
 }
 
+// NewCompressDBManager wraps the current database instance with a compressdb
+// instance. Previous database versions are not wrapped, as they may have been
+// written without compression. See compressdb.Wrap for when the current
+// database can be wrapped.
+func (m *manager) NewCompressDBManager(config compressdb.Config) (Manager, error) {
+	currentDB := m.Current()
+	currentCompressDB, err := compressdb.Wrap(config, currentDB.Database)
+	if err != nil {
+		return nil, err
+	}
+	newManager := &manager{
+		databases: make([]*VersionedDatabase, len(m.databases)),
+	}
+	copy(newManager.databases[1:], m.databases[1:])
+	// Overwrite the current database with the compress DB
+	newManager.databases[0] = &VersionedDatabase{
+		Database: currentCompressDB,
+		Version:  currentDB.Version,
+	}
+	return newManager, nil
+}
+
 // wrapManager returns a new database manager with each managed database wrapped
 // by the [wrap] function. If an error is returned by wrap, the error is
 // returned immediately. If [wrap] never returns an error, then wrapManager is
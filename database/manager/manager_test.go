@@ -15,6 +15,7 @@ import (
 	"github.com/ava-labs/avalanchego/database/leveldb"
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/database/meterdb"
+	"github.com/ava-labs/avalanchego/database/pebbledb"
 	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/metric"
@@ -46,6 +47,31 @@ func TestNewSingleLevelDB(t *testing.T) {
 	require.NoError(manager.Close())
 }
 
+func TestNewSinglePebbleDB(t *testing.T) {
+	require := require.New(t)
+	dir := t.TempDir()
+
+	v1 := version.Semantic1_0_0
+
+	dbPath := filepath.Join(dir, v1.String())
+	db, err := pebbledb.New(dbPath, nil, logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+
+	require.NoError(db.Close())
+
+	manager, err := NewPebbleDB(dir, nil, logging.NoLog{}, v1, "", prometheus.NewRegistry())
+	require.NoError(err)
+
+	semDB := manager.Current()
+	require.Zero(semDB.Version.Compare(v1))
+
+	_, exists := manager.Previous()
+	require.False(exists)
+	require.Len(manager.GetDatabases(), 1)
+
+	require.NoError(manager.Close())
+}
+
 func TestNewCreatesSingleDB(t *testing.T) {
 	require := require.New(t)
 
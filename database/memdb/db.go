@@ -5,9 +5,9 @@ package memdb
 
 import (
 	"context"
-	"strings"
 	"sync"
 
+	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
 
 	"github.com/ava-labs/avalanchego/database"
@@ -22,9 +22,12 @@ const (
 )
 
 var (
-	_ database.Database = (*Database)(nil)
-	_ database.Batch    = (*batch)(nil)
-	_ database.Iterator = (*iterator)(nil)
+	_ database.Database      = (*Database)(nil)
+	_ database.RangeIteratee = (*Database)(nil)
+	_ database.Snapshotter   = (*Database)(nil)
+	_ database.Snapshot      = (*snapshot)(nil)
+	_ database.Batch         = (*batch)(nil)
+	_ database.Iterator      = (*iterator)(nil)
 )
 
 // Database is an ephemeral key-value store that implements the Database
@@ -109,6 +112,26 @@ func (db *Database) Delete(key []byte) error {
 	return nil
 }
 
+func (db *Database) DeleteRange(start, limit []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.db == nil {
+		return database.ErrClosed
+	}
+	db.deleteRange(start, limit)
+	return nil
+}
+
+// Assumes the lock is held.
+func (db *Database) deleteRange(start, limit []byte) {
+	for key := range db.db {
+		if database.InRange([]byte(key), start, limit) {
+			delete(db.db, key)
+		}
+	}
+}
+
 func (db *Database) NewBatch() database.Batch {
 	return &batch{db: db}
 }
@@ -126,6 +149,13 @@ func (db *Database) NewIteratorWithPrefix(prefix []byte) database.Iterator {
 }
 
 func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return db.NewIteratorWithOptions(database.IteratorOptions{
+		Start:  start,
+		Prefix: prefix,
+	})
+}
+
+func (db *Database) NewIteratorWithOptions(opts database.IteratorOptions) database.Iterator {
 	db.lock.RLock()
 	defer db.lock.RUnlock()
 
@@ -135,15 +165,21 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 		}
 	}
 
-	startString := string(start)
-	prefixString := string(prefix)
+	lower, upper := opts.Bounds()
 	keys := make([]string, 0, len(db.db))
 	for key := range db.db {
-		if strings.HasPrefix(key, prefixString) && key >= startString {
+		if database.InRange([]byte(key), lower, upper) {
 			keys = append(keys, key)
 		}
 	}
-	slices.Sort(keys) // Keys need to be in sorted order
+	// Keys need to be in sorted order
+	if opts.Reverse {
+		slices.SortFunc(keys, func(a, b string) bool {
+			return a > b
+		})
+	} else {
+		slices.Sort(keys)
+	}
 	values := make([][]byte, 0, len(keys))
 	for _, key := range keys {
 		values = append(values, db.db[key])
@@ -165,6 +201,20 @@ func (db *Database) Compact(_, _ []byte) error {
 	return nil
 }
 
+// NewSnapshot returns a copy of the database. Values stored in the database
+// are never modified in place, so only the map needs to be copied.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return nil, database.ErrClosed
+	}
+	return &snapshot{
+		Database: &Database{db: maps.Clone(db.db)},
+	}, nil
+}
+
 func (db *Database) HealthCheck(context.Context) (interface{}, error) {
 	if db.isClosed() {
 		return nil, database.ErrClosed
@@ -187,9 +237,12 @@ func (b *batch) Write() error {
 	}
 
 	for _, op := range b.Ops {
-		if op.Delete {
+		switch {
+		case op.DeleteRange:
+			b.db.deleteRange(op.Key, op.Limit)
+		case op.Delete:
 			delete(b.db.db, string(op.Key))
-		} else {
+		default:
 			b.db.db[string(op.Key)] = op.Value
 		}
 	}
@@ -200,6 +253,15 @@ func (b *batch) Inner() database.Batch {
 	return b
 }
 
+// snapshot is a read-only copy of a database.
+type snapshot struct {
+	*Database
+}
+
+func (s *snapshot) Release() {
+	_ = s.Close()
+}
+
 type iterator struct {
 	db          *Database
 	initialized bool
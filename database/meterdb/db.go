@@ -13,9 +13,11 @@ import (
 )
 
 var (
-	_ database.Database = (*Database)(nil)
-	_ database.Batch    = (*batch)(nil)
-	_ database.Iterator = (*iterator)(nil)
+	_ database.Database      = (*Database)(nil)
+	_ database.RangeIteratee = (*Database)(nil)
+	_ database.Snapshotter   = (*Database)(nil)
+	_ database.Batch         = (*batch)(nil)
+	_ database.Iterator      = (*iterator)(nil)
 )
 
 // Database tracks the amount of time each operation takes and how many bytes
@@ -79,6 +81,16 @@ func (db *Database) Delete(key []byte) error {
 	return err
 }
 
+func (db *Database) DeleteRange(start, limit []byte) error {
+	startTime := db.clock.Time()
+	err := db.db.DeleteRange(start, limit)
+	end := db.clock.Time()
+	db.writeSize.Observe(float64(len(start) + len(limit)))
+	db.deleteRange.Observe(float64(end.Sub(startTime)))
+	db.deleteRangeSize.Observe(float64(len(start) + len(limit)))
+	return err
+}
+
 func (db *Database) NewBatch() database.Batch {
 	start := db.clock.Time()
 	b := &batch{
@@ -106,9 +118,16 @@ func (db *Database) NewIteratorWithStartAndPrefix(
 	start,
 	prefix []byte,
 ) database.Iterator {
+	return db.NewIteratorWithOptions(database.IteratorOptions{
+		Start:  start,
+		Prefix: prefix,
+	})
+}
+
+func (db *Database) NewIteratorWithOptions(opts database.IteratorOptions) database.Iterator {
 	startTime := db.clock.Time()
 	it := &iterator{
-		iterator: db.db.NewIteratorWithStartAndPrefix(start, prefix),
+		iterator: database.NewIteratorWithOptions(db.db, opts),
 		db:       db,
 	}
 	end := db.clock.Time()
@@ -124,6 +143,14 @@ func (db *Database) Compact(start, limit []byte) error {
 	return err
 }
 
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	start := db.clock.Time()
+	snapshot, err := database.NewSnapshot(db.db)
+	end := db.clock.Time()
+	db.newSnapshot.Observe(float64(end.Sub(start)))
+	return snapshot, err
+}
+
 func (db *Database) Close() error {
 	start := db.clock.Time()
 	err := db.db.Close()
@@ -163,6 +190,15 @@ func (b *batch) Delete(key []byte) error {
 	return err
 }
 
+func (b *batch) DeleteRange(start, limit []byte) error {
+	startTime := b.db.clock.Time()
+	err := b.batch.DeleteRange(start, limit)
+	end := b.db.clock.Time()
+	b.db.bDeleteRange.Observe(float64(end.Sub(startTime)))
+	b.db.bDeleteRangeSize.Observe(float64(len(start) + len(limit)))
+	return err
+}
+
 func (b *batch) Size() int {
 	start := b.db.clock.Time()
 	size := b.batch.Size()
@@ -0,0 +1,184 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package migration
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+var (
+	_ Step = (*copyStep)(nil)
+
+	errDestinationNotEmpty = errors.New("destination isn't empty")
+	errCopyMismatch        = errors.New("copied keys don't match")
+)
+
+type copyStep struct {
+	name      string
+	prefix    []byte
+	batchSize int
+}
+
+// NewCopyStep returns a step that copies every key with [prefix] from the
+// previous database into the current database without modification. A
+// checkpoint is saved every time [batchSize] bytes have been written.
+//
+// The step refuses to start copying if the current database already has a key
+// with [prefix], so that stale keys aren't merged into the copied state. The
+// copy is verified by comparing the number of keys with [prefix] and a hash of
+// them in both databases.
+func NewCopyStep(name string, prefix []byte, batchSize int) Step {
+	return &copyStep{
+		name:      name,
+		prefix:    prefix,
+		batchSize: batchSize,
+	}
+}
+
+func (s *copyStep) Name() string {
+	return s.name
+}
+
+func (s *copyStep) Migrate(ctx context.Context, from, to database.Database, progress *Progress) error {
+	if !progress.Resumed() {
+		empty, err := isEmpty(to, s.prefix)
+		if err != nil {
+			return err
+		}
+		if !empty {
+			return fmt.Errorf("%w: prefix 0x%x", errDestinationNotEmpty, s.prefix)
+		}
+		// Record that copying started, so that the keys written before the
+		// first checkpoint don't prevent the step from being resumed.
+		if err := progress.Checkpoint(nil, 0); err != nil {
+			return err
+		}
+	}
+
+	// The cursor is the last key that was copied, so copying resumes from the
+	// key after it.
+	start := progress.Cursor()
+	if start != nil {
+		start = append(start, 0x00)
+	}
+
+	it := from.NewIteratorWithStartAndPrefix(start, s.prefix)
+	defer it.Release()
+
+	var (
+		batch   = to.NewBatch()
+		numKeys int
+		lastKey []byte
+	)
+	for it.Next() {
+		// The key is copied because it is used after the iterator advances.
+		lastKey = slices.Clone(it.Key())
+		if err := batch.Put(lastKey, it.Value()); err != nil {
+			return err
+		}
+		numKeys++
+		if batch.Size() < s.batchSize {
+			continue
+		}
+
+		if err := batch.Write(); err != nil {
+			return err
+		}
+		if err := progress.Checkpoint(lastKey, numKeys); err != nil {
+			return err
+		}
+		batch.Reset()
+		numKeys = 0
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+
+	if numKeys == 0 {
+		return nil
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	return progress.Checkpoint(lastKey, numKeys)
+}
+
+func (s *copyStep) Verify(ctx context.Context, from, to database.Database) error {
+	fromNumKeys, fromHash, err := digest(ctx, from, s.prefix)
+	if err != nil {
+		return err
+	}
+	toNumKeys, toHash, err := digest(ctx, to, s.prefix)
+	if err != nil {
+		return err
+	}
+	if fromNumKeys != toNumKeys || fromHash != toHash {
+		return fmt.Errorf("%w: prefix 0x%x has %d keys with hash %s, but %d keys with hash %s were copied",
+			errCopyMismatch,
+			s.prefix,
+			fromNumKeys,
+			fromHash,
+			toNumKeys,
+			toHash,
+		)
+	}
+	return nil
+}
+
+// isEmpty returns true if [db] has no key with [prefix].
+func isEmpty(db database.Iteratee, prefix []byte) (bool, error) {
+	it := db.NewIteratorWithPrefix(prefix)
+	defer it.Release()
+
+	return !it.Next(), it.Error()
+}
+
+// digest returns the number of keys with [prefix] in [db] and a hash of those
+// keys and their values.
+func digest(ctx context.Context, db database.Iteratee, prefix []byte) (uint64, ids.ID, error) {
+	it := db.NewIteratorWithPrefix(prefix)
+	defer it.Release()
+
+	var (
+		hasher  = sha256.New()
+		numKeys uint64
+		buf     []byte
+	)
+	for it.Next() {
+		// The lengths are included so that the hash identifies where each key
+		// and value end.
+		key := it.Key()
+		value := it.Value()
+		buf = binary.AppendUvarint(buf[:0], uint64(len(key)))
+		buf = append(buf, key...)
+		buf = binary.AppendUvarint(buf, uint64(len(value)))
+		buf = append(buf, value...)
+		_, _ = hasher.Write(buf)
+		numKeys++
+
+		if err := ctx.Err(); err != nil {
+			return 0, ids.Empty, err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return 0, ids.Empty, err
+	}
+
+	var hash ids.ID
+	hasher.Sum(hash[:0])
+	return numKeys, hash, nil
+}
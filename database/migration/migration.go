@@ -0,0 +1,275 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package migration migrates the state of chains from the previous versioned
+// database into the current versioned database.
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/manager"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+var (
+	// Prefix of the migration metadata in the current database.
+	//
+	// Must be kept in sync with database/dbtool/labels.go.
+	migrationPrefix = []byte("migration")
+
+	cursorPrefix   = []byte("cursor")
+	donePrefix     = []byte("done")
+	verifiedPrefix = []byte("verified")
+
+	migratedVersionKey = []byte("migratedVersion")
+
+	errDuplicateStep = errors.New("duplicate migration step")
+)
+
+// Step migrates a portion of the state of a chain, or of the node, from the
+// previous database version into the current database version.
+//
+// Migrate may be interrupted at any point. It is resumed from the last
+// checkpoint saved through [progress], so any writes made after the last
+// checkpoint must be safe to repeat.
+type Step interface {
+	// Name uniquely identifies the step among the steps of its chain, or among
+	// the steps of the node. The progress of a step is persisted under its
+	// name, so the name of a step must never change.
+	Name() string
+
+	// Migrate migrates state from [from] into [to]. Both databases are
+	// prefixed with the ID of the chain the step was registered for. The
+	// steps of the node are given the whole databases.
+	Migrate(ctx context.Context, from, to database.Database, progress *Progress) error
+
+	// Verify returns an error if the state that was migrated into [to]
+	// doesn't match the state in [from]. It is called once every step has
+	// been migrated, and the previous database is only recorded as migrated
+	// once every step has been verified.
+	Verify(ctx context.Context, from, to database.Database) error
+}
+
+type scopedStep struct {
+	// scope describes the state migrated by the step in logs.
+	scope string
+	// prefix of the state migrated by the step, or nil if the step migrates
+	// the whole database.
+	prefix []byte
+	step   Step
+}
+
+// key returns the key that the progress of the step is persisted under.
+func (s scopedStep) key() []byte {
+	name := s.step.Name()
+	key := make([]byte, 0, len(s.prefix)+len(name))
+	key = append(key, s.prefix...)
+	return append(key, name...)
+}
+
+// databases returns the databases that the step migrates from and into.
+func (s scopedStep) databases(previous, current database.Database) (database.Database, database.Database) {
+	if s.prefix == nil {
+		return previous, current
+	}
+	return prefixdb.New(s.prefix, previous), prefixdb.New(s.prefix, current)
+}
+
+// Migrator runs the registered migration steps of every chain and of the node.
+type Migrator struct {
+	log   logging.Logger
+	steps []scopedStep
+	keys  set.Set[string]
+}
+
+func New(log logging.Logger) *Migrator {
+	return &Migrator{
+		log: log,
+	}
+}
+
+// Register adds [step] to the steps of [chainID]. Steps are run in the order
+// they are registered.
+func (m *Migrator) Register(chainID ids.ID, step Step) error {
+	return m.register(scopedStep{
+		scope:  chainID.String(),
+		prefix: chainID[:],
+		step:   step,
+	})
+}
+
+// RegisterNode adds [step] to the steps that migrate the state of the node
+// that isn't specific to a chain. Steps are run in the order they are
+// registered.
+func (m *Migrator) RegisterNode(step Step) error {
+	return m.register(scopedStep{
+		scope: "node",
+		step:  step,
+	})
+}
+
+func (m *Migrator) register(s scopedStep) error {
+	key := string(s.key())
+	if m.keys.Contains(key) {
+		return fmt.Errorf("%w: %q of %s", errDuplicateStep, s.step.Name(), s.scope)
+	}
+	m.keys.Add(key)
+	m.steps = append(m.steps, s)
+	return nil
+}
+
+// Run migrates the state of every chain from the previous database of
+// [dbManager] into its current database.
+//
+// Steps that have already completed are skipped, and steps that were
+// interrupted are resumed from their last checkpoint. Once every step has
+// completed and has been verified, the previous version is recorded as
+// migrated in the current database. If no steps are registered, nothing is
+// migrated and the previous version is not recorded as migrated.
+func (m *Migrator) Run(ctx context.Context, dbManager manager.Manager) error {
+	previous, ok := dbManager.Previous()
+	if !ok {
+		m.log.Debug("skipping database migration",
+			zap.String("reason", "no previous database"),
+		)
+		return nil
+	}
+	if len(m.steps) == 0 {
+		m.log.Debug("skipping database migration",
+			zap.String("reason", "no registered steps"),
+		)
+		return nil
+	}
+	current := dbManager.Current()
+
+	metadataDB := prefixdb.New(migrationPrefix, current.Database)
+	cursorDB := prefixdb.New(cursorPrefix, metadataDB)
+	doneDB := prefixdb.New(donePrefix, metadataDB)
+	verifiedDB := prefixdb.New(verifiedPrefix, metadataDB)
+
+	m.log.Info("migrating database",
+		zap.Stringer("from", previous.Version),
+		zap.Stringer("to", current.Version),
+		zap.Int("numSteps", len(m.steps)),
+	)
+	for _, s := range m.steps {
+		key := s.key()
+		done, err := doneDB.Has(key)
+		if err != nil {
+			return err
+		}
+		if done {
+			m.log.Debug("skipping completed migration step",
+				zap.String("scope", s.scope),
+				zap.String("step", s.step.Name()),
+			)
+			continue
+		}
+
+		progress, err := newProgress(m.log, s, cursorDB)
+		if err != nil {
+			return err
+		}
+		m.log.Info("running migration step",
+			zap.String("scope", s.scope),
+			zap.String("step", s.step.Name()),
+			zap.Bool("resumed", progress.Resumed()),
+		)
+
+		from, to := s.databases(previous.Database, current.Database)
+		if err := s.step.Migrate(ctx, from, to, progress); err != nil {
+			return fmt.Errorf("migration step %q of %s failed: %w", s.step.Name(), s.scope, err)
+		}
+
+		if err := doneDB.Put(key, nil); err != nil {
+			return err
+		}
+		if err := cursorDB.Delete(key); err != nil {
+			return err
+		}
+		m.log.Info("finished migration step",
+			zap.String("scope", s.scope),
+			zap.String("step", s.step.Name()),
+			zap.Uint64("numKeys", progress.numKeys),
+		)
+	}
+
+	// The previous database may be deleted once it is recorded as migrated,
+	// so every step is verified first.
+	for _, s := range m.steps {
+		key := s.key()
+		verified, err := verifiedDB.Has(key)
+		if err != nil {
+			return err
+		}
+		if verified {
+			continue
+		}
+
+		from, to := s.databases(previous.Database, current.Database)
+		if err := s.step.Verify(ctx, from, to); err != nil {
+			return fmt.Errorf("migration step %q of %s failed verification: %w", s.step.Name(), s.scope, err)
+		}
+		if err := verifiedDB.Put(key, nil); err != nil {
+			return err
+		}
+		m.log.Info("verified migration step",
+			zap.String("scope", s.scope),
+			zap.String("step", s.step.Name()),
+		)
+	}
+
+	if err := metadataDB.Put(migratedVersionKey, []byte(previous.Version.String())); err != nil {
+		return err
+	}
+	m.log.Info("finished migrating database",
+		zap.Stringer("from", previous.Version),
+		zap.Stringer("to", current.Version),
+	)
+	return nil
+}
+
+// MigratedVersion returns the version that was migrated into [db], which is
+// expected to be the current database. Returns false if no version was
+// migrated into [db].
+func MigratedVersion(db database.Database) (*version.Semantic, bool, error) {
+	metadataDB := prefixdb.New(migrationPrefix, db)
+	versionBytes, err := metadataDB.Get(migratedVersionKey)
+	if err == database.ErrNotFound {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	migratedVersion, err := version.Parse(string(versionBytes))
+	return migratedVersion, err == nil, err
+}
+
+// Deletable returns the versions of the previous databases of [dbManager] that
+// are no longer needed, because a version at least as recent has been migrated
+// into the current database.
+func Deletable(dbManager manager.Manager) ([]*version.Semantic, error) {
+	current := dbManager.Current()
+	migratedVersion, ok, err := MigratedVersion(current.Database)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	var versions []*version.Semantic
+	for _, db := range dbManager.GetDatabases() {
+		if db.Version.Compare(current.Version) < 0 && db.Version.Compare(migratedVersion) <= 0 {
+			versions = append(versions, db.Version)
+		}
+	}
+	return versions, nil
+}
@@ -0,0 +1,313 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package migration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/manager"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+var (
+	v1 = &version.Semantic{Major: 1}
+	v2 = &version.Semantic{Major: 2}
+	v3 = &version.Semantic{Major: 3}
+)
+
+func newTestManager(t *testing.T, versions ...*version.Semantic) manager.Manager {
+	dbs := make([]*manager.VersionedDatabase, len(versions))
+	for i, v := range versions {
+		dbs[i] = &manager.VersionedDatabase{
+			Database: memdb.New(),
+			Version:  v,
+		}
+	}
+	dbManager, err := manager.NewManagerFromDBs(dbs)
+	require.NoError(t, err)
+	return dbManager
+}
+
+// countingStep counts the number of times it is run, and records the cursor
+// it is run from.
+type countingStep struct {
+	Step
+	numRuns int
+	cursors [][]byte
+}
+
+func (s *countingStep) Migrate(ctx context.Context, from, to database.Database, progress *Progress) error {
+	s.numRuns++
+	s.cursors = append(s.cursors, progress.Cursor())
+	return s.Step.Migrate(ctx, from, to, progress)
+}
+
+// extraKeyStep writes an extra key into the current database after running
+// its step.
+type extraKeyStep struct {
+	Step
+}
+
+func (s *extraKeyStep) Migrate(ctx context.Context, from, to database.Database, progress *Progress) error {
+	if err := s.Step.Migrate(ctx, from, to, progress); err != nil {
+		return err
+	}
+	return to.Put([]byte("extra"), nil)
+}
+
+func TestRegisterDuplicate(t *testing.T) {
+	require := require.New(t)
+
+	chainID := ids.GenerateTestID()
+	m := New(logging.NoLog{})
+	require.NoError(m.Register(chainID, NewCopyStep("copy", nil, 1)))
+	require.NoError(m.Register(ids.GenerateTestID(), NewCopyStep("copy", nil, 1)))
+
+	err := m.Register(chainID, NewCopyStep("copy", []byte("other"), 1))
+	require.ErrorIs(err, errDuplicateStep)
+}
+
+func TestRunNoPrevious(t *testing.T) {
+	require := require.New(t)
+
+	dbManager := newTestManager(t, v2)
+	m := New(logging.NoLog{})
+	require.NoError(m.Register(ids.GenerateTestID(), NewCopyStep("copy", nil, 1)))
+	require.NoError(m.Run(context.Background(), dbManager))
+
+	_, ok, err := MigratedVersion(dbManager.Current().Database)
+	require.NoError(err)
+	require.False(ok)
+}
+
+func TestRunNoSteps(t *testing.T) {
+	require := require.New(t)
+
+	dbManager := newTestManager(t, v1, v2)
+	m := New(logging.NoLog{})
+	require.NoError(m.Run(context.Background(), dbManager))
+
+	_, ok, err := MigratedVersion(dbManager.Current().Database)
+	require.NoError(err)
+	require.False(ok)
+
+	versions, err := Deletable(dbManager)
+	require.NoError(err)
+	require.Empty(versions)
+}
+
+func TestRunCopy(t *testing.T) {
+	require := require.New(t)
+
+	dbManager := newTestManager(t, v1, v2)
+	previous, _ := dbManager.Previous()
+	current := dbManager.Current()
+
+	chainID := ids.GenerateTestID()
+	fromDB := prefixdb.New(chainID[:], previous.Database)
+	require.NoError(fromDB.Put([]byte("a1"), []byte("1")))
+	require.NoError(fromDB.Put([]byte("a2"), []byte("2")))
+	require.NoError(fromDB.Put([]byte("b1"), []byte("3")))
+
+	// Keys of other chains must not be migrated.
+	otherChainID := ids.GenerateTestID()
+	require.NoError(prefixdb.New(otherChainID[:], previous.Database).Put([]byte("a1"), []byte("4")))
+
+	step := &countingStep{
+		Step: NewCopyStep("copy", []byte("a"), 1024),
+	}
+	m := New(logging.NoLog{})
+	require.NoError(m.Register(chainID, step))
+	require.NoError(m.Run(context.Background(), dbManager))
+	require.Equal(1, step.numRuns)
+
+	toDB := prefixdb.New(chainID[:], current.Database)
+	for key, expected := range map[string][]byte{
+		"a1": []byte("1"),
+		"a2": []byte("2"),
+	} {
+		value, err := toDB.Get([]byte(key))
+		require.NoError(err)
+		require.Equal(expected, value)
+	}
+	has, err := toDB.Has([]byte("b1"))
+	require.NoError(err)
+	require.False(has)
+
+	has, err = prefixdb.New(otherChainID[:], current.Database).Has([]byte("a1"))
+	require.NoError(err)
+	require.False(has)
+
+	migratedVersion, ok, err := MigratedVersion(current.Database)
+	require.NoError(err)
+	require.True(ok)
+	require.Zero(v1.Compare(migratedVersion))
+
+	// Completed steps are not run again.
+	require.NoError(m.Run(context.Background(), dbManager))
+	require.Equal(1, step.numRuns)
+}
+
+func TestRunResume(t *testing.T) {
+	require := require.New(t)
+
+	dbManager := newTestManager(t, v1, v2)
+	previous, _ := dbManager.Previous()
+	current := dbManager.Current()
+
+	chainID := ids.GenerateTestID()
+	fromDB := prefixdb.New(chainID[:], previous.Database)
+	keys := [][]byte{{0x00}, {0x01}, {0x02}, {0x03}}
+	for _, key := range keys {
+		require.NoError(fromDB.Put(key, key))
+	}
+
+	step := &countingStep{
+		Step: NewCopyStep("copy", nil, 1),
+	}
+	m := New(logging.NoLog{})
+	require.NoError(m.Register(chainID, step))
+
+	// Every key is checkpointed, so the cancellation is noticed after the first
+	// key is copied.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := m.Run(ctx, dbManager)
+	require.ErrorIs(err, context.Canceled)
+
+	toDB := prefixdb.New(chainID[:], current.Database)
+	has, err := toDB.Has(keys[0])
+	require.NoError(err)
+	require.True(has)
+	has, err = toDB.Has(keys[1])
+	require.NoError(err)
+	require.False(has)
+
+	_, ok, err := MigratedVersion(current.Database)
+	require.NoError(err)
+	require.False(ok)
+
+	// The step resumes after the copied key, even though the destination
+	// isn't empty.
+	require.NoError(m.Run(context.Background(), dbManager))
+	require.Equal([][]byte{nil, keys[0]}, step.cursors)
+	for _, key := range keys {
+		value, err := toDB.Get(key)
+		require.NoError(err)
+		require.Equal(key, value)
+	}
+
+	_, ok, err = MigratedVersion(current.Database)
+	require.NoError(err)
+	require.True(ok)
+}
+
+func TestRunCopyNonEmptyDestination(t *testing.T) {
+	require := require.New(t)
+
+	dbManager := newTestManager(t, v1, v2)
+	previous, _ := dbManager.Previous()
+	current := dbManager.Current()
+
+	chainID := ids.GenerateTestID()
+	require.NoError(prefixdb.New(chainID[:], previous.Database).Put([]byte{1}, []byte{1}))
+
+	// A stale key left in the destination must not be merged into the copy.
+	toDB := prefixdb.New(chainID[:], current.Database)
+	require.NoError(toDB.Put([]byte{2}, []byte{2}))
+
+	m := New(logging.NoLog{})
+	require.NoError(m.Register(chainID, NewCopyStep("copy", nil, 1)))
+	err := m.Run(context.Background(), dbManager)
+	require.ErrorIs(err, errDestinationNotEmpty)
+
+	has, err := toDB.Has([]byte{1})
+	require.NoError(err)
+	require.False(has)
+
+	versions, err := Deletable(dbManager)
+	require.NoError(err)
+	require.Empty(versions)
+}
+
+func TestRunVerifyMismatch(t *testing.T) {
+	require := require.New(t)
+
+	dbManager := newTestManager(t, v1, v2)
+	previous, _ := dbManager.Previous()
+
+	chainID := ids.GenerateTestID()
+	require.NoError(prefixdb.New(chainID[:], previous.Database).Put([]byte{1}, []byte{1}))
+
+	m := New(logging.NoLog{})
+	require.NoError(m.Register(chainID, &extraKeyStep{
+		Step: NewCopyStep("copy", nil, 1),
+	}))
+
+	// The previous database isn't deletable until the copy is verified.
+	err := m.Run(context.Background(), dbManager)
+	require.ErrorIs(err, errCopyMismatch)
+
+	versions, err := Deletable(dbManager)
+	require.NoError(err)
+	require.Empty(versions)
+
+	err = m.Run(context.Background(), dbManager)
+	require.ErrorIs(err, errCopyMismatch)
+}
+
+func TestRunNode(t *testing.T) {
+	require := require.New(t)
+
+	dbManager := newTestManager(t, v1, v2)
+	previous, _ := dbManager.Previous()
+	current := dbManager.Current()
+
+	// Node steps are given the whole database, rather than a chain's prefix.
+	require.NoError(previous.Database.Put([]byte("node key"), []byte{1}))
+	require.NoError(previous.Database.Put([]byte("other key"), []byte{2}))
+
+	m := New(logging.NoLog{})
+	require.NoError(m.RegisterNode(NewCopyStep("copy", []byte("node"), 1)))
+	require.NoError(m.Register(ids.Empty, NewCopyStep("copy", nil, 1)))
+	require.NoError(m.Run(context.Background(), dbManager))
+
+	value, err := current.Database.Get([]byte("node key"))
+	require.NoError(err)
+	require.Equal([]byte{1}, value)
+
+	has, err := current.Database.Has([]byte("other key"))
+	require.NoError(err)
+	require.False(has)
+
+	_, ok, err := MigratedVersion(current.Database)
+	require.NoError(err)
+	require.True(ok)
+}
+
+func TestDeletable(t *testing.T) {
+	require := require.New(t)
+
+	dbManager := newTestManager(t, v1, v2, v3)
+
+	versions, err := Deletable(dbManager)
+	require.NoError(err)
+	require.Empty(versions)
+
+	m := New(logging.NoLog{})
+	require.NoError(m.Register(ids.GenerateTestID(), NewCopyStep("copy", nil, 1)))
+	require.NoError(m.Run(context.Background(), dbManager))
+
+	versions, err = Deletable(dbManager)
+	require.NoError(err)
+	require.Equal([]*version.Semantic{v2, v1}, versions)
+}
@@ -0,0 +1,117 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package migration
+
+import (
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+// logPeriod is how often the progress of a step is logged.
+const logPeriod = 30 * time.Second
+
+var errInvalidCheckpoint = errors.New("invalid migration checkpoint")
+
+// Progress tracks how far a migration step has progressed, so that the step
+// can be resumed after it is interrupted.
+type Progress struct {
+	log   logging.Logger
+	scope string
+	step  string
+	key   []byte
+	db    database.KeyValueWriter
+
+	resumed bool
+	cursor  []byte
+	numKeys uint64
+	lastLog time.Time
+}
+
+func newProgress(log logging.Logger, s scopedStep, db database.KeyValueReaderWriter) (*Progress, error) {
+	p := &Progress{
+		log:     log,
+		scope:   s.scope,
+		step:    s.step.Name(),
+		key:     s.key(),
+		db:      db,
+		lastLog: time.Now(),
+	}
+
+	// The checkpoint is encoded as the number of migrated keys followed by the
+	// cursor.
+	checkpoint, err := db.Get(p.key)
+	switch err {
+	case nil:
+		if len(checkpoint) < wrappers.LongLen {
+			return nil, errInvalidCheckpoint
+		}
+		p.resumed = true
+		p.numKeys = binary.BigEndian.Uint64(checkpoint)
+		// An empty cursor is treated as no cursor, so the step is resumed from
+		// the start.
+		if len(checkpoint) > wrappers.LongLen {
+			p.cursor = checkpoint[wrappers.LongLen:]
+		}
+		return p, nil
+	case database.ErrNotFound:
+		return p, nil
+	default:
+		return nil, err
+	}
+}
+
+// Resumed returns true if the step saved a checkpoint before it was
+// interrupted.
+func (p *Progress) Resumed() bool {
+	return p.resumed
+}
+
+// Cursor returns the cursor of the last checkpoint, or nil if the step hasn't
+// saved a checkpoint with a cursor.
+func (p *Progress) Cursor() []byte {
+	return slices.Clone(p.cursor)
+}
+
+// NumKeys returns the number of keys the step has reported as migrated,
+// including before the step was resumed.
+func (p *Progress) NumKeys() uint64 {
+	return p.numKeys
+}
+
+// Checkpoint records that [numKeys] more keys have been migrated, and that the
+// step can be resumed from [cursor]. A nil, or empty, [cursor] resumes the step
+// from the start.
+//
+// The writes the step made before calling Checkpoint must have been written to
+// disk, otherwise they may be lost if the step is interrupted.
+func (p *Progress) Checkpoint(cursor []byte, numKeys int) error {
+	p.numKeys += uint64(numKeys)
+	p.cursor = slices.Clone(cursor)
+
+	checkpoint := make([]byte, wrappers.LongLen+len(cursor))
+	binary.BigEndian.PutUint64(checkpoint, p.numKeys)
+	copy(checkpoint[wrappers.LongLen:], cursor)
+	if err := p.db.Put(p.key, checkpoint); err != nil {
+		return err
+	}
+
+	if now := time.Now(); now.Sub(p.lastLog) >= logPeriod {
+		p.lastLog = now
+		p.log.Info("migrating database",
+			zap.String("scope", p.scope),
+			zap.String("step", p.step),
+			zap.Uint64("numKeys", p.numKeys),
+		)
+	}
+	return nil
+}
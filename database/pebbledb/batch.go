@@ -0,0 +1,166 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pebbledb
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/cockroachdb/pebble"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/avalanchego/database"
+)
+
+var _ database.Batch = (*batch)(nil)
+
+// batch is a wrapper around a pebble batch to contain sizes.
+type batch struct {
+	batch *pebble.Batch
+	db    *Database
+	size  int
+
+	// written is true iff [batch] has been committed to the database since the
+	// last time [Reset] was called.
+	written bool
+}
+
+// Put the value into the batch for later writing
+func (b *batch) Put(key, value []byte) error {
+	b.size += len(key) + len(value) + pebbleByteOverhead
+	return b.batch.Set(key, value, pebble.Sync)
+}
+
+// Delete the key during writing
+func (b *batch) Delete(key []byte) error {
+	b.size += len(key) + pebbleByteOverhead
+	return b.batch.Delete(key, pebble.Sync)
+}
+
+// DeleteRange removes all keys in the range [start, limit) during writing
+func (b *batch) DeleteRange(start, limit []byte) error {
+	b.size += len(start) + len(limit) + pebbleByteOverhead
+
+	if len(limit) == 0 {
+		// pebble doesn't support unbounded range deletions, so a key after the
+		// greatest key in either the database or this batch is used as the
+		// [limit] instead.
+		var err error
+		limit, err = b.upperBound()
+		if err != nil {
+			return err
+		}
+	}
+
+	if bytes.Compare(start, limit) >= 0 {
+		// pebble requires [start] < [limit]
+		return nil
+	}
+	return b.batch.DeleteRange(start, limit, pebble.Sync)
+}
+
+// upperBound returns a key that is greater than every key in the database and
+// every key written into this batch.
+func (b *batch) upperBound() ([]byte, error) {
+	b.db.lock.RLock()
+	defer b.db.lock.RUnlock()
+
+	if b.db.closed {
+		return nil, database.ErrClosed
+	}
+
+	upperBound, err := b.db.upperBound()
+	if err != nil {
+		return nil, updateError(err)
+	}
+
+	reader := b.batch.Reader()
+	for {
+		kind, key, value, ok := reader.Next()
+		if !ok {
+			return upperBound, nil
+		}
+		switch {
+		case kind == pebble.InternalKeyKindRangeDelete:
+			// The end of a range deletion is already exclusive.
+			if bytes.Compare(value, upperBound) > 0 {
+				upperBound = slices.Clone(value)
+			}
+		case bytes.Compare(key, upperBound) >= 0:
+			upperBound = append(slices.Clone(key), 0)
+		}
+	}
+}
+
+// Size retrieves the amount of data queued up for writing.
+func (b *batch) Size() int {
+	return b.size
+}
+
+// Write flushes any accumulated data to disk.
+func (b *batch) Write() error {
+	b.db.lock.RLock()
+	defer b.db.lock.RUnlock()
+
+	// pebble panics when committing to a closed database.
+	if b.db.closed {
+		return database.ErrClosed
+	}
+
+	if !b.written {
+		if err := b.batch.Commit(pebble.Sync); err != nil {
+			return updateError(err)
+		}
+		b.written = true
+		return nil
+	}
+
+	// pebble doesn't support committing a batch twice, so the contents are
+	// copied into a new batch which is committed instead.
+	batchClone := b.db.pebbleDB.NewBatch()
+	if err := batchClone.Apply(b.batch, nil); err != nil {
+		return err
+	}
+	return updateError(batchClone.Commit(pebble.Sync))
+}
+
+// Reset resets the batch for reuse.
+func (b *batch) Reset() {
+	b.batch.Reset()
+	b.written = false
+	b.size = 0
+}
+
+// Replay the batch contents.
+func (b *batch) Replay(w database.KeyValueWriterDeleter) error {
+	reader := b.batch.Reader()
+	for {
+		kind, key, value, ok := reader.Next()
+		if !ok {
+			return nil
+		}
+		switch kind {
+		case pebble.InternalKeyKindSet:
+			if err := w.Put(key, value); err != nil {
+				return err
+			}
+		case pebble.InternalKeyKindDelete:
+			if err := w.Delete(key); err != nil {
+				return err
+			}
+		case pebble.InternalKeyKindRangeDelete:
+			if err := database.DeleteRange(w, key, value); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("%w: %v", errInvalidOperation, kind)
+		}
+	}
+}
+
+// Inner returns itself
+func (b *batch) Inner() database.Batch {
+	return b
+}
@@ -0,0 +1,501 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pebbledb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"go.uber.org/zap"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+const (
+	// Name is the name of this database for database switches
+	Name = "pebbledb"
+
+	// DefaultCacheSize is the number of bytes to use for block caching in
+	// pebble.
+	DefaultCacheSize = 512 * units.MiB
+
+	// DefaultMetricUpdateFrequency is the frequency to poll the pebble
+	// metrics.
+	DefaultMetricUpdateFrequency = 10 * time.Second
+
+	// pebbleByteOverhead is the number of bytes of constant overhead that
+	// should be added to a batch size per operation.
+	pebbleByteOverhead = 8
+)
+
+var (
+	_ database.Database      = (*Database)(nil)
+	_ database.RangeIteratee = (*Database)(nil)
+	_ database.Snapshotter   = (*Database)(nil)
+	_ database.Snapshot      = (*snapshot)(nil)
+	_ database.RangeIteratee = (*snapshot)(nil)
+
+	ErrInvalidConfig = errors.New("invalid config")
+	ErrCouldNotOpen  = errors.New("could not open")
+
+	errInvalidOperation = errors.New("invalid operation")
+)
+
+// Database is a persistent key-value store backed by pebble. Apart from basic
+// data storage functionality it also supports batch writes and iterating over
+// the keyspace in binary-alphabetical order.
+type Database struct {
+	lock     sync.RWMutex
+	pebbleDB *pebble.DB
+	closed   bool
+	// openIterators are released when the database is closed, because pebble
+	// panics if the database is closed with open iterators.
+	openIterators set.Set[*iter]
+	// openSnapshots are released when the database is closed, because pebble
+	// errors if the database is closed with open snapshots.
+	openSnapshots set.Set[*snapshot]
+
+	// metrics is only initialized and used when [MetricUpdateFrequency] is >= 0
+	// in the config
+	metrics *metrics
+	// closeCh is closed when Close() is called.
+	closeCh chan struct{}
+	// closeWg is used to wait for all goroutines created by New() to exit.
+	closeWg sync.WaitGroup
+}
+
+type config struct {
+	// CacheSize is the number of bytes of uncompressed blocks to cache.
+	//
+	// The default value is 512MiB.
+	CacheSize int64 `json:"cacheSize"`
+	// BytesPerSync syncs sstables periodically in order to smooth out writes
+	// to disk.
+	//
+	// The default value is 512KiB.
+	BytesPerSync int `json:"bytesPerSync"`
+	// WALBytesPerSync syncs the WAL periodically in order to smooth out
+	// writes to disk. 0 disables background syncing of the WAL.
+	//
+	// The default value is 0.
+	WALBytesPerSync int `json:"walBytesPerSync"`
+	// MemTableStopWritesThreshold is a hard limit on the number of queued
+	// memtables. Writes are stopped when the sum of the queued memtable sizes
+	// exceeds MemTableStopWritesThreshold*MemTableSize.
+	//
+	// The default value is 8.
+	MemTableStopWritesThreshold int `json:"memTableStopWritesThreshold"`
+	// MemTableSize is the size of a memtable. Larger memtables reduce write
+	// amplification at the cost of memory usage.
+	//
+	// The default value is 128MiB.
+	MemTableSize int `json:"memTableSize"`
+	// L0CompactionThreshold is the number of L0 read-amplification that
+	// triggers an L0 compaction.
+	//
+	// The default value is 4.
+	L0CompactionThreshold int `json:"l0CompactionThreshold"`
+	// L0StopWritesThreshold is the number of L0 read-amplification that
+	// causes writes to be stopped.
+	//
+	// The default value is 12.
+	L0StopWritesThreshold int `json:"l0StopWritesThreshold"`
+	// MaxOpenFiles is a soft limit on the number of open files that pebble
+	// will use.
+	//
+	// The default value is 4096.
+	MaxOpenFiles int `json:"maxOpenFiles"`
+	// MaxConcurrentCompactions is the maximum number of concurrent
+	// compactions.
+	//
+	// The default value is 1.
+	MaxConcurrentCompactions int `json:"maxConcurrentCompactions"`
+	// DisableSeeksCompaction disables read triggered compactions.
+	//
+	// The default is true.
+	DisableSeeksCompaction bool `json:"disableSeeksCompaction"`
+
+	// MetricUpdateFrequency is the frequency to poll pebble metrics.
+	// If <= 0, pebble metrics aren't polled.
+	MetricUpdateFrequency time.Duration `json:"metricUpdateFrequency"`
+
+	// ReadOnly opens the database in read-only mode. All writes to a read-only
+	// database will fail.
+	//
+	// The default is false.
+	ReadOnly bool `json:"readOnly"`
+}
+
+// New returns a wrapped pebble object.
+func New(file string, configBytes []byte, log logging.Logger, namespace string, reg prometheus.Registerer) (database.Database, error) {
+	parsedConfig := config{
+		CacheSize:                   DefaultCacheSize,
+		BytesPerSync:                512 * units.KiB,
+		MemTableStopWritesThreshold: 8,
+		MemTableSize:                DefaultCacheSize / 4,
+		L0CompactionThreshold:       4,
+		L0StopWritesThreshold:       12,
+		MaxOpenFiles:                4096,
+		MaxConcurrentCompactions:    1,
+		DisableSeeksCompaction:      true,
+		MetricUpdateFrequency:       DefaultMetricUpdateFrequency,
+	}
+	if len(configBytes) > 0 {
+		if err := json.Unmarshal(configBytes, &parsedConfig); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+		}
+	}
+
+	log.Info("creating pebble",
+		zap.Reflect("config", parsedConfig),
+	)
+
+	wrappedDB := &Database{
+		openIterators: set.Set[*iter]{},
+		openSnapshots: set.Set[*snapshot]{},
+		closeCh:       make(chan struct{}),
+	}
+
+	cache := pebble.NewCache(parsedConfig.CacheSize)
+	defer cache.Unref()
+
+	opts := &pebble.Options{
+		Cache:                       cache,
+		BytesPerSync:                parsedConfig.BytesPerSync,
+		Comparer:                    pebble.DefaultComparer,
+		WALBytesPerSync:             parsedConfig.WALBytesPerSync,
+		MemTableStopWritesThreshold: parsedConfig.MemTableStopWritesThreshold,
+		MemTableSize:                parsedConfig.MemTableSize,
+		L0CompactionThreshold:       parsedConfig.L0CompactionThreshold,
+		L0StopWritesThreshold:       parsedConfig.L0StopWritesThreshold,
+		MaxOpenFiles:                parsedConfig.MaxOpenFiles,
+		MaxConcurrentCompactions: func() int {
+			return parsedConfig.MaxConcurrentCompactions
+		},
+		ReadOnly: parsedConfig.ReadOnly,
+	}
+	if parsedConfig.DisableSeeksCompaction {
+		opts.Experimental.ReadSamplingMultiplier = -1
+	}
+
+	if parsedConfig.MetricUpdateFrequency > 0 {
+		metrics, err := newMetrics(namespace, reg)
+		if err != nil {
+			return nil, err
+		}
+		wrappedDB.metrics = metrics
+		opts.EventListener = &pebble.EventListener{
+			WriteStallBegin: func(pebble.WriteStallBeginInfo) {
+				wrappedDB.metrics.writeStallBegin()
+			},
+			WriteStallEnd: wrappedDB.metrics.writeStallEnd,
+		}
+	}
+
+	db, err := pebble.Open(file, opts)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCouldNotOpen, err)
+	}
+	wrappedDB.pebbleDB = db
+
+	if parsedConfig.MetricUpdateFrequency > 0 {
+		wrappedDB.closeWg.Add(1)
+		go func() {
+			t := time.NewTicker(parsedConfig.MetricUpdateFrequency)
+			defer func() {
+				t.Stop()
+				wrappedDB.closeWg.Done()
+			}()
+
+			for {
+				wrappedDB.updateMetrics()
+
+				select {
+				case <-t.C:
+				case <-wrappedDB.closeCh:
+					return
+				}
+			}
+		}()
+	}
+	return wrappedDB, nil
+}
+
+// Has returns if the key is set in the database
+func (db *Database) Has(key []byte) (bool, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return false, database.ErrClosed
+	}
+
+	_, closer, err := db.pebbleDB.Get(key)
+	if err == pebble.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, updateError(err)
+	}
+	return true, closer.Close()
+}
+
+// Get returns the value the key maps to in the database
+func (db *Database) Get(key []byte) ([]byte, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return nil, database.ErrClosed
+	}
+
+	value, closer, err := db.pebbleDB.Get(key)
+	if err != nil {
+		return nil, updateError(err)
+	}
+	return slices.Clone(value), closer.Close()
+}
+
+// Put sets the value of the provided key to the provided value
+func (db *Database) Put(key []byte, value []byte) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+	return updateError(db.pebbleDB.Set(key, value, pebble.Sync))
+}
+
+// Delete removes the key from the database
+func (db *Database) Delete(key []byte) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+	return updateError(db.pebbleDB.Delete(key, pebble.Sync))
+}
+
+// NewBatch creates a write/delete-only buffer that is atomically committed to
+// the database when write is called
+func (db *Database) NewBatch() database.Batch {
+	return &batch{
+		db:    db,
+		batch: db.pebbleDB.NewBatch(),
+	}
+}
+
+// NewIterator creates a lexicographically ordered iterator over the database
+func (db *Database) NewIterator() database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+// NewIteratorWithStart creates a lexicographically ordered iterator over the
+// database starting at the provided key
+func (db *Database) NewIteratorWithStart(start []byte) database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+// NewIteratorWithPrefix creates a lexicographically ordered iterator over the
+// database ignoring keys that do not start with the provided prefix
+func (db *Database) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewIteratorWithStartAndPrefix creates a lexicographically ordered iterator
+// over the database starting at start and ignoring keys that do not start with
+// the provided prefix
+func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return db.NewIteratorWithOptions(database.IteratorOptions{
+		Start:  start,
+		Prefix: prefix,
+	})
+}
+
+// NewIteratorWithOptions creates a lexicographically ordered iterator over
+// the keys of the database described by [opts]
+func (db *Database) NewIteratorWithOptions(opts database.IteratorOptions) database.Iterator {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.closed {
+		return &iter{
+			db:     db,
+			closed: true,
+			err:    database.ErrClosed,
+		}
+	}
+
+	it := &iter{
+		db:      db,
+		iter:    db.pebbleDB.NewIter(iterOptions(opts)),
+		reverse: opts.Reverse,
+	}
+	db.openIterators.Add(it)
+	return it
+}
+
+// NewSnapshot returns a read-only view of the database as of the time
+// NewSnapshot is called.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.closed {
+		return nil, database.ErrClosed
+	}
+
+	s := &snapshot{
+		db:       db,
+		snapshot: db.pebbleDB.NewSnapshot(),
+	}
+	db.openSnapshots.Add(s)
+	return s, nil
+}
+
+// Compact the underlying DB for the given key range.
+//
+// A nil start is treated as a key before all keys in the DB.
+// And a nil limit is treated as a key after all keys in the DB.
+// Therefore if both are nil then it will compact entire DB.
+func (db *Database) Compact(start []byte, limit []byte) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+
+	if limit == nil {
+		// pebble treats a nil [limit] as a key before all keys, so a key after
+		// the greatest key in the database is used as the [limit] instead.
+		var err error
+		limit, err = db.upperBound()
+		if err != nil {
+			return updateError(err)
+		}
+	}
+
+	if bytes.Compare(start, limit) >= 0 {
+		// pebble requires [start] < [limit]
+		return nil
+	}
+	return updateError(db.pebbleDB.Compact(start, limit, true /*=parallelize*/))
+}
+
+// DeleteRange removes all keys in the range [start, limit) from the database.
+//
+// A nil start is treated as a key before all keys in the DB.
+// And a nil, or empty, limit is treated as a key after all keys in the DB.
+func (db *Database) DeleteRange(start, limit []byte) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+
+	if len(limit) == 0 {
+		// pebble doesn't support unbounded range deletions, so a key after the
+		// greatest key in the database is used as the [limit] instead.
+		var err error
+		limit, err = db.upperBound()
+		if err != nil {
+			return updateError(err)
+		}
+	}
+
+	if bytes.Compare(start, limit) >= 0 {
+		// pebble requires [start] < [limit]
+		return nil
+	}
+	return updateError(db.pebbleDB.DeleteRange(start, limit, pebble.Sync))
+}
+
+// upperBound returns a key that is greater than every key in the database. If
+// the database is empty, nil is returned.
+//
+// Assumes the lock is held and the database isn't closed.
+func (db *Database) upperBound() ([]byte, error) {
+	it := db.pebbleDB.NewIter(&pebble.IterOptions{})
+	if !it.Last() {
+		// The database is empty.
+		return nil, it.Close()
+	}
+	// Limits are exclusive, so a byte is appended to the greatest key to
+	// include it in the range.
+	upperBound := append(slices.Clone(it.Key()), 0)
+	return upperBound, it.Close()
+}
+
+func (db *Database) Close() error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+	db.closed = true
+
+	for it := range db.openIterators {
+		it.lock.Lock()
+		it.release()
+		it.lock.Unlock()
+	}
+	db.openIterators.Clear()
+
+	for s := range db.openSnapshots {
+		s.release()
+	}
+	db.openSnapshots.Clear()
+
+	close(db.closeCh)
+	db.closeWg.Wait()
+	return updateError(db.pebbleDB.Close())
+}
+
+func (db *Database) HealthCheck(context.Context) (interface{}, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return nil, database.ErrClosed
+	}
+	return nil, nil
+}
+
+func iterOptions(opts database.IteratorOptions) *pebble.IterOptions {
+	lower, upper := opts.Bounds()
+	return &pebble.IterOptions{
+		LowerBound: lower,
+		UpperBound: upper,
+	}
+}
+
+func updateError(err error) error {
+	switch {
+	case errors.Is(err, pebble.ErrClosed):
+		return database.ErrClosed
+	case errors.Is(err, pebble.ErrNotFound):
+		return database.ErrNotFound
+	default:
+		return err
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pebbledb
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/pebble"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestInterface(t *testing.T) {
+	for _, test := range database.Tests {
+		folder := t.TempDir()
+		db, err := New(folder, nil, logging.NoLog{}, "", prometheus.NewRegistry())
+		require.NoError(t, err)
+
+		test(t, db)
+
+		_ = db.Close()
+	}
+}
+
+func TestReadOnly(t *testing.T) {
+	require := require.New(t)
+
+	key := []byte("hello")
+	value := []byte("world")
+
+	folder := t.TempDir()
+	db, err := New(folder, nil, logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+	require.NoError(db.Put(key, value))
+	require.NoError(db.Close())
+
+	db, err = New(folder, []byte(`{"readOnly":true}`), logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+
+	gotValue, err := db.Get(key)
+	require.NoError(err)
+	require.Equal(value, gotValue)
+
+	err = db.Put(key, value)
+	require.ErrorIs(err, pebble.ErrReadOnly)
+	require.NoError(db.Close())
+}
+
+func FuzzKeyValue(f *testing.F) {
+	folder := f.TempDir()
+	db, err := New(folder, nil, logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(f, err)
+
+	defer db.Close()
+
+	database.FuzzKeyValue(f, db)
+}
+
+func FuzzNewIteratorWithPrefix(f *testing.F) {
+	folder := f.TempDir()
+	db, err := New(folder, nil, logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(f, err)
+
+	defer db.Close()
+
+	database.FuzzNewIteratorWithPrefix(f, db)
+}
+
+func BenchmarkInterface(b *testing.B) {
+	for _, size := range database.BenchmarkSizes {
+		keys, values := database.SetupBenchmark(b, size[0], size[1], size[2])
+		for _, bench := range database.Benchmarks {
+			folder := b.TempDir()
+
+			db, err := New(folder, nil, logging.NoLog{}, "", prometheus.NewRegistry())
+			require.NoError(b, err)
+
+			bench(b, db, "pebbledb", keys, values)
+
+			// The database may have been closed by the test, so we don't care if it
+			// errors here.
+			_ = db.Close()
+		}
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pebbledb
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/cockroachdb/pebble"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/avalanchego/database"
+)
+
+var (
+	_ database.Iterator = (*iter)(nil)
+
+	errCouldNotGetValue = fmt.Errorf("%w: could not get iterator value", errInvalidOperation)
+)
+
+type iter struct {
+	// [lock] ensures that only one goroutine can access [iter] at a time.
+	// Because [Database.Close] releases all open iterators, [lock] prevents
+	// the user and [Database.Close] from releasing the iterator concurrently.
+	//
+	// Invariant: [Database.lock] is never grabbed while holding [lock].
+	lock sync.Mutex
+
+	db   *Database
+	iter *pebble.Iterator
+
+	// reverse iterates from the last key to the first key.
+	reverse     bool
+	initialized bool
+	closed      bool
+	err         error
+
+	key, val []byte
+}
+
+// Must not be called with [db.lock] held.
+func (it *iter) Next() bool {
+	it.lock.Lock()
+	defer it.lock.Unlock()
+
+	var hasNext bool
+	switch {
+	case it.err != nil:
+	case it.closed:
+		it.err = database.ErrClosed
+	case !it.initialized && it.reverse:
+		hasNext = it.iter.Last()
+		it.initialized = true
+	case !it.initialized:
+		hasNext = it.iter.First()
+		it.initialized = true
+	case it.reverse:
+		hasNext = it.iter.Prev()
+	default:
+		hasNext = it.iter.Next()
+	}
+
+	if !hasNext {
+		it.key = nil
+		it.val = nil
+		return false
+	}
+
+	value, err := it.iter.ValueAndErr()
+	if err != nil {
+		it.key = nil
+		it.val = nil
+		it.err = fmt.Errorf("%w: %w", errCouldNotGetValue, err)
+		return false
+	}
+
+	it.key = slices.Clone(it.iter.Key())
+	it.val = slices.Clone(value)
+	return true
+}
+
+func (it *iter) Error() error {
+	it.lock.Lock()
+	defer it.lock.Unlock()
+
+	if it.err != nil || it.closed {
+		return it.err
+	}
+	return updateError(it.iter.Error())
+}
+
+func (it *iter) Key() []byte {
+	it.lock.Lock()
+	defer it.lock.Unlock()
+
+	return it.key
+}
+
+func (it *iter) Value() []byte {
+	it.lock.Lock()
+	defer it.lock.Unlock()
+
+	return it.val
+}
+
+func (it *iter) Release() {
+	it.db.lock.Lock()
+	defer it.db.lock.Unlock()
+
+	it.lock.Lock()
+	defer it.lock.Unlock()
+
+	it.release()
+}
+
+// Assumes [it.lock] and [it.db.lock] are held.
+func (it *iter) release() {
+	if it.closed {
+		return
+	}
+
+	it.db.openIterators.Remove(it)
+
+	it.closed = true
+	if err := it.iter.Close(); err != nil {
+		it.err = updateError(err)
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pebbledb
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+var levelLabels = []string{"level"}
+
+type metrics struct {
+	// total number of write stalls caused by compaction or flushing
+	writeStallCount prometheus.Counter
+	// total amount of time (in ns) that writes have been stalled
+	writeStallDuration prometheus.Counter
+	// set to 1 if writes are currently stalled
+	writeIsStalled prometheus.Gauge
+
+	// total write amplification across all levels
+	writeAmplification prometheus.Gauge
+	// total number of compactions performed
+	compactions prometheus.Counter
+	// estimated number of bytes that need to be compacted for the LSM to
+	// reach a stable state
+	compactionDebt prometheus.Gauge
+	// total number of memtable flushes performed
+	flushes prometheus.Counter
+
+	// total number of bytes used on disk, including obsolete files
+	diskUsage prometheus.Gauge
+
+	// number of tables per level
+	levelTableCount *prometheus.GaugeVec
+	// size of each level
+	levelSize *prometheus.GaugeVec
+	// amount of bytes read while compacting into each level
+	levelReads *prometheus.CounterVec
+	// amount of bytes written while compacting or flushing into each level
+	levelWrites *prometheus.CounterVec
+
+	// stallLock protects [stallStart]
+	stallLock  sync.Mutex
+	stallStart time.Time
+
+	priorCompactions int64
+	priorFlushes     int64
+	priorLevelReads  []uint64
+	priorLevelWrites []uint64
+}
+
+func newMetrics(namespace string, reg prometheus.Registerer) (*metrics, error) {
+	m := &metrics{
+		writeStallCount: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "write_stalls",
+			Help:      "number of cumulative write stalls",
+		}),
+		writeStallDuration: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "write_stall_duration",
+			Help:      "amount of time (in ns) that writes have been stalled",
+		}),
+		writeIsStalled: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "write_stalled",
+			Help:      "1 if writes are currently stalled",
+		}),
+
+		writeAmplification: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "write_amplification",
+			Help:      "total write amplification across all levels",
+		}),
+		compactions: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "compactions",
+			Help:      "total number of compactions performed",
+		}),
+		compactionDebt: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "compaction_debt",
+			Help:      "estimated number of bytes that need to be compacted",
+		}),
+		flushes: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "flushes",
+			Help:      "total number of memtable flushes performed",
+		}),
+
+		diskUsage: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "disk_usage",
+			Help:      "total number of bytes used on disk",
+		}),
+
+		levelTableCount: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "table_count",
+				Help:      "number of tables allocated by level",
+			},
+			levelLabels,
+		),
+		levelSize: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "size",
+				Help:      "amount of bytes allocated by level",
+			},
+			levelLabels,
+		),
+		levelReads: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "reads",
+				Help:      "amount of bytes read during compaction by level",
+			},
+			levelLabels,
+		),
+		levelWrites: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "writes",
+				Help:      "amount of bytes written during compaction by level",
+			},
+			levelLabels,
+		),
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		reg.Register(m.writeStallCount),
+		reg.Register(m.writeStallDuration),
+		reg.Register(m.writeIsStalled),
+
+		reg.Register(m.writeAmplification),
+		reg.Register(m.compactions),
+		reg.Register(m.compactionDebt),
+		reg.Register(m.flushes),
+
+		reg.Register(m.diskUsage),
+
+		reg.Register(m.levelTableCount),
+		reg.Register(m.levelSize),
+		reg.Register(m.levelReads),
+		reg.Register(m.levelWrites),
+	)
+	return m, errs.Err
+}
+
+func (m *metrics) writeStallBegin() {
+	m.stallLock.Lock()
+	defer m.stallLock.Unlock()
+
+	m.stallStart = time.Now()
+	m.writeStallCount.Inc()
+	m.writeIsStalled.Set(1)
+}
+
+func (m *metrics) writeStallEnd() {
+	m.stallLock.Lock()
+	defer m.stallLock.Unlock()
+
+	m.writeStallDuration.Add(float64(time.Since(m.stallStart)))
+	m.writeIsStalled.Set(0)
+}
+
+func (db *Database) updateMetrics() {
+	metrics := db.metrics
+	stats := db.pebbleDB.Metrics()
+
+	total := stats.Total()
+	metrics.writeAmplification.Set(total.WriteAmp())
+	metrics.compactions.Add(float64(stats.Compact.Count - metrics.priorCompactions))
+	metrics.compactionDebt.Set(float64(stats.Compact.EstimatedDebt))
+	metrics.flushes.Add(float64(stats.Flush.Count - metrics.priorFlushes))
+	metrics.diskUsage.Set(float64(stats.DiskSpaceUsage()))
+
+	if metrics.priorLevelReads == nil {
+		metrics.priorLevelReads = make([]uint64, len(stats.Levels))
+		metrics.priorLevelWrites = make([]uint64, len(stats.Levels))
+	}
+	for level, levelStats := range stats.Levels {
+		levelStr := strconv.Itoa(level)
+		metrics.levelTableCount.WithLabelValues(levelStr).Set(float64(levelStats.NumFiles))
+		metrics.levelSize.WithLabelValues(levelStr).Set(float64(levelStats.Size))
+
+		levelWrites := levelStats.BytesFlushed + levelStats.BytesCompacted
+		metrics.levelReads.WithLabelValues(levelStr).Add(float64(levelStats.BytesRead - metrics.priorLevelReads[level]))
+		metrics.levelWrites.WithLabelValues(levelStr).Add(float64(levelWrites - metrics.priorLevelWrites[level]))
+		metrics.priorLevelReads[level] = levelStats.BytesRead
+		metrics.priorLevelWrites[level] = levelWrites
+	}
+
+	// update the prior values to update the counters correctly next time this
+	// method is called
+	metrics.priorCompactions = stats.Compact.Count
+	metrics.priorFlushes = stats.Flush.Count
+}
@@ -0,0 +1,126 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pebbledb
+
+import (
+	"github.com/cockroachdb/pebble"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/avalanchego/database"
+)
+
+// snapshot is a wrapper around a pebble snapshot.
+//
+// All fields are protected by [db.lock].
+type snapshot struct {
+	db       *Database
+	snapshot *pebble.Snapshot
+	closed   bool
+}
+
+// Has returns if the key is set in the snapshot
+func (s *snapshot) Has(key []byte) (bool, error) {
+	s.db.lock.RLock()
+	defer s.db.lock.RUnlock()
+
+	if s.db.closed || s.closed {
+		return false, database.ErrClosed
+	}
+
+	_, closer, err := s.snapshot.Get(key)
+	if err == pebble.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, updateError(err)
+	}
+	return true, closer.Close()
+}
+
+// Get returns the value the key maps to in the snapshot
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	s.db.lock.RLock()
+	defer s.db.lock.RUnlock()
+
+	if s.db.closed || s.closed {
+		return nil, database.ErrClosed
+	}
+
+	value, closer, err := s.snapshot.Get(key)
+	if err != nil {
+		return nil, updateError(err)
+	}
+	return slices.Clone(value), closer.Close()
+}
+
+// NewIterator creates a lexicographically ordered iterator over the snapshot
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+// NewIteratorWithStart creates a lexicographically ordered iterator over the
+// snapshot starting at the provided key
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+// NewIteratorWithPrefix creates a lexicographically ordered iterator over the
+// snapshot ignoring keys that do not start with the provided prefix
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewIteratorWithStartAndPrefix creates a lexicographically ordered iterator
+// over the snapshot starting at start and ignoring keys that do not start with
+// the provided prefix
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return s.NewIteratorWithOptions(database.IteratorOptions{
+		Start:  start,
+		Prefix: prefix,
+	})
+}
+
+// NewIteratorWithOptions creates a lexicographically ordered iterator over
+// the keys of the snapshot described by [opts]
+func (s *snapshot) NewIteratorWithOptions(opts database.IteratorOptions) database.Iterator {
+	s.db.lock.Lock()
+	defer s.db.lock.Unlock()
+
+	if s.db.closed || s.closed {
+		return &iter{
+			db:     s.db,
+			closed: true,
+			err:    database.ErrClosed,
+		}
+	}
+
+	it := &iter{
+		db:      s.db,
+		iter:    s.snapshot.NewIter(iterOptions(opts)),
+		reverse: opts.Reverse,
+	}
+	s.db.openIterators.Add(it)
+	return it
+}
+
+// Release releases the snapshot. Iterators created from the snapshot remain
+// valid until they are released.
+func (s *snapshot) Release() {
+	s.db.lock.Lock()
+	defer s.db.lock.Unlock()
+
+	s.release()
+}
+
+// Assumes [s.db.lock] is held.
+func (s *snapshot) release() {
+	if s.closed {
+		return
+	}
+
+	s.db.openSnapshots.Remove(s)
+	s.closed = true
+	_ = s.snapshot.Close()
+}
@@ -4,6 +4,7 @@
 package prefixdb
 
 import (
+	"bytes"
 	"context"
 	"sync"
 
@@ -18,9 +19,13 @@ const (
 )
 
 var (
-	_ database.Database = (*Database)(nil)
-	_ database.Batch    = (*batch)(nil)
-	_ database.Iterator = (*iterator)(nil)
+	_ database.Database      = (*Database)(nil)
+	_ database.RangeIteratee = (*Database)(nil)
+	_ database.Snapshotter   = (*Database)(nil)
+	_ database.Snapshot      = (*dbSnapshot)(nil)
+	_ database.RangeIteratee = (*dbSnapshot)(nil)
+	_ database.Batch         = (*batch)(nil)
+	_ database.Iterator      = (*iterator)(nil)
 )
 
 // Database partitions a database into a sub-database by prefixing all keys with
@@ -42,10 +47,7 @@ type Database struct {
 // New returns a new prefixed database
 func New(prefix []byte, db database.Database) *Database {
 	if prefixDB, ok := db.(*Database); ok {
-		simplePrefix := make([]byte, len(prefixDB.dbPrefix)+len(prefix))
-		copy(simplePrefix, prefixDB.dbPrefix)
-		copy(simplePrefix[len(prefixDB.dbPrefix):], prefix)
-		return NewNested(simplePrefix, prefixDB.db)
+		return newWithDBPrefix(JoinPrefixes(prefixDB.dbPrefix, prefix), prefixDB.db)
 	}
 	return NewNested(prefix, db)
 }
@@ -53,8 +55,28 @@ func New(prefix []byte, db database.Database) *Database {
 // NewNested returns a new prefixed database without attempting to compress
 // prefixes.
 func NewNested(prefix []byte, db database.Database) *Database {
+	return newWithDBPrefix(MakePrefix(prefix), db)
+}
+
+// MakePrefix returns the bytes that a database created with [prefix] prepends
+// to every key written to a database that isn't a prefixed database.
+func MakePrefix(prefix []byte) []byte {
+	return hashing.ComputeHash256(prefix)
+}
+
+// JoinPrefixes returns the bytes that a database created with [prefix]
+// prepends to every key, when created on top of a prefixed database that
+// prepends [dbPrefix] to every key.
+func JoinPrefixes(dbPrefix, prefix []byte) []byte {
+	simplePrefix := make([]byte, len(dbPrefix)+len(prefix))
+	copy(simplePrefix, dbPrefix)
+	copy(simplePrefix[len(dbPrefix):], prefix)
+	return MakePrefix(simplePrefix)
+}
+
+func newWithDBPrefix(dbPrefix []byte, db database.Database) *Database {
 	return &Database{
-		dbPrefix: hashing.ComputeHash256(prefix),
+		dbPrefix: dbPrefix,
 		db:       db,
 		bufferPool: sync.Pool{
 			New: func() interface{} {
@@ -129,6 +151,23 @@ func (db *Database) Delete(key []byte) error {
 	return err
 }
 
+// Assumes that it is OK for the arguments to db.db.DeleteRange
+// to be modified after db.db.DeleteRange returns.
+// [start] and [limit] may be modified after this method returns.
+func (db *Database) DeleteRange(start, limit []byte) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+	prefixedStart, prefixedLimit := db.prefixRange(start, limit)
+	err := db.db.DeleteRange(prefixedStart, prefixedLimit)
+	db.bufferPool.Put(prefixedStart)
+	db.bufferPool.Put(prefixedLimit)
+	return err
+}
+
 func (db *Database) NewBatch() database.Batch {
 	return &batch{
 		Batch: db.db.NewBatch(),
@@ -148,9 +187,16 @@ func (db *Database) NewIteratorWithPrefix(prefix []byte) database.Iterator {
 	return db.NewIteratorWithStartAndPrefix(nil, prefix)
 }
 
-// Assumes it is safe to modify the arguments to db.db.NewIteratorWithStartAndPrefix after it returns.
 // It is safe to modify [start] and [prefix] after this method returns.
 func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return db.NewIteratorWithOptions(database.IteratorOptions{
+		Start:  start,
+		Prefix: prefix,
+	})
+}
+
+// It is safe to modify the slices of [opts] after this method returns.
+func (db *Database) NewIteratorWithOptions(opts database.IteratorOptions) database.Iterator {
 	db.lock.RLock()
 	defer db.lock.RUnlock()
 
@@ -159,14 +205,32 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 			Err: database.ErrClosed,
 		}
 	}
-	prefixedStart := db.prefix(start)
-	prefixedPrefix := db.prefix(prefix)
+	return db.newIterator(db.db, opts)
+}
+
+// newIterator returns an iterator over the keys of [iteratee] described by
+// [opts] after they have been prefixed.
+//
+// Assumes it is safe to modify the arguments to
+// database.NewIteratorWithOptions after it returns.
+func (db *Database) newIterator(iteratee database.Iteratee, opts database.IteratorOptions) database.Iterator {
+	prefixedOpts := database.IteratorOptions{
+		Start:   db.prefix(opts.Start),
+		Prefix:  db.prefix(opts.Prefix),
+		Reverse: opts.Reverse,
+	}
+	if len(opts.End) > 0 {
+		prefixedOpts.End = db.prefix(opts.End)
+	}
 	it := &iterator{
-		Iterator: db.db.NewIteratorWithStartAndPrefix(prefixedStart, prefixedPrefix),
+		Iterator: database.NewIteratorWithOptions(iteratee, prefixedOpts),
 		db:       db,
 	}
-	db.bufferPool.Put(prefixedStart)
-	db.bufferPool.Put(prefixedPrefix)
+	db.bufferPool.Put(prefixedOpts.Start)
+	db.bufferPool.Put(prefixedOpts.Prefix)
+	if prefixedOpts.End != nil {
+		db.bufferPool.Put(prefixedOpts.End)
+	}
 	return it
 }
 
@@ -180,6 +244,25 @@ func (db *Database) Compact(start, limit []byte) error {
 	return db.db.Compact(db.prefix(start), db.prefix(limit))
 }
 
+// NewSnapshot returns a read-only view of this database. The underlying
+// database must implement database.Snapshotter.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return nil, database.ErrClosed
+	}
+	snapshot, err := database.NewSnapshot(db.db)
+	if err != nil {
+		return nil, err
+	}
+	return &dbSnapshot{
+		Snapshot: snapshot,
+		db:       db,
+	}, nil
+}
+
 func (db *Database) Close() error {
 	db.lock.Lock()
 	defer db.lock.Unlock()
@@ -229,6 +312,33 @@ func (db *Database) prefix(key []byte) []byte {
 	return prefixedKey
 }
 
+// Return the range of keys in the underlying database that corresponds to the
+// range [start, limit) of this database.
+// The returned slices should be put back in the pool
+// when they're done being used.
+func (db *Database) prefixRange(start, limit []byte) ([]byte, []byte) {
+	prefixedStart := db.prefix(start)
+	if len(limit) > 0 {
+		return prefixedStart, db.prefix(limit)
+	}
+	// An empty [limit] is treated as a key after all keys in this database,
+	// which is the first key after all keys starting with this db's prefix.
+	return prefixedStart, prefixUpperBound(db.dbPrefix)
+}
+
+// Return the smallest key that is greater than every key starting with
+// [prefix]. If no such key exists, nil is returned.
+func prefixUpperBound(prefix []byte) []byte {
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if prefix[i] != 0xFF {
+			upperBound := slices.Clone(prefix[:i+1])
+			upperBound[i]++
+			return upperBound
+		}
+	}
+	return nil
+}
+
 // Batch of database operations
 type batch struct {
 	database.Batch
@@ -266,6 +376,19 @@ func (b *batch) Delete(key []byte) error {
 	return b.Batch.Delete(prefixedKey)
 }
 
+// Assumes that it is OK for the arguments to b.Batch.DeleteRange
+// to be modified after b.Batch.DeleteRange returns
+// [start] and [limit] may be modified after this method returns.
+func (b *batch) DeleteRange(start, limit []byte) error {
+	prefixedStart, prefixedLimit := b.db.prefixRange(start, limit)
+	b.ops = append(b.ops, database.BatchOp{
+		Key:         prefixedStart,
+		DeleteRange: true,
+		Limit:       prefixedLimit,
+	})
+	return b.Batch.DeleteRange(prefixedStart, prefixedLimit)
+}
+
 // Write flushes any accumulated data to the memory database.
 func (b *batch) Write() error {
 	b.db.lock.RLock()
@@ -285,6 +408,9 @@ func (b *batch) Reset() {
 	// value argument to w.Put.
 	for _, op := range b.ops {
 		b.db.bufferPool.Put(op.Key)
+		if op.DeleteRange {
+			b.db.bufferPool.Put(op.Limit)
+		}
 	}
 
 	// Clear b.writes
@@ -302,11 +428,22 @@ func (b *batch) Reset() {
 func (b *batch) Replay(w database.KeyValueWriterDeleter) error {
 	for _, op := range b.ops {
 		keyWithoutPrefix := op.Key[len(b.db.dbPrefix):]
-		if op.Delete {
+		switch {
+		case op.DeleteRange:
+			// If the limit doesn't start with this db's prefix, the range was
+			// unbounded.
+			var limitWithoutPrefix []byte
+			if bytes.HasPrefix(op.Limit, b.db.dbPrefix) {
+				limitWithoutPrefix = op.Limit[len(b.db.dbPrefix):]
+			}
+			if err := database.DeleteRange(w, keyWithoutPrefix, limitWithoutPrefix); err != nil {
+				return err
+			}
+		case op.Delete:
 			if err := w.Delete(keyWithoutPrefix); err != nil {
 				return err
 			}
-		} else {
+		default:
 			if err := w.Put(keyWithoutPrefix, op.Value); err != nil {
 				return err
 			}
@@ -364,3 +501,51 @@ func (it *iterator) Error() error {
 	}
 	return it.Iterator.Error()
 }
+
+// dbSnapshot prefixes all keys passed to the snapshot of the underlying
+// database.
+type dbSnapshot struct {
+	database.Snapshot
+	db *Database
+}
+
+// [key] may be modified after this method returns.
+func (s *dbSnapshot) Has(key []byte) (bool, error) {
+	prefixedKey := s.db.prefix(key)
+	has, err := s.Snapshot.Has(prefixedKey)
+	s.db.bufferPool.Put(prefixedKey)
+	return has, err
+}
+
+// [key] may be modified after this method returns.
+func (s *dbSnapshot) Get(key []byte) ([]byte, error) {
+	prefixedKey := s.db.prefix(key)
+	val, err := s.Snapshot.Get(prefixedKey)
+	s.db.bufferPool.Put(prefixedKey)
+	return val, err
+}
+
+func (s *dbSnapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *dbSnapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *dbSnapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// It is safe to modify [start] and [prefix] after this method returns.
+func (s *dbSnapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return s.NewIteratorWithOptions(database.IteratorOptions{
+		Start:  start,
+		Prefix: prefix,
+	})
+}
+
+// It is safe to modify the slices of [opts] after this method returns.
+func (s *dbSnapshot) NewIteratorWithOptions(opts database.IteratorOptions) database.Iterator {
+	return s.db.newIterator(s.Snapshot, opts)
+}
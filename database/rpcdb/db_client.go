@@ -18,9 +18,10 @@ import (
 )
 
 var (
-	_ database.Database = (*DatabaseClient)(nil)
-	_ database.Batch    = (*batch)(nil)
-	_ database.Iterator = (*iterator)(nil)
+	_ database.Database      = (*DatabaseClient)(nil)
+	_ database.RangeIteratee = (*DatabaseClient)(nil)
+	_ database.Batch         = (*batch)(nil)
+	_ database.Iterator      = (*iterator)(nil)
 )
 
 // DatabaseClient is an implementation of database that talks over RPC.
@@ -80,6 +81,18 @@ func (db *DatabaseClient) Delete(key []byte) error {
 	return errEnumToError[resp.Err]
 }
 
+// DeleteRange attempts to remove all keys in the range [start, limit)
+func (db *DatabaseClient) DeleteRange(start, limit []byte) error {
+	resp, err := db.client.DeleteRange(context.Background(), &rpcdbpb.DeleteRangeRequest{
+		Start: start,
+		Limit: limit,
+	})
+	if err != nil {
+		return err
+	}
+	return errEnumToError[resp.Err]
+}
+
 // NewBatch returns a new batch
 func (db *DatabaseClient) NewBatch() database.Batch {
 	return &batch{db: db}
@@ -111,6 +124,23 @@ func (db *DatabaseClient) NewIteratorWithStartAndPrefix(start, prefix []byte) da
 	return newIterator(db, resp.Id)
 }
 
+// NewIteratorWithOptions returns a new iterator over the keys described by
+// [opts]
+func (db *DatabaseClient) NewIteratorWithOptions(opts database.IteratorOptions) database.Iterator {
+	resp, err := db.client.NewIteratorWithOptions(context.Background(), &rpcdbpb.NewIteratorWithOptionsRequest{
+		Start:   opts.Start,
+		End:     opts.End,
+		Prefix:  opts.Prefix,
+		Reverse: opts.Reverse,
+	})
+	if err != nil {
+		return &database.IteratorError{
+			Err: err,
+		}
+	}
+	return newIterator(db, resp.Id)
+}
+
 // Compact attempts to optimize the space utilization in the provided range
 func (db *DatabaseClient) Compact(start, limit []byte) error {
 	resp, err := db.client.Compact(context.Background(), &rpcdbpb.CompactRequest{
@@ -153,8 +183,19 @@ func (b *batch) Write() error {
 	keySet := set.NewSet[string](len(b.Ops))
 	for i := len(b.Ops) - 1; i >= 0; i-- {
 		op := b.Ops[i]
+		if op.DeleteRange {
+			request.DeleteRanges = append(request.DeleteRanges, &rpcdbpb.DeleteRangeRequest{
+				Start: op.Key,
+				Limit: op.Limit,
+			})
+			continue
+		}
+
+		// Range deletions are applied before puts and deletes, so any
+		// operation that was followed by a range deletion covering its key is
+		// dropped.
 		key := string(op.Key)
-		if keySet.Contains(key) {
+		if keySet.Contains(key) || inDeletedRange(request.DeleteRanges, op.Key) {
 			continue
 		}
 		keySet.Add(key)
@@ -182,6 +223,15 @@ func (b *batch) Inner() database.Batch {
 	return b
 }
 
+func inDeletedRange(deleteRanges []*rpcdbpb.DeleteRangeRequest, key []byte) bool {
+	for _, r := range deleteRanges {
+		if database.InRange(key, r.Start, r.Limit) {
+			return true
+		}
+	}
+	return false
+}
+
 type iterator struct {
 	db *DatabaseClient
 	id uint64
@@ -76,6 +76,13 @@ func (db *DatabaseServer) Delete(_ context.Context, req *rpcdbpb.DeleteRequest)
 	return &rpcdbpb.DeleteResponse{Err: errorToErrEnum[err]}, errorToRPCError(err)
 }
 
+// DeleteRange delegates the DeleteRange call to the managed database and
+// returns the result
+func (db *DatabaseServer) DeleteRange(_ context.Context, req *rpcdbpb.DeleteRangeRequest) (*rpcdbpb.DeleteRangeResponse, error) {
+	err := db.db.DeleteRange(req.Start, req.Limit)
+	return &rpcdbpb.DeleteRangeResponse{Err: errorToErrEnum[err]}, errorToRPCError(err)
+}
+
 // Compact delegates the Compact call to the managed database and returns the
 // result
 func (db *DatabaseServer) Compact(_ context.Context, req *rpcdbpb.CompactRequest) (*rpcdbpb.CompactResponse, error) {
@@ -103,9 +110,17 @@ func (db *DatabaseServer) HealthCheck(ctx context.Context, _ *emptypb.Empty) (*r
 }
 
 // WriteBatch takes in a set of key-value pairs and atomically writes them to
-// the internal database
+// the internal database. Range deletions are applied before the key-value
+// pairs.
 func (db *DatabaseServer) WriteBatch(_ context.Context, req *rpcdbpb.WriteBatchRequest) (*rpcdbpb.WriteBatchResponse, error) {
 	batch := db.db.NewBatch()
+	for _, deleteRange := range req.DeleteRanges {
+		if err := batch.DeleteRange(deleteRange.Start, deleteRange.Limit); err != nil {
+			return &rpcdbpb.WriteBatchResponse{
+				Err: errorToErrEnum[err],
+			}, errorToRPCError(err)
+		}
+	}
 	for _, put := range req.Puts {
 		if err := batch.Put(put.Key, put.Value); err != nil {
 			return &rpcdbpb.WriteBatchResponse{
@@ -141,6 +156,23 @@ func (db *DatabaseServer) NewIteratorWithStartAndPrefix(_ context.Context, req *
 	return &rpcdbpb.NewIteratorWithStartAndPrefixResponse{Id: id}, nil
 }
 
+func (db *DatabaseServer) NewIteratorWithOptions(_ context.Context, req *rpcdbpb.NewIteratorWithOptionsRequest) (*rpcdbpb.NewIteratorWithOptionsResponse, error) {
+	it := database.NewIteratorWithOptions(db.db, database.IteratorOptions{
+		Start:   req.Start,
+		End:     req.End,
+		Prefix:  req.Prefix,
+		Reverse: req.Reverse,
+	})
+
+	db.iteratorLock.Lock()
+	defer db.iteratorLock.Unlock()
+
+	id := db.nextIteratorID
+	db.iterators[id] = it
+	db.nextIteratorID++
+	return &rpcdbpb.NewIteratorWithOptionsResponse{Id: id}, nil
+}
+
 // IteratorNext attempts to call next on the requested iterator
 func (db *DatabaseServer) IteratorNext(_ context.Context, req *rpcdbpb.IteratorNextRequest) (*rpcdbpb.IteratorNextResponse, error) {
 	db.iteratorLock.RLock()
@@ -0,0 +1,178 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snapshotdb
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/database"
+)
+
+var (
+	_ database.Database      = (*Database)(nil)
+	_ database.RangeIteratee = (*Database)(nil)
+	_ database.Batch         = (*batch)(nil)
+
+	ErrReadOnly = errors.New("snapshot is read-only")
+)
+
+// Database exposes a database.Snapshot as a read-only database.Database, so
+// that code written against database.Database can read from a snapshot.
+//
+// All writes return ErrReadOnly. Closing the database releases the snapshot.
+type Database struct {
+	lock     sync.RWMutex
+	snapshot database.Snapshot
+	closed   bool
+}
+
+// New returns a new read-only database that reads from [snapshot].
+func New(snapshot database.Snapshot) *Database {
+	return &Database{
+		snapshot: snapshot,
+	}
+}
+
+func (db *Database) Has(key []byte) (bool, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return false, database.ErrClosed
+	}
+	return db.snapshot.Has(key)
+}
+
+func (db *Database) Get(key []byte) ([]byte, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return nil, database.ErrClosed
+	}
+	return db.snapshot.Get(key)
+}
+
+func (db *Database) Put([]byte, []byte) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+	return ErrReadOnly
+}
+
+func (db *Database) Delete([]byte) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+	return ErrReadOnly
+}
+
+func (db *Database) DeleteRange([]byte, []byte) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+	return ErrReadOnly
+}
+
+func (db *Database) NewBatch() database.Batch {
+	return &batch{db: db}
+}
+
+func (db *Database) NewIterator() database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (db *Database) NewIteratorWithStart(start []byte) database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (db *Database) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return db.NewIteratorWithOptions(database.IteratorOptions{
+		Start:  start,
+		Prefix: prefix,
+	})
+}
+
+func (db *Database) NewIteratorWithOptions(opts database.IteratorOptions) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return &database.IteratorError{
+			Err: database.ErrClosed,
+		}
+	}
+	return database.NewIteratorWithOptions(db.snapshot, opts)
+}
+
+// Compact is a no-op, as the snapshot can't be modified.
+func (db *Database) Compact([]byte, []byte) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+	return nil
+}
+
+// Close releases the underlying snapshot.
+func (db *Database) Close() error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+	db.closed = true
+	db.snapshot.Release()
+	return nil
+}
+
+func (db *Database) HealthCheck(context.Context) (interface{}, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return nil, database.ErrClosed
+	}
+	return nil, nil
+}
+
+// batch records operations so that they can be replayed, but can never be
+// written.
+type batch struct {
+	database.BatchOps
+
+	db *Database
+}
+
+func (b *batch) Write() error {
+	b.db.lock.RLock()
+	defer b.db.lock.RUnlock()
+
+	if b.db.closed {
+		return database.ErrClosed
+	}
+	return ErrReadOnly
+}
+
+func (b *batch) Inner() database.Batch {
+	return b
+}
@@ -0,0 +1,67 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snapshotdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+)
+
+func TestReadOnly(t *testing.T) {
+	require := require.New(t)
+
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+
+	baseDB := memdb.New()
+	require.NoError(baseDB.Put(key1, value1))
+
+	snapshot, err := baseDB.NewSnapshot()
+	require.NoError(err)
+
+	db := New(snapshot)
+	require.NoError(baseDB.Put(key2, value2))
+
+	value, err := db.Get(key1)
+	require.NoError(err)
+	require.Equal(value1, value)
+
+	has, err := db.Has(key2)
+	require.NoError(err)
+	require.False(has)
+
+	iterator := db.NewIterator()
+	require.True(iterator.Next())
+	require.Equal(key1, iterator.Key())
+	require.Equal(value1, iterator.Value())
+	require.False(iterator.Next())
+	require.NoError(iterator.Error())
+	iterator.Release()
+
+	require.ErrorIs(db.Put(key2, value2), ErrReadOnly)
+	require.ErrorIs(db.Delete(key1), ErrReadOnly)
+
+	batch := db.NewBatch()
+	require.NoError(batch.Put(key2, value2))
+	require.ErrorIs(batch.Write(), ErrReadOnly)
+
+	require.NoError(db.Compact(nil, nil))
+	require.NoError(db.Close())
+
+	_, err = db.Get(key1)
+	require.ErrorIs(err, database.ErrClosed)
+
+	// Closing the database should have released the snapshot.
+	_, err = snapshot.Get(key1)
+	require.ErrorIs(err, database.ErrClosed)
+
+	require.ErrorIs(db.Close(), database.ErrClosed)
+}
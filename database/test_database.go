@@ -33,6 +33,9 @@ var Tests = []func(t *testing.T, db Database){
 	TestNewBatchClosed,
 	TestBatchPut,
 	TestBatchDelete,
+	TestDeleteRange,
+	TestBatchDeleteRange,
+	TestBatchDeleteRangeReplay,
 	TestBatchReset,
 	TestBatchReuse,
 	TestBatchRewrite,
@@ -45,11 +48,16 @@ var Tests = []func(t *testing.T, db Database){
 	TestIteratorStart,
 	TestIteratorPrefix,
 	TestIteratorStartPrefix,
+	TestIteratorEnd,
+	TestIteratorReverse,
+	TestIteratorReverseStartEndPrefix,
 	TestIteratorMemorySafety,
 	TestIteratorClosed,
 	TestIteratorError,
 	TestIteratorErrorAfterRelease,
 	TestCompactNoPanic,
+	TestSnapshot,
+	TestSnapshotClose,
 	TestMemorySafetyDatabase,
 	TestMemorySafetyBatch,
 	TestAtomicClear,
@@ -198,6 +206,7 @@ func TestSimpleKeyValueClosed(t *testing.T, db Database) {
 
 	require.Equal(ErrClosed, db.Put(key, value))
 	require.Equal(ErrClosed, db.Delete(key))
+	require.Equal(ErrClosed, db.DeleteRange(nil, nil))
 	require.Equal(ErrClosed, db.Close())
 }
 
@@ -310,6 +319,187 @@ func TestBatchDelete(t *testing.T, db Database) {
 	require.NoError(db.Delete(key))
 }
 
+// TestDeleteRange tests to make sure that range deletions remove exactly the
+// keys in the range.
+func TestDeleteRange(t *testing.T, db Database) {
+	require := require.New(t)
+
+	keys := [][]byte{
+		{0x00},
+		{0x01},
+		{0x01, 0x00},
+		{0x02},
+		{0x03},
+		{0x03, 0xff},
+		{0xff},
+		{0xff, 0xff},
+	}
+	putAll := func() {
+		for _, key := range keys {
+			require.NoError(db.Put(key, key))
+		}
+	}
+	requireKeys := func(expected ...[]byte) {
+		it := db.NewIterator()
+		defer it.Release()
+
+		var actual [][]byte
+		for it.Next() {
+			actual = append(actual, slices.Clone(it.Key()))
+		}
+		require.NoError(it.Error())
+		require.Equal(expected, actual)
+
+		for _, key := range keys {
+			has, err := db.Has(key)
+			require.NoError(err)
+			require.Equal(slices.ContainsFunc(expected, func(e []byte) bool {
+				return bytes.Equal(e, key)
+			}), has)
+		}
+	}
+
+	putAll()
+
+	// An empty range shouldn't delete anything.
+	require.NoError(db.DeleteRange([]byte{0x02}, []byte{0x02}))
+	require.NoError(db.DeleteRange([]byte{0x03}, []byte{0x02}))
+	requireKeys(keys...)
+
+	// [limit] is exclusive.
+	require.NoError(db.DeleteRange([]byte{0x01}, []byte{0x02}))
+	requireKeys(keys[0], keys[3], keys[4], keys[5], keys[6], keys[7])
+
+	_, err := db.Get(keys[1])
+	require.Equal(ErrNotFound, err)
+
+	// A nil [limit] is treated as a key after all keys.
+	require.NoError(db.DeleteRange([]byte{0x03}, nil))
+	requireKeys(keys[0], keys[3])
+
+	// A nil [start] is treated as a key before all keys.
+	putAll()
+	require.NoError(db.DeleteRange(nil, []byte{0x01, 0x00}))
+	requireKeys(keys[2:]...)
+
+	require.NoError(db.DeleteRange(nil, nil))
+	requireKeys()
+
+	// Keys put after the range deletion should be unaffected.
+	putAll()
+	requireKeys(keys...)
+}
+
+// TestBatchDeleteRange tests to make sure that range deletions in a batch are
+// applied in the order they were added to the batch.
+func TestBatchDeleteRange(t *testing.T, db Database) {
+	require := require.New(t)
+
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+	key3 := []byte("hello3")
+	value3 := []byte("world3")
+
+	require.NoError(db.Put(key1, value1))
+	require.NoError(db.Put(key3, value3))
+
+	batch := db.NewBatch()
+	require.NotNil(batch)
+
+	require.NoError(batch.Put(key2, value2))
+	require.NoError(batch.DeleteRange(key1, key3))
+	require.Positive(batch.Size())
+
+	// The batch shouldn't be applied until it is written.
+	has, err := db.Has(key1)
+	require.NoError(err)
+	require.True(has)
+
+	require.NoError(batch.Write())
+
+	has, err = db.Has(key1)
+	require.NoError(err)
+	require.False(has)
+
+	has, err = db.Has(key2)
+	require.NoError(err)
+	require.False(has)
+
+	v, err := db.Get(key3)
+	require.NoError(err)
+	require.Equal(value3, v)
+
+	// Operations after the range deletion should be applied.
+	batch.Reset()
+	require.NoError(batch.Put(key1, value1))
+	require.NoError(batch.DeleteRange(nil, nil))
+	require.NoError(batch.Put(key2, value2))
+	require.NoError(batch.Write())
+
+	has, err = db.Has(key1)
+	require.NoError(err)
+	require.False(has)
+
+	v, err = db.Get(key2)
+	require.NoError(err)
+	require.Equal(value2, v)
+
+	has, err = db.Has(key3)
+	require.NoError(err)
+	require.False(has)
+
+	batch = db.NewBatch()
+	require.NoError(batch.DeleteRange(nil, nil))
+	require.NoError(db.Close())
+	require.Equal(ErrClosed, batch.Write())
+}
+
+// TestBatchDeleteRangeReplay tests to make sure that range deletions are
+// replayed in order, and that replaying them onto a writer that doesn't
+// support range deletions fails.
+func TestBatchDeleteRangeReplay(t *testing.T, db Database) {
+	ctrl := gomock.NewController(t)
+	require := require.New(t)
+
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+
+	batch := db.NewBatch()
+	require.NotNil(batch)
+
+	require.NoError(batch.Put(key1, value1))
+	require.NoError(batch.DeleteRange(key1, key2))
+	require.NoError(batch.Put(key2, value2))
+
+	mockBatch := NewMockBatch(ctrl)
+	gomock.InOrder(
+		mockBatch.EXPECT().Put(key1, value1).Times(1),
+		mockBatch.EXPECT().DeleteRange(key1, key2).Times(1),
+		mockBatch.EXPECT().Put(key2, value2).Times(1),
+	)
+	require.NoError(batch.Replay(mockBatch))
+
+	err := batch.Replay(&keyValueWriterDeleter{})
+	require.ErrorIs(err, ErrRangeDeleteNotSupported)
+}
+
+// keyValueWriterDeleter is a KeyValueWriterDeleter that doesn't support range
+// deletions.
+type keyValueWriterDeleter struct{}
+
+func (*keyValueWriterDeleter) Put([]byte, []byte) error {
+	return nil
+}
+
+func (*keyValueWriterDeleter) Delete([]byte) error {
+	return nil
+}
+
 // TestMemorySafetyDatabase ensures it is safe to modify a key after passing it
 // to Batch.Put.
 func TestMemorySafetyBatch(t *testing.T, db Database) {
@@ -747,6 +937,143 @@ func TestIteratorStartPrefix(t *testing.T, db Database) {
 	require.NoError(iterator.Error())
 }
 
+// TestIteratorEnd tests to make sure the iterator stops before the provided
+// end key.
+func TestIteratorEnd(t *testing.T, db Database) {
+	require := require.New(t)
+
+	keys := [][]byte{
+		[]byte("a1"),
+		[]byte("a2"),
+		[]byte("b"),
+		[]byte("c"),
+	}
+	for _, key := range keys {
+		require.NoError(db.Put(key, key))
+	}
+
+	requireIteratorWithOptions(
+		t,
+		db,
+		IteratorOptions{
+			Start: []byte("a2"),
+			End:   []byte("c"),
+		},
+		[][]byte{
+			[]byte("a2"),
+			[]byte("b"),
+		},
+	)
+}
+
+// TestIteratorReverse tests to make sure the iterator can return all keys in
+// descending order.
+func TestIteratorReverse(t *testing.T, db Database) {
+	require := require.New(t)
+
+	keys := [][]byte{
+		{0x00},
+		[]byte("a"),
+		[]byte("b"),
+		{0xFF, 0xFF},
+	}
+	for _, key := range keys {
+		require.NoError(db.Put(key, key))
+	}
+
+	requireIteratorWithOptions(
+		t,
+		db,
+		IteratorOptions{
+			Reverse: true,
+		},
+		[][]byte{
+			{0xFF, 0xFF},
+			[]byte("b"),
+			[]byte("a"),
+			{0x00},
+		},
+	)
+}
+
+// TestIteratorReverseStartEndPrefix tests to make sure the reverse iterator
+// respects all of the provided bounds.
+func TestIteratorReverseStartEndPrefix(t *testing.T, db Database) {
+	require := require.New(t)
+
+	keys := [][]byte{
+		[]byte("g"),
+		[]byte("hello1"),
+		[]byte("hello2"),
+		[]byte("hello3"),
+		[]byte("hello4"),
+		[]byte("z"),
+	}
+	for _, key := range keys {
+		require.NoError(db.Put(key, key))
+	}
+
+	requireIteratorWithOptions(
+		t,
+		db,
+		IteratorOptions{
+			Start:   []byte("hello2"),
+			End:     []byte("hello4"),
+			Prefix:  []byte("h"),
+			Reverse: true,
+		},
+		[][]byte{
+			[]byte("hello3"),
+			[]byte("hello2"),
+		},
+	)
+	requireIteratorWithOptions(
+		t,
+		db,
+		IteratorOptions{
+			End:     []byte("z"),
+			Prefix:  []byte("hello"),
+			Reverse: true,
+		},
+		[][]byte{
+			[]byte("hello4"),
+			[]byte("hello3"),
+			[]byte("hello2"),
+			[]byte("hello1"),
+		},
+	)
+}
+
+// requireIteratorWithOptions verifies that both the native and the emulated
+// iterators described by [opts] return [expectedKeys], which are expected to
+// be mapped to themselves.
+func requireIteratorWithOptions(t *testing.T, db Database, opts IteratorOptions, expectedKeys [][]byte) {
+	require := require.New(t)
+
+	for _, iteratee := range []Iteratee{db, iterateeFunc{db}} {
+		iterator := NewIteratorWithOptions(iteratee, opts)
+		require.NotNil(iterator)
+
+		for _, expectedKey := range expectedKeys {
+			require.True(iterator.Next())
+			require.Equal(expectedKey, iterator.Key())
+			require.Equal(expectedKey, iterator.Value())
+		}
+
+		require.False(iterator.Next())
+		require.Nil(iterator.Key())
+		require.Nil(iterator.Value())
+		require.NoError(iterator.Error())
+		iterator.Release()
+	}
+}
+
+// iterateeFunc hides any native support for NewIteratorWithOptions of the
+// wrapped Iteratee.
+type iterateeFunc struct {
+	Iteratee
+}
+
 // TestIteratorMemorySafety tests to make sure that keys can values are able to
 // be modified from the returned iterator.
 func TestIteratorMemorySafety(t *testing.T, db Database) {
@@ -939,6 +1266,111 @@ func TestCompactNoPanic(t *testing.T, db Database) {
 	require.ErrorIs(err, ErrClosed)
 }
 
+// TestSnapshot tests to make sure that a snapshot is a consistent view of the
+// database that isn't affected by later writes. Databases that don't support
+// snapshots are ignored.
+func TestSnapshot(t *testing.T, db Database) {
+	require := require.New(t)
+
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+	value2New := []byte("world2 new")
+
+	key3 := []byte("z")
+	value3 := []byte("world3")
+
+	require.NoError(db.Put(key1, value1))
+	require.NoError(db.Put(key2, value2))
+
+	snapshot, err := NewSnapshot(db)
+	if err == ErrSnapshotNotSupported {
+		return
+	}
+	require.NoError(err)
+
+	require.NoError(db.Delete(key1))
+	require.NoError(db.Put(key2, value2New))
+	require.NoError(db.Put(key3, value3))
+
+	has, err := snapshot.Has(key1)
+	require.NoError(err)
+	require.True(has)
+
+	value, err := snapshot.Get(key2)
+	require.NoError(err)
+	require.Equal(value2, value)
+
+	has, err = snapshot.Has(key3)
+	require.NoError(err)
+	require.False(has)
+
+	_, err = snapshot.Get(key3)
+	require.ErrorIs(err, ErrNotFound)
+
+	iterator := snapshot.NewIterator()
+	require.True(iterator.Next())
+	require.Equal(key1, iterator.Key())
+	require.Equal(value1, iterator.Value())
+	require.True(iterator.Next())
+	require.Equal(key2, iterator.Key())
+	require.Equal(value2, iterator.Value())
+	require.False(iterator.Next())
+	require.NoError(iterator.Error())
+	iterator.Release()
+
+	iterator = snapshot.NewIteratorWithStartAndPrefix(key2, []byte("hello"))
+	require.True(iterator.Next())
+	require.Equal(key2, iterator.Key())
+	require.Equal(value2, iterator.Value())
+	require.False(iterator.Next())
+	require.NoError(iterator.Error())
+	iterator.Release()
+
+	// The database itself should reflect the writes made after the snapshot
+	// was taken.
+	_, err = db.Get(key1)
+	require.ErrorIs(err, ErrNotFound)
+
+	snapshot.Release()
+	snapshot.Release() // Releasing twice shouldn't panic
+
+	_, err = snapshot.Get(key2)
+	require.ErrorIs(err, ErrClosed)
+
+	require.NoError(db.Close())
+	_, err = NewSnapshot(db)
+	require.ErrorIs(err, ErrClosed)
+}
+
+// TestSnapshotClose tests to make sure that a database can be closed while a
+// snapshot of it, and an iterator over that snapshot, are still open. Databases that don't support snapshots are
+// ignored.
+func TestSnapshotClose(t *testing.T, db Database) {
+	require := require.New(t)
+
+	key := []byte("hello")
+	value := []byte("world")
+
+	require.NoError(db.Put(key, value))
+
+	snapshot, err := NewSnapshot(db)
+	if err == ErrSnapshotNotSupported {
+		return
+	}
+	require.NoError(err)
+
+	iterator := snapshot.NewIterator()
+	require.NoError(db.Close())
+
+	// Releasing the snapshot and its iterators after the database was closed
+	// shouldn't panic.
+	iterator.Release()
+	snapshot.Release()
+}
+
 func TestAtomicClear(t *testing.T, db Database) {
 	testClear(t, db, func(db Database) error {
 		return AtomicClear(db, db)
@@ -5,7 +5,6 @@ package versiondb
 
 import (
 	"context"
-	"strings"
 	"sync"
 
 	"golang.org/x/exp/maps"
@@ -16,10 +15,14 @@ import (
 )
 
 var (
-	_ database.Database = (*Database)(nil)
-	_ Commitable        = (*Database)(nil)
-	_ database.Batch    = (*batch)(nil)
-	_ database.Iterator = (*iterator)(nil)
+	_ database.Database      = (*Database)(nil)
+	_ database.RangeIteratee = (*Database)(nil)
+	_ database.Snapshotter   = (*Database)(nil)
+	_ database.Snapshot      = (*dbSnapshot)(nil)
+	_ database.RangeIteratee = (*dbSnapshot)(nil)
+	_ Commitable             = (*Database)(nil)
+	_ database.Batch         = (*batch)(nil)
+	_ database.Iterator      = (*iterator)(nil)
 )
 
 // Commitable defines the interface that specifies that something may be
@@ -37,6 +40,10 @@ type Database struct {
 	mem   map[string]valueDelete
 	db    database.Database
 	batch database.Batch
+
+	// deletedRanges are the ranges of keys that have been deleted from the
+	// underlying database. Keys in [mem] take precedence over [deletedRanges].
+	deletedRanges []keyRange
 }
 
 type valueDelete struct {
@@ -44,6 +51,12 @@ type valueDelete struct {
 	delete bool
 }
 
+// keyRange is the range of keys [start, limit). An empty limit is treated as
+// a key after all keys.
+type keyRange struct {
+	start, limit []byte
+}
+
 // New returns a new versioned database
 func New(db database.Database) *Database {
 	return &Database{
@@ -63,6 +76,9 @@ func (db *Database) Has(key []byte) (bool, error) {
 	if val, has := db.mem[string(key)]; has {
 		return !val.delete, nil
 	}
+	if isDeleted(db.deletedRanges, key) {
+		return false, nil
+	}
 	return db.db.Has(key)
 }
 
@@ -79,6 +95,9 @@ func (db *Database) Get(key []byte) ([]byte, error) {
 		}
 		return slices.Clone(val.value), nil
 	}
+	if isDeleted(db.deletedRanges, key) {
+		return nil, database.ErrNotFound
+	}
 	return db.db.Get(key)
 }
 
@@ -104,6 +123,31 @@ func (db *Database) Delete(key []byte) error {
 	return nil
 }
 
+func (db *Database) DeleteRange(start, limit []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.mem == nil {
+		return database.ErrClosed
+	}
+	db.deleteRange(start, limit)
+	return nil
+}
+
+// Assumes the lock is held.
+func (db *Database) deleteRange(start, limit []byte) {
+	// Any operations in the range are overridden by the deletion.
+	for key := range db.mem {
+		if database.InRange([]byte(key), start, limit) {
+			delete(db.mem, key)
+		}
+	}
+	db.deletedRanges = append(db.deletedRanges, keyRange{
+		start: slices.Clone(start),
+		limit: slices.Clone(limit),
+	})
+}
+
 func (db *Database) NewBatch() database.Batch {
 	return &batch{db: db}
 }
@@ -121,6 +165,13 @@ func (db *Database) NewIteratorWithPrefix(prefix []byte) database.Iterator {
 }
 
 func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return db.NewIteratorWithOptions(database.IteratorOptions{
+		Start:  start,
+		Prefix: prefix,
+	})
+}
+
+func (db *Database) NewIteratorWithOptions(opts database.IteratorOptions) database.Iterator {
 	db.lock.RLock()
 	defer db.lock.RUnlock()
 
@@ -130,23 +181,11 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 		}
 	}
 
-	startString := string(start)
-	prefixString := string(prefix)
-	keys := make([]string, 0, len(db.mem))
-	for key := range db.mem {
-		if strings.HasPrefix(key, prefixString) && key >= startString {
-			keys = append(keys, key)
-		}
-	}
-	slices.Sort(keys) // Keys need to be in sorted order
-	values := make([]valueDelete, len(keys))
-	for i, key := range keys {
-		values[i] = db.mem[key]
-	}
-
+	keys, values := sortedEntries(db.mem, opts)
 	return &iterator{
 		db:       db,
-		Iterator: db.db.NewIteratorWithStartAndPrefix(start, prefix),
+		Iterator: newFilteredIterator(database.NewIteratorWithOptions(db.db, opts), db.deletedRanges),
+		reverse:  opts.Reverse,
 		keys:     keys,
 		values:   values,
 	}
@@ -162,6 +201,30 @@ func (db *Database) Compact(start, limit []byte) error {
 	return db.db.Compact(start, limit)
 }
 
+// NewSnapshot returns a read-only view of the underlying database with the
+// uncommitted operations of this database applied. The underlying database
+// must implement database.Snapshotter.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.mem == nil {
+		return nil, database.ErrClosed
+	}
+	snapshot, err := database.NewSnapshot(db.db)
+	if err != nil {
+		return nil, err
+	}
+	// Values in [db.mem] are never modified in place, so only the map needs to
+	// be copied.
+	return &dbSnapshot{
+		Snapshot:      snapshot,
+		db:            db,
+		mem:           maps.Clone(db.mem),
+		deletedRanges: slices.Clone(db.deletedRanges),
+	}, nil
+}
+
 // SetDatabase changes the underlying database to the specified database
 func (db *Database) SetDatabase(newDB database.Database) error {
 	db.lock.Lock()
@@ -211,6 +274,7 @@ func (db *Database) Abort() {
 
 func (db *Database) abort() {
 	maps.Clear(db.mem)
+	db.deletedRanges = nil
 }
 
 // CommitBatch returns a batch that contains all uncommitted puts/deletes.
@@ -232,6 +296,13 @@ func (db *Database) commitBatch() (database.Batch, error) {
 	}
 
 	db.batch.Reset()
+	// Any operations in [db.mem] happened after the ranges were deleted, so
+	// the ranges must be deleted first.
+	for _, r := range db.deletedRanges {
+		if err := db.batch.DeleteRange(r.start, r.limit); err != nil {
+			return nil, err
+		}
+	}
 	for key, value := range db.mem {
 		if value.delete {
 			if err := db.batch.Delete([]byte(key)); err != nil {
@@ -254,6 +325,7 @@ func (db *Database) Close() error {
 	}
 	db.batch = nil
 	db.mem = nil
+	db.deletedRanges = nil
 	db.db = nil
 	return nil
 }
@@ -290,6 +362,10 @@ func (b *batch) Write() error {
 	}
 
 	for _, op := range b.Ops {
+		if op.DeleteRange {
+			b.db.deleteRange(op.Key, op.Limit)
+			continue
+		}
 		b.db.mem[string(op.Key)] = valueDelete{
 			value:  op.Value,
 			delete: op.Delete,
@@ -302,6 +378,67 @@ func (b *batch) Inner() database.Batch {
 	return b
 }
 
+// sortedEntries returns the keys in [mem] that are covered by [opts], and
+// their values, in the order they should be iterated over.
+func sortedEntries(mem map[string]valueDelete, opts database.IteratorOptions) ([]string, []valueDelete) {
+	lower, upper := opts.Bounds()
+	keys := make([]string, 0, len(mem))
+	for key := range mem {
+		if database.InRange([]byte(key), lower, upper) {
+			keys = append(keys, key)
+		}
+	}
+	// Keys need to be in sorted order
+	if opts.Reverse {
+		slices.SortFunc(keys, func(a, b string) bool {
+			return a > b
+		})
+	} else {
+		slices.Sort(keys)
+	}
+	values := make([]valueDelete, len(keys))
+	for i, key := range keys {
+		values[i] = mem[key]
+	}
+	return keys, values
+}
+
+// isDeleted returns true if [key] is in any of the [deletedRanges].
+func isDeleted(deletedRanges []keyRange, key []byte) bool {
+	for _, r := range deletedRanges {
+		if database.InRange(key, r.start, r.limit) {
+			return true
+		}
+	}
+	return false
+}
+
+// filteredIterator skips the keys of the wrapped iterator that are in any of
+// the [deletedRanges].
+type filteredIterator struct {
+	database.Iterator
+	deletedRanges []keyRange
+}
+
+func newFilteredIterator(it database.Iterator, deletedRanges []keyRange) database.Iterator {
+	if len(deletedRanges) == 0 {
+		return it
+	}
+	return &filteredIterator{
+		Iterator:      it,
+		deletedRanges: deletedRanges,
+	}
+}
+
+func (it *filteredIterator) Next() bool {
+	for it.Iterator.Next() {
+		if !isDeleted(it.deletedRanges, it.Iterator.Key()) {
+			return true
+		}
+	}
+	return false
+}
+
 // iterator walks over both the in memory database and the underlying database
 // at the same time.
 type iterator struct {
@@ -314,6 +451,9 @@ type iterator struct {
 	keys   []string
 	values []valueDelete
 
+	// reverse iterates from the last key to the first key.
+	reverse bool
+
 	initialized, exhausted bool
 }
 
@@ -367,7 +507,7 @@ func (it *iterator) Next() bool {
 
 			dbStringKey := string(dbKey)
 			switch {
-			case memKey < dbStringKey:
+			case it.before(memKey, dbStringKey):
 				it.keys[0] = ""
 				it.keys = it.keys[1:]
 				it.values[0].value = nil
@@ -378,7 +518,7 @@ func (it *iterator) Next() bool {
 					it.value = memValue.value
 					return true
 				}
-			case dbStringKey < memKey:
+			case it.before(dbStringKey, memKey):
 				it.key = dbKey
 				it.value = it.Iterator.Value()
 				it.exhausted = !it.Iterator.Next()
@@ -400,6 +540,14 @@ func (it *iterator) Next() bool {
 	}
 }
 
+// before returns true if [a] should be iterated over before [b].
+func (it *iterator) before(a, b string) bool {
+	if it.reverse {
+		return a > b
+	}
+	return a < b
+}
+
 func (it *iterator) Error() error {
 	if it.err != nil {
 		return it.err
@@ -303,3 +303,169 @@ func BenchmarkInterface(b *testing.B) {
 		}
 	}
 }
+
+func TestSnapshotUncommitted(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db := New(baseDB)
+
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+
+	require.NoError(db.Put(key1, value1))
+	require.NoError(db.Commit())
+
+	require.NoError(db.Delete(key1))
+	require.NoError(db.Put(key2, value2))
+
+	snapshot, err := db.NewSnapshot()
+	require.NoError(err)
+	defer snapshot.Release()
+
+	// Operations after the snapshot was taken should not be visible through
+	// the snapshot.
+	require.NoError(db.Put(key1, value1))
+
+	has, err := snapshot.Has(key1)
+	require.NoError(err)
+	require.False(has)
+
+	value, err := snapshot.Get(key2)
+	require.NoError(err)
+	require.Equal(value2, value)
+
+	iterator := snapshot.NewIterator()
+	defer iterator.Release()
+
+	require.True(iterator.Next())
+	require.Equal(key2, iterator.Key())
+	require.Equal(value2, iterator.Value())
+	require.False(iterator.Next())
+	require.NoError(iterator.Error())
+
+	// The base database should not include the uncommitted operations.
+	value, err = baseDB.Get(key2)
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestDeleteRangeUncommitted(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db := New(baseDB)
+
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+
+	key3 := []byte("hello3")
+	value3 := []byte("world3")
+
+	require.NoError(db.Put(key1, value1))
+	require.NoError(db.Put(key2, value2))
+	require.NoError(db.Commit())
+
+	require.NoError(db.Put(key3, value3))
+	require.NoError(db.DeleteRange(key1, nil))
+	require.NoError(db.Put(key2, value2))
+
+	snapshot, err := db.NewSnapshot()
+	require.NoError(err)
+	defer snapshot.Release()
+
+	for _, reader := range []database.KeyValueReader{db, snapshot} {
+		has, err := reader.Has(key1)
+		require.NoError(err)
+		require.False(has)
+
+		value, err := reader.Get(key2)
+		require.NoError(err)
+		require.Equal(value2, value)
+
+		_, err = reader.Get(key3)
+		require.ErrorIs(err, database.ErrNotFound)
+	}
+
+	for _, iteratee := range []database.Iteratee{db, snapshot} {
+		iterator := iteratee.NewIterator()
+		require.True(iterator.Next())
+		require.Equal(key2, iterator.Key())
+		require.Equal(value2, iterator.Value())
+		require.False(iterator.Next())
+		require.NoError(iterator.Error())
+		iterator.Release()
+	}
+
+	// The base database should not include the uncommitted range deletion.
+	value, err := baseDB.Get(key1)
+	require.NoError(err)
+	require.Equal(value1, value)
+
+	require.NoError(db.Commit())
+
+	has, err := baseDB.Has(key1)
+	require.NoError(err)
+	require.False(has)
+
+	value, err = baseDB.Get(key2)
+	require.NoError(err)
+	require.Equal(value2, value)
+
+	has, err = baseDB.Has(key3)
+	require.NoError(err)
+	require.False(has)
+}
+
+func TestIteratorReverseUncommitted(t *testing.T) {
+	require := require.New(t)
+
+	db := New(memdb.New())
+
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+
+	key3 := []byte("hello3")
+	value3 := []byte("world3")
+
+	key4 := []byte("hello4")
+	value4 := []byte("world4")
+
+	require.NoError(db.Put(key1, value1))
+	require.NoError(db.Put(key3, value3))
+	require.NoError(db.Put(key4, value4))
+	require.NoError(db.Commit())
+
+	require.NoError(db.Put(key2, value2))
+	require.NoError(db.Delete(key3))
+
+	iterator := db.NewIteratorWithOptions(database.IteratorOptions{
+		Reverse: true,
+	})
+	defer iterator.Release()
+
+	require.True(iterator.Next())
+	require.Equal(key4, iterator.Key())
+	require.Equal(value4, iterator.Value())
+
+	require.True(iterator.Next())
+	require.Equal(key2, iterator.Key())
+	require.Equal(value2, iterator.Value())
+
+	require.True(iterator.Next())
+	require.Equal(key1, iterator.Key())
+	require.Equal(value1, iterator.Value())
+
+	require.False(iterator.Next())
+	require.Nil(iterator.Key())
+	require.Nil(iterator.Value())
+	require.NoError(iterator.Error())
+}
@@ -0,0 +1,108 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package versiondb
+
+import (
+	"sync"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/avalanchego/database"
+)
+
+// dbSnapshot overlays the uncommitted operations of a versiondb, as of the time
+// the snapshot was taken, on top of a snapshot of the underlying database.
+type dbSnapshot struct {
+	database.Snapshot
+	db *Database
+
+	// lock needs to be held during Release to guarantee mem will not be set to
+	// nil concurrently with another operation.
+	lock          sync.RWMutex
+	mem           map[string]valueDelete
+	deletedRanges []keyRange
+}
+
+func (s *dbSnapshot) Has(key []byte) (bool, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.mem == nil {
+		return false, database.ErrClosed
+	}
+	if val, has := s.mem[string(key)]; has {
+		return !val.delete, nil
+	}
+	if isDeleted(s.deletedRanges, key) {
+		return false, nil
+	}
+	return s.Snapshot.Has(key)
+}
+
+func (s *dbSnapshot) Get(key []byte) ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.mem == nil {
+		return nil, database.ErrClosed
+	}
+	if val, has := s.mem[string(key)]; has {
+		if val.delete {
+			return nil, database.ErrNotFound
+		}
+		return slices.Clone(val.value), nil
+	}
+	if isDeleted(s.deletedRanges, key) {
+		return nil, database.ErrNotFound
+	}
+	return s.Snapshot.Get(key)
+}
+
+func (s *dbSnapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *dbSnapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *dbSnapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (s *dbSnapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return s.NewIteratorWithOptions(database.IteratorOptions{
+		Start:  start,
+		Prefix: prefix,
+	})
+}
+
+func (s *dbSnapshot) NewIteratorWithOptions(opts database.IteratorOptions) database.Iterator {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.mem == nil {
+		return &database.IteratorError{
+			Err: database.ErrClosed,
+		}
+	}
+
+	keys, values := sortedEntries(s.mem, opts)
+	return &iterator{
+		db:       s.db,
+		Iterator: newFilteredIterator(database.NewIteratorWithOptions(s.Snapshot, opts), s.deletedRanges),
+		reverse:  opts.Reverse,
+		keys:     keys,
+		values:   values,
+	}
+}
+
+func (s *dbSnapshot) Release() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.mem = nil
+	s.deletedRanges = nil
+	s.Snapshot.Release()
+}
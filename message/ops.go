@@ -354,6 +354,68 @@ func Unwrap(m *p2p.Message) (fmt.Stringer, error) {
 	}
 }
 
+// Wrap is the inverse of Unwrap. It returns the p2p.Message that contains
+// [msg].
+func Wrap(msg fmt.Stringer) (*p2p.Message, error) {
+	m := &p2p.Message{}
+	switch msg := msg.(type) {
+	// Handshake:
+	case *p2p.Ping:
+		m.Message = &p2p.Message_Ping{Ping: msg}
+	case *p2p.Pong:
+		m.Message = &p2p.Message_Pong{Pong: msg}
+	case *p2p.Version:
+		m.Message = &p2p.Message_Version{Version: msg}
+	case *p2p.PeerList:
+		m.Message = &p2p.Message_PeerList{PeerList: msg}
+	case *p2p.PeerListAck:
+		m.Message = &p2p.Message_PeerListAck{PeerListAck: msg}
+	// State sync:
+	case *p2p.GetStateSummaryFrontier:
+		m.Message = &p2p.Message_GetStateSummaryFrontier{GetStateSummaryFrontier: msg}
+	case *p2p.StateSummaryFrontier:
+		m.Message = &p2p.Message_StateSummaryFrontier_{StateSummaryFrontier_: msg}
+	case *p2p.GetAcceptedStateSummary:
+		m.Message = &p2p.Message_GetAcceptedStateSummary{GetAcceptedStateSummary: msg}
+	case *p2p.AcceptedStateSummary:
+		m.Message = &p2p.Message_AcceptedStateSummary_{AcceptedStateSummary_: msg}
+	// Bootstrapping:
+	case *p2p.GetAcceptedFrontier:
+		m.Message = &p2p.Message_GetAcceptedFrontier{GetAcceptedFrontier: msg}
+	case *p2p.AcceptedFrontier:
+		m.Message = &p2p.Message_AcceptedFrontier_{AcceptedFrontier_: msg}
+	case *p2p.GetAccepted:
+		m.Message = &p2p.Message_GetAccepted{GetAccepted: msg}
+	case *p2p.Accepted:
+		m.Message = &p2p.Message_Accepted_{Accepted_: msg}
+	case *p2p.GetAncestors:
+		m.Message = &p2p.Message_GetAncestors{GetAncestors: msg}
+	case *p2p.Ancestors:
+		m.Message = &p2p.Message_Ancestors_{Ancestors_: msg}
+	// Consensus:
+	case *p2p.Get:
+		m.Message = &p2p.Message_Get{Get: msg}
+	case *p2p.Put:
+		m.Message = &p2p.Message_Put{Put: msg}
+	case *p2p.PushQuery:
+		m.Message = &p2p.Message_PushQuery{PushQuery: msg}
+	case *p2p.PullQuery:
+		m.Message = &p2p.Message_PullQuery{PullQuery: msg}
+	case *p2p.Chits:
+		m.Message = &p2p.Message_Chits{Chits: msg}
+	// Application:
+	case *p2p.AppRequest:
+		m.Message = &p2p.Message_AppRequest{AppRequest: msg}
+	case *p2p.AppResponse:
+		m.Message = &p2p.Message_AppResponse{AppResponse: msg}
+	case *p2p.AppGossip:
+		m.Message = &p2p.Message_AppGossip{AppGossip: msg}
+	default:
+		return nil, fmt.Errorf("%w: %T", errUnknownMessageType, msg)
+	}
+	return m, nil
+}
+
 func ToOp(m *p2p.Message) (Op, error) {
 	switch msg := m.GetMessage().(type) {
 	case *p2p.Message_Ping:
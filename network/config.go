@@ -12,6 +12,7 @@ import (
 	"github.com/ava-labs/avalanchego/network/dialer"
 	"github.com/ava-labs/avalanchego/network/peer"
 	"github.com/ava-labs/avalanchego/network/throttling"
+	"github.com/ava-labs/avalanchego/snow/networking/reputation"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/uptime"
 	"github.com/ava-labs/avalanchego/snow/validators"
@@ -169,6 +170,9 @@ type Config struct {
 	// (there is one buffer per peer)
 	PeerWriteBufferSize int `json:"peerWriteBufferSize"`
 
+	// Describes how the messages sent to each peer are prioritized.
+	OutboundMessageQueueConfig peer.PrioritizedMessageQueueConfig `json:"outboundMessageQueueConfig"`
+
 	// Tracks the CPU/disk usage caused by processing messages of each peer.
 	ResourceTracker tracker.ResourceTracker `json:"-"`
 
@@ -182,4 +186,11 @@ type Config struct {
 
 	// Tracks which validators have been sent to which peers
 	GossipTracker peer.GossipTracker `json:"-"`
+
+	// Tracks the reputation of each peer.
+	ReputationTracker reputation.Tracker `json:"-"`
+
+	// MinInboundReputation is the reputation score below which inbound
+	// connections are rejected. If 0, no inbound connections are rejected.
+	MinInboundReputation float64 `json:"minInboundReputation"`
 }
@@ -28,6 +28,7 @@ type metrics struct {
 	acceptFailed                    prometheus.Counter
 	inboundConnRateLimited          prometheus.Counter
 	inboundConnAllowed              prometheus.Counter
+	inboundConnLowReputation        prometheus.Counter
 	tlsConnRejected                 prometheus.Counter
 	numUselessPeerListBytes         prometheus.Counter
 	nodeUptimeWeightedAverage       prometheus.Gauge
@@ -106,6 +107,11 @@ func newMetrics(namespace string, registerer prometheus.Registerer, initialSubne
 			Name:      "num_useless_peerlist_bytes",
 			Help:      "Amount of useless bytes (i.e. information about nodes we already knew/don't want to connect to) received in PeerList messages",
 		}),
+		inboundConnLowReputation: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "inbound_conn_low_reputation",
+			Help:      "Times this node rejected an inbound connection due to the reputation of the peer",
+		}),
 		inboundConnRateLimited: prometheus.NewCounter(prometheus.CounterOpts{
 			Namespace: namespace,
 			Name:      "inbound_conn_throttler_rate_limited",
@@ -162,6 +168,7 @@ func newMetrics(namespace string, registerer prometheus.Registerer, initialSubne
 		registerer.Register(m.tlsConnRejected),
 		registerer.Register(m.numUselessPeerListBytes),
 		registerer.Register(m.inboundConnRateLimited),
+		registerer.Register(m.inboundConnLowReputation),
 		registerer.Register(m.nodeUptimeWeightedAverage),
 		registerer.Register(m.nodeUptimeRewardingStake),
 		registerer.Register(m.nodeSubnetUptimeWeightedAverage),
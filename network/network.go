@@ -124,6 +124,7 @@ type network struct {
 	metrics    *metrics
 
 	outboundMsgThrottler throttling.OutboundMsgThrottler
+	messageQueueMetrics  *peer.MessageQueueMetrics
 
 	// Limits the number of connection attempts based on IP.
 	inboundConnUpgradeThrottler throttling.InboundConnUpgradeThrottler
@@ -225,6 +226,7 @@ func NewNetwork(
 		config.ResourceTracker,
 		config.CPUTargeter,
 		config.DiskTargeter,
+		config.ReputationTracker,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("initializing inbound message throttler failed with: %w", err)
@@ -246,6 +248,11 @@ func NewNetwork(
 		return nil, fmt.Errorf("initializing peer metrics failed with: %w", err)
 	}
 
+	messageQueueMetrics, err := peer.NewMessageQueueMetrics(config.Namespace, metricsRegisterer)
+	if err != nil {
+		return nil, fmt.Errorf("initializing message queue metrics failed with: %w", err)
+	}
+
 	metrics, err := newMetrics(config.Namespace, metricsRegisterer, config.TrackedSubnets)
 	if err != nil {
 		return nil, fmt.Errorf("initializing network metrics failed with: %w", err)
@@ -269,6 +276,7 @@ func NewNetwork(
 		PongTimeout:          config.PingPongTimeout,
 		MaxClockDifference:   config.MaxClockDifference,
 		ResourceTracker:      config.ResourceTracker,
+		ReputationTracker:    config.ReputationTracker,
 		UptimeCalculator:     config.UptimeCalculator,
 		IPSigner:             peer.NewIPSigner(config.MyIPPort, config.TLSKey),
 	}
@@ -279,6 +287,7 @@ func NewNetwork(
 		peerConfig:           peerConfig,
 		metrics:              metrics,
 		outboundMsgThrottler: outboundMsgThrottler,
+		messageQueueMetrics:  messageQueueMetrics,
 
 		inboundConnUpgradeThrottler: throttling.NewInboundConnUpgradeThrottler(log, config.ThrottlerConfig.InboundConnUpgradeThrottlerConfig),
 		listener:                    listener,
@@ -472,6 +481,14 @@ func (n *network) AllowConnection(nodeID ids.NodeID) bool {
 		n.WantsConnection(nodeID)
 }
 
+// hasLowReputation returns the reputation score of [nodeID] and true if inbound
+// connections from it should be rejected. Once the score of [nodeID] recovers,
+// its inbound connections are accepted again.
+func (n *network) hasLowReputation(nodeID ids.NodeID) (float64, bool) {
+	score := n.config.ReputationTracker.Score(nodeID)
+	return score, score < n.config.MinInboundReputation
+}
+
 func (n *network) Track(peerID ids.NodeID, claimedIPPorts []*ips.ClaimedIPPort) ([]*p2p.PeerAck, error) {
 	// Perform all signature verification and hashing before grabbing the peer
 	// lock.
@@ -1226,6 +1243,22 @@ func (n *network) upgrade(conn net.Conn, upgrader peer.Upgrader) error {
 		return nil
 	}
 
+	// Only inbound connections are rejected based on reputation, as outbound
+	// connections are only attempted to peers we want to connect to.
+	if upgrader == n.serverUpgrader {
+		if score, low := n.hasLowReputation(nodeID); low {
+			_ = tlsConn.Close()
+			n.peerConfig.Log.Verbo(
+				"dropping connection",
+				zap.String("reason", "low reputation"),
+				zap.Stringer("nodeID", nodeID),
+				zap.Float64("score", score),
+			)
+			n.metrics.inboundConnLowReputation.Inc()
+			return nil
+		}
+	}
+
 	n.peersLock.Lock()
 	if n.closing {
 		n.peersLock.Unlock()
@@ -1282,11 +1315,13 @@ func (n *network) upgrade(conn net.Conn, upgrader peer.Upgrader) error {
 		tlsConn,
 		cert,
 		nodeID,
-		peer.NewThrottledMessageQueue(
+		peer.NewPrioritizedMessageQueue(
 			n.peerConfig.Metrics,
 			nodeID,
 			n.peerConfig.Log,
 			n.outboundMsgThrottler,
+			n.messageQueueMetrics,
+			n.config.OutboundMessageQueueConfig,
 		),
 	)
 	n.connectingPeers.Add(peer)
@@ -22,6 +22,7 @@ import (
 	"github.com/ava-labs/avalanchego/network/peer"
 	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/proto/pb/p2p"
+	"github.com/ava-labs/avalanchego/snow/networking/reputation"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/uptime"
@@ -123,6 +124,7 @@ var (
 
 		MaximumInboundMessageTimeout: 30 * time.Second,
 		ResourceTracker:              newDefaultResourceTracker(),
+		ReputationTracker:            reputation.NewNoTracker(),
 		CPUTargeter:                  nil, // Set in init
 		DiskTargeter:                 nil, // Set in init
 	}
@@ -692,3 +694,47 @@ func TestDialContext(t *testing.T) {
 	network.StartClose()
 	wg.Wait()
 }
+
+// Test that a peer whose reputation dropped below the inbound threshold is
+// admitted again once its reputation recovers, and that no peer is rejected
+// by default.
+func TestLowReputationRecovers(t *testing.T) {
+	require := require.New(t)
+
+	clock := &mockable.Clock{}
+	clock.Set(time.Unix(0, 0))
+	reputationTracker, err := reputation.NewCustomTracker(
+		reputation.Config{
+			Halflife:       time.Minute,
+			TimeoutPenalty: constants.DefaultReputationTimeoutPenalty,
+		},
+		clock,
+		"",
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+
+	config := defaultConfig
+	config.ReputationTracker = reputationTracker
+	config.MinInboundReputation = constants.DefaultReputationMinInboundScore
+	n := &network{
+		config: &config,
+	}
+
+	nodeID := ids.GenerateTestNodeID()
+	for i := 0; i < 100; i++ {
+		reputationTracker.RegisterEvent(nodeID, reputation.Timeout)
+	}
+
+	_, low := n.hasLowReputation(nodeID)
+	require.False(low)
+
+	config.MinInboundReputation = 0.5
+	_, low = n.hasLowReputation(nodeID)
+	require.True(low)
+
+	// The penalty decays below 1 after enough halflives.
+	clock.Set(time.Unix(0, 0).Add(10 * time.Minute))
+	_, low = n.hasLowReputation(nodeID)
+	require.False(low)
+}
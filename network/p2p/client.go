@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
@@ -46,6 +47,9 @@ type Client struct {
 	sender        common.AppSender
 	// nodeSampler is used to select nodes to route AppRequestAny to
 	nodeSampler NodeSampler
+	// responseTracker is [nodeSampler] if it tracks the responses to
+	// AppRequests, nil otherwise
+	responseTracker ResponseTracker
 }
 
 // AppRequestAny issues an AppRequest to an arbitrary node decided by Client.
@@ -99,6 +103,8 @@ func (c *Client) AppRequest(
 		c.router.pendingAppRequests[requestID] = pendingAppRequest{
 			AppResponseCallback: onResponse,
 			metrics:             c.router.handlers[c.handlerID].metrics,
+			responseTracker:     c.responseTracker,
+			sentAt:              time.Now(),
 		}
 		c.router.requestID += 2
 	}
@@ -5,6 +5,8 @@ package gossip
 
 import (
 	"context"
+	"math"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -13,16 +15,21 @@ import (
 
 	"google.golang.org/protobuf/proto"
 
+	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/network/p2p"
 	"github.com/ava-labs/avalanchego/proto/pb/sdk"
+	"github.com/ava-labs/avalanchego/utils/heap"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
 
 var (
 	_ Gossiper = (*ValidatorGossiper)(nil)
 	_ Gossiper = (*PullGossiper[testTx, *testTx])(nil)
+	_ Gossiper = (*PushGossiper[*testTx])(nil)
 )
 
 // Gossiper gossips Gossipables to other nodes
@@ -181,6 +188,256 @@ func (p *PullGossiper[T, U]) handleResponse(
 	p.receivedBytes.Add(float64(receivedBytes))
 }
 
+type PushGossiperConfig struct {
+	Namespace string
+	// ValidatorFanout is the number of validators each message is sent to.
+	ValidatorFanout int
+	// NonValidatorFanout is the number of connected non-validators each
+	// message is sent to.
+	NonValidatorFanout int
+	// TargetGossipSize is the number of bytes that are attempted to be sent in
+	// each message.
+	TargetGossipSize int
+	// RegossipFrequency is how long to wait before an item is regossiped for
+	// the first time. The wait doubles after every regossip.
+	RegossipFrequency time.Duration
+	// MaxRegossips is the number of times an item is regossiped after it was
+	// first gossiped.
+	MaxRegossips int
+	// DiscardedCacheSize is the number of items that are remembered after they
+	// were regossiped [MaxRegossips] times, so that they aren't gossiped again
+	// if they are re-added.
+	DiscardedCacheSize int
+}
+
+func NewPushGossiper[T Gossipable](
+	config PushGossiperConfig,
+	log logging.Logger,
+	client *p2p.Client,
+	validators *p2p.Validators,
+	peers p2p.NodeSampler,
+	metrics prometheus.Registerer,
+) (*PushGossiper[T], error) {
+	p := &PushGossiper[T]{
+		config:     config,
+		log:        log,
+		client:     client,
+		validators: validators,
+		peers:      peers,
+		tracked:    make(map[ids.ID]*pushedGossipable[T]),
+		regossipQueue: heap.NewQueue(func(a, b *pushedGossipable[T]) bool {
+			return a.nextGossip.Before(b.nextGossip)
+		}),
+		discarded: &cache.LRU[ids.ID, struct{}]{Size: config.DiscardedCacheSize},
+		sentN: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: config.Namespace,
+			Name:      "gossip_pushed_n",
+			Help:      "amount of gossip pushed (n)",
+		}),
+		sentBytes: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: config.Namespace,
+			Name:      "gossip_pushed_bytes",
+			Help:      "amount of gossip pushed (bytes)",
+		}),
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		metrics.Register(p.sentN),
+		metrics.Register(p.sentBytes),
+	)
+
+	return p, errs.Err
+}
+
+// PushGossiper sends items to a sample of validators and non-validators as
+// soon as they are added, and regossips them with an exponential backoff.
+type PushGossiper[T Gossipable] struct {
+	config     PushGossiperConfig
+	log        logging.Logger
+	client     *p2p.Client
+	validators *p2p.Validators
+	peers      p2p.NodeSampler
+	clock      mockable.Clock
+
+	lock sync.Mutex
+	// Items that haven't been gossiped yet, or that failed to be sent.
+	pending []*pushedGossipable[T]
+	// Items that are pending or waiting to be regossiped.
+	tracked       map[ids.ID]*pushedGossipable[T]
+	regossipQueue heap.Queue[*pushedGossipable[T]]
+	discarded     *cache.LRU[ids.ID, struct{}]
+
+	sentN     prometheus.Counter
+	sentBytes prometheus.Counter
+}
+
+type pushedGossipable[T Gossipable] struct {
+	gossipable T
+	// Number of times this item was gossiped.
+	gossips    int
+	nextGossip time.Time
+}
+
+// Add queues [gossipables] to be sent on the next call to [Gossip].
+// Items that were already added are ignored.
+func (p *PushGossiper[T]) Add(gossipables ...T) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	for _, gossipable := range gossipables {
+		id := gossipable.GetID()
+		if _, ok := p.tracked[id]; ok {
+			continue
+		}
+		if _, ok := p.discarded.Get(id); ok {
+			continue
+		}
+
+		item := &pushedGossipable[T]{
+			gossipable: gossipable,
+		}
+		p.tracked[id] = item
+		p.pending = append(p.pending, item)
+	}
+}
+
+// Gossip sends the items that haven't been gossiped yet and the items that
+// are due to be regossiped.
+func (p *PushGossiper[T]) Gossip(ctx context.Context) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	now := p.clock.Time()
+	toGossip := p.pending
+	p.pending = nil
+	for {
+		item, ok := p.regossipQueue.Peek()
+		if !ok || item.nextGossip.After(now) {
+			break
+		}
+		_, _ = p.regossipQueue.Pop()
+		toGossip = append(toGossip, item)
+	}
+
+	var (
+		batch      []*pushedGossipable[T]
+		gossip     [][]byte
+		gossipSize int
+	)
+	for i, item := range toGossip {
+		bytes, err := item.gossipable.Marshal()
+		if err != nil {
+			id := item.gossipable.GetID()
+			p.log.Debug(
+				"failed to marshal gossip",
+				zap.Stringer("id", id),
+				zap.Error(err),
+			)
+			delete(p.tracked, id)
+			continue
+		}
+
+		batch = append(batch, item)
+		gossip = append(gossip, bytes)
+		gossipSize += len(bytes)
+		if gossipSize < p.config.TargetGossipSize {
+			continue
+		}
+
+		if err := p.sendBatch(ctx, batch, gossip, gossipSize, now); err != nil {
+			// The items that weren't attempted are sent on the next call.
+			p.pending = append(p.pending, toGossip[i+1:]...)
+			return err
+		}
+		batch = nil
+		gossip = nil
+		gossipSize = 0
+	}
+
+	if len(batch) == 0 {
+		return nil
+	}
+	return p.sendBatch(ctx, batch, gossip, gossipSize, now)
+}
+
+// sendBatch sends [gossip], the marshalled [batch], and schedules the items of
+// [batch] to be regossiped. If [gossip] couldn't be sent, the items of [batch]
+// are added back to the pending items so that they are sent on the next call
+// to [Gossip].
+//
+// Assumes [p.lock] is held.
+func (p *PushGossiper[T]) sendBatch(
+	ctx context.Context,
+	batch []*pushedGossipable[T],
+	gossip [][]byte,
+	gossipSize int,
+	now time.Time,
+) error {
+	if err := p.send(ctx, gossip, gossipSize); err != nil {
+		p.pending = append(p.pending, batch...)
+		return err
+	}
+	for _, item := range batch {
+		p.schedule(item, now)
+	}
+	return nil
+}
+
+// schedule [item] to be regossiped, or discards it if it was regossiped
+// [MaxRegossips] times.
+//
+// Assumes [p.lock] is held.
+func (p *PushGossiper[T]) schedule(item *pushedGossipable[T], now time.Time) {
+	item.gossips++
+	if item.gossips > p.config.MaxRegossips {
+		id := item.gossipable.GetID()
+		delete(p.tracked, id)
+		p.discarded.Put(id, struct{}{})
+		return
+	}
+
+	item.nextGossip = now.Add(p.config.RegossipFrequency << (item.gossips - 1))
+	p.regossipQueue.Push(item)
+}
+
+// send [gossip] to a sample of validators and non-validators.
+func (p *PushGossiper[T]) send(ctx context.Context, gossip [][]byte, gossipSize int) error {
+	msgBytes, err := proto.Marshal(&sdk.PushGossip{
+		Gossip: gossip,
+	})
+	if err != nil {
+		return err
+	}
+
+	nodeIDs := set.NewSet[ids.NodeID](p.config.ValidatorFanout + p.config.NonValidatorFanout)
+	nodeIDs.Add(p.validators.Sample(ctx, p.config.ValidatorFanout)...)
+
+	if p.config.NonValidatorFanout > 0 {
+		// The peers are sampled in a random order, so the first
+		// non-validators are a uniform sample of the connected non-validators.
+		numNonValidators := 0
+		for _, nodeID := range p.peers.Sample(ctx, math.MaxInt) {
+			if numNonValidators >= p.config.NonValidatorFanout {
+				break
+			}
+			if p.validators.Has(ctx, nodeID) {
+				continue
+			}
+			nodeIDs.Add(nodeID)
+			numNonValidators++
+		}
+	}
+
+	if err := p.client.AppGossipSpecific(ctx, nodeIDs, msgBytes); err != nil {
+		return err
+	}
+
+	p.sentN.Add(float64(len(gossip)))
+	p.sentBytes.Add(float64(gossipSize))
+	return nil
+}
+
 // Every calls [Gossip] every [frequency] amount of time.
 func Every(ctx context.Context, log logging.Logger, gossiper Gossiper, frequency time.Duration) {
 	ticker := time.NewTicker(frequency)
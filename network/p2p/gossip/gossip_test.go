@@ -5,6 +5,7 @@ package gossip
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"testing"
 	"time"
@@ -18,11 +19,14 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/network/p2p"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/set"
 )
 
 var (
+	errTestSend = errors.New("test send failed")
+
 	_ p2p.ValidatorSet = (*testValidatorSet)(nil)
 	_ Gossiper         = (*testGossiper)(nil)
 )
@@ -265,3 +269,206 @@ type testValidatorSet struct {
 func (t testValidatorSet) Has(_ context.Context, nodeID ids.NodeID) bool {
 	return t.validators.Contains(nodeID)
 }
+
+func TestPushGossiper(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	validatorID := ids.GenerateTestNodeID()
+	nonValidatorID := ids.GenerateTestNodeID()
+	validatorState := &validators.TestState{
+		GetCurrentHeightF: func(context.Context) (uint64, error) {
+			return 1, nil
+		},
+		GetValidatorSetF: func(context.Context, uint64, ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+			return map[ids.NodeID]*validators.GetValidatorOutput{
+				validatorID: {NodeID: validatorID},
+			}, nil
+		},
+	}
+	peers := &p2p.Peers{}
+	require.NoError(peers.Connected(context.Background(), validatorID, nil))
+	require.NoError(peers.Connected(context.Background(), nonValidatorID, nil))
+
+	// Pushed gossip is delivered to the receiver's handler.
+	receiveBloom, err := NewBloomFilter(1000, 0.01)
+	require.NoError(err)
+	receiveSet := testSet{
+		set:   set.Set[*testTx]{},
+		bloom: receiveBloom,
+	}
+	receiveRouter := p2p.NewRouter(logging.NoLog{}, common.NewMockSender(ctrl), prometheus.NewRegistry(), "")
+	handler, err := NewPushHandler[testTx, *testTx](logging.NoLog{}, receiveSet, HandlerConfig{}, prometheus.NewRegistry())
+	require.NoError(err)
+	_, err = receiveRouter.RegisterAppProtocol(0x0, handler, peers)
+	require.NoError(err)
+
+	var sentTo []set.Set[ids.NodeID]
+	sender := common.NewMockSender(ctrl)
+	sender.EXPECT().SendAppGossipSpecific(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, nodeIDs set.Set[ids.NodeID], gossipBytes []byte) error {
+			sentTo = append(sentTo, nodeIDs)
+			return receiveRouter.AppGossip(ctx, validatorID, gossipBytes)
+		}).AnyTimes()
+	router := p2p.NewRouter(logging.NoLog{}, sender, prometheus.NewRegistry(), "")
+	client, err := router.RegisterAppProtocol(0x0, nil, peers)
+	require.NoError(err)
+
+	const regossipFrequency = time.Minute
+	gossiper, err := NewPushGossiper[*testTx](
+		PushGossiperConfig{
+			ValidatorFanout:    1,
+			NonValidatorFanout: 1,
+			TargetGossipSize:   ids.IDLen,
+			RegossipFrequency:  regossipFrequency,
+			MaxRegossips:       2,
+			DiscardedCacheSize: 10,
+		},
+		logging.NoLog{},
+		client,
+		p2p.NewValidators(logging.NoLog{}, ids.Empty, validatorState, time.Hour),
+		peers,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+	now := time.Now()
+	gossiper.clock.Set(now)
+
+	// Nothing is sent if nothing was added.
+	require.NoError(gossiper.Gossip(context.Background()))
+	require.Empty(sentTo)
+
+	// Added items are sent as soon as possible. Duplicates are only sent
+	// once, and each message targets [TargetGossipSize].
+	tx0 := &testTx{id: ids.ID{0}}
+	tx1 := &testTx{id: ids.ID{1}}
+	gossiper.Add(tx0, tx1, tx0)
+	require.NoError(gossiper.Gossip(context.Background()))
+	require.Len(sentTo, 2)
+	for _, nodeIDs := range sentTo {
+		require.Equal(set.Of(validatorID, nonValidatorID), nodeIDs)
+	}
+	require.Len(receiveSet.set, 2)
+	require.True(receiveBloom.Has(tx0))
+	require.True(receiveBloom.Has(tx1))
+
+	// Items aren't sent again until they are due to be regossiped.
+	sentTo = nil
+	gossiper.Add(tx0)
+	require.NoError(gossiper.Gossip(context.Background()))
+	require.Empty(sentTo)
+
+	// The wait before regossiping doubles after every regossip.
+	waits := []time.Duration{regossipFrequency, 2 * regossipFrequency}
+	for _, wait := range waits {
+		now = now.Add(wait - time.Second)
+		gossiper.clock.Set(now)
+		require.NoError(gossiper.Gossip(context.Background()))
+		require.Empty(sentTo)
+
+		now = now.Add(time.Second)
+		gossiper.clock.Set(now)
+		require.NoError(gossiper.Gossip(context.Background()))
+		require.Len(sentTo, 2)
+		sentTo = nil
+	}
+
+	// Items are discarded after [MaxRegossips] regossips, and aren't
+	// gossiped again if they are re-added.
+	now = now.Add(time.Hour)
+	gossiper.clock.Set(now)
+	gossiper.Add(tx0)
+	require.NoError(gossiper.Gossip(context.Background()))
+	require.Empty(sentTo)
+	require.Empty(gossiper.tracked)
+}
+
+// Test that items aren't scheduled to be regossiped unless they were sent, and
+// that the items that weren't sent are retried on the next call to Gossip.
+func TestPushGossiperSendFailure(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	validatorID := ids.GenerateTestNodeID()
+	validatorState := &validators.TestState{
+		GetCurrentHeightF: func(context.Context) (uint64, error) {
+			return 1, nil
+		},
+		GetValidatorSetF: func(context.Context, uint64, ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+			return map[ids.NodeID]*validators.GetValidatorOutput{
+				validatorID: {NodeID: validatorID},
+			}, nil
+		},
+	}
+	peers := &p2p.Peers{}
+	require.NoError(peers.Connected(context.Background(), validatorID, nil))
+
+	var (
+		failSends bool
+		numSent   int
+	)
+	sender := common.NewMockSender(ctrl)
+	sender.EXPECT().SendAppGossipSpecific(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(context.Context, set.Set[ids.NodeID], []byte) error {
+			if failSends {
+				return errTestSend
+			}
+			numSent++
+			return nil
+		}).AnyTimes()
+	router := p2p.NewRouter(logging.NoLog{}, sender, prometheus.NewRegistry(), "")
+	client, err := router.RegisterAppProtocol(0x0, nil, peers)
+	require.NoError(err)
+
+	const regossipFrequency = time.Minute
+	gossiper, err := NewPushGossiper[*testTx](
+		PushGossiperConfig{
+			ValidatorFanout:    1,
+			TargetGossipSize:   ids.IDLen,
+			RegossipFrequency:  regossipFrequency,
+			MaxRegossips:       1,
+			DiscardedCacheSize: 10,
+		},
+		logging.NoLog{},
+		client,
+		p2p.NewValidators(logging.NoLog{}, ids.Empty, validatorState, time.Hour),
+		peers,
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+	now := time.Now()
+	gossiper.clock.Set(now)
+
+	// The first send fails, so neither item is scheduled to be regossiped and
+	// both are kept to be sent again.
+	failSends = true
+	gossiper.Add(&testTx{id: ids.ID{0}}, &testTx{id: ids.ID{1}})
+	err = gossiper.Gossip(context.Background())
+	require.ErrorIs(err, errTestSend)
+	require.Len(gossiper.pending, 2)
+	require.Zero(gossiper.regossipQueue.Len())
+
+	// Once sending succeeds, both items are sent and scheduled.
+	failSends = false
+	require.NoError(gossiper.Gossip(context.Background()))
+	require.Equal(2, numSent)
+	require.Empty(gossiper.pending)
+	require.Equal(2, gossiper.regossipQueue.Len())
+
+	// A failed regossip doesn't count towards [MaxRegossips].
+	now = now.Add(regossipFrequency)
+	gossiper.clock.Set(now)
+	failSends = true
+	err = gossiper.Gossip(context.Background())
+	require.ErrorIs(err, errTestSend)
+	require.Len(gossiper.pending, 2)
+
+	failSends = false
+	require.NoError(gossiper.Gossip(context.Background()))
+	require.Equal(4, numSent)
+
+	// Both items were regossiped [MaxRegossips] times, so they are discarded.
+	require.Empty(gossiper.pending)
+	require.Zero(gossiper.regossipQueue.Len())
+	require.Empty(gossiper.tracked)
+}
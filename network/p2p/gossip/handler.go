@@ -12,16 +12,20 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"go.uber.org/zap"
+
 	"google.golang.org/protobuf/proto"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/network/p2p"
 	"github.com/ava-labs/avalanchego/proto/pb/sdk"
+	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
 
 var (
 	_ p2p.Handler = (*Handler[Gossipable])(nil)
+	_ p2p.Handler = (*PushHandler[testTx, *testTx])(nil)
 
 	ErrInvalidID = errors.New("invalid id")
 )
@@ -124,3 +128,84 @@ func (h Handler[T]) AppRequest(_ context.Context, _ ids.NodeID, _ time.Time, req
 
 	return proto.Marshal(response)
 }
+
+func NewPushHandler[T any, U GossipableAny[T]](
+	log logging.Logger,
+	set Set[U],
+	config HandlerConfig,
+	metrics prometheus.Registerer,
+) (*PushHandler[T, U], error) {
+	handler, err := NewHandler[U](set, config, metrics)
+	if err != nil {
+		return nil, err
+	}
+
+	h := &PushHandler[T, U]{
+		Handler: handler,
+		log:     log,
+		receivedN: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: config.Namespace,
+			Name:      "gossip_push_received_n",
+			Help:      "amount of pushed gossip received (n)",
+		}),
+		receivedBytes: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: config.Namespace,
+			Name:      "gossip_push_received_bytes",
+			Help:      "amount of pushed gossip received (bytes)",
+		}),
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		metrics.Register(h.receivedN),
+		metrics.Register(h.receivedBytes),
+	)
+
+	return h, errs.Err
+}
+
+// PushHandler serves pull gossip requests like [Handler] and adds the gossip
+// pushed by peers to the same set.
+type PushHandler[T any, U GossipableAny[T]] struct {
+	*Handler[U]
+	log logging.Logger
+
+	receivedN     prometheus.Counter
+	receivedBytes prometheus.Counter
+}
+
+func (h *PushHandler[T, U]) AppGossip(_ context.Context, nodeID ids.NodeID, gossipBytes []byte) error {
+	msg := &sdk.PushGossip{}
+	if err := proto.Unmarshal(gossipBytes, msg); err != nil {
+		return err
+	}
+
+	receivedBytes := 0
+	for _, bytes := range msg.Gossip {
+		receivedBytes += len(bytes)
+
+		gossipable := U(new(T))
+		if err := gossipable.Unmarshal(bytes); err != nil {
+			h.log.Debug(
+				"failed to unmarshal pushed gossip",
+				zap.Stringer("nodeID", nodeID),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		if err := h.set.Add(gossipable); err != nil {
+			h.log.Debug(
+				"failed to add pushed gossip to the known set",
+				zap.Stringer("nodeID", nodeID),
+				zap.Stringer("id", gossipable.GetID()),
+				zap.Error(err),
+			)
+			continue
+		}
+	}
+
+	h.receivedN.Add(float64(len(msg.Gossip)))
+	h.receivedBytes.Add(float64(receivedBytes))
+	return nil
+}
@@ -0,0 +1,250 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p2p
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	stdmath "math"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+const (
+	peerStatsHalflife = 5 * time.Minute
+
+	// responseOverhead is added to the size of each response when estimating
+	// the bandwidth of a peer so that peers sending small responses are still
+	// ranked by their latency.
+	responseOverhead = units.KiB
+
+	// minWeightFactor is the fraction of the mean weight of the measured peers
+	// that every peer is given, so that poorly performing peers are still
+	// explored occasionally.
+	minWeightFactor = 0.1
+)
+
+var (
+	_ validators.Connector = (*PeerTracker)(nil)
+	_ NodeSampler          = (*PeerTracker)(nil)
+	_ ResponseTracker      = (*PeerTracker)(nil)
+)
+
+// ResponseTracker is notified of the outcome of the AppRequests sent by a
+// Client. If the NodeSampler of a Client implements ResponseTracker, it is
+// notified of every response to or failure of the Client's AppRequests.
+type ResponseTracker interface {
+	// RegisterResponse is called when [nodeID] responded to a request with
+	// [responseSize] bytes, [latency] after the request was sent.
+	RegisterResponse(nodeID ids.NodeID, latency time.Duration, responseSize int)
+	// RegisterFailure is called when a request sent to [nodeID] failed.
+	RegisterFailure(nodeID ids.NodeID)
+}
+
+// PeerStats are the performance statistics tracked for a peer. Each statistic
+// is an exponential moving average.
+type PeerStats struct {
+	// Latency is the average time it took the peer to respond to a request.
+	Latency time.Duration
+	// FailureRate is the fraction of requests the peer failed to respond to.
+	FailureRate float64
+	// Bandwidth is the average rate, in bytes per second, at which the peer
+	// responded to requests.
+	Bandwidth float64
+}
+
+type peerStats struct {
+	// nil until the peer responded to a request
+	latency   math.Averager
+	bandwidth math.Averager
+	// nil until the peer responded to or failed a request
+	failureRate math.Averager
+}
+
+// measured returns true if the peer was sent a request that completed.
+func (s *peerStats) measured() bool {
+	return s.failureRate != nil
+}
+
+// weight returns the relative rate at which the peer is expected to
+// successfully serve data.
+// Assumes the peer was measured.
+func (s *peerStats) weight() float64 {
+	if s.bandwidth == nil {
+		return 0
+	}
+	return (1 - s.failureRate.Read()) * s.bandwidth.Read()
+}
+
+func (s *peerStats) observeFailure(failed float64, now time.Time) {
+	if s.failureRate == nil {
+		s.failureRate = math.NewAverager(failed, peerStatsHalflife, now)
+	} else {
+		s.failureRate.Observe(failed, now)
+	}
+}
+
+type sampledPeer struct {
+	nodeID ids.NodeID
+	key    float64
+}
+
+// PeerTracker contains a set of nodes that we are connected to and tracks the
+// latency, failure rate and bandwidth of their responses.
+//
+// Nodes are sampled with a probability proportional to the rate at which they
+// are expected to successfully serve data. Nodes that haven't been measured
+// yet are given the mean weight of the measured nodes and poorly performing
+// nodes are given a minimum weight, so that all nodes are explored.
+type PeerTracker struct {
+	lock  sync.RWMutex
+	peers map[ids.NodeID]*peerStats
+}
+
+func NewPeerTracker() *PeerTracker {
+	return &PeerTracker{
+		peers: make(map[ids.NodeID]*peerStats),
+	}
+}
+
+func (p *PeerTracker) Connected(_ context.Context, nodeID ids.NodeID, _ *version.Application) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if _, ok := p.peers[nodeID]; !ok {
+		p.peers[nodeID] = &peerStats{}
+	}
+	return nil
+}
+
+func (p *PeerTracker) Disconnected(_ context.Context, nodeID ids.NodeID) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	delete(p.peers, nodeID)
+	return nil
+}
+
+func (p *PeerTracker) RegisterResponse(nodeID ids.NodeID, latency time.Duration, responseSize int) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	peer, ok := p.peers[nodeID]
+	if !ok {
+		// we're not connected to this peer, nothing to do here
+		return
+	}
+
+	var (
+		now       = time.Now()
+		seconds   = stdmath.Max(latency.Seconds(), stdmath.SmallestNonzeroFloat64)
+		bandwidth = float64(responseSize+responseOverhead) / seconds
+	)
+	if peer.latency == nil {
+		peer.latency = math.NewAverager(float64(latency), peerStatsHalflife, now)
+		peer.bandwidth = math.NewAverager(bandwidth, peerStatsHalflife, now)
+	} else {
+		peer.latency.Observe(float64(latency), now)
+		peer.bandwidth.Observe(bandwidth, now)
+	}
+	peer.observeFailure(0, now)
+}
+
+func (p *PeerTracker) RegisterFailure(nodeID ids.NodeID) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	peer, ok := p.peers[nodeID]
+	if !ok {
+		// we're not connected to this peer, nothing to do here
+		return
+	}
+	peer.observeFailure(1, time.Now())
+}
+
+// Stats returns the statistics of [nodeID]. Returns false if we aren't
+// connected to [nodeID] or it hasn't responded to a request yet.
+func (p *PeerTracker) Stats(nodeID ids.NodeID) (PeerStats, bool) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	peer, ok := p.peers[nodeID]
+	if !ok || peer.latency == nil {
+		return PeerStats{}, false
+	}
+	return PeerStats{
+		Latency:     time.Duration(peer.latency.Read()),
+		FailureRate: peer.failureRate.Read(),
+		Bandwidth:   peer.bandwidth.Read(),
+	}, true
+}
+
+// Sample returns at most [limit] distinct nodes, sampled without replacement
+// with a probability proportional to their weight.
+func (p *PeerTracker) Sample(_ context.Context, limit int) []ids.NodeID {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	if limit <= 0 || len(p.peers) == 0 {
+		return nil
+	}
+
+	var (
+		sampled     = make([]sampledPeer, 0, len(p.peers))
+		totalWeight float64
+		numMeasured int
+	)
+	for nodeID, peer := range p.peers {
+		weight := -1.0 // placeholder for unmeasured peers
+		if peer.measured() {
+			weight = peer.weight()
+			totalWeight += weight
+			numMeasured++
+		}
+		sampled = append(sampled, sampledPeer{
+			nodeID: nodeID,
+			key:    weight,
+		})
+	}
+
+	meanWeight := 1.0
+	if numMeasured > 0 && totalWeight > 0 {
+		meanWeight = totalWeight / float64(numMeasured)
+	}
+	minWeight := minWeightFactor * meanWeight
+
+	// Weighted sampling without replacement: each node is given the key
+	// log(u)/weight, where u is uniform in [0, 1), and the nodes with the
+	// largest keys are returned. See Efraimidis and Spirakis, "Weighted random
+	// sampling with a reservoir".
+	for i := range sampled {
+		weight := sampled[i].key
+		if weight < 0 {
+			weight = meanWeight
+		}
+		weight = stdmath.Max(weight, minWeight)
+		sampled[i].key = stdmath.Log(rand.Float64()) / weight // #nosec G404
+	}
+	slices.SortFunc(sampled, func(a, b sampledPeer) bool {
+		return a.key > b.key
+	})
+
+	if limit > len(sampled) {
+		limit = len(sampled)
+	}
+	nodeIDs := make([]ids.NodeID, limit)
+	for i := range nodeIDs {
+		nodeIDs[i] = sampled[i].nodeID
+	}
+	return nodeIDs
+}
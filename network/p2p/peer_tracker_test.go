@@ -0,0 +1,207 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p2p
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+func TestPeerTrackerSample(t *testing.T) {
+	tests := []struct {
+		name     string
+		numPeers int
+		limit    int
+		expected int
+	}{
+		{
+			name:     "no peers",
+			numPeers: 0,
+			limit:    1,
+			expected: 0,
+		},
+		{
+			name:     "zero limit",
+			numPeers: 3,
+			limit:    0,
+			expected: 0,
+		},
+		{
+			name:     "less than limit peers",
+			numPeers: 3,
+			limit:    4,
+			expected: 3,
+		},
+		{
+			name:     "limit peers",
+			numPeers: 3,
+			limit:    3,
+			expected: 3,
+		},
+		{
+			name:     "more than limit peers",
+			numPeers: 3,
+			limit:    2,
+			expected: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			peers := NewPeerTracker()
+			connected := set.NewSet[ids.NodeID](tt.numPeers)
+			for i := 0; i < tt.numPeers; i++ {
+				nodeID := ids.GenerateTestNodeID()
+				connected.Add(nodeID)
+				require.NoError(peers.Connected(context.Background(), nodeID, nil))
+				// Measured and unmeasured peers should both be sampled.
+				if i%2 == 0 {
+					peers.RegisterResponse(nodeID, time.Second, 1)
+				}
+			}
+
+			sampled := peers.Sample(context.Background(), tt.limit)
+			require.Len(sampled, tt.expected)
+			sampledSet := set.Of(sampled...)
+			require.Len(sampledSet, tt.expected)
+			sampledSet.Difference(connected)
+			require.Empty(sampledSet)
+		})
+	}
+}
+
+// Sample should favor peers that respond quickly and reliably, but still
+// explore the others.
+func TestPeerTrackerSamplePrefersGoodPeers(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		peers       = NewPeerTracker()
+		fastPeer    = ids.GenerateTestNodeID()
+		slowPeer    = ids.GenerateTestNodeID()
+		failingPeer = ids.GenerateTestNodeID()
+	)
+	for _, nodeID := range []ids.NodeID{fastPeer, slowPeer, failingPeer} {
+		require.NoError(peers.Connected(context.Background(), nodeID, nil))
+	}
+	peers.RegisterResponse(fastPeer, time.Millisecond, 1)
+	peers.RegisterResponse(slowPeer, time.Second, 1)
+	peers.RegisterFailure(failingPeer)
+
+	counts := make(map[ids.NodeID]int)
+	for i := 0; i < 1000; i++ {
+		sampled := peers.Sample(context.Background(), 1)
+		require.Len(sampled, 1)
+		counts[sampled[0]]++
+	}
+
+	require.Greater(counts[fastPeer], 800)
+	require.Positive(counts[slowPeer])
+	require.Positive(counts[failingPeer])
+}
+
+func TestPeerTrackerStats(t *testing.T) {
+	require := require.New(t)
+
+	peers := NewPeerTracker()
+	nodeID := ids.GenerateTestNodeID()
+
+	// Responses from peers we aren't connected to are dropped.
+	peers.RegisterResponse(nodeID, time.Second, 1)
+	_, ok := peers.Stats(nodeID)
+	require.False(ok)
+
+	require.NoError(peers.Connected(context.Background(), nodeID, nil))
+	_, ok = peers.Stats(nodeID)
+	require.False(ok)
+
+	peers.RegisterResponse(nodeID, time.Second, 1)
+	stats, ok := peers.Stats(nodeID)
+	require.True(ok)
+	require.Equal(PeerStats{
+		Latency:     time.Second,
+		FailureRate: 0,
+		Bandwidth:   1 + responseOverhead,
+	}, stats)
+
+	peers.RegisterFailure(nodeID)
+	stats, ok = peers.Stats(nodeID)
+	require.True(ok)
+	require.Greater(stats.FailureRate, 0.0)
+	require.Less(stats.FailureRate, 1.0)
+
+	require.NoError(peers.Disconnected(context.Background(), nodeID))
+	_, ok = peers.Stats(nodeID)
+	require.False(ok)
+}
+
+// A Client should report the outcome of its requests to its NodeSampler if it
+// tracks responses.
+func TestPeerTrackerRegistersClientResponses(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	var (
+		respondingPeer = ids.GenerateTestNodeID()
+		failingPeer    = ids.GenerateTestNodeID()
+		response       = []byte("response")
+		sender         = common.NewMockSender(ctrl)
+		router         = NewRouter(logging.NoLog{}, sender, prometheus.NewRegistry(), "")
+		peers          = NewPeerTracker()
+	)
+	require.NoError(peers.Connected(context.Background(), respondingPeer, nil))
+	require.NoError(peers.Connected(context.Background(), failingPeer, nil))
+
+	client, err := router.RegisterAppProtocol(0x0, nil, peers)
+	require.NoError(err)
+
+	// The router's lock is held while sending requests, so responses must be
+	// delivered asynchronously.
+	wg := sync.WaitGroup{}
+	wg.Add(2)
+	sender.EXPECT().SendAppRequest(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Do(func(ctx context.Context, nodeIDs set.Set[ids.NodeID], requestID uint32, _ []byte) {
+			for nodeID := range nodeIDs {
+				nodeID := nodeID
+				go func() {
+					if nodeID == respondingPeer {
+						require.NoError(router.AppResponse(ctx, nodeID, requestID, response))
+					} else {
+						require.NoError(router.AppRequestFailed(ctx, nodeID, requestID))
+					}
+				}()
+			}
+		}).Times(2)
+
+	onResponse := func(context.Context, ids.NodeID, []byte, error) {
+		wg.Done()
+	}
+	require.NoError(client.AppRequest(context.Background(), set.Of(respondingPeer, failingPeer), []byte("request"), onResponse))
+	wg.Wait()
+
+	stats, ok := peers.Stats(respondingPeer)
+	require.True(ok)
+	require.Zero(stats.FailureRate)
+
+	_, ok = peers.Stats(failingPeer)
+	require.False(ok)
+	peers.lock.RLock()
+	require.Equal(1.0, peers.peers[failingPeer].failureRate.Read())
+	peers.lock.RUnlock()
+}
@@ -42,6 +42,9 @@ type metrics struct {
 type pendingAppRequest struct {
 	*metrics
 	AppResponseCallback
+	// responseTracker is notified of the outcome of the request, if non-nil
+	responseTracker ResponseTracker
+	sentAt          time.Time
 }
 
 type pendingCrossChainAppRequest struct {
@@ -189,12 +192,14 @@ func (r *Router) RegisterAppProtocol(handlerID uint64, handler Handler, nodeSamp
 		},
 	}
 
+	responseTracker, _ := nodeSampler.(ResponseTracker)
 	return &Client{
-		handlerID:     handlerID,
-		handlerPrefix: binary.AppendUvarint(nil, handlerID),
-		sender:        r.sender,
-		router:        r,
-		nodeSampler:   nodeSampler,
+		handlerID:       handlerID,
+		handlerPrefix:   binary.AppendUvarint(nil, handlerID),
+		sender:          r.sender,
+		router:          r,
+		nodeSampler:     nodeSampler,
+		responseTracker: responseTracker,
 	}, nil
 }
 
@@ -227,6 +232,9 @@ func (r *Router) AppRequestFailed(ctx context.Context, nodeID ids.NodeID, reques
 		return ErrUnrequestedResponse
 	}
 
+	if pending.responseTracker != nil {
+		pending.responseTracker.RegisterFailure(nodeID)
+	}
 	pending.AppResponseCallback(ctx, nodeID, nil, ErrAppRequestFailed)
 	pending.appRequestFailedTime.Observe(float64(time.Since(start)))
 	return nil
@@ -239,6 +247,9 @@ func (r *Router) AppResponse(ctx context.Context, nodeID ids.NodeID, requestID u
 		return ErrUnrequestedResponse
 	}
 
+	if pending.responseTracker != nil {
+		pending.responseTracker.RegisterResponse(nodeID, start.Sub(pending.sentAt), len(response))
+	}
 	pending.AppResponseCallback(ctx, nodeID, response, nil)
 	pending.appResponseTime.Observe(float64(time.Since(start)))
 	return nil
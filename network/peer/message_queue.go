@@ -5,6 +5,7 @@ package peer
 
 import (
 	"context"
+	"math"
 	"sync"
 
 	"go.uber.org/zap"
@@ -18,11 +19,67 @@ import (
 
 const initialQueueSize = 64
 
+// Outbound message classes, from the highest to the lowest priority.
+const (
+	// ConsensusMessageClass contains the handshake messages and the messages
+	// used while running consensus.
+	ConsensusMessageClass MessageClass = iota
+	// BootstrappingMessageClass contains the messages used during state sync
+	// and bootstrapping.
+	BootstrappingMessageClass
+	// ApplicationMessageClass contains the VM-defined messages.
+	ApplicationMessageClass
+
+	numMessageClasses = iota
+)
+
 var (
 	_ MessageQueue = (*throttledMessageQueue)(nil)
+	_ MessageQueue = (*prioritizedMessageQueue)(nil)
 	_ MessageQueue = (*blockingMessageQueue)(nil)
 )
 
+// MessageClass is the priority class of an outbound message.
+type MessageClass int
+
+// ClassifyMessage returns the class of messages with the [op] opcode.
+func ClassifyMessage(op message.Op) MessageClass {
+	switch op {
+	case message.GetStateSummaryFrontierOp,
+		message.StateSummaryFrontierOp,
+		message.GetAcceptedStateSummaryOp,
+		message.AcceptedStateSummaryOp,
+		message.GetAcceptedFrontierOp,
+		message.AcceptedFrontierOp,
+		message.GetAcceptedOp,
+		message.AcceptedOp,
+		message.GetAncestorsOp,
+		message.AncestorsOp:
+		return BootstrappingMessageClass
+	case message.AppRequestOp,
+		message.AppResponseOp,
+		message.AppGossipOp,
+		message.CrossChainAppRequestOp,
+		message.CrossChainAppResponseOp:
+		return ApplicationMessageClass
+	default:
+		return ConsensusMessageClass
+	}
+}
+
+func (c MessageClass) String() string {
+	switch c {
+	case ConsensusMessageClass:
+		return "consensus"
+	case BootstrappingMessageClass:
+		return "bootstrapping"
+	case ApplicationMessageClass:
+		return "application"
+	default:
+		return "unknown"
+	}
+}
+
 type SendFailedCallback interface {
 	SendFailed(message.OutboundMessage)
 }
@@ -194,6 +251,269 @@ func (q *throttledMessageQueue) Close() {
 	q.cond.Broadcast()
 }
 
+type MessageClassConfig struct {
+	// Weight is the relative share of the outbound bandwidth given to the
+	// class when classes are served by weighted priority. A weight of 0 is
+	// treated as a weight of 1.
+	Weight uint64 `json:"weight"`
+
+	// MaxBytes is the maximum number of bytes of messages of the class that
+	// may be queued. Messages pushed beyond this limit are dropped. If 0,
+	// there is no limit.
+	MaxBytes uint64 `json:"maxBytes"`
+}
+
+type PrioritizedMessageQueueConfig struct {
+	// StrictPriority, if true, always sends the messages of the highest
+	// priority class before the messages of lower priority classes.
+	// Otherwise, the bandwidth is shared between the classes by their weight.
+	StrictPriority bool `json:"strictPriority"`
+
+	Consensus     MessageClassConfig `json:"consensus"`
+	Bootstrapping MessageClassConfig `json:"bootstrapping"`
+	Application   MessageClassConfig `json:"application"`
+}
+
+type messageClassQueue struct {
+	class    MessageClass
+	weight   float64
+	maxBytes uint64
+
+	// queue of the messages of this class and the number of bytes in it
+	queue buffer.Deque[message.OutboundMessage]
+	bytes uint64
+
+	// virtual time at which the next message of this class starts being
+	// served, used to share the bandwidth between classes by their weight.
+	// See "Start-time fair queuing" by Goyal, Vin and Cheng.
+	start float64
+}
+
+type prioritizedMessageQueue struct {
+	onFailed SendFailedCallback
+	// [id] of the peer we're sending messages to
+	id                   ids.NodeID
+	log                  logging.Logger
+	outboundMsgThrottler throttling.OutboundMsgThrottler
+	metrics              *MessageQueueMetrics
+	strictPriority       bool
+
+	// Signalled when a message is added to the queue and when Close() is
+	// called.
+	cond *sync.Cond
+
+	// closed flags whether the send queue has been closed.
+	// [cond.L] must be held while accessing [closed].
+	closed bool
+
+	// queues of the messages of each class, from the highest to the lowest
+	// priority.
+	// [cond.L] must be held while accessing [classes] or [virtualTime].
+	classes [numMessageClasses]*messageClassQueue
+	// start time of the last message that was popped
+	virtualTime float64
+}
+
+// NewPrioritizedMessageQueue returns a queue that sends the messages of each
+// MessageClass in order, but that prioritizes messages across classes as
+// described by [config], so that bursts of large low priority messages don't
+// delay consensus messages.
+func NewPrioritizedMessageQueue(
+	onFailed SendFailedCallback,
+	id ids.NodeID,
+	log logging.Logger,
+	outboundMsgThrottler throttling.OutboundMsgThrottler,
+	metrics *MessageQueueMetrics,
+	config PrioritizedMessageQueueConfig,
+) MessageQueue {
+	q := &prioritizedMessageQueue{
+		onFailed:             onFailed,
+		id:                   id,
+		log:                  log,
+		outboundMsgThrottler: outboundMsgThrottler,
+		metrics:              metrics,
+		strictPriority:       config.StrictPriority,
+		cond:                 sync.NewCond(&sync.Mutex{}),
+	}
+	classConfigs := [numMessageClasses]MessageClassConfig{
+		ConsensusMessageClass:     config.Consensus,
+		BootstrappingMessageClass: config.Bootstrapping,
+		ApplicationMessageClass:   config.Application,
+	}
+	for i, classConfig := range classConfigs {
+		weight := classConfig.Weight
+		if weight == 0 {
+			weight = 1
+		}
+		q.classes[i] = &messageClassQueue{
+			class:    MessageClass(i),
+			weight:   float64(weight),
+			maxBytes: classConfig.MaxBytes,
+			queue:    buffer.NewUnboundedDeque[message.OutboundMessage](initialQueueSize),
+		}
+	}
+	return q
+}
+
+func (q *prioritizedMessageQueue) Push(ctx context.Context, msg message.OutboundMessage) bool {
+	if err := ctx.Err(); err != nil {
+		q.log.Debug(
+			"dropping outgoing message",
+			zap.Stringer("messageOp", msg.Op()),
+			zap.Stringer("nodeID", q.id),
+			zap.Error(err),
+		)
+		q.onFailed.SendFailed(msg)
+		return false
+	}
+
+	// Acquire space on the outbound message queue, or drop [msg] if we can't.
+	if !q.outboundMsgThrottler.Acquire(msg, q.id) {
+		q.log.Debug(
+			"dropping outgoing message",
+			zap.String("reason", "rate-limiting"),
+			zap.Stringer("messageOp", msg.Op()),
+			zap.Stringer("nodeID", q.id),
+		)
+		q.onFailed.SendFailed(msg)
+		return false
+	}
+
+	// Invariant: must call q.outboundMsgThrottler.Release(msg, q.id) when [msg]
+	// is popped or, if this queue closes before [msg] is popped, when this
+	// queue closes.
+
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	if q.closed {
+		q.log.Debug(
+			"dropping outgoing message",
+			zap.String("reason", "closed queue"),
+			zap.Stringer("messageOp", msg.Op()),
+			zap.Stringer("nodeID", q.id),
+		)
+		q.outboundMsgThrottler.Release(msg, q.id)
+		q.onFailed.SendFailed(msg)
+		return false
+	}
+
+	class := q.classes[ClassifyMessage(msg.Op())]
+	msgLen := uint64(len(msg.Bytes()))
+	if class.maxBytes != 0 && class.bytes+msgLen > class.maxBytes {
+		q.log.Debug(
+			"dropping outgoing message",
+			zap.String("reason", "class queue full"),
+			zap.Stringer("messageOp", msg.Op()),
+			zap.Stringer("messageClass", class.class),
+			zap.Stringer("nodeID", q.id),
+		)
+		q.metrics.Dropped(class.class)
+		q.outboundMsgThrottler.Release(msg, q.id)
+		q.onFailed.SendFailed(msg)
+		return false
+	}
+
+	if class.queue.Len() == 0 {
+		// An idle class doesn't accumulate credit while it isn't sending.
+		class.start = math.Max(class.start, q.virtualTime)
+	}
+	class.queue.PushRight(msg)
+	class.bytes += msgLen
+	q.metrics.Pushed(class.class, msgLen)
+	q.cond.Signal()
+	return true
+}
+
+func (q *prioritizedMessageQueue) Pop() (message.OutboundMessage, bool) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	for {
+		if q.closed {
+			return nil, false
+		}
+		if class := q.nextClass(); class != nil {
+			return q.pop(class), true
+		}
+		// Wait until there is a message
+		q.cond.Wait()
+	}
+}
+
+func (q *prioritizedMessageQueue) PopNow() (message.OutboundMessage, bool) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	if q.closed {
+		return nil, false
+	}
+
+	class := q.nextClass()
+	if class == nil {
+		// There isn't a message
+		return nil, false
+	}
+	return q.pop(class), true
+}
+
+// nextClass returns the class to send the next message from, or nil if there
+// are no messages.
+// Assumes [q.cond.L] is held.
+func (q *prioritizedMessageQueue) nextClass() *messageClassQueue {
+	var next *messageClassQueue
+	for _, class := range q.classes {
+		if class.queue.Len() == 0 {
+			continue
+		}
+		if q.strictPriority {
+			return class
+		}
+		if next == nil || class.start < next.start {
+			next = class
+		}
+	}
+	return next
+}
+
+// Assumes [q.cond.L] is held and [class] isn't empty.
+func (q *prioritizedMessageQueue) pop(class *messageClassQueue) message.OutboundMessage {
+	msg, _ := class.queue.PopLeft()
+	msgLen := uint64(len(msg.Bytes()))
+	class.bytes -= msgLen
+	q.metrics.Popped(class.class, msgLen)
+
+	q.virtualTime = class.start
+	class.start += float64(msgLen) / class.weight
+
+	q.outboundMsgThrottler.Release(msg, q.id)
+	return msg
+}
+
+func (q *prioritizedMessageQueue) Close() {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	if q.closed {
+		return
+	}
+
+	q.closed = true
+
+	for _, class := range q.classes {
+		for class.queue.Len() > 0 {
+			msg, _ := class.queue.PopLeft()
+			q.metrics.Popped(class.class, uint64(len(msg.Bytes())))
+			q.outboundMsgThrottler.Release(msg, q.id)
+			q.onFailed.SendFailed(msg)
+		}
+		class.queue = nil
+		class.bytes = 0
+	}
+
+	q.cond.Broadcast()
+}
+
 type blockingMessageQueue struct {
 	onFailed SendFailedCallback
 	log      logging.Logger
@@ -7,12 +7,18 @@ import (
 	"context"
 	"testing"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
 	"github.com/stretchr/testify/require"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/proto/pb/p2p"
+	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/units"
 )
 
 func TestMessageQueue(t *testing.T) {
@@ -87,3 +93,244 @@ func TestMessageQueue(t *testing.T) {
 	_, ok = q.Pop()
 	require.False(ok)
 }
+
+type prioritizedMessageQueueTest struct {
+	mc      message.Creator
+	queue   MessageQueue
+	metrics *MessageQueueMetrics
+	failed  []message.OutboundMessage
+}
+
+func newPrioritizedMessageQueueTest(t *testing.T, config PrioritizedMessageQueueConfig) *prioritizedMessageQueueTest {
+	require := require.New(t)
+
+	metrics, err := NewMessageQueueMetrics("", prometheus.NewRegistry())
+	require.NoError(err)
+
+	test := &prioritizedMessageQueueTest{
+		mc:      newMessageCreator(t),
+		metrics: metrics,
+	}
+	test.queue = NewPrioritizedMessageQueue(
+		SendFailedFunc(func(msg message.OutboundMessage) {
+			test.failed = append(test.failed, msg)
+		}),
+		ids.GenerateTestNodeID(),
+		logging.NoLog{},
+		throttling.NewNoOutboundThrottler(),
+		metrics,
+		config,
+	)
+	return test
+}
+
+func (q *prioritizedMessageQueueTest) chits(t *testing.T) message.OutboundMessage {
+	msg, err := q.mc.Chits(ids.GenerateTestID(), 0, ids.GenerateTestID(), ids.GenerateTestID(), ids.GenerateTestID())
+	require.NoError(t, err)
+	return msg
+}
+
+func (q *prioritizedMessageQueueTest) ancestors(t *testing.T, size int) message.OutboundMessage {
+	msg, err := q.mc.Ancestors(ids.GenerateTestID(), 0, [][]byte{utils.RandomBytes(size)})
+	require.NoError(t, err)
+	return msg
+}
+
+func (q *prioritizedMessageQueueTest) appGossip(t *testing.T, size int) message.OutboundMessage {
+	msg, err := q.mc.AppGossip(ids.GenerateTestID(), utils.RandomBytes(size))
+	require.NoError(t, err)
+	return msg
+}
+
+func (q *prioritizedMessageQueueTest) push(t *testing.T, msgs ...message.OutboundMessage) {
+	for _, msg := range msgs {
+		require.True(t, q.queue.Push(context.Background(), msg))
+	}
+}
+
+func (q *prioritizedMessageQueueTest) popAll(t *testing.T) []message.OutboundMessage {
+	var msgs []message.OutboundMessage
+	for {
+		msg, ok := q.queue.PopNow()
+		if !ok {
+			return msgs
+		}
+		msgs = append(msgs, msg)
+	}
+}
+
+func TestClassifyMessage(t *testing.T) {
+	tests := []struct {
+		op    message.Op
+		class MessageClass
+	}{
+		{op: message.PingOp, class: ConsensusMessageClass},
+		{op: message.PeerListOp, class: ConsensusMessageClass},
+		{op: message.PushQueryOp, class: ConsensusMessageClass},
+		{op: message.ChitsOp, class: ConsensusMessageClass},
+		{op: message.PutOp, class: ConsensusMessageClass},
+		{op: message.StateSummaryFrontierOp, class: BootstrappingMessageClass},
+		{op: message.GetAcceptedFrontierOp, class: BootstrappingMessageClass},
+		{op: message.AncestorsOp, class: BootstrappingMessageClass},
+		{op: message.AppRequestOp, class: ApplicationMessageClass},
+		{op: message.AppGossipOp, class: ApplicationMessageClass},
+		{op: message.CrossChainAppResponseOp, class: ApplicationMessageClass},
+	}
+	for _, test := range tests {
+		t.Run(test.op.String(), func(t *testing.T) {
+			require.Equal(t, test.class, ClassifyMessage(test.op))
+		})
+	}
+}
+
+func TestPrioritizedMessageQueueStrictPriority(t *testing.T) {
+	require := require.New(t)
+
+	q := newPrioritizedMessageQueueTest(t, PrioritizedMessageQueueConfig{
+		StrictPriority: true,
+	})
+
+	gossip0 := q.appGossip(t, units.KiB)
+	gossip1 := q.appGossip(t, units.KiB)
+	ancestors := q.ancestors(t, units.KiB)
+	chits := q.chits(t)
+	q.push(t, gossip0, ancestors, gossip1, chits)
+
+	require.Equal(
+		[]message.OutboundMessage{chits, ancestors, gossip0, gossip1},
+		q.popAll(t),
+	)
+}
+
+// A burst of large application messages shouldn't delay consensus messages
+// pushed after it.
+func TestPrioritizedMessageQueueWeightedPriority(t *testing.T) {
+	require := require.New(t)
+
+	q := newPrioritizedMessageQueueTest(t, PrioritizedMessageQueueConfig{
+		Consensus:   MessageClassConfig{Weight: 4},
+		Application: MessageClassConfig{Weight: 1},
+	})
+
+	gossip0 := q.appGossip(t, 10*units.KiB)
+	gossip1 := q.appGossip(t, 10*units.KiB)
+	gossip2 := q.appGossip(t, 10*units.KiB)
+	chits0 := q.chits(t)
+	chits1 := q.chits(t)
+	chits2 := q.chits(t)
+	q.push(t, gossip0, gossip1, gossip2, chits0, chits1, chits2)
+
+	require.Equal(
+		[]message.OutboundMessage{chits0, gossip0, chits1, chits2, gossip1, gossip2},
+		q.popAll(t),
+	)
+}
+
+// Backlogged classes should share the bandwidth by their weight.
+func TestPrioritizedMessageQueueWeightedBandwidth(t *testing.T) {
+	require := require.New(t)
+
+	q := newPrioritizedMessageQueueTest(t, PrioritizedMessageQueueConfig{
+		Bootstrapping: MessageClassConfig{Weight: 2},
+		Application:   MessageClassConfig{Weight: 1},
+	})
+
+	for i := 0; i < 100; i++ {
+		q.push(t, q.ancestors(t, units.KiB), q.appGossip(t, units.KiB))
+	}
+
+	numPopped := make(map[MessageClass]int)
+	for i := 0; i < 60; i++ {
+		msg, ok := q.queue.PopNow()
+		require.True(ok)
+		numPopped[ClassifyMessage(msg.Op())]++
+	}
+	require.InDelta(40, numPopped[BootstrappingMessageClass], 2)
+	require.InDelta(20, numPopped[ApplicationMessageClass], 2)
+}
+
+func TestPrioritizedMessageQueueMaxBytes(t *testing.T) {
+	require := require.New(t)
+
+	q := newPrioritizedMessageQueueTest(t, PrioritizedMessageQueueConfig{})
+	gossip0 := q.appGossip(t, units.KiB)
+	gossip1 := q.appGossip(t, units.KiB)
+
+	q = newPrioritizedMessageQueueTest(t, PrioritizedMessageQueueConfig{
+		Application: MessageClassConfig{
+			MaxBytes: uint64(len(gossip0.Bytes()) + len(gossip1.Bytes()) - 1),
+		},
+	})
+
+	chits := q.chits(t)
+	q.push(t, gossip0, chits)
+	require.False(q.queue.Push(context.Background(), gossip1))
+	require.Equal([]message.OutboundMessage{gossip1}, q.failed)
+
+	require.Equal(1.0, testutil.ToFloat64(q.metrics.queuedMessages[ConsensusMessageClass]))
+	require.Equal(1.0, testutil.ToFloat64(q.metrics.queuedMessages[ApplicationMessageClass]))
+	require.Equal(float64(len(gossip0.Bytes())), testutil.ToFloat64(q.metrics.queuedBytes[ApplicationMessageClass]))
+	require.Equal(1.0, testutil.ToFloat64(q.metrics.dropped[ApplicationMessageClass]))
+
+	// Once a message is sent, there is room for another one.
+	require.Len(q.popAll(t), 2)
+	q.push(t, gossip1)
+	require.Equal([]message.OutboundMessage{gossip1}, q.popAll(t))
+}
+
+func TestPrioritizedMessageQueueClose(t *testing.T) {
+	require := require.New(t)
+
+	q := newPrioritizedMessageQueueTest(t, PrioritizedMessageQueueConfig{})
+	gossip := q.appGossip(t, units.KiB)
+	chits := q.chits(t)
+	q.push(t, gossip, chits)
+
+	// Pop blocks until a message is pushed or the queue is closed.
+	popped := make(chan bool)
+	go func() {
+		for {
+			_, ok := q.queue.Pop()
+			if !ok {
+				close(popped)
+				return
+			}
+			popped <- ok
+		}
+	}()
+	require.True(<-popped)
+	require.True(<-popped)
+
+	late := q.chits(t)
+	q.queue.Close()
+	<-popped
+	require.False(q.queue.Push(context.Background(), late))
+	require.Equal([]message.OutboundMessage{late}, q.failed)
+
+	_, ok := q.queue.PopNow()
+	require.False(ok)
+	for class := MessageClass(0); class < numMessageClasses; class++ {
+		require.Zero(testutil.ToFloat64(q.metrics.queuedMessages[class]))
+		require.Zero(testutil.ToFloat64(q.metrics.queuedBytes[class]))
+	}
+}
+
+// Messages pending when the queue is closed should be reported as failed.
+func TestPrioritizedMessageQueueCloseFailsPending(t *testing.T) {
+	require := require.New(t)
+
+	q := newPrioritizedMessageQueueTest(t, PrioritizedMessageQueueConfig{})
+	gossip := q.appGossip(t, units.KiB)
+	chits := q.chits(t)
+	q.push(t, gossip, chits)
+
+	q.queue.Close()
+	require.ElementsMatch([]message.OutboundMessage{gossip, chits}, q.failed)
+	for class := MessageClass(0); class < numMessageClasses; class++ {
+		require.Zero(testutil.ToFloat64(q.metrics.queuedMessages[class]))
+		require.Zero(testutil.ToFloat64(q.metrics.queuedBytes[class]))
+	}
+
+	_, ok := q.queue.Pop()
+	require.False(ok)
+}
@@ -182,3 +182,63 @@ func (m *Metrics) Received(msg message.InboundMessage, msgLen uint32) {
 		msgMetrics.SavedReceivedBytes.Observe(float64(saved))
 	}
 }
+
+// MessageQueueMetrics tracks the messages queued by the prioritized message
+// queues of all peers.
+type MessageQueueMetrics struct {
+	queuedMessages [numMessageClasses]prometheus.Gauge
+	queuedBytes    [numMessageClasses]prometheus.Gauge
+	dropped        [numMessageClasses]prometheus.Counter
+}
+
+func NewMessageQueueMetrics(
+	namespace string,
+	registerer prometheus.Registerer,
+) (*MessageQueueMetrics, error) {
+	m := &MessageQueueMetrics{}
+	errs := wrappers.Errs{}
+	for i := range m.queuedMessages {
+		class := MessageClass(i)
+		m.queuedMessages[i] = prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      fmt.Sprintf("outbound_queue_%s_msgs", class),
+			Help:      fmt.Sprintf("Number of %s messages queued to be sent to peers", class),
+		})
+		m.queuedBytes[i] = prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      fmt.Sprintf("outbound_queue_%s_bytes", class),
+			Help:      fmt.Sprintf("Size, in bytes, of the %s messages queued to be sent to peers", class),
+		})
+		m.dropped[i] = prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      fmt.Sprintf("outbound_queue_%s_dropped", class),
+			Help:      fmt.Sprintf("Number of %s messages dropped because their class' queue was full", class),
+		})
+		errs.Add(
+			registerer.Register(m.queuedMessages[i]),
+			registerer.Register(m.queuedBytes[i]),
+			registerer.Register(m.dropped[i]),
+		)
+	}
+	return m, errs.Err
+}
+
+// Pushed updates the metrics for having queued a message of [class] with
+// [size] bytes.
+func (m *MessageQueueMetrics) Pushed(class MessageClass, size uint64) {
+	m.queuedMessages[class].Inc()
+	m.queuedBytes[class].Add(float64(size))
+}
+
+// Popped updates the metrics for having removed a message of [class] with
+// [size] bytes from a queue.
+func (m *MessageQueueMetrics) Popped(class MessageClass, size uint64) {
+	m.queuedMessages[class].Dec()
+	m.queuedBytes[class].Sub(float64(size))
+}
+
+// Dropped updates the metrics for having dropped a message of [class] because
+// its queue was full.
+func (m *MessageQueueMetrics) Dropped(class MessageClass) {
+	m.dropped[class].Inc()
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/proto/pb/p2p"
+	"github.com/ava-labs/avalanchego/snow/networking/reputation"
 	"github.com/ava-labs/avalanchego/staking"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
@@ -389,6 +390,7 @@ func (p *peer) readMessages() {
 				zap.Stringer("nodeID", p.id),
 				zap.Error(err),
 			)
+			p.ReputationTracker.RegisterEvent(p.id, reputation.InvalidMessage)
 			return
 		}
 
@@ -461,6 +463,7 @@ func (p *peer) readMessages() {
 			)
 
 			p.Metrics.FailedToParse.Inc()
+			p.ReputationTracker.RegisterEvent(p.id, reputation.InvalidMessage)
 
 			// Couldn't parse the message. Read the next one.
 			onFinishedHandling()
@@ -779,6 +782,7 @@ func (p *peer) observeUptimes(primaryUptime uint32, subnetUptimes []*p2p.SubnetU
 			zap.Stringer("subnetID", constants.PrimaryNetworkID),
 			zap.Uint32("uptime", primaryUptime),
 		)
+		p.ReputationTracker.RegisterEvent(p.id, reputation.InvalidMessage)
 		p.StartClose()
 		return
 	}
@@ -791,6 +795,7 @@ func (p *peer) observeUptimes(primaryUptime uint32, subnetUptimes []*p2p.SubnetU
 				zap.Stringer("nodeID", p.id),
 				zap.Error(err),
 			)
+			p.ReputationTracker.RegisterEvent(p.id, reputation.InvalidMessage)
 			p.StartClose()
 			return
 		}
@@ -800,6 +805,7 @@ func (p *peer) observeUptimes(primaryUptime uint32, subnetUptimes []*p2p.SubnetU
 				zap.Stringer("nodeID", p.id),
 				zap.Stringer("subnetID", subnetID),
 			)
+			p.ReputationTracker.RegisterEvent(p.id, reputation.InvalidMessage)
 			p.StartClose()
 			return
 		}
@@ -811,6 +817,7 @@ func (p *peer) observeUptimes(primaryUptime uint32, subnetUptimes []*p2p.SubnetU
 				zap.Stringer("subnetID", subnetID),
 				zap.Uint32("uptime", uptime),
 			)
+			p.ReputationTracker.RegisterEvent(p.id, reputation.InvalidMessage)
 			p.StartClose()
 			return
 		}
@@ -843,6 +850,7 @@ func (p *peer) handleVersion(msg *p2p.Version) {
 			zap.Uint32("peerNetworkID", msg.NetworkId),
 			zap.Uint32("ourNetworkID", p.NetworkID),
 		)
+		p.ReputationTracker.RegisterEvent(p.id, reputation.HandshakeFailure)
 		p.StartClose()
 		return
 	}
@@ -866,6 +874,7 @@ func (p *peer) handleVersion(msg *p2p.Version) {
 				zap.Uint64("myTime", myTime),
 			)
 		}
+		p.ReputationTracker.RegisterEvent(p.id, reputation.HandshakeFailure)
 		p.StartClose()
 		return
 	}
@@ -876,6 +885,7 @@ func (p *peer) handleVersion(msg *p2p.Version) {
 			zap.Stringer("nodeID", p.id),
 			zap.Error(err),
 		)
+		p.ReputationTracker.RegisterEvent(p.id, reputation.HandshakeFailure)
 		p.StartClose()
 		return
 	}
@@ -901,6 +911,7 @@ func (p *peer) handleVersion(msg *p2p.Version) {
 			zap.Stringer("peerVersion", peerVersion),
 			zap.Error(err),
 		)
+		p.ReputationTracker.RegisterEvent(p.id, reputation.HandshakeFailure)
 		p.StartClose()
 		return
 	}
@@ -913,6 +924,7 @@ func (p *peer) handleVersion(msg *p2p.Version) {
 			zap.Stringer("nodeID", p.id),
 			zap.Uint64("versionTime", msg.MyVersionTime),
 		)
+		p.ReputationTracker.RegisterEvent(p.id, reputation.HandshakeFailure)
 		p.StartClose()
 		return
 	}
@@ -925,6 +937,7 @@ func (p *peer) handleVersion(msg *p2p.Version) {
 				zap.Stringer("nodeID", p.id),
 				zap.Error(err),
 			)
+			p.ReputationTracker.RegisterEvent(p.id, reputation.HandshakeFailure)
 			p.StartClose()
 			return
 		}
@@ -942,6 +955,7 @@ func (p *peer) handleVersion(msg *p2p.Version) {
 			zap.String("field", "IP"),
 			zap.Int("ipLen", ipLen),
 		)
+		p.ReputationTracker.RegisterEvent(p.id, reputation.HandshakeFailure)
 		p.StartClose()
 		return
 	}
@@ -961,6 +975,7 @@ func (p *peer) handleVersion(msg *p2p.Version) {
 			zap.Stringer("nodeID", p.id),
 			zap.Error(err),
 		)
+		p.ReputationTracker.RegisterEvent(p.id, reputation.HandshakeFailure)
 		p.StartClose()
 		return
 	}
@@ -1020,6 +1035,7 @@ func (p *peer) handlePeerList(msg *p2p.PeerList) {
 				zap.String("field", "Cert"),
 				zap.Error(err),
 			)
+			p.ReputationTracker.RegisterEvent(p.id, reputation.InvalidMessage)
 			p.StartClose()
 			return
 		}
@@ -1032,6 +1048,7 @@ func (p *peer) handlePeerList(msg *p2p.PeerList) {
 				zap.String("field", "IP"),
 				zap.Int("ipLen", ipLen),
 			)
+			p.ReputationTracker.RegisterEvent(p.id, reputation.InvalidMessage)
 			p.StartClose()
 			return
 		}
@@ -1044,6 +1061,7 @@ func (p *peer) handlePeerList(msg *p2p.PeerList) {
 				zap.String("field", "txID"),
 				zap.Error(err),
 			)
+			p.ReputationTracker.RegisterEvent(p.id, reputation.InvalidMessage)
 			p.StartClose()
 			return
 		}
@@ -1068,6 +1086,7 @@ func (p *peer) handlePeerList(msg *p2p.PeerList) {
 			zap.String("field", "claimedIP"),
 			zap.Error(err),
 		)
+		p.ReputationTracker.RegisterEvent(p.id, reputation.InvalidMessage)
 		p.StartClose()
 		return
 	}
@@ -1104,6 +1123,7 @@ func (p *peer) handlePeerListAck(msg *p2p.PeerListAck) {
 			zap.String("field", "txID"),
 			zap.Error(err),
 		)
+		p.ReputationTracker.RegisterEvent(p.id, reputation.InvalidMessage)
 		p.StartClose()
 	}
 }
@@ -18,6 +18,7 @@ import (
 	"github.com/ava-labs/avalanchego/network/dialer"
 	"github.com/ava-labs/avalanchego/network/peer"
 	"github.com/ava-labs/avalanchego/network/throttling"
+	"github.com/ava-labs/avalanchego/snow/networking/reputation"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/uptime"
@@ -193,6 +194,7 @@ func NewTestNetwork(
 	networkConfig.Beacons = beacons
 	// This never actually does anything because we never initialize the P-chain
 	networkConfig.UptimeCalculator = uptime.NoOpCalculator
+	networkConfig.ReputationTracker = reputation.NewNoTracker()
 
 	// TODO actually monitor usage
 	// TestNetwork doesn't use disk so we don't need to track it, but we should
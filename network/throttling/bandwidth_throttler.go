@@ -15,6 +15,7 @@ import (
 	"golang.org/x/time/rate"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/networking/reputation"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/metric"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
@@ -24,6 +25,7 @@ var _ bandwidthThrottler = (*bandwidthThrottlerImpl)(nil)
 
 // Returns a bandwidth throttler that uses a token bucket
 // model, where each token is 1 byte, to rate-limit bandwidth usage.
+// Nodes that exceed their allocation are reported to the reputation tracker.
 // See https://pkg.go.dev/golang.org/x/time/rate#Limiter
 type bandwidthThrottler interface {
 	// Blocks until [nodeID] can read a message of size [msgSize].
@@ -63,11 +65,13 @@ func newBandwidthThrottler(
 	namespace string,
 	registerer prometheus.Registerer,
 	config BandwidthThrottlerConfig,
+	reputationTracker reputation.Tracker,
 ) (bandwidthThrottler, error) {
 	errs := wrappers.Errs{}
 	t := &bandwidthThrottlerImpl{
 		BandwidthThrottlerConfig: config,
 		log:                      log,
+		reputationTracker:        reputationTracker,
 		limiters:                 make(map[ids.NodeID]*rate.Limiter),
 		metrics: bandwidthThrottlerMetrics{
 			acquireLatency: metric.NewAveragerWithErrs(
@@ -95,9 +99,10 @@ type bandwidthThrottlerMetrics struct {
 
 type bandwidthThrottlerImpl struct {
 	BandwidthThrottlerConfig
-	metrics bandwidthThrottlerMetrics
-	log     logging.Logger
-	lock    sync.RWMutex
+	metrics           bandwidthThrottlerMetrics
+	log               logging.Logger
+	reputationTracker reputation.Tracker
+	lock              sync.RWMutex
 	// Node ID --> token bucket based rate limiter where each token
 	// is a byte of bandwidth.
 	limiters map[ids.NodeID]*rate.Limiter
@@ -127,6 +132,11 @@ func (t *bandwidthThrottlerImpl) Acquire(
 		)
 		return
 	}
+	if limiter.AllowN(startTime, int(msgSize)) {
+		return
+	}
+	// The node sent more bytes than it is allocated.
+	t.reputationTracker.RegisterEvent(nodeID, reputation.BandwidthAbuse)
 	if err := limiter.WaitN(ctx, int(msgSize)); err != nil {
 		// This should only happen on shutdown.
 		t.log.Debug("error while waiting for throttler",
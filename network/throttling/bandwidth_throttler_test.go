@@ -7,12 +7,14 @@ import (
 	"context"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/networking/reputation"
 	"github.com/ava-labs/avalanchego/utils/logging"
 )
 
@@ -23,7 +25,7 @@ func TestBandwidthThrottler(t *testing.T) {
 		RefillRate:   8,
 		MaxBurstSize: 10,
 	}
-	throttlerIntf, err := newBandwidthThrottler(logging.NoLog{}, "", prometheus.NewRegistry(), config)
+	throttlerIntf, err := newBandwidthThrottler(logging.NoLog{}, "", prometheus.NewRegistry(), config, reputation.NewNoTracker())
 	require.NoError(err)
 	require.IsType(&bandwidthThrottlerImpl{}, throttlerIntf)
 	throttler := throttlerIntf.(*bandwidthThrottlerImpl)
@@ -60,3 +62,34 @@ func TestBandwidthThrottler(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestBandwidthThrottlerReportsAbuse(t *testing.T) {
+	require := require.New(t)
+
+	config := BandwidthThrottlerConfig{
+		RefillRate:   1024,
+		MaxBurstSize: 10,
+	}
+	reputationTracker, err := reputation.NewTracker(
+		reputation.Config{
+			Halflife:              time.Minute,
+			BandwidthAbusePenalty: 1,
+		},
+		"",
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+	throttler, err := newBandwidthThrottler(logging.NoLog{}, "", prometheus.NewRegistry(), config, reputationTracker)
+	require.NoError(err)
+
+	nodeID := ids.GenerateTestNodeID()
+	throttler.AddNode(nodeID)
+
+	// Staying within the allocation doesn't affect the reputation.
+	throttler.Acquire(context.Background(), config.MaxBurstSize, nodeID)
+	require.Equal(1.0, reputationTracker.Score(nodeID))
+
+	// Exceeding the allocation does.
+	throttler.Acquire(context.Background(), config.MaxBurstSize, nodeID)
+	require.Less(reputationTracker.Score(nodeID), 1.0)
+}
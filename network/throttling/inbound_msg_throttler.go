@@ -10,6 +10,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/networking/reputation"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/logging"
@@ -61,6 +62,7 @@ func NewInboundMsgThrottler(
 	resourceTracker tracker.ResourceTracker,
 	cpuTargeter tracker.Targeter,
 	diskTargeter tracker.Targeter,
+	reputationTracker reputation.Tracker,
 ) (InboundMsgThrottler, error) {
 	byteThrottler, err := newInboundMsgByteThrottler(
 		log,
@@ -85,6 +87,7 @@ func NewInboundMsgThrottler(
 		namespace,
 		registerer,
 		throttlerConfig.BandwidthThrottlerConfig,
+		reputationTracker,
 	)
 	if err != nil {
 		return nil, err
@@ -14,6 +14,8 @@ import (
 	"github.com/ava-labs/avalanchego/nat"
 	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
+	"github.com/ava-labs/avalanchego/snow/networking/capture"
+	"github.com/ava-labs/avalanchego/snow/networking/reputation"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/subnets"
@@ -168,8 +170,12 @@ type Config struct {
 
 	BenchlistConfig benchlist.Config `json:"benchlistConfig"`
 
+	ReputationConfig reputation.Config `json:"reputationConfig"`
+
 	ProfilerConfig profiler.Config `json:"profilerConfig"`
 
+	CaptureConfig capture.Config `json:"captureConfig"`
+
 	LoggingConfig logging.Config `json:"loggingConfig"`
 
 	PluginDir string `json:"pluginDir"`
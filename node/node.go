@@ -39,6 +39,7 @@ import (
 	"github.com/ava-labs/avalanchego/database/leveldb"
 	"github.com/ava-labs/avalanchego/database/manager"
 	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/pebbledb"
 	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/genesis"
 	"github.com/ava-labs/avalanchego/ids"
@@ -52,6 +53,8 @@ import (
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
+	"github.com/ava-labs/avalanchego/snow/networking/capture"
+	"github.com/ava-labs/avalanchego/snow/networking/reputation"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/timeout"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
@@ -114,6 +117,10 @@ type Node struct {
 	// Profiles the process. Nil if continuous profiling is disabled.
 	profiler profiler.ContinuousProfiler
 
+	// Captures the messages exchanged with peers. Nil if message capture is
+	// disabled.
+	capture *capture.Recorder
+
 	// Indexes blocks, transactions and blocks
 	indexer indexer.Indexer
 
@@ -135,6 +142,9 @@ type Node struct {
 	// Manages validator benching
 	benchlistManager benchlist.Manager
 
+	// Scores peers based on their recent behavior
+	reputationTracker reputation.Tracker
+
 	uptimeCalculator uptime.LockedCalculator
 
 	// dispatcher for events as they happen in consensus
@@ -285,11 +295,24 @@ func (n *Node) initNetworking(primaryNetVdrs validators.Set) error {
 
 	tlsConfig := peer.TLSConfig(n.Config.StakingTLSCert, n.tlsKeyLogWriterCloser)
 
+	// Configure reputation tracking
+	n.reputationTracker, err = reputation.NewTracker(
+		n.Config.ReputationConfig,
+		"reputation",
+		n.MetricsRegisterer,
+	)
+	if err != nil {
+		return err
+	}
+
 	// Configure benchlist
 	n.Config.BenchlistConfig.Validators = n.vdrs
 	n.Config.BenchlistConfig.Benchable = n.Config.ConsensusRouter
 	n.Config.BenchlistConfig.SybilProtectionEnabled = n.Config.SybilProtectionEnabled
-	n.benchlistManager = benchlist.NewManager(&n.Config.BenchlistConfig)
+	n.benchlistManager = reputation.Benchlist(
+		benchlist.NewManager(&n.Config.BenchlistConfig),
+		n.reputationTracker,
+	)
 
 	n.uptimeCalculator = uptime.NewLockedCalculator()
 
@@ -374,6 +397,7 @@ func (n *Node) initNetworking(primaryNetVdrs validators.Set) error {
 	n.Config.NetworkConfig.CPUTargeter = n.cpuTargeter
 	n.Config.NetworkConfig.DiskTargeter = n.diskTargeter
 	n.Config.NetworkConfig.GossipTracker = gossipTracker
+	n.Config.NetworkConfig.ReputationTracker = n.reputationTracker
 
 	n.Net, err = network.NewNetwork(
 		&n.Config.NetworkConfig,
@@ -384,8 +408,14 @@ func (n *Node) initNetworking(primaryNetVdrs validators.Set) error {
 		dialer.NewDialer(constants.NetworkType, n.Config.NetworkConfig.DialerConfig, n.Log),
 		consensusRouter,
 	)
+	if err != nil {
+		return err
+	}
 
-	return err
+	if n.capture != nil {
+		n.Net = capture.Network(n.Net, n.capture)
+	}
+	return nil
 }
 
 type NodeProcessContext struct {
@@ -505,12 +535,15 @@ func (n *Node) initDatabase() error {
 		dbManager, err = manager.NewLevelDB(n.Config.DatabaseConfig.Path, n.Config.DatabaseConfig.Config, n.Log, version.CurrentDatabase, "db_internal", n.MetricsRegisterer)
 	case memdb.Name:
 		dbManager = manager.NewMemDB(version.CurrentDatabase)
+	case pebbledb.Name:
+		dbManager, err = manager.NewPebbleDB(n.Config.DatabaseConfig.Path, n.Config.DatabaseConfig.Config, n.Log, version.CurrentDatabase, "db_internal", n.MetricsRegisterer)
 	default:
 		err = fmt.Errorf(
-			"db-type was %q but should have been one of {%s, %s}",
+			"db-type was %q but should have been one of {%s, %s, %s}",
 			n.Config.DatabaseConfig.Name,
 			leveldb.Name,
 			memdb.Name,
+			pebbledb.Name,
 		)
 	}
 	if err != nil {
@@ -846,6 +879,8 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 		ApricotPhase4Time:                       version.GetApricotPhase4Time(n.Config.NetworkID),
 		ApricotPhase4MinPChainHeight:            version.GetApricotPhase4MinPChainHeight(n.Config.NetworkID),
 		ResourceTracker:                         n.resourceTracker,
+		ReputationTracker:                       n.reputationTracker,
+		ReputationSamplingEnabled:               n.Config.ReputationConfig.SamplingEnabled,
 		StateSyncBeacons:                        n.Config.StateSyncIDs,
 		TracingEnabled:                          n.Config.TraceConfig.Enabled,
 		Tracer:                                  n.tracer,
@@ -1035,14 +1070,16 @@ func (n *Node) initAdminAPI() error {
 	n.Log.Info("initializing admin API")
 	service, err := admin.NewService(
 		admin.Config{
-			Log:          n.Log,
-			ChainManager: n.chainManager,
-			HTTPServer:   n.APIServer,
-			ProfileDir:   n.Config.ProfilerConfig.Dir,
-			LogFactory:   n.LogFactory,
-			NodeConfig:   n.Config,
-			VMManager:    n.VMManager,
-			VMRegistry:   n.VMRegistry,
+			Log:             n.Log,
+			ChainManager:    n.chainManager,
+			HTTPServer:      n.APIServer,
+			ProfileDir:      n.Config.ProfilerConfig.Dir,
+			LogFactory:      n.LogFactory,
+			NodeConfig:      n.Config,
+			VMManager:       n.VMManager,
+			VMRegistry:      n.VMRegistry,
+			DBManager:       n.DBManager,
+			NewCheckpointDB: n.newCheckpointDB,
 		},
 	)
 	if err != nil {
@@ -1051,6 +1088,24 @@ func (n *Node) initAdminAPI() error {
 	return n.APIServer.AddRoute(service, &sync.RWMutex{}, "admin", "")
 }
 
+// newCheckpointDB creates a database of the configured db-type at [path] for
+// an admin database checkpoint to be written into.
+func (n *Node) newCheckpointDB(path string) (database.Database, error) {
+	switch n.Config.DatabaseConfig.Name {
+	case leveldb.Name:
+		return leveldb.New(path, n.Config.DatabaseConfig.Config, n.Log, "", prometheus.NewRegistry())
+	case pebbledb.Name:
+		return pebbledb.New(path, n.Config.DatabaseConfig.Config, n.Log, "", prometheus.NewRegistry())
+	default:
+		return nil, fmt.Errorf(
+			"db-type was %q but checkpoints are only supported for {%s, %s}",
+			n.Config.DatabaseConfig.Name,
+			leveldb.Name,
+			pebbledb.Name,
+		)
+	}
+}
+
 // initProfiler initializes the continuous profiling
 func (n *Node) initProfiler() {
 	if !n.Config.ProfilerConfig.Enabled {
@@ -1108,6 +1163,7 @@ func (n *Node) initInfoAPI() error {
 		n.Net,
 		primaryValidators,
 		n.benchlistManager,
+		n.reputationTracker,
 	)
 	if err != nil {
 		return err
@@ -1380,6 +1436,8 @@ func (n *Node) Initialize(
 		return fmt.Errorf("problem initializing node beacons: %w", err)
 	}
 
+	n.initMetrics()
+
 	// Set up tracer
 	n.tracer, err = trace.New(n.Config.TraceConfig)
 	if err != nil {
@@ -1390,7 +1448,21 @@ func (n *Node) Initialize(
 		n.Config.ConsensusRouter = router.Trace(n.Config.ConsensusRouter, n.tracer)
 	}
 
-	n.initMetrics()
+	if n.Config.CaptureConfig.Enabled {
+		n.Log.Info("capturing messages",
+			zap.String("directory", n.Config.CaptureConfig.Directory),
+		)
+		n.capture, err = capture.NewFileRecorder(
+			n.Log,
+			n.Config.CaptureConfig,
+			"capture",
+			n.MetricsRegisterer,
+		)
+		if err != nil {
+			return fmt.Errorf("couldn't initialize message capture: %w", err)
+		}
+		n.Config.ConsensusRouter = capture.Router(n.Config.ConsensusRouter, n.capture)
+	}
 
 	if err := n.initAPIServer(); err != nil { // Start the API Server
 		return fmt.Errorf("couldn't initialize API server: %w", err)
@@ -1537,6 +1609,13 @@ func (n *Node) shutdown() {
 	if n.Net != nil {
 		n.Net.StartClose()
 	}
+	if n.capture != nil {
+		if err := n.capture.Close(); err != nil {
+			n.Log.Debug("error closing message capture",
+				zap.Error(err),
+			)
+		}
+	}
 	if err := n.APIServer.Shutdown(); err != nil {
 		n.Log.Debug("error during API shutdown",
 			zap.Error(err),
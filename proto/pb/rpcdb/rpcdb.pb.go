@@ -471,6 +471,108 @@ func (x *DeleteResponse) GetErr() Error {
 	return Error_ERROR_UNSPECIFIED
 }
 
+type DeleteRangeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Start []byte `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	Limit []byte `protobuf:"bytes,2,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *DeleteRangeRequest) Reset() {
+	*x = DeleteRangeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteRangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRangeRequest) ProtoMessage() {}
+
+func (x *DeleteRangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRangeRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRangeRequest) Descriptor() ([]byte, []int) {
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteRangeRequest) GetStart() []byte {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *DeleteRangeRequest) GetLimit() []byte {
+	if x != nil {
+		return x.Limit
+	}
+	return nil
+}
+
+type DeleteRangeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Err Error `protobuf:"varint,1,opt,name=err,proto3,enum=rpcdb.Error" json:"err,omitempty"`
+}
+
+func (x *DeleteRangeResponse) Reset() {
+	*x = DeleteRangeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteRangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRangeResponse) ProtoMessage() {}
+
+func (x *DeleteRangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRangeResponse.ProtoReflect.Descriptor instead.
+func (*DeleteRangeResponse) Descriptor() ([]byte, []int) {
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeleteRangeResponse) GetErr() Error {
+	if x != nil {
+		return x.Err
+	}
+	return Error_ERROR_UNSPECIFIED
+}
+
 type CompactRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -483,7 +585,7 @@ type CompactRequest struct {
 func (x *CompactRequest) Reset() {
 	*x = CompactRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[8]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -496,7 +598,7 @@ func (x *CompactRequest) String() string {
 func (*CompactRequest) ProtoMessage() {}
 
 func (x *CompactRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[8]
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -509,7 +611,7 @@ func (x *CompactRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CompactRequest.ProtoReflect.Descriptor instead.
 func (*CompactRequest) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{8}
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *CompactRequest) GetStart() []byte {
@@ -537,7 +639,7 @@ type CompactResponse struct {
 func (x *CompactResponse) Reset() {
 	*x = CompactResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[9]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -550,7 +652,7 @@ func (x *CompactResponse) String() string {
 func (*CompactResponse) ProtoMessage() {}
 
 func (x *CompactResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[9]
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -563,7 +665,7 @@ func (x *CompactResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CompactResponse.ProtoReflect.Descriptor instead.
 func (*CompactResponse) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{9}
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *CompactResponse) GetErr() Error {
@@ -582,7 +684,7 @@ type CloseRequest struct {
 func (x *CloseRequest) Reset() {
 	*x = CloseRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[10]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -595,7 +697,7 @@ func (x *CloseRequest) String() string {
 func (*CloseRequest) ProtoMessage() {}
 
 func (x *CloseRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[10]
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -608,7 +710,7 @@ func (x *CloseRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CloseRequest.ProtoReflect.Descriptor instead.
 func (*CloseRequest) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{10}
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{12}
 }
 
 type CloseResponse struct {
@@ -622,7 +724,7 @@ type CloseResponse struct {
 func (x *CloseResponse) Reset() {
 	*x = CloseResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[11]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -635,7 +737,7 @@ func (x *CloseResponse) String() string {
 func (*CloseResponse) ProtoMessage() {}
 
 func (x *CloseResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[11]
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -648,7 +750,7 @@ func (x *CloseResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CloseResponse.ProtoReflect.Descriptor instead.
 func (*CloseResponse) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{11}
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *CloseResponse) GetErr() Error {
@@ -665,12 +767,14 @@ type WriteBatchRequest struct {
 
 	Puts    []*PutRequest    `protobuf:"bytes,1,rep,name=puts,proto3" json:"puts,omitempty"`
 	Deletes []*DeleteRequest `protobuf:"bytes,2,rep,name=deletes,proto3" json:"deletes,omitempty"`
+	// delete_ranges are applied before puts and deletes.
+	DeleteRanges []*DeleteRangeRequest `protobuf:"bytes,3,rep,name=delete_ranges,json=deleteRanges,proto3" json:"delete_ranges,omitempty"`
 }
 
 func (x *WriteBatchRequest) Reset() {
 	*x = WriteBatchRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[12]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -683,7 +787,7 @@ func (x *WriteBatchRequest) String() string {
 func (*WriteBatchRequest) ProtoMessage() {}
 
 func (x *WriteBatchRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[12]
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -696,7 +800,7 @@ func (x *WriteBatchRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WriteBatchRequest.ProtoReflect.Descriptor instead.
 func (*WriteBatchRequest) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{12}
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *WriteBatchRequest) GetPuts() []*PutRequest {
@@ -713,6 +817,13 @@ func (x *WriteBatchRequest) GetDeletes() []*DeleteRequest {
 	return nil
 }
 
+func (x *WriteBatchRequest) GetDeleteRanges() []*DeleteRangeRequest {
+	if x != nil {
+		return x.DeleteRanges
+	}
+	return nil
+}
+
 type WriteBatchResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -724,7 +835,7 @@ type WriteBatchResponse struct {
 func (x *WriteBatchResponse) Reset() {
 	*x = WriteBatchResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[13]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -737,7 +848,7 @@ func (x *WriteBatchResponse) String() string {
 func (*WriteBatchResponse) ProtoMessage() {}
 
 func (x *WriteBatchResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[13]
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -750,7 +861,7 @@ func (x *WriteBatchResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WriteBatchResponse.ProtoReflect.Descriptor instead.
 func (*WriteBatchResponse) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{13}
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *WriteBatchResponse) GetErr() Error {
@@ -769,7 +880,7 @@ type NewIteratorRequest struct {
 func (x *NewIteratorRequest) Reset() {
 	*x = NewIteratorRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[14]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -782,7 +893,7 @@ func (x *NewIteratorRequest) String() string {
 func (*NewIteratorRequest) ProtoMessage() {}
 
 func (x *NewIteratorRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[14]
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -795,7 +906,7 @@ func (x *NewIteratorRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NewIteratorRequest.ProtoReflect.Descriptor instead.
 func (*NewIteratorRequest) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{14}
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{16}
 }
 
 type NewIteratorWithStartAndPrefixRequest struct {
@@ -810,7 +921,7 @@ type NewIteratorWithStartAndPrefixRequest struct {
 func (x *NewIteratorWithStartAndPrefixRequest) Reset() {
 	*x = NewIteratorWithStartAndPrefixRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[15]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -823,7 +934,7 @@ func (x *NewIteratorWithStartAndPrefixRequest) String() string {
 func (*NewIteratorWithStartAndPrefixRequest) ProtoMessage() {}
 
 func (x *NewIteratorWithStartAndPrefixRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[15]
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -836,7 +947,7 @@ func (x *NewIteratorWithStartAndPrefixRequest) ProtoReflect() protoreflect.Messa
 
 // Deprecated: Use NewIteratorWithStartAndPrefixRequest.ProtoReflect.Descriptor instead.
 func (*NewIteratorWithStartAndPrefixRequest) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{15}
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *NewIteratorWithStartAndPrefixRequest) GetStart() []byte {
@@ -864,7 +975,7 @@ type NewIteratorWithStartAndPrefixResponse struct {
 func (x *NewIteratorWithStartAndPrefixResponse) Reset() {
 	*x = NewIteratorWithStartAndPrefixResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[16]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -877,7 +988,7 @@ func (x *NewIteratorWithStartAndPrefixResponse) String() string {
 func (*NewIteratorWithStartAndPrefixResponse) ProtoMessage() {}
 
 func (x *NewIteratorWithStartAndPrefixResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[16]
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -890,7 +1001,7 @@ func (x *NewIteratorWithStartAndPrefixResponse) ProtoReflect() protoreflect.Mess
 
 // Deprecated: Use NewIteratorWithStartAndPrefixResponse.ProtoReflect.Descriptor instead.
 func (*NewIteratorWithStartAndPrefixResponse) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{16}
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *NewIteratorWithStartAndPrefixResponse) GetId() uint64 {
@@ -900,6 +1011,124 @@ func (x *NewIteratorWithStartAndPrefixResponse) GetId() uint64 {
 	return 0
 }
 
+type NewIteratorWithOptionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Start   []byte `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	End     []byte `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	Prefix  []byte `protobuf:"bytes,3,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Reverse bool   `protobuf:"varint,4,opt,name=reverse,proto3" json:"reverse,omitempty"`
+}
+
+func (x *NewIteratorWithOptionsRequest) Reset() {
+	*x = NewIteratorWithOptionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NewIteratorWithOptionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewIteratorWithOptionsRequest) ProtoMessage() {}
+
+func (x *NewIteratorWithOptionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewIteratorWithOptionsRequest.ProtoReflect.Descriptor instead.
+func (*NewIteratorWithOptionsRequest) Descriptor() ([]byte, []int) {
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *NewIteratorWithOptionsRequest) GetStart() []byte {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *NewIteratorWithOptionsRequest) GetEnd() []byte {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *NewIteratorWithOptionsRequest) GetPrefix() []byte {
+	if x != nil {
+		return x.Prefix
+	}
+	return nil
+}
+
+func (x *NewIteratorWithOptionsRequest) GetReverse() bool {
+	if x != nil {
+		return x.Reverse
+	}
+	return false
+}
+
+type NewIteratorWithOptionsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *NewIteratorWithOptionsResponse) Reset() {
+	*x = NewIteratorWithOptionsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NewIteratorWithOptionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewIteratorWithOptionsResponse) ProtoMessage() {}
+
+func (x *NewIteratorWithOptionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewIteratorWithOptionsResponse.ProtoReflect.Descriptor instead.
+func (*NewIteratorWithOptionsResponse) Descriptor() ([]byte, []int) {
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *NewIteratorWithOptionsResponse) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
 type IteratorNextRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -911,7 +1140,7 @@ type IteratorNextRequest struct {
 func (x *IteratorNextRequest) Reset() {
 	*x = IteratorNextRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[17]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -924,7 +1153,7 @@ func (x *IteratorNextRequest) String() string {
 func (*IteratorNextRequest) ProtoMessage() {}
 
 func (x *IteratorNextRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[17]
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -937,7 +1166,7 @@ func (x *IteratorNextRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IteratorNextRequest.ProtoReflect.Descriptor instead.
 func (*IteratorNextRequest) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{17}
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *IteratorNextRequest) GetId() uint64 {
@@ -958,7 +1187,7 @@ type IteratorNextResponse struct {
 func (x *IteratorNextResponse) Reset() {
 	*x = IteratorNextResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[18]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -971,7 +1200,7 @@ func (x *IteratorNextResponse) String() string {
 func (*IteratorNextResponse) ProtoMessage() {}
 
 func (x *IteratorNextResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[18]
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -984,7 +1213,7 @@ func (x *IteratorNextResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IteratorNextResponse.ProtoReflect.Descriptor instead.
 func (*IteratorNextResponse) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{18}
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *IteratorNextResponse) GetData() []*PutRequest {
@@ -1005,7 +1234,7 @@ type IteratorErrorRequest struct {
 func (x *IteratorErrorRequest) Reset() {
 	*x = IteratorErrorRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[19]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1018,7 +1247,7 @@ func (x *IteratorErrorRequest) String() string {
 func (*IteratorErrorRequest) ProtoMessage() {}
 
 func (x *IteratorErrorRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[19]
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1031,7 +1260,7 @@ func (x *IteratorErrorRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IteratorErrorRequest.ProtoReflect.Descriptor instead.
 func (*IteratorErrorRequest) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{19}
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *IteratorErrorRequest) GetId() uint64 {
@@ -1052,7 +1281,7 @@ type IteratorErrorResponse struct {
 func (x *IteratorErrorResponse) Reset() {
 	*x = IteratorErrorResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[20]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1065,7 +1294,7 @@ func (x *IteratorErrorResponse) String() string {
 func (*IteratorErrorResponse) ProtoMessage() {}
 
 func (x *IteratorErrorResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[20]
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1078,7 +1307,7 @@ func (x *IteratorErrorResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IteratorErrorResponse.ProtoReflect.Descriptor instead.
 func (*IteratorErrorResponse) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{20}
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *IteratorErrorResponse) GetErr() Error {
@@ -1099,7 +1328,7 @@ type IteratorReleaseRequest struct {
 func (x *IteratorReleaseRequest) Reset() {
 	*x = IteratorReleaseRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[21]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1112,7 +1341,7 @@ func (x *IteratorReleaseRequest) String() string {
 func (*IteratorReleaseRequest) ProtoMessage() {}
 
 func (x *IteratorReleaseRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[21]
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1125,7 +1354,7 @@ func (x *IteratorReleaseRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IteratorReleaseRequest.ProtoReflect.Descriptor instead.
 func (*IteratorReleaseRequest) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{21}
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *IteratorReleaseRequest) GetId() uint64 {
@@ -1146,7 +1375,7 @@ type IteratorReleaseResponse struct {
 func (x *IteratorReleaseResponse) Reset() {
 	*x = IteratorReleaseResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[22]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1159,7 +1388,7 @@ func (x *IteratorReleaseResponse) String() string {
 func (*IteratorReleaseResponse) ProtoMessage() {}
 
 func (x *IteratorReleaseResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[22]
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1172,7 +1401,7 @@ func (x *IteratorReleaseResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IteratorReleaseResponse.ProtoReflect.Descriptor instead.
 func (*IteratorReleaseResponse) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{22}
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *IteratorReleaseResponse) GetErr() Error {
@@ -1193,7 +1422,7 @@ type HealthCheckResponse struct {
 func (x *HealthCheckResponse) Reset() {
 	*x = HealthCheckResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rpcdb_rpcdb_proto_msgTypes[23]
+		mi := &file_rpcdb_rpcdb_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1206,7 +1435,7 @@ func (x *HealthCheckResponse) String() string {
 func (*HealthCheckResponse) ProtoMessage() {}
 
 func (x *HealthCheckResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpcdb_rpcdb_proto_msgTypes[23]
+	mi := &file_rpcdb_rpcdb_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1219,7 +1448,7 @@ func (x *HealthCheckResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HealthCheckResponse.ProtoReflect.Descriptor instead.
 func (*HealthCheckResponse) Descriptor() ([]byte, []int) {
-	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{23}
+	return file_rpcdb_rpcdb_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *HealthCheckResponse) GetDetails() []byte {
@@ -1259,119 +1488,152 @@ var file_rpcdb_rpcdb_proto_rawDesc = []byte{
 	0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0x30, 0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52,
 	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x03, 0x65, 0x72, 0x72, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x45, 0x72, 0x72,
-	0x6f, 0x72, 0x52, 0x03, 0x65, 0x72, 0x72, 0x22, 0x3c, 0x0a, 0x0e, 0x43, 0x6f, 0x6d, 0x70, 0x61,
-	0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61,
-	0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12,
-	0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05,
-	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x31, 0x0a, 0x0f, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x03, 0x65, 0x72, 0x72, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x45, 0x72,
-	0x72, 0x6f, 0x72, 0x52, 0x03, 0x65, 0x72, 0x72, 0x22, 0x0e, 0x0a, 0x0c, 0x43, 0x6c, 0x6f, 0x73,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x2f, 0x0a, 0x0d, 0x43, 0x6c, 0x6f, 0x73,
-	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x03, 0x65, 0x72, 0x72,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x45,
-	0x72, 0x72, 0x6f, 0x72, 0x52, 0x03, 0x65, 0x72, 0x72, 0x22, 0x6a, 0x0a, 0x11, 0x57, 0x72, 0x69,
-	0x74, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25,
-	0x0a, 0x04, 0x70, 0x75, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x72,
-	0x70, 0x63, 0x64, 0x62, 0x2e, 0x50, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52,
-	0x04, 0x70, 0x75, 0x74, 0x73, 0x12, 0x2e, 0x0a, 0x07, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x73,
-	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x44,
-	0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x07, 0x64, 0x65,
-	0x6c, 0x65, 0x74, 0x65, 0x73, 0x22, 0x34, 0x0a, 0x12, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42, 0x61,
-	0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x03, 0x65,
-	0x72, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62,
-	0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x03, 0x65, 0x72, 0x72, 0x22, 0x14, 0x0a, 0x12, 0x4e,
-	0x65, 0x77, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x22, 0x54, 0x0a, 0x24, 0x4e, 0x65, 0x77, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72,
+	0x6f, 0x72, 0x52, 0x03, 0x65, 0x72, 0x72, 0x22, 0x40, 0x0a, 0x12, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x35, 0x0a, 0x13, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x1e, 0x0a, 0x03, 0x65, 0x72, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e,
+	0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x03, 0x65, 0x72, 0x72,
+	0x22, 0x3c, 0x0a, 0x0e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x31,
+	0x0a, 0x0f, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x1e, 0x0a, 0x03, 0x65, 0x72, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c,
+	0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x03, 0x65, 0x72,
+	0x72, 0x22, 0x0e, 0x0a, 0x0c, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x22, 0x2f, 0x0a, 0x0d, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x1e, 0x0a, 0x03, 0x65, 0x72, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x0c, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x03, 0x65,
+	0x72, 0x72, 0x22, 0xaa, 0x01, 0x0a, 0x11, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x04, 0x70, 0x75, 0x74, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x50,
+	0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x04, 0x70, 0x75, 0x74, 0x73, 0x12,
+	0x2e, 0x0a, 0x07, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x07, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x73, 0x12,
+	0x3e, 0x0a, 0x0d, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x52, 0x0c, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x22,
+	0x34, 0x0a, 0x12, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x03, 0x65, 0x72, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x52, 0x03, 0x65, 0x72, 0x72, 0x22, 0x14, 0x0a, 0x12, 0x4e, 0x65, 0x77, 0x49, 0x74, 0x65, 0x72,
+	0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x54, 0x0a, 0x24, 0x4e,
+	0x65, 0x77, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74,
+	0x61, 0x72, 0x74, 0x41, 0x6e, 0x64, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72, 0x65,
+	0x66, 0x69, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69,
+	0x78, 0x22, 0x37, 0x0a, 0x25, 0x4e, 0x65, 0x77, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72,
 	0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x6e, 0x64, 0x50, 0x72, 0x65, 0x66,
-	0x69, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61,
-	0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12,
-	0x16, 0x0a, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x22, 0x37, 0x0a, 0x25, 0x4e, 0x65, 0x77, 0x49, 0x74,
-	0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41,
-	0x6e, 0x64, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64,
-	0x22, 0x25, 0x0a, 0x13, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x4e, 0x65, 0x78, 0x74,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0x3d, 0x0a, 0x14, 0x49, 0x74, 0x65, 0x72, 0x61,
-	0x74, 0x6f, 0x72, 0x4e, 0x65, 0x78, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x25, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e,
-	0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x50, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x26, 0x0a, 0x14, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74,
-	0x6f, 0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e,
-	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0x37,
-	0x0a, 0x15, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x03, 0x65, 0x72, 0x72, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x45, 0x72, 0x72,
-	0x6f, 0x72, 0x52, 0x03, 0x65, 0x72, 0x72, 0x22, 0x28, 0x0a, 0x16, 0x49, 0x74, 0x65, 0x72, 0x61,
-	0x74, 0x6f, 0x72, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69,
-	0x64, 0x22, 0x39, 0x0a, 0x17, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x6c,
-	0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x03,
-	0x65, 0x72, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x64,
-	0x62, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x03, 0x65, 0x72, 0x72, 0x22, 0x2f, 0x0a, 0x13,
-	0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x2a, 0x45, 0x0a,
-	0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x15, 0x0a, 0x11, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f,
-	0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x10, 0x0a,
-	0x0c, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f, 0x43, 0x4c, 0x4f, 0x53, 0x45, 0x44, 0x10, 0x01, 0x12,
-	0x13, 0x0a, 0x0f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x46, 0x4f, 0x55,
-	0x4e, 0x44, 0x10, 0x02, 0x32, 0xa2, 0x06, 0x0a, 0x08, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73,
-	0x65, 0x12, 0x2c, 0x0a, 0x03, 0x48, 0x61, 0x73, 0x12, 0x11, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62,
-	0x2e, 0x48, 0x61, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x72, 0x70,
-	0x63, 0x64, 0x62, 0x2e, 0x48, 0x61, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x2c, 0x0a, 0x03, 0x47, 0x65, 0x74, 0x12, 0x11, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x47,
-	0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x72, 0x70, 0x63, 0x64,
-	0x62, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a,
-	0x03, 0x50, 0x75, 0x74, 0x12, 0x11, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x50, 0x75, 0x74,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e,
-	0x50, 0x75, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x06, 0x44,
-	0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x14, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x44, 0x65,
-	0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x72, 0x70,
-	0x63, 0x64, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x38, 0x0a, 0x07, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x12, 0x15, 0x2e,
-	0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x43, 0x6f, 0x6d,
-	0x70, 0x61, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x05,
-	0x43, 0x6c, 0x6f, 0x73, 0x65, 0x12, 0x13, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x43, 0x6c,
-	0x6f, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x72, 0x70, 0x63,
-	0x64, 0x62, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x41, 0x0a, 0x0b, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x12,
-	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1a, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e,
-	0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a, 0x0a, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42, 0x61, 0x74, 0x63,
-	0x68, 0x12, 0x18, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42,
-	0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x72, 0x70,
-	0x63, 0x64, 0x62, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x7a, 0x0a, 0x1d, 0x4e, 0x65, 0x77, 0x49, 0x74, 0x65,
-	0x72, 0x61, 0x74, 0x6f, 0x72, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x6e,
-	0x64, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x2b, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e,
-	0x4e, 0x65, 0x77, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x57, 0x69, 0x74, 0x68, 0x53,
-	0x74, 0x61, 0x72, 0x74, 0x41, 0x6e, 0x64, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x4e, 0x65, 0x77,
+	0x69, 0x78, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0x79, 0x0a, 0x1d, 0x4e, 0x65,
+	0x77, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x57, 0x69, 0x74, 0x68, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03,
+	0x65, 0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x18, 0x0a, 0x07, 0x72,
+	0x65, 0x76, 0x65, 0x72, 0x73, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x65,
+	0x76, 0x65, 0x72, 0x73, 0x65, 0x22, 0x30, 0x0a, 0x1e, 0x4e, 0x65, 0x77, 0x49, 0x74, 0x65, 0x72,
+	0x61, 0x74, 0x6f, 0x72, 0x57, 0x69, 0x74, 0x68, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0x25, 0x0a, 0x13, 0x49, 0x74, 0x65, 0x72, 0x61,
+	0x74, 0x6f, 0x72, 0x4e, 0x65, 0x78, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0x3d,
+	0x0a, 0x14, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x4e, 0x65, 0x78, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x50, 0x75, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x26, 0x0a,
+	0x14, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0x37, 0x0a, 0x15, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f,
+	0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e,
+	0x0a, 0x03, 0x65, 0x72, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x72, 0x70,
+	0x63, 0x64, 0x62, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x03, 0x65, 0x72, 0x72, 0x22, 0x28,
+	0x0a, 0x16, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0x39, 0x0a, 0x17, 0x49, 0x74, 0x65, 0x72,
+	0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x03, 0x65, 0x72, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x03,
+	0x65, 0x72, 0x72, 0x22, 0x2f, 0x0a, 0x13, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65,
+	0x74, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x64, 0x65, 0x74,
+	0x61, 0x69, 0x6c, 0x73, 0x2a, 0x45, 0x0a, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x15, 0x0a,
+	0x11, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49,
+	0x45, 0x44, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f, 0x43, 0x4c,
+	0x4f, 0x53, 0x45, 0x44, 0x10, 0x01, 0x12, 0x13, 0x0a, 0x0f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f,
+	0x4e, 0x4f, 0x54, 0x5f, 0x46, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x02, 0x32, 0xcf, 0x07, 0x0a, 0x08,
+	0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x03, 0x48, 0x61, 0x73, 0x12,
+	0x11, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x48, 0x61, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x12, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x48, 0x61, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x03, 0x47, 0x65, 0x74, 0x12, 0x11, 0x2e,
+	0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x12, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x03, 0x50, 0x75, 0x74, 0x12, 0x11, 0x2e, 0x72, 0x70,
+	0x63, 0x64, 0x62, 0x2e, 0x50, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12,
+	0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x50, 0x75, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x35, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x14, 0x2e, 0x72,
+	0x70, 0x63, 0x64, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x15, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a, 0x0b, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x19, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62,
+	0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x38, 0x0a, 0x07, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x12, 0x15, 0x2e, 0x72, 0x70, 0x63,
+	0x64, 0x62, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x16, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x63,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x05, 0x43, 0x6c, 0x6f,
+	0x73, 0x65, 0x12, 0x13, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e,
+	0x43, 0x6c, 0x6f, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a,
+	0x0b, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x16, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1a, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x48, 0x65, 0x61,
+	0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x41, 0x0a, 0x0a, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x18,
+	0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62,
+	0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x7a, 0x0a, 0x1d, 0x4e, 0x65, 0x77, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74,
+	0x6f, 0x72, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x6e, 0x64, 0x50, 0x72,
+	0x65, 0x66, 0x69, 0x78, 0x12, 0x2b, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x4e, 0x65, 0x77,
 	0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x61, 0x72,
-	0x74, 0x41, 0x6e, 0x64, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x47, 0x0a, 0x0c, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x4e, 0x65,
-	0x78, 0x74, 0x12, 0x1a, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x49, 0x74, 0x65, 0x72, 0x61,
-	0x74, 0x6f, 0x72, 0x4e, 0x65, 0x78, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b,
-	0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x4e,
-	0x65, 0x78, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4a, 0x0a, 0x0d, 0x49,
-	0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x1b, 0x2e, 0x72,
-	0x70, 0x63, 0x64, 0x62, 0x2e, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x45, 0x72, 0x72,
-	0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x72, 0x70, 0x63, 0x64,
-	0x62, 0x2e, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x50, 0x0a, 0x0f, 0x49, 0x74, 0x65, 0x72, 0x61,
-	0x74, 0x6f, 0x72, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x12, 0x1d, 0x2e, 0x72, 0x70, 0x63,
-	0x64, 0x62, 0x2e, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x6c, 0x65, 0x61,
-	0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x72, 0x70, 0x63, 0x64,
-	0x62, 0x2e, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73,
-	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x30, 0x5a, 0x2e, 0x67, 0x69, 0x74,
-	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x76, 0x61, 0x2d, 0x6c, 0x61, 0x62, 0x73,
-	0x2f, 0x61, 0x76, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x68, 0x65, 0x67, 0x6f, 0x2f, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2f, 0x70, 0x62, 0x2f, 0x72, 0x70, 0x63, 0x64, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	0x74, 0x41, 0x6e, 0x64, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x2c, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x4e, 0x65, 0x77, 0x49, 0x74, 0x65,
+	0x72, 0x61, 0x74, 0x6f, 0x72, 0x57, 0x69, 0x74, 0x68, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x6e,
+	0x64, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x65, 0x0a, 0x16, 0x4e, 0x65, 0x77, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x57, 0x69,
+	0x74, 0x68, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x24, 0x2e, 0x72, 0x70, 0x63, 0x64,
+	0x62, 0x2e, 0x4e, 0x65, 0x77, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x57, 0x69, 0x74,
+	0x68, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x25, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x4e, 0x65, 0x77, 0x49, 0x74, 0x65, 0x72, 0x61,
+	0x74, 0x6f, 0x72, 0x57, 0x69, 0x74, 0x68, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x0c, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74,
+	0x6f, 0x72, 0x4e, 0x65, 0x78, 0x74, 0x12, 0x1a, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x49,
+	0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x4e, 0x65, 0x78, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x49, 0x74, 0x65, 0x72, 0x61,
+	0x74, 0x6f, 0x72, 0x4e, 0x65, 0x78, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x4a, 0x0a, 0x0d, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x12, 0x1b, 0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f,
+	0x72, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e,
+	0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x45, 0x72,
+	0x72, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x50, 0x0a, 0x0f, 0x49,
+	0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x12, 0x1d,
+	0x2e, 0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52,
+	0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e,
+	0x72, 0x70, 0x63, 0x64, 0x62, 0x2e, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65,
+	0x6c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x30, 0x5a,
+	0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x76, 0x61, 0x2d,
+	0x6c, 0x61, 0x62, 0x73, 0x2f, 0x61, 0x76, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x68, 0x65, 0x67, 0x6f,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x70, 0x62, 0x2f, 0x72, 0x70, 0x63, 0x64, 0x62, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1387,7 +1649,7 @@ func file_rpcdb_rpcdb_proto_rawDescGZIP() []byte {
 }
 
 var file_rpcdb_rpcdb_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_rpcdb_rpcdb_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_rpcdb_rpcdb_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
 var file_rpcdb_rpcdb_proto_goTypes = []interface{}{
 	(Error)(0),                                    // 0: rpcdb.Error
 	(*HasRequest)(nil),                            // 1: rpcdb.HasRequest
@@ -1398,66 +1660,76 @@ var file_rpcdb_rpcdb_proto_goTypes = []interface{}{
 	(*PutResponse)(nil),                           // 6: rpcdb.PutResponse
 	(*DeleteRequest)(nil),                         // 7: rpcdb.DeleteRequest
 	(*DeleteResponse)(nil),                        // 8: rpcdb.DeleteResponse
-	(*CompactRequest)(nil),                        // 9: rpcdb.CompactRequest
-	(*CompactResponse)(nil),                       // 10: rpcdb.CompactResponse
-	(*CloseRequest)(nil),                          // 11: rpcdb.CloseRequest
-	(*CloseResponse)(nil),                         // 12: rpcdb.CloseResponse
-	(*WriteBatchRequest)(nil),                     // 13: rpcdb.WriteBatchRequest
-	(*WriteBatchResponse)(nil),                    // 14: rpcdb.WriteBatchResponse
-	(*NewIteratorRequest)(nil),                    // 15: rpcdb.NewIteratorRequest
-	(*NewIteratorWithStartAndPrefixRequest)(nil),  // 16: rpcdb.NewIteratorWithStartAndPrefixRequest
-	(*NewIteratorWithStartAndPrefixResponse)(nil), // 17: rpcdb.NewIteratorWithStartAndPrefixResponse
-	(*IteratorNextRequest)(nil),                   // 18: rpcdb.IteratorNextRequest
-	(*IteratorNextResponse)(nil),                  // 19: rpcdb.IteratorNextResponse
-	(*IteratorErrorRequest)(nil),                  // 20: rpcdb.IteratorErrorRequest
-	(*IteratorErrorResponse)(nil),                 // 21: rpcdb.IteratorErrorResponse
-	(*IteratorReleaseRequest)(nil),                // 22: rpcdb.IteratorReleaseRequest
-	(*IteratorReleaseResponse)(nil),               // 23: rpcdb.IteratorReleaseResponse
-	(*HealthCheckResponse)(nil),                   // 24: rpcdb.HealthCheckResponse
-	(*emptypb.Empty)(nil),                         // 25: google.protobuf.Empty
+	(*DeleteRangeRequest)(nil),                    // 9: rpcdb.DeleteRangeRequest
+	(*DeleteRangeResponse)(nil),                   // 10: rpcdb.DeleteRangeResponse
+	(*CompactRequest)(nil),                        // 11: rpcdb.CompactRequest
+	(*CompactResponse)(nil),                       // 12: rpcdb.CompactResponse
+	(*CloseRequest)(nil),                          // 13: rpcdb.CloseRequest
+	(*CloseResponse)(nil),                         // 14: rpcdb.CloseResponse
+	(*WriteBatchRequest)(nil),                     // 15: rpcdb.WriteBatchRequest
+	(*WriteBatchResponse)(nil),                    // 16: rpcdb.WriteBatchResponse
+	(*NewIteratorRequest)(nil),                    // 17: rpcdb.NewIteratorRequest
+	(*NewIteratorWithStartAndPrefixRequest)(nil),  // 18: rpcdb.NewIteratorWithStartAndPrefixRequest
+	(*NewIteratorWithStartAndPrefixResponse)(nil), // 19: rpcdb.NewIteratorWithStartAndPrefixResponse
+	(*NewIteratorWithOptionsRequest)(nil),         // 20: rpcdb.NewIteratorWithOptionsRequest
+	(*NewIteratorWithOptionsResponse)(nil),        // 21: rpcdb.NewIteratorWithOptionsResponse
+	(*IteratorNextRequest)(nil),                   // 22: rpcdb.IteratorNextRequest
+	(*IteratorNextResponse)(nil),                  // 23: rpcdb.IteratorNextResponse
+	(*IteratorErrorRequest)(nil),                  // 24: rpcdb.IteratorErrorRequest
+	(*IteratorErrorResponse)(nil),                 // 25: rpcdb.IteratorErrorResponse
+	(*IteratorReleaseRequest)(nil),                // 26: rpcdb.IteratorReleaseRequest
+	(*IteratorReleaseResponse)(nil),               // 27: rpcdb.IteratorReleaseResponse
+	(*HealthCheckResponse)(nil),                   // 28: rpcdb.HealthCheckResponse
+	(*emptypb.Empty)(nil),                         // 29: google.protobuf.Empty
 }
 var file_rpcdb_rpcdb_proto_depIdxs = []int32{
 	0,  // 0: rpcdb.HasResponse.err:type_name -> rpcdb.Error
 	0,  // 1: rpcdb.GetResponse.err:type_name -> rpcdb.Error
 	0,  // 2: rpcdb.PutResponse.err:type_name -> rpcdb.Error
 	0,  // 3: rpcdb.DeleteResponse.err:type_name -> rpcdb.Error
-	0,  // 4: rpcdb.CompactResponse.err:type_name -> rpcdb.Error
-	0,  // 5: rpcdb.CloseResponse.err:type_name -> rpcdb.Error
-	5,  // 6: rpcdb.WriteBatchRequest.puts:type_name -> rpcdb.PutRequest
-	7,  // 7: rpcdb.WriteBatchRequest.deletes:type_name -> rpcdb.DeleteRequest
-	0,  // 8: rpcdb.WriteBatchResponse.err:type_name -> rpcdb.Error
-	5,  // 9: rpcdb.IteratorNextResponse.data:type_name -> rpcdb.PutRequest
-	0,  // 10: rpcdb.IteratorErrorResponse.err:type_name -> rpcdb.Error
-	0,  // 11: rpcdb.IteratorReleaseResponse.err:type_name -> rpcdb.Error
-	1,  // 12: rpcdb.Database.Has:input_type -> rpcdb.HasRequest
-	3,  // 13: rpcdb.Database.Get:input_type -> rpcdb.GetRequest
-	5,  // 14: rpcdb.Database.Put:input_type -> rpcdb.PutRequest
-	7,  // 15: rpcdb.Database.Delete:input_type -> rpcdb.DeleteRequest
-	9,  // 16: rpcdb.Database.Compact:input_type -> rpcdb.CompactRequest
-	11, // 17: rpcdb.Database.Close:input_type -> rpcdb.CloseRequest
-	25, // 18: rpcdb.Database.HealthCheck:input_type -> google.protobuf.Empty
-	13, // 19: rpcdb.Database.WriteBatch:input_type -> rpcdb.WriteBatchRequest
-	16, // 20: rpcdb.Database.NewIteratorWithStartAndPrefix:input_type -> rpcdb.NewIteratorWithStartAndPrefixRequest
-	18, // 21: rpcdb.Database.IteratorNext:input_type -> rpcdb.IteratorNextRequest
-	20, // 22: rpcdb.Database.IteratorError:input_type -> rpcdb.IteratorErrorRequest
-	22, // 23: rpcdb.Database.IteratorRelease:input_type -> rpcdb.IteratorReleaseRequest
-	2,  // 24: rpcdb.Database.Has:output_type -> rpcdb.HasResponse
-	4,  // 25: rpcdb.Database.Get:output_type -> rpcdb.GetResponse
-	6,  // 26: rpcdb.Database.Put:output_type -> rpcdb.PutResponse
-	8,  // 27: rpcdb.Database.Delete:output_type -> rpcdb.DeleteResponse
-	10, // 28: rpcdb.Database.Compact:output_type -> rpcdb.CompactResponse
-	12, // 29: rpcdb.Database.Close:output_type -> rpcdb.CloseResponse
-	24, // 30: rpcdb.Database.HealthCheck:output_type -> rpcdb.HealthCheckResponse
-	14, // 31: rpcdb.Database.WriteBatch:output_type -> rpcdb.WriteBatchResponse
-	17, // 32: rpcdb.Database.NewIteratorWithStartAndPrefix:output_type -> rpcdb.NewIteratorWithStartAndPrefixResponse
-	19, // 33: rpcdb.Database.IteratorNext:output_type -> rpcdb.IteratorNextResponse
-	21, // 34: rpcdb.Database.IteratorError:output_type -> rpcdb.IteratorErrorResponse
-	23, // 35: rpcdb.Database.IteratorRelease:output_type -> rpcdb.IteratorReleaseResponse
-	24, // [24:36] is the sub-list for method output_type
-	12, // [12:24] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	0,  // 4: rpcdb.DeleteRangeResponse.err:type_name -> rpcdb.Error
+	0,  // 5: rpcdb.CompactResponse.err:type_name -> rpcdb.Error
+	0,  // 6: rpcdb.CloseResponse.err:type_name -> rpcdb.Error
+	5,  // 7: rpcdb.WriteBatchRequest.puts:type_name -> rpcdb.PutRequest
+	7,  // 8: rpcdb.WriteBatchRequest.deletes:type_name -> rpcdb.DeleteRequest
+	9,  // 9: rpcdb.WriteBatchRequest.delete_ranges:type_name -> rpcdb.DeleteRangeRequest
+	0,  // 10: rpcdb.WriteBatchResponse.err:type_name -> rpcdb.Error
+	5,  // 11: rpcdb.IteratorNextResponse.data:type_name -> rpcdb.PutRequest
+	0,  // 12: rpcdb.IteratorErrorResponse.err:type_name -> rpcdb.Error
+	0,  // 13: rpcdb.IteratorReleaseResponse.err:type_name -> rpcdb.Error
+	1,  // 14: rpcdb.Database.Has:input_type -> rpcdb.HasRequest
+	3,  // 15: rpcdb.Database.Get:input_type -> rpcdb.GetRequest
+	5,  // 16: rpcdb.Database.Put:input_type -> rpcdb.PutRequest
+	7,  // 17: rpcdb.Database.Delete:input_type -> rpcdb.DeleteRequest
+	9,  // 18: rpcdb.Database.DeleteRange:input_type -> rpcdb.DeleteRangeRequest
+	11, // 19: rpcdb.Database.Compact:input_type -> rpcdb.CompactRequest
+	13, // 20: rpcdb.Database.Close:input_type -> rpcdb.CloseRequest
+	29, // 21: rpcdb.Database.HealthCheck:input_type -> google.protobuf.Empty
+	15, // 22: rpcdb.Database.WriteBatch:input_type -> rpcdb.WriteBatchRequest
+	18, // 23: rpcdb.Database.NewIteratorWithStartAndPrefix:input_type -> rpcdb.NewIteratorWithStartAndPrefixRequest
+	20, // 24: rpcdb.Database.NewIteratorWithOptions:input_type -> rpcdb.NewIteratorWithOptionsRequest
+	22, // 25: rpcdb.Database.IteratorNext:input_type -> rpcdb.IteratorNextRequest
+	24, // 26: rpcdb.Database.IteratorError:input_type -> rpcdb.IteratorErrorRequest
+	26, // 27: rpcdb.Database.IteratorRelease:input_type -> rpcdb.IteratorReleaseRequest
+	2,  // 28: rpcdb.Database.Has:output_type -> rpcdb.HasResponse
+	4,  // 29: rpcdb.Database.Get:output_type -> rpcdb.GetResponse
+	6,  // 30: rpcdb.Database.Put:output_type -> rpcdb.PutResponse
+	8,  // 31: rpcdb.Database.Delete:output_type -> rpcdb.DeleteResponse
+	10, // 32: rpcdb.Database.DeleteRange:output_type -> rpcdb.DeleteRangeResponse
+	12, // 33: rpcdb.Database.Compact:output_type -> rpcdb.CompactResponse
+	14, // 34: rpcdb.Database.Close:output_type -> rpcdb.CloseResponse
+	28, // 35: rpcdb.Database.HealthCheck:output_type -> rpcdb.HealthCheckResponse
+	16, // 36: rpcdb.Database.WriteBatch:output_type -> rpcdb.WriteBatchResponse
+	19, // 37: rpcdb.Database.NewIteratorWithStartAndPrefix:output_type -> rpcdb.NewIteratorWithStartAndPrefixResponse
+	21, // 38: rpcdb.Database.NewIteratorWithOptions:output_type -> rpcdb.NewIteratorWithOptionsResponse
+	23, // 39: rpcdb.Database.IteratorNext:output_type -> rpcdb.IteratorNextResponse
+	25, // 40: rpcdb.Database.IteratorError:output_type -> rpcdb.IteratorErrorResponse
+	27, // 41: rpcdb.Database.IteratorRelease:output_type -> rpcdb.IteratorReleaseResponse
+	28, // [28:42] is the sub-list for method output_type
+	14, // [14:28] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_rpcdb_rpcdb_proto_init() }
@@ -1563,7 +1835,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CompactRequest); i {
+			switch v := v.(*DeleteRangeRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1575,7 +1847,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CompactResponse); i {
+			switch v := v.(*DeleteRangeResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1587,7 +1859,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CloseRequest); i {
+			switch v := v.(*CompactRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1599,7 +1871,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CloseResponse); i {
+			switch v := v.(*CompactResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1611,7 +1883,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WriteBatchRequest); i {
+			switch v := v.(*CloseRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1623,7 +1895,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WriteBatchResponse); i {
+			switch v := v.(*CloseResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1635,7 +1907,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NewIteratorRequest); i {
+			switch v := v.(*WriteBatchRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1647,7 +1919,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NewIteratorWithStartAndPrefixRequest); i {
+			switch v := v.(*WriteBatchResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1659,7 +1931,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NewIteratorWithStartAndPrefixResponse); i {
+			switch v := v.(*NewIteratorRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1671,7 +1943,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IteratorNextRequest); i {
+			switch v := v.(*NewIteratorWithStartAndPrefixRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1683,7 +1955,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IteratorNextResponse); i {
+			switch v := v.(*NewIteratorWithStartAndPrefixResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1695,7 +1967,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IteratorErrorRequest); i {
+			switch v := v.(*NewIteratorWithOptionsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1707,7 +1979,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IteratorErrorResponse); i {
+			switch v := v.(*NewIteratorWithOptionsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1719,7 +1991,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IteratorReleaseRequest); i {
+			switch v := v.(*IteratorNextRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1731,7 +2003,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IteratorReleaseResponse); i {
+			switch v := v.(*IteratorNextResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1743,6 +2015,54 @@ func file_rpcdb_rpcdb_proto_init() {
 			}
 		}
 		file_rpcdb_rpcdb_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IteratorErrorRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpcdb_rpcdb_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IteratorErrorResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpcdb_rpcdb_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IteratorReleaseRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpcdb_rpcdb_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IteratorReleaseResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpcdb_rpcdb_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*HealthCheckResponse); i {
 			case 0:
 				return &v.state
@@ -1761,7 +2081,7 @@ func file_rpcdb_rpcdb_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_rpcdb_rpcdb_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   24,
+			NumMessages:   28,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
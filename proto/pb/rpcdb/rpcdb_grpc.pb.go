@@ -24,11 +24,13 @@ const (
 	Database_Get_FullMethodName                           = "/rpcdb.Database/Get"
 	Database_Put_FullMethodName                           = "/rpcdb.Database/Put"
 	Database_Delete_FullMethodName                        = "/rpcdb.Database/Delete"
+	Database_DeleteRange_FullMethodName                   = "/rpcdb.Database/DeleteRange"
 	Database_Compact_FullMethodName                       = "/rpcdb.Database/Compact"
 	Database_Close_FullMethodName                         = "/rpcdb.Database/Close"
 	Database_HealthCheck_FullMethodName                   = "/rpcdb.Database/HealthCheck"
 	Database_WriteBatch_FullMethodName                    = "/rpcdb.Database/WriteBatch"
 	Database_NewIteratorWithStartAndPrefix_FullMethodName = "/rpcdb.Database/NewIteratorWithStartAndPrefix"
+	Database_NewIteratorWithOptions_FullMethodName        = "/rpcdb.Database/NewIteratorWithOptions"
 	Database_IteratorNext_FullMethodName                  = "/rpcdb.Database/IteratorNext"
 	Database_IteratorError_FullMethodName                 = "/rpcdb.Database/IteratorError"
 	Database_IteratorRelease_FullMethodName               = "/rpcdb.Database/IteratorRelease"
@@ -42,11 +44,13 @@ type DatabaseClient interface {
 	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
 	Put(ctx context.Context, in *PutRequest, opts ...grpc.CallOption) (*PutResponse, error)
 	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	DeleteRange(ctx context.Context, in *DeleteRangeRequest, opts ...grpc.CallOption) (*DeleteRangeResponse, error)
 	Compact(ctx context.Context, in *CompactRequest, opts ...grpc.CallOption) (*CompactResponse, error)
 	Close(ctx context.Context, in *CloseRequest, opts ...grpc.CallOption) (*CloseResponse, error)
 	HealthCheck(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*HealthCheckResponse, error)
 	WriteBatch(ctx context.Context, in *WriteBatchRequest, opts ...grpc.CallOption) (*WriteBatchResponse, error)
 	NewIteratorWithStartAndPrefix(ctx context.Context, in *NewIteratorWithStartAndPrefixRequest, opts ...grpc.CallOption) (*NewIteratorWithStartAndPrefixResponse, error)
+	NewIteratorWithOptions(ctx context.Context, in *NewIteratorWithOptionsRequest, opts ...grpc.CallOption) (*NewIteratorWithOptionsResponse, error)
 	IteratorNext(ctx context.Context, in *IteratorNextRequest, opts ...grpc.CallOption) (*IteratorNextResponse, error)
 	IteratorError(ctx context.Context, in *IteratorErrorRequest, opts ...grpc.CallOption) (*IteratorErrorResponse, error)
 	IteratorRelease(ctx context.Context, in *IteratorReleaseRequest, opts ...grpc.CallOption) (*IteratorReleaseResponse, error)
@@ -96,6 +100,15 @@ func (c *databaseClient) Delete(ctx context.Context, in *DeleteRequest, opts ...
 	return out, nil
 }
 
+func (c *databaseClient) DeleteRange(ctx context.Context, in *DeleteRangeRequest, opts ...grpc.CallOption) (*DeleteRangeResponse, error) {
+	out := new(DeleteRangeResponse)
+	err := c.cc.Invoke(ctx, Database_DeleteRange_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *databaseClient) Compact(ctx context.Context, in *CompactRequest, opts ...grpc.CallOption) (*CompactResponse, error) {
 	out := new(CompactResponse)
 	err := c.cc.Invoke(ctx, Database_Compact_FullMethodName, in, out, opts...)
@@ -141,6 +154,15 @@ func (c *databaseClient) NewIteratorWithStartAndPrefix(ctx context.Context, in *
 	return out, nil
 }
 
+func (c *databaseClient) NewIteratorWithOptions(ctx context.Context, in *NewIteratorWithOptionsRequest, opts ...grpc.CallOption) (*NewIteratorWithOptionsResponse, error) {
+	out := new(NewIteratorWithOptionsResponse)
+	err := c.cc.Invoke(ctx, Database_NewIteratorWithOptions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *databaseClient) IteratorNext(ctx context.Context, in *IteratorNextRequest, opts ...grpc.CallOption) (*IteratorNextResponse, error) {
 	out := new(IteratorNextResponse)
 	err := c.cc.Invoke(ctx, Database_IteratorNext_FullMethodName, in, out, opts...)
@@ -176,11 +198,13 @@ type DatabaseServer interface {
 	Get(context.Context, *GetRequest) (*GetResponse, error)
 	Put(context.Context, *PutRequest) (*PutResponse, error)
 	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	DeleteRange(context.Context, *DeleteRangeRequest) (*DeleteRangeResponse, error)
 	Compact(context.Context, *CompactRequest) (*CompactResponse, error)
 	Close(context.Context, *CloseRequest) (*CloseResponse, error)
 	HealthCheck(context.Context, *emptypb.Empty) (*HealthCheckResponse, error)
 	WriteBatch(context.Context, *WriteBatchRequest) (*WriteBatchResponse, error)
 	NewIteratorWithStartAndPrefix(context.Context, *NewIteratorWithStartAndPrefixRequest) (*NewIteratorWithStartAndPrefixResponse, error)
+	NewIteratorWithOptions(context.Context, *NewIteratorWithOptionsRequest) (*NewIteratorWithOptionsResponse, error)
 	IteratorNext(context.Context, *IteratorNextRequest) (*IteratorNextResponse, error)
 	IteratorError(context.Context, *IteratorErrorRequest) (*IteratorErrorResponse, error)
 	IteratorRelease(context.Context, *IteratorReleaseRequest) (*IteratorReleaseResponse, error)
@@ -203,6 +227,9 @@ func (UnimplementedDatabaseServer) Put(context.Context, *PutRequest) (*PutRespon
 func (UnimplementedDatabaseServer) Delete(context.Context, *DeleteRequest) (*DeleteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
+func (UnimplementedDatabaseServer) DeleteRange(context.Context, *DeleteRangeRequest) (*DeleteRangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRange not implemented")
+}
 func (UnimplementedDatabaseServer) Compact(context.Context, *CompactRequest) (*CompactResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Compact not implemented")
 }
@@ -218,6 +245,9 @@ func (UnimplementedDatabaseServer) WriteBatch(context.Context, *WriteBatchReques
 func (UnimplementedDatabaseServer) NewIteratorWithStartAndPrefix(context.Context, *NewIteratorWithStartAndPrefixRequest) (*NewIteratorWithStartAndPrefixResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method NewIteratorWithStartAndPrefix not implemented")
 }
+func (UnimplementedDatabaseServer) NewIteratorWithOptions(context.Context, *NewIteratorWithOptionsRequest) (*NewIteratorWithOptionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NewIteratorWithOptions not implemented")
+}
 func (UnimplementedDatabaseServer) IteratorNext(context.Context, *IteratorNextRequest) (*IteratorNextResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method IteratorNext not implemented")
 }
@@ -312,6 +342,24 @@ func _Database_Delete_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Database_DeleteRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).DeleteRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Database_DeleteRange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).DeleteRange(ctx, req.(*DeleteRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Database_Compact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CompactRequest)
 	if err := dec(in); err != nil {
@@ -402,6 +450,24 @@ func _Database_NewIteratorWithStartAndPrefix_Handler(srv interface{}, ctx contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Database_NewIteratorWithOptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewIteratorWithOptionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).NewIteratorWithOptions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Database_NewIteratorWithOptions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).NewIteratorWithOptions(ctx, req.(*NewIteratorWithOptionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Database_IteratorNext_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(IteratorNextRequest)
 	if err := dec(in); err != nil {
@@ -479,6 +545,10 @@ var Database_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Delete",
 			Handler:    _Database_Delete_Handler,
 		},
+		{
+			MethodName: "DeleteRange",
+			Handler:    _Database_DeleteRange_Handler,
+		},
 		{
 			MethodName: "Compact",
 			Handler:    _Database_Compact_Handler,
@@ -499,6 +569,10 @@ var Database_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "NewIteratorWithStartAndPrefix",
 			Handler:    _Database_NewIteratorWithStartAndPrefix_Handler,
 		},
+		{
+			MethodName: "NewIteratorWithOptions",
+			Handler:    _Database_NewIteratorWithOptions_Handler,
+		},
 		{
 			MethodName: "IteratorNext",
 			Handler:    _Database_IteratorNext_Handler,
@@ -122,6 +122,53 @@ func (x *PullGossipResponse) GetGossip() [][]byte {
 	return nil
 }
 
+type PushGossip struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Gossip [][]byte `protobuf:"bytes,1,rep,name=gossip,proto3" json:"gossip,omitempty"`
+}
+
+func (x *PushGossip) Reset() {
+	*x = PushGossip{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sdk_sdk_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PushGossip) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushGossip) ProtoMessage() {}
+
+func (x *PushGossip) ProtoReflect() protoreflect.Message {
+	mi := &file_sdk_sdk_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushGossip.ProtoReflect.Descriptor instead.
+func (*PushGossip) Descriptor() ([]byte, []int) {
+	return file_sdk_sdk_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PushGossip) GetGossip() [][]byte {
+	if x != nil {
+		return x.Gossip
+	}
+	return nil
+}
+
 var File_sdk_sdk_proto protoreflect.FileDescriptor
 
 var file_sdk_sdk_proto_rawDesc = []byte{
@@ -133,10 +180,13 @@ var file_sdk_sdk_proto_rawDesc = []byte{
 	0x04, 0x73, 0x61, 0x6c, 0x74, 0x22, 0x2c, 0x0a, 0x12, 0x50, 0x75, 0x6c, 0x6c, 0x47, 0x6f, 0x73,
 	0x73, 0x69, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x67,
 	0x6f, 0x73, 0x73, 0x69, 0x70, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x06, 0x67, 0x6f, 0x73,
-	0x73, 0x69, 0x70, 0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
-	0x6d, 0x2f, 0x61, 0x76, 0x61, 0x2d, 0x6c, 0x61, 0x62, 0x73, 0x2f, 0x61, 0x76, 0x61, 0x6c, 0x61,
-	0x6e, 0x63, 0x68, 0x65, 0x67, 0x6f, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x70, 0x62, 0x2f,
-	0x73, 0x64, 0x6b, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x73, 0x69, 0x70, 0x22, 0x24, 0x0a, 0x0a, 0x50, 0x75, 0x73, 0x68, 0x47, 0x6f, 0x73, 0x73, 0x69,
+	0x70, 0x12, 0x16, 0x0a, 0x06, 0x67, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0c, 0x52, 0x06, 0x67, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x76, 0x61, 0x2d, 0x6c, 0x61, 0x62, 0x73,
+	0x2f, 0x61, 0x76, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x68, 0x65, 0x67, 0x6f, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2f, 0x70, 0x62, 0x2f, 0x73, 0x64, 0x6b, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 }
 
 var (
@@ -151,10 +201,11 @@ func file_sdk_sdk_proto_rawDescGZIP() []byte {
 	return file_sdk_sdk_proto_rawDescData
 }
 
-var file_sdk_sdk_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_sdk_sdk_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_sdk_sdk_proto_goTypes = []interface{}{
 	(*PullGossipRequest)(nil),  // 0: sdk.PullGossipRequest
 	(*PullGossipResponse)(nil), // 1: sdk.PullGossipResponse
+	(*PushGossip)(nil),         // 2: sdk.PushGossip
 }
 var file_sdk_sdk_proto_depIdxs = []int32{
 	0, // [0:0] is the sub-list for method output_type
@@ -194,6 +245,18 @@ func file_sdk_sdk_proto_init() {
 				return nil
 			}
 		}
+		file_sdk_sdk_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushGossip); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -201,7 +264,7 @@ func file_sdk_sdk_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_sdk_sdk_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
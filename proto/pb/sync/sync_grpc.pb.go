@@ -22,6 +22,7 @@ const _ = grpc.SupportPackageIsVersion7
 const (
 	DB_GetMerkleRoot_FullMethodName     = "/sync.DB/GetMerkleRoot"
 	DB_GetProof_FullMethodName          = "/sync.DB/GetProof"
+	DB_GetMultiProof_FullMethodName     = "/sync.DB/GetMultiProof"
 	DB_GetChangeProof_FullMethodName    = "/sync.DB/GetChangeProof"
 	DB_VerifyChangeProof_FullMethodName = "/sync.DB/VerifyChangeProof"
 	DB_CommitChangeProof_FullMethodName = "/sync.DB/CommitChangeProof"
@@ -35,6 +36,7 @@ const (
 type DBClient interface {
 	GetMerkleRoot(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetMerkleRootResponse, error)
 	GetProof(ctx context.Context, in *GetProofRequest, opts ...grpc.CallOption) (*GetProofResponse, error)
+	GetMultiProof(ctx context.Context, in *GetMultiProofRequest, opts ...grpc.CallOption) (*GetMultiProofResponse, error)
 	GetChangeProof(ctx context.Context, in *GetChangeProofRequest, opts ...grpc.CallOption) (*GetChangeProofResponse, error)
 	VerifyChangeProof(ctx context.Context, in *VerifyChangeProofRequest, opts ...grpc.CallOption) (*VerifyChangeProofResponse, error)
 	CommitChangeProof(ctx context.Context, in *CommitChangeProofRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
@@ -68,6 +70,15 @@ func (c *dBClient) GetProof(ctx context.Context, in *GetProofRequest, opts ...gr
 	return out, nil
 }
 
+func (c *dBClient) GetMultiProof(ctx context.Context, in *GetMultiProofRequest, opts ...grpc.CallOption) (*GetMultiProofResponse, error) {
+	out := new(GetMultiProofResponse)
+	err := c.cc.Invoke(ctx, DB_GetMultiProof_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dBClient) GetChangeProof(ctx context.Context, in *GetChangeProofRequest, opts ...grpc.CallOption) (*GetChangeProofResponse, error) {
 	out := new(GetChangeProofResponse)
 	err := c.cc.Invoke(ctx, DB_GetChangeProof_FullMethodName, in, out, opts...)
@@ -119,6 +130,7 @@ func (c *dBClient) CommitRangeProof(ctx context.Context, in *CommitRangeProofReq
 type DBServer interface {
 	GetMerkleRoot(context.Context, *emptypb.Empty) (*GetMerkleRootResponse, error)
 	GetProof(context.Context, *GetProofRequest) (*GetProofResponse, error)
+	GetMultiProof(context.Context, *GetMultiProofRequest) (*GetMultiProofResponse, error)
 	GetChangeProof(context.Context, *GetChangeProofRequest) (*GetChangeProofResponse, error)
 	VerifyChangeProof(context.Context, *VerifyChangeProofRequest) (*VerifyChangeProofResponse, error)
 	CommitChangeProof(context.Context, *CommitChangeProofRequest) (*emptypb.Empty, error)
@@ -137,6 +149,9 @@ func (UnimplementedDBServer) GetMerkleRoot(context.Context, *emptypb.Empty) (*Ge
 func (UnimplementedDBServer) GetProof(context.Context, *GetProofRequest) (*GetProofResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetProof not implemented")
 }
+func (UnimplementedDBServer) GetMultiProof(context.Context, *GetMultiProofRequest) (*GetMultiProofResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMultiProof not implemented")
+}
 func (UnimplementedDBServer) GetChangeProof(context.Context, *GetChangeProofRequest) (*GetChangeProofResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetChangeProof not implemented")
 }
@@ -201,6 +216,24 @@ func _DB_GetProof_Handler(srv interface{}, ctx context.Context, dec func(interfa
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DB_GetMultiProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMultiProofRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServer).GetMultiProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DB_GetMultiProof_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServer).GetMultiProof(ctx, req.(*GetMultiProofRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DB_GetChangeProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetChangeProofRequest)
 	if err := dec(in); err != nil {
@@ -306,6 +339,10 @@ var DB_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetProof",
 			Handler:    _DB_GetProof_Handler,
 		},
+		{
+			MethodName: "GetMultiProof",
+			Handler:    _DB_GetMultiProof_Handler,
+		},
 		{
 			MethodName: "GetChangeProof",
 			Handler:    _DB_GetChangeProof_Handler,
@@ -0,0 +1,56 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package capture
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/network"
+	"github.com/ava-labs/avalanchego/subnets"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+var _ network.Network = (*capturedNetwork)(nil)
+
+type capturedNetwork struct {
+	network.Network
+	recorder *Recorder
+}
+
+// Network returns a network.Network that records every consensus message sent
+// through [network] to at least one node with [recorder].
+func Network(network network.Network, recorder *Recorder) network.Network {
+	return &capturedNetwork{
+		Network:  network,
+		recorder: recorder,
+	}
+}
+
+func (n *capturedNetwork) Send(
+	msg message.OutboundMessage,
+	nodeIDs set.Set[ids.NodeID],
+	subnetID ids.ID,
+	allower subnets.Allower,
+) set.Set[ids.NodeID] {
+	sentTo := n.Network.Send(msg, nodeIDs, subnetID, allower)
+	if sentTo.Len() > 0 {
+		n.recorder.RecordOutbound(msg, sentTo)
+	}
+	return sentTo
+}
+
+func (n *capturedNetwork) Gossip(
+	msg message.OutboundMessage,
+	subnetID ids.ID,
+	numValidatorsToSend int,
+	numNonValidatorsToSend int,
+	numPeersToSend int,
+	allower subnets.Allower,
+) set.Set[ids.NodeID] {
+	sentTo := n.Network.Gossip(msg, subnetID, numValidatorsToSend, numNonValidatorsToSend, numPeersToSend, allower)
+	if sentTo.Len() > 0 {
+		n.recorder.RecordOutbound(msg, sentTo)
+	}
+	return sentTo
+}
@@ -0,0 +1,154 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package capture
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const (
+	Inbound Direction = iota
+	Outbound
+)
+
+const (
+	frameLenSize = wrappers.IntLen
+	// maxRecordSize is the largest record that may be read. It leaves room for
+	// the fields of the record alongside a message of the max message size.
+	maxRecordSize = 2 * constants.DefaultMaxMessageSize
+)
+
+var (
+	errInvalidDirection = errors.New("invalid direction")
+	errRecordTooLarge   = errors.New("record too large")
+)
+
+// Direction describes whether a message was received or sent.
+type Direction byte
+
+func (d Direction) String() string {
+	switch d {
+	case Inbound:
+		return "inbound"
+	case Outbound:
+		return "outbound"
+	default:
+		return "unknown"
+	}
+}
+
+// Record is a message that was exchanged with peers.
+type Record struct {
+	// Timestamp is when the message was received or sent.
+	Timestamp time.Time
+	Direction Direction
+	// NodeIDs is the sender of an inbound message or the recipients of an
+	// outbound message.
+	NodeIDs []ids.NodeID
+	Op      message.Op
+	// Bytes is the message as sent over the network. It can be parsed with
+	// message.InboundMsgBuilder.
+	Bytes []byte
+}
+
+func (r *Record) size() int {
+	return wrappers.LongLen + // timestamp
+		wrappers.ByteLen + // direction
+		wrappers.IntLen + len(r.NodeIDs)*ids.NodeIDLen + // node IDs
+		wrappers.ByteLen + // op
+		wrappers.IntLen + len(r.Bytes) // bytes
+}
+
+// WriteRecord writes [r] to [w] as a single length-prefixed frame.
+func WriteRecord(w io.Writer, r *Record) error {
+	size := r.size()
+	p := wrappers.Packer{
+		Bytes:   make([]byte, 0, frameLenSize+size),
+		MaxSize: frameLenSize + size,
+	}
+	p.PackInt(uint32(size))
+	p.PackLong(uint64(r.Timestamp.UnixNano()))
+	p.PackByte(byte(r.Direction))
+	p.PackInt(uint32(len(r.NodeIDs)))
+	for _, nodeID := range r.NodeIDs {
+		p.PackFixedBytes(nodeID[:])
+	}
+	p.PackByte(byte(r.Op))
+	p.PackBytes(r.Bytes)
+	if p.Err != nil {
+		return p.Err
+	}
+
+	// Write the frame at once so that it is never split across files.
+	_, err := w.Write(p.Bytes)
+	return err
+}
+
+// ReadRecord reads the next record from [r]. Returns io.EOF if there are no
+// more records.
+func ReadRecord(r io.Reader) (*Record, error) {
+	var frameLen [frameLenSize]byte
+	if _, err := io.ReadFull(r, frameLen[:]); err != nil {
+		return nil, err
+	}
+	size := binary.BigEndian.Uint32(frameLen[:])
+	if size > maxRecordSize {
+		return nil, fmt.Errorf("%w: %d > %d", errRecordTooLarge, size, maxRecordSize)
+	}
+
+	frame := make([]byte, size)
+	if _, err := io.ReadFull(r, frame); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	p := wrappers.Packer{Bytes: frame}
+	record := &Record{
+		Timestamp: time.Unix(0, int64(p.UnpackLong())),
+		Direction: Direction(p.UnpackByte()),
+	}
+	numNodeIDs := p.UnpackInt()
+	if uint64(numNodeIDs)*ids.NodeIDLen > uint64(size) {
+		return nil, fmt.Errorf("%w: %d node IDs", errRecordTooLarge, numNodeIDs)
+	}
+	record.NodeIDs = make([]ids.NodeID, numNodeIDs)
+	for i := range record.NodeIDs {
+		copy(record.NodeIDs[i][:], p.UnpackFixedBytes(ids.NodeIDLen))
+	}
+	record.Op = message.Op(p.UnpackByte())
+	record.Bytes = p.UnpackBytes()
+	if p.Err != nil {
+		return nil, p.Err
+	}
+	if record.Direction != Inbound && record.Direction != Outbound {
+		return nil, fmt.Errorf("%w: %d", errInvalidDirection, record.Direction)
+	}
+	return record, nil
+}
+
+// ReadRecords reads all the records from [r].
+func ReadRecords(r io.Reader) ([]*Record, error) {
+	var records []*Record
+	for {
+		record, err := ReadRecord(r)
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+}
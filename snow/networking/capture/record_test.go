@@ -0,0 +1,107 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package capture
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+)
+
+func TestRecordRoundTrip(t *testing.T) {
+	require := require.New(t)
+
+	records := []*Record{
+		{
+			Timestamp: time.Unix(0, 1),
+			Direction: Inbound,
+			NodeIDs:   []ids.NodeID{ids.GenerateTestNodeID()},
+			Op:        message.PushQueryOp,
+			Bytes:     []byte("push query"),
+		},
+		{
+			Timestamp: time.Unix(123, 456),
+			Direction: Outbound,
+			NodeIDs:   []ids.NodeID{ids.GenerateTestNodeID(), ids.GenerateTestNodeID()},
+			Op:        message.ChitsOp,
+			Bytes:     []byte("chits"),
+		},
+		{
+			Timestamp: time.Unix(789, 0),
+			Direction: Outbound,
+			NodeIDs:   []ids.NodeID{},
+			Op:        message.AppGossipOp,
+			Bytes:     []byte{},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	for _, record := range records {
+		require.NoError(WriteRecord(buf, record))
+	}
+
+	readRecords, err := ReadRecords(buf)
+	require.NoError(err)
+	require.Equal(records, readRecords)
+}
+
+func TestReadRecordErrors(t *testing.T) {
+	record := &Record{
+		Timestamp: time.Unix(0, 1),
+		Direction: Inbound,
+		NodeIDs:   []ids.NodeID{ids.GenerateTestNodeID()},
+		Op:        message.PushQueryOp,
+		Bytes:     []byte("push query"),
+	}
+	buf := &bytes.Buffer{}
+	require.NoError(t, WriteRecord(buf, record))
+	recordBytes := buf.Bytes()
+
+	invalidDirection := bytes.Clone(recordBytes)
+	invalidDirection[frameLenSize+8] = 2
+
+	tests := []struct {
+		name        string
+		bytes       []byte
+		expectedErr error
+	}{
+		{
+			name:        "empty",
+			bytes:       nil,
+			expectedErr: io.EOF,
+		},
+		{
+			name:        "truncated length",
+			bytes:       recordBytes[:frameLenSize-1],
+			expectedErr: io.ErrUnexpectedEOF,
+		},
+		{
+			name:        "truncated record",
+			bytes:       recordBytes[:len(recordBytes)-1],
+			expectedErr: io.ErrUnexpectedEOF,
+		},
+		{
+			name:        "too large",
+			bytes:       []byte{0xff, 0xff, 0xff, 0xff},
+			expectedErr: errRecordTooLarge,
+		},
+		{
+			name:        "invalid direction",
+			bytes:       invalidDirection,
+			expectedErr: errInvalidDirection,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ReadRecord(bytes.NewReader(test.bytes))
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package capture
+
+import (
+	"io"
+	"path/filepath"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"go.uber.org/zap"
+
+	"google.golang.org/protobuf/proto"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+const (
+	// FileName is the name of the file messages are captured into. Rotated
+	// files are renamed to include the time they were rotated.
+	FileName = "messages.capture"
+
+	// bufferSize is the number of records that can wait to be written before
+	// new records are dropped.
+	bufferSize = 1024
+)
+
+type Config struct {
+	// Enabled is true if the messages exchanged with peers should be captured.
+	Enabled bool `json:"enabled"`
+	// Directory is where the capture files are written.
+	Directory string `json:"directory"`
+	// MaxFileSize is the size, in megabytes, a capture file grows to before it
+	// is rotated.
+	MaxFileSize int `json:"maxFileSize"`
+	// MaxFiles is the number of rotated capture files to keep. If 0, all the
+	// rotated files are kept.
+	MaxFiles int `json:"maxFiles"`
+}
+
+// Recorder writes the messages exchanged with peers as Records.
+//
+// Records are written by a background goroutine, so that recording never
+// blocks on [writer]. If records are produced faster than they can be written,
+// new records are dropped.
+type Recorder struct {
+	log     logging.Logger
+	clock   mockable.Clock
+	writer  io.WriteCloser
+	dropped prometheus.Counter
+
+	// lock prevents [records] from being closed while a record is sent on it.
+	lock    sync.RWMutex
+	closed  bool
+	records chan *Record
+	// written is closed once every record sent on [records] has been
+	// written.
+	written chan struct{}
+}
+
+// NewRecorder returns a Recorder that writes records into [writer].
+func NewRecorder(
+	log logging.Logger,
+	writer io.WriteCloser,
+	namespace string,
+	registerer prometheus.Registerer,
+) (*Recorder, error) {
+	r := &Recorder{
+		log:    log,
+		writer: writer,
+		dropped: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "dropped_records",
+			Help:      "Number of captured messages that were dropped because they couldn't be written quickly enough",
+		}),
+		records: make(chan *Record, bufferSize),
+		written: make(chan struct{}),
+	}
+	if err := registerer.Register(r.dropped); err != nil {
+		return nil, err
+	}
+
+	go r.write()
+	return r, nil
+}
+
+// NewFileRecorder returns a Recorder that writes records into rotating files
+// described by [config].
+func NewFileRecorder(
+	log logging.Logger,
+	config Config,
+	namespace string,
+	registerer prometheus.Registerer,
+) (*Recorder, error) {
+	return NewRecorder(
+		log,
+		&lumberjack.Logger{
+			Filename:   filepath.Join(config.Directory, FileName),
+			MaxSize:    config.MaxFileSize,
+			MaxBackups: config.MaxFiles,
+		},
+		namespace,
+		registerer,
+	)
+}
+
+// RecordInbound records that [msg] was received from [msg.NodeID()].
+// Messages that weren't received from the network are ignored.
+func (r *Recorder) RecordInbound(msg message.InboundMessage) {
+	wrapped, err := message.Wrap(msg.Message())
+	if err != nil {
+		r.log.Debug("not capturing internal message",
+			zap.Stringer("messageOp", msg.Op()),
+		)
+		return
+	}
+	bytes, err := proto.Marshal(wrapped)
+	if err != nil {
+		r.log.Warn("failed to marshal captured message",
+			zap.Stringer("messageOp", msg.Op()),
+			zap.Error(err),
+		)
+		return
+	}
+	r.record(&Record{
+		Direction: Inbound,
+		NodeIDs:   []ids.NodeID{msg.NodeID()},
+		Op:        msg.Op(),
+		Bytes:     bytes,
+	})
+}
+
+// RecordOutbound records that [msg] was sent to [nodeIDs].
+func (r *Recorder) RecordOutbound(msg message.OutboundMessage, nodeIDs set.Set[ids.NodeID]) {
+	r.record(&Record{
+		Direction: Outbound,
+		NodeIDs:   nodeIDs.List(),
+		Op:        msg.Op(),
+		Bytes:     msg.Bytes(),
+	})
+}
+
+// record queues [record] to be written. If the queue is full, [record] is
+// dropped.
+func (r *Recorder) record(record *Record) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	if r.closed {
+		return
+	}
+
+	record.Timestamp = r.clock.Time()
+	select {
+	case r.records <- record:
+	default:
+		r.dropped.Inc()
+	}
+}
+
+// write writes the queued records until the queue is closed.
+func (r *Recorder) write() {
+	defer close(r.written)
+
+	for record := range r.records {
+		if err := WriteRecord(r.writer, record); err != nil {
+			r.log.Warn("failed to capture message",
+				zap.Stringer("direction", record.Direction),
+				zap.Stringer("messageOp", record.Op),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// Close stops recording messages, waits for the queued records to be written
+// and closes the underlying writer.
+func (r *Recorder) Close() error {
+	r.lock.Lock()
+	if r.closed {
+		r.lock.Unlock()
+		return nil
+	}
+	r.closed = true
+	close(r.records)
+	r.lock.Unlock()
+
+	<-r.written
+	return r.writer.Close()
+}
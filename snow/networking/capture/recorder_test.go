@@ -0,0 +1,189 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package capture
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/proto/pb/p2p"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+type bufferCloser struct {
+	bytes.Buffer
+}
+
+func (*bufferCloser) Close() error {
+	return nil
+}
+
+func newMessageCreator(t *testing.T) message.Creator {
+	mc, err := message.NewCreator(
+		logging.NoLog{},
+		prometheus.NewRegistry(),
+		"",
+		constants.DefaultNetworkCompressionType,
+		10*time.Second,
+	)
+	require.NoError(t, err)
+	return mc
+}
+
+func TestRecorder(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		mc       = newMessageCreator(t)
+		buf      = &bufferCloser{}
+		chainID  = ids.GenerateTestID()
+		sender   = ids.GenerateTestNodeID()
+		receiver = ids.GenerateTestNodeID()
+	)
+	recorder, err := NewRecorder(logging.NoLog{}, buf, "", prometheus.NewRegistry())
+	require.NoError(err)
+
+	inboundMsg := message.InboundChits(
+		chainID,
+		1,
+		ids.GenerateTestID(),
+		ids.GenerateTestID(),
+		ids.GenerateTestID(),
+		sender,
+	)
+	recorder.RecordInbound(inboundMsg)
+
+	outboundMsg, err := mc.PushQuery(
+		chainID,
+		2,
+		time.Second,
+		[]byte("container"),
+		3,
+		p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+	)
+	require.NoError(err)
+	recorder.RecordOutbound(outboundMsg, set.Of(receiver))
+
+	// Internal messages aren't captured.
+	recorder.RecordInbound(message.InternalConnected(sender, nil))
+
+	// Messages recorded after the recorder is closed are dropped.
+	require.NoError(recorder.Close())
+	recorder.RecordOutbound(outboundMsg, set.Of(receiver))
+
+	records, err := ReadRecords(&buf.Buffer)
+	require.NoError(err)
+	require.Len(records, 2)
+
+	inbound := records[0]
+	require.Equal(Inbound, inbound.Direction)
+	require.Equal([]ids.NodeID{sender}, inbound.NodeIDs)
+	require.Equal(message.ChitsOp, inbound.Op)
+
+	parsedInbound, err := mc.Parse(inbound.Bytes, sender, nil)
+	require.NoError(err)
+	require.Equal(message.ChitsOp, parsedInbound.Op())
+	require.Equal(inboundMsg.Message().(*p2p.Chits).PreferredId, parsedInbound.Message().(*p2p.Chits).PreferredId)
+
+	outbound := records[1]
+	require.Equal(Outbound, outbound.Direction)
+	require.Equal([]ids.NodeID{receiver}, outbound.NodeIDs)
+	require.Equal(message.PushQueryOp, outbound.Op)
+	require.Equal(outboundMsg.Bytes(), outbound.Bytes)
+
+	parsedOutbound, err := mc.Parse(outbound.Bytes, receiver, nil)
+	require.NoError(err)
+	require.Equal(message.PushQueryOp, parsedOutbound.Op())
+}
+
+func TestFileRecorder(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		dir = t.TempDir()
+		mc  = newMessageCreator(t)
+	)
+	recorder, err := NewFileRecorder(
+		logging.NoLog{},
+		Config{
+			Enabled:     true,
+			Directory:   dir,
+			MaxFileSize: 1,
+			MaxFiles:    1,
+		},
+		"",
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+
+	msg, err := mc.AppGossip(ids.GenerateTestID(), []byte("gossip"))
+	require.NoError(err)
+	recorder.RecordOutbound(msg, nil)
+	require.NoError(recorder.Close())
+
+	f, err := os.Open(filepath.Join(dir, FileName))
+	require.NoError(err)
+	defer f.Close()
+
+	records, err := ReadRecords(f)
+	require.NoError(err)
+	require.Len(records, 1)
+	require.Equal(message.AppGossipOp, records[0].Op)
+	require.Empty(records[0].NodeIDs)
+}
+
+// blockingWriter blocks every write until [unblock] is closed.
+type blockingWriter struct {
+	bufferCloser
+	unblock chan struct{}
+}
+
+func (w *blockingWriter) Write(b []byte) (int, error) {
+	<-w.unblock
+	return w.bufferCloser.Write(b)
+}
+
+func TestRecorderDropsWhenFull(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		mc     = newMessageCreator(t)
+		writer = &blockingWriter{
+			unblock: make(chan struct{}),
+		}
+	)
+	recorder, err := NewRecorder(logging.NoLog{}, writer, "", prometheus.NewRegistry())
+	require.NoError(err)
+
+	msg, err := mc.AppGossip(ids.GenerateTestID(), []byte("gossip"))
+	require.NoError(err)
+
+	// At most one record is being written while the rest wait in the queue,
+	// so recording doesn't block even though the writer does.
+	const numRecords = 2*bufferSize + 1
+	for i := 0; i < numRecords; i++ {
+		recorder.RecordOutbound(msg, nil)
+	}
+	numDropped := testutil.ToFloat64(recorder.dropped)
+	require.GreaterOrEqual(numDropped, float64(numRecords-bufferSize-1))
+
+	close(writer.unblock)
+	require.NoError(recorder.Close())
+
+	records, err := ReadRecords(&writer.Buffer)
+	require.NoError(err)
+	require.Len(records, numRecords-int(numDropped))
+}
@@ -0,0 +1,116 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package capture
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/snow/networking/handler"
+	"github.com/ava-labs/avalanchego/snow/networking/sender"
+	"github.com/ava-labs/avalanchego/subnets"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+var (
+	errInvalidInboundRecord = errors.New("inbound record must have exactly one node ID")
+
+	_ sender.ExternalSender = (*externalSender)(nil)
+)
+
+type externalSender struct {
+	recorder *Recorder
+}
+
+// ExternalSender returns a sender.ExternalSender that records the messages it
+// is asked to send with [recorder] rather than sending them. Messages sent to
+// specific nodes are reported as sent to all of them. Gossiped messages are
+// reported as sent to no node.
+//
+// It is meant to be used by the chain a capture is replayed into, so that the
+// messages sent during the replay can be compared to the captured ones.
+func ExternalSender(recorder *Recorder) sender.ExternalSender {
+	return &externalSender{
+		recorder: recorder,
+	}
+}
+
+func (s *externalSender) Send(
+	msg message.OutboundMessage,
+	nodeIDs set.Set[ids.NodeID],
+	_ ids.ID,
+	_ subnets.Allower,
+) set.Set[ids.NodeID] {
+	s.recorder.RecordOutbound(msg, nodeIDs)
+	return nodeIDs
+}
+
+func (s *externalSender) Gossip(
+	msg message.OutboundMessage,
+	_ ids.ID,
+	_ int,
+	_ int,
+	_ int,
+	_ subnets.Allower,
+) set.Set[ids.NodeID] {
+	s.recorder.RecordOutbound(msg, nil)
+	return nil
+}
+
+// Replay pushes the inbound messages of [records] that are destined to the
+// chain of [h] into [h], in order. Each message is pushed only once the
+// previous one was handled, so that the engine processes the messages in the
+// same order every time the capture is replayed.
+//
+// Messages are pushed directly into [h], bypassing the router. Responses are
+// therefore delivered even if the engine didn't send the corresponding
+// request during the replay.
+func Replay(
+	ctx context.Context,
+	parser message.InboundMsgBuilder,
+	h handler.Handler,
+	records []*Record,
+) error {
+	chainID := h.Context().ChainID
+	for i, record := range records {
+		if record.Direction != Inbound {
+			continue
+		}
+		if len(record.NodeIDs) != 1 {
+			return fmt.Errorf("%w: record %d has %d", errInvalidInboundRecord, i, len(record.NodeIDs))
+		}
+
+		handled := make(chan struct{})
+		msg, err := parser.Parse(record.Bytes, record.NodeIDs[0], func() {
+			close(handled)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to parse record %d: %w", i, err)
+		}
+
+		m := msg.Message()
+		destinationChainID, err := message.GetChainID(m)
+		if err != nil || destinationChainID != chainID {
+			continue
+		}
+
+		// Note: engineType is not guaranteed to be one of the explicitly named
+		// enum values. If it was not specified it defaults to UNSPECIFIED.
+		engineType, _ := message.GetEngineType(m)
+		h.Push(ctx, handler.Message{
+			InboundMessage: msg,
+			EngineType:     engineType,
+		})
+
+		select {
+		case <-handled:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package capture
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/networking/handler"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+func TestReplay(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	var (
+		mc           = newMessageCreator(t)
+		buf          = &bufferCloser{}
+		chainID      = ids.GenerateTestID()
+		otherChainID = ids.GenerateTestID()
+		nodeID       = ids.GenerateTestNodeID()
+	)
+	recorder, err := NewRecorder(logging.NoLog{}, buf, "", prometheus.NewRegistry())
+	require.NoError(err)
+
+	recorder.RecordInbound(message.InboundChits(chainID, 1, ids.Empty, ids.Empty, ids.Empty, nodeID))
+	recorder.RecordInbound(message.InboundChits(otherChainID, 2, ids.Empty, ids.Empty, ids.Empty, nodeID))
+	outboundMsg, err := mc.AppGossip(chainID, []byte("gossip"))
+	require.NoError(err)
+	recorder.RecordOutbound(outboundMsg, set.Of(nodeID))
+	recorder.RecordInbound(message.InboundChits(chainID, 3, ids.Empty, ids.Empty, ids.Empty, nodeID))
+	require.NoError(recorder.Close())
+
+	records, err := ReadRecords(&buf.Buffer)
+	require.NoError(err)
+	require.Len(records, 4)
+
+	ctx := snow.DefaultConsensusContextTest()
+	ctx.ChainID = chainID
+
+	var replayed []uint32
+	h := handler.NewMockHandler(ctrl)
+	h.EXPECT().Context().Return(ctx).AnyTimes()
+	h.EXPECT().Push(gomock.Any(), gomock.Any()).Do(func(_ context.Context, msg handler.Message) {
+		require.Equal(nodeID, msg.NodeID())
+		requestID, ok := message.GetRequestID(msg.Message())
+		require.True(ok)
+		replayed = append(replayed, requestID)
+		// The engine finishes handling messages asynchronously.
+		go msg.OnFinishedHandling()
+	}).Times(2)
+
+	require.NoError(Replay(context.Background(), mc, h, records))
+	require.Equal([]uint32{1, 3}, replayed)
+}
+
+func TestReplayInvalidInboundRecord(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	ctx := snow.DefaultConsensusContextTest()
+	h := handler.NewMockHandler(ctrl)
+	h.EXPECT().Context().Return(ctx).AnyTimes()
+
+	records := []*Record{
+		{
+			Direction: Inbound,
+			Op:        message.ChitsOp,
+		},
+	}
+	err := Replay(context.Background(), newMessageCreator(t), h, records)
+	require.ErrorIs(t, err, errInvalidInboundRecord)
+}
+
+func TestExternalSender(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		mc      = newMessageCreator(t)
+		buf     = &bufferCloser{}
+		chainID = ids.GenerateTestID()
+		nodeIDs = set.Of(ids.GenerateTestNodeID(), ids.GenerateTestNodeID())
+	)
+	recorder, err := NewRecorder(logging.NoLog{}, buf, "", prometheus.NewRegistry())
+	require.NoError(err)
+	sender := ExternalSender(recorder)
+
+	msg, err := mc.AppGossip(chainID, []byte("gossip"))
+	require.NoError(err)
+
+	sentTo := sender.Send(msg, nodeIDs, ids.Empty, nil)
+	require.Equal(nodeIDs, sentTo)
+
+	sentTo = sender.Gossip(msg, ids.Empty, 1, 1, 1, nil)
+	require.Empty(sentTo)
+	require.NoError(recorder.Close())
+
+	records, err := ReadRecords(&buf.Buffer)
+	require.NoError(err)
+	require.Len(records, 2)
+
+	require.Equal(Outbound, records[0].Direction)
+	require.Equal(nodeIDs, set.Of(records[0].NodeIDs...))
+	require.Equal(msg.Bytes(), records[0].Bytes)
+
+	require.Equal(Outbound, records[1].Direction)
+	require.Empty(records[1].NodeIDs)
+}
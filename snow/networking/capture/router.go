@@ -0,0 +1,32 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package capture
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/snow/networking/router"
+)
+
+var _ router.Router = (*capturedRouter)(nil)
+
+type capturedRouter struct {
+	router.Router
+	recorder *Recorder
+}
+
+// Router returns a router.Router that records every message received from the
+// network with [recorder] before routing it with [router].
+func Router(router router.Router, recorder *Recorder) router.Router {
+	return &capturedRouter{
+		Router:   router,
+		recorder: recorder,
+	}
+}
+
+func (r *capturedRouter) HandleInbound(ctx context.Context, msg message.InboundMessage) {
+	r.recorder.RecordInbound(msg)
+	r.Router.HandleInbound(ctx, msg)
+}
@@ -0,0 +1,35 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package reputation
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
+)
+
+var _ benchlist.Manager = (*benchlistManager)(nil)
+
+type benchlistManager struct {
+	benchlist.Manager
+	tracker Tracker
+}
+
+// Benchlist returns a benchlist.Manager that reports the responses and
+// timeouts of requests to [tracker] before passing them to [manager].
+func Benchlist(manager benchlist.Manager, tracker Tracker) benchlist.Manager {
+	return &benchlistManager{
+		Manager: manager,
+		tracker: tracker,
+	}
+}
+
+func (b *benchlistManager) RegisterResponse(chainID ids.ID, nodeID ids.NodeID) {
+	b.tracker.RegisterEvent(nodeID, Response)
+	b.Manager.RegisterResponse(chainID, nodeID)
+}
+
+func (b *benchlistManager) RegisterFailure(chainID ids.ID, nodeID ids.NodeID) {
+	b.tracker.RegisterEvent(nodeID, Timeout)
+	b.Manager.RegisterFailure(chainID, nodeID)
+}
@@ -0,0 +1,48 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package reputation
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+type metrics struct {
+	events       *prometheus.CounterVec
+	trackedNodes prometheus.Gauge
+	scores       prometheus.Histogram
+}
+
+func newMetrics(namespace string, registerer prometheus.Registerer) (*metrics, error) {
+	m := &metrics{
+		events: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "events",
+				Help:      "Number of events that affected the reputation of nodes",
+			},
+			[]string{"event"},
+		),
+		trackedNodes: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "tracked_nodes",
+			Help:      "Number of nodes whose penalty is tracked",
+		}),
+		scores: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "scores",
+			Help:      "Scores of nodes after an event affected their reputation",
+			Buckets:   []float64{.01, .05, .1, .25, .5, .75, .9, .99, 1},
+		}),
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(m.events),
+		registerer.Register(m.trackedNodes),
+		registerer.Register(m.scores),
+	)
+	return m, errs.Err
+}
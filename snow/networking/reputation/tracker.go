@@ -0,0 +1,215 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package reputation
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+const (
+	Response Event = iota
+	Timeout
+	InvalidMessage
+	BandwidthAbuse
+	HandshakeFailure
+)
+
+// maxTrackedNodes is the number of nodes whose penalty is remembered. Once
+// exceeded, the penalty of the least recently accessed node is forgotten.
+const maxTrackedNodes = 16384
+
+var (
+	_ Tracker = (*tracker)(nil)
+	_ Tracker = (*noTracker)(nil)
+)
+
+// Event is an observation about the behavior of a node that affects its
+// reputation.
+type Event byte
+
+func (e Event) String() string {
+	switch e {
+	case Response:
+		return "response"
+	case Timeout:
+		return "timeout"
+	case InvalidMessage:
+		return "invalid_message"
+	case BandwidthAbuse:
+		return "bandwidth_abuse"
+	case HandshakeFailure:
+		return "handshake_failure"
+	default:
+		return "unknown"
+	}
+}
+
+type Config struct {
+	// Halflife is the time it takes for the penalty of a node to decay by
+	// half.
+	Halflife time.Duration `json:"halflife"`
+
+	// TimeoutPenalty is added to the penalty of a node each time it fails to
+	// respond to a request in time.
+	TimeoutPenalty float64 `json:"timeoutPenalty"`
+
+	// InvalidMessagePenalty is added to the penalty of a node each time it
+	// sends a message that can't be parsed or is otherwise invalid.
+	InvalidMessagePenalty float64 `json:"invalidMessagePenalty"`
+
+	// BandwidthAbusePenalty is added to the penalty of a node each time it
+	// sends more bytes than it is allocated by the inbound bandwidth
+	// throttler.
+	BandwidthAbusePenalty float64 `json:"bandwidthAbusePenalty"`
+
+	// HandshakeFailurePenalty is added to the penalty of a node each time the
+	// p2p handshake with it fails.
+	HandshakeFailurePenalty float64 `json:"handshakeFailurePenalty"`
+
+	// ResponseReward is removed from the penalty of a node each time it
+	// responds to a request in time.
+	ResponseReward float64 `json:"responseReward"`
+
+	// SamplingEnabled is true if consensus polls should be biased towards
+	// validators with higher scores.
+	SamplingEnabled bool `json:"samplingEnabled"`
+}
+
+// Tracker scores nodes based on their recent behavior.
+//
+// Every node starts with a penalty of 0. Misbehavior increases the penalty,
+// timely responses decrease it and it decays exponentially over time. The
+// score of a node is 1/(1+penalty), so it lies in (0, 1] and is 1 for nodes
+// that haven't misbehaved recently.
+type Tracker interface {
+	// RegisterEvent records that [event] was observed from [nodeID].
+	RegisterEvent(nodeID ids.NodeID, event Event)
+
+	// Score returns the current score of [nodeID].
+	Score(nodeID ids.NodeID) float64
+}
+
+type penalty struct {
+	value       float64
+	lastUpdated time.Time
+}
+
+type tracker struct {
+	config  Config
+	metrics *metrics
+	clock   *mockable.Clock
+
+	lock sync.Mutex
+	// Node ID --> Penalty of the node as of its last update
+	penalties cache.LRU[ids.NodeID, *penalty]
+}
+
+func NewTracker(
+	config Config,
+	namespace string,
+	registerer prometheus.Registerer,
+) (Tracker, error) {
+	return NewCustomTracker(config, &mockable.Clock{}, namespace, registerer)
+}
+
+// NewCustomTracker returns a Tracker that decays the penalties of nodes by the
+// time of [clock].
+func NewCustomTracker(
+	config Config,
+	clock *mockable.Clock,
+	namespace string,
+	registerer prometheus.Registerer,
+) (Tracker, error) {
+	metrics, err := newMetrics(namespace, registerer)
+	return &tracker{
+		config:    config,
+		metrics:   metrics,
+		clock:     clock,
+		penalties: cache.LRU[ids.NodeID, *penalty]{Size: maxTrackedNodes},
+	}, err
+}
+
+func (t *tracker) RegisterEvent(nodeID ids.NodeID, event Event) {
+	t.metrics.events.WithLabelValues(event.String()).Inc()
+
+	var delta float64
+	switch event {
+	case Response:
+		delta = -t.config.ResponseReward
+	case Timeout:
+		delta = t.config.TimeoutPenalty
+	case InvalidMessage:
+		delta = t.config.InvalidMessagePenalty
+	case BandwidthAbuse:
+		delta = t.config.BandwidthAbusePenalty
+	case HandshakeFailure:
+		delta = t.config.HandshakeFailurePenalty
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := t.clock.Time()
+	p, ok := t.penalties.Get(nodeID)
+	if !ok {
+		if delta <= 0 {
+			// The node has no penalty to reduce.
+			return
+		}
+		p = &penalty{}
+	}
+
+	p.value = math.Max(t.read(p, now)+delta, 0)
+	p.lastUpdated = now
+	t.penalties.Put(nodeID, p)
+	t.metrics.trackedNodes.Set(float64(t.penalties.Len()))
+	t.metrics.scores.Observe(score(p.value))
+}
+
+func (t *tracker) Score(nodeID ids.NodeID) float64 {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	p, ok := t.penalties.Get(nodeID)
+	if !ok {
+		return 1
+	}
+	return score(t.read(p, t.clock.Time()))
+}
+
+// read returns the value of [p] at [now] after it decayed since it was last
+// updated.
+func (t *tracker) read(p *penalty, now time.Time) float64 {
+	elapsed := now.Sub(p.lastUpdated)
+	if elapsed <= 0 || t.config.Halflife <= 0 {
+		return p.value
+	}
+	return p.value * math.Exp2(-float64(elapsed)/float64(t.config.Halflife))
+}
+
+// score returns the score of a node with [penalty].
+func score(penalty float64) float64 {
+	return 1 / (1 + penalty)
+}
+
+type noTracker struct{}
+
+// NewNoTracker returns a Tracker that gives every node a perfect score.
+func NewNoTracker() Tracker {
+	return noTracker{}
+}
+
+func (noTracker) RegisterEvent(ids.NodeID, Event) {}
+
+func (noTracker) Score(ids.NodeID) float64 {
+	return 1
+}
@@ -0,0 +1,129 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package reputation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+var testConfig = Config{
+	Halflife:                time.Minute,
+	TimeoutPenalty:          1,
+	InvalidMessagePenalty:   2,
+	BandwidthAbusePenalty:   3,
+	HandshakeFailurePenalty: 4,
+	ResponseReward:          0.5,
+}
+
+func newTestTracker(t *testing.T) *tracker {
+	trackerIntf, err := NewTracker(testConfig, "", prometheus.NewRegistry())
+	require.NoError(t, err)
+	require.IsType(t, &tracker{}, trackerIntf)
+	return trackerIntf.(*tracker)
+}
+
+func TestTrackerPenalties(t *testing.T) {
+	tests := []struct {
+		event         Event
+		expectedScore float64
+	}{
+		{
+			event:         Timeout,
+			expectedScore: 1.0 / 2,
+		},
+		{
+			event:         InvalidMessage,
+			expectedScore: 1.0 / 3,
+		},
+		{
+			event:         BandwidthAbuse,
+			expectedScore: 1.0 / 4,
+		},
+		{
+			event:         HandshakeFailure,
+			expectedScore: 1.0 / 5,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.event.String(), func(t *testing.T) {
+			require := require.New(t)
+
+			tracker := newTestTracker(t)
+			tracker.clock.Set(time.Unix(0, 0))
+
+			nodeID := ids.GenerateTestNodeID()
+			require.Equal(1.0, tracker.Score(nodeID))
+
+			tracker.RegisterEvent(nodeID, test.event)
+			require.InDelta(test.expectedScore, tracker.Score(nodeID), 1e-9)
+
+			// Other nodes are unaffected.
+			require.Equal(1.0, tracker.Score(ids.GenerateTestNodeID()))
+		})
+	}
+}
+
+func TestTrackerResponses(t *testing.T) {
+	require := require.New(t)
+
+	tracker := newTestTracker(t)
+	tracker.clock.Set(time.Unix(0, 0))
+	nodeID := ids.GenerateTestNodeID()
+
+	// Responses from nodes without a penalty aren't tracked.
+	tracker.RegisterEvent(nodeID, Response)
+	require.Equal(1.0, tracker.Score(nodeID))
+	require.Zero(tracker.penalties.Len())
+
+	tracker.RegisterEvent(nodeID, Timeout)
+	tracker.RegisterEvent(nodeID, Timeout)
+	require.InDelta(1.0/3, tracker.Score(nodeID), 1e-9)
+
+	tracker.RegisterEvent(nodeID, Response)
+	require.InDelta(1.0/2.5, tracker.Score(nodeID), 1e-9)
+
+	// The penalty never becomes negative.
+	for i := 0; i < 10; i++ {
+		tracker.RegisterEvent(nodeID, Response)
+	}
+	require.Equal(1.0, tracker.Score(nodeID))
+}
+
+func TestTrackerDecay(t *testing.T) {
+	require := require.New(t)
+
+	tracker := newTestTracker(t)
+	now := time.Unix(0, 0)
+	tracker.clock.Set(now)
+	nodeID := ids.GenerateTestNodeID()
+
+	tracker.RegisterEvent(nodeID, HandshakeFailure)
+	require.InDelta(1.0/5, tracker.Score(nodeID), 1e-9)
+
+	now = now.Add(testConfig.Halflife)
+	tracker.clock.Set(now)
+	require.InDelta(1.0/3, tracker.Score(nodeID), 1e-9)
+
+	// Penalties are added to the decayed penalty.
+	tracker.RegisterEvent(nodeID, Timeout)
+	require.InDelta(1.0/4, tracker.Score(nodeID), 1e-9)
+
+	now = now.Add(2 * testConfig.Halflife)
+	tracker.clock.Set(now)
+	require.InDelta(1.0/1.75, tracker.Score(nodeID), 1e-9)
+}
+
+func TestNoTracker(t *testing.T) {
+	tracker := NewNoTracker()
+	nodeID := ids.GenerateTestNodeID()
+	tracker.RegisterEvent(nodeID, HandshakeFailure)
+	require.Equal(t, 1.0, tracker.Score(nodeID))
+}
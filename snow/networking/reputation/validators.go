@@ -0,0 +1,137 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package reputation
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/sampler"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+// samplerRefreshFrequency is how often the sampling weights are recomputed
+// from the scores of the validators. The weights are also recomputed whenever
+// the validator set changes.
+const samplerRefreshFrequency = 5 * time.Second
+
+var (
+	_ validators.Set                 = (*validatorSet)(nil)
+	_ validators.SetCallbackListener = (*validatorSet)(nil)
+)
+
+type validatorSet struct {
+	validators.Set
+	tracker Tracker
+	clock   mockable.Clock
+
+	// changed is set when the underlying validator set changes. It isn't
+	// protected by [lock] because the callbacks are called while the lock of
+	// the underlying validator set is held.
+	changed utils.Atomic[bool]
+
+	lock        sync.Mutex
+	lastRefresh time.Time
+	nodeIDs     []ids.NodeID
+	weights     []uint64
+	totalWeight uint64
+	sampler     sampler.WeightedWithoutReplacement
+}
+
+// Validators returns a validators.Set that samples the validators of [vdrs]
+// with a probability proportional to their weight scaled by their score in
+// [tracker]. Every validator keeps a weight of at least 1, so that it can
+// still be sampled once its score recovers.
+//
+// The scaled weights are cached, so changes in score are only reflected in
+// sampling once the cache is refreshed.
+func Validators(vdrs validators.Set, tracker Tracker) validators.Set {
+	v := &validatorSet{
+		Set:     vdrs,
+		tracker: tracker,
+		sampler: sampler.NewWeightedWithoutReplacement(),
+	}
+	v.changed.Set(true)
+	vdrs.RegisterCallbackListener(v)
+	return v
+}
+
+func (v *validatorSet) Sample(size int) ([]ids.NodeID, error) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if err := v.refresh(); err != nil {
+		return nil, err
+	}
+
+	// Scaling down the weights may make the requested sample size impossible
+	// even though sampling from the underlying set is possible.
+	if v.totalWeight < uint64(size) {
+		return v.Set.Sample(size)
+	}
+
+	indices, err := v.sampler.Sample(size)
+	if err != nil {
+		return nil, err
+	}
+
+	sampled := make([]ids.NodeID, len(indices))
+	for i, index := range indices {
+		sampled[i] = v.nodeIDs[index]
+	}
+	return sampled, nil
+}
+
+func (v *validatorSet) OnValidatorAdded(ids.NodeID, *bls.PublicKey, ids.ID, uint64) {
+	v.changed.Set(true)
+}
+
+func (v *validatorSet) OnValidatorRemoved(ids.NodeID, uint64) {
+	v.changed.Set(true)
+}
+
+func (v *validatorSet) OnValidatorWeightChanged(ids.NodeID, uint64, uint64) {
+	v.changed.Set(true)
+}
+
+// refresh recomputes the sampling weights if the validator set changed or if
+// they haven't been recomputed for [samplerRefreshFrequency].
+//
+// Assumes [v.lock] is held.
+func (v *validatorSet) refresh() error {
+	now := v.clock.Time()
+	if !v.changed.Get() && now.Sub(v.lastRefresh) < samplerRefreshFrequency {
+		return nil
+	}
+
+	// [changed] is cleared before the validators are read, so that any change
+	// made while they are read causes another refresh.
+	v.changed.Set(false)
+	vdrs := v.Set.Map()
+
+	v.nodeIDs = v.nodeIDs[:0]
+	v.weights = v.weights[:0]
+	v.totalWeight = 0
+	for nodeID, vdr := range vdrs {
+		weight := uint64(float64(vdr.Weight) * v.tracker.Score(nodeID))
+		if weight == 0 {
+			weight = 1
+		}
+		v.nodeIDs = append(v.nodeIDs, nodeID)
+		v.weights = append(v.weights, weight)
+		v.totalWeight += weight
+	}
+
+	if err := v.sampler.Initialize(v.weights); err != nil {
+		// Force the next sample to retry the refresh.
+		v.changed.Set(true)
+		return err
+	}
+	v.lastRefresh = now
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package reputation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/sampler"
+)
+
+func TestValidatorsSample(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		tracker  = newTestTracker(t)
+		vdrs     = validators.NewSet()
+		goodNode = ids.GenerateTestNodeID()
+		badNode  = ids.GenerateTestNodeID()
+	)
+	require.NoError(vdrs.Add(goodNode, nil, ids.Empty, 100))
+	require.NoError(vdrs.Add(badNode, nil, ids.Empty, 100))
+
+	// A score of 1/101 reduces the weight of [badNode] to 1.
+	for i := 0; i < 100; i++ {
+		tracker.RegisterEvent(badNode, Timeout)
+	}
+
+	reputationVdrs := Validators(vdrs, tracker)
+	sampled, err := reputationVdrs.Sample(101)
+	require.NoError(err)
+	require.Len(sampled, 101)
+
+	counts := make(map[ids.NodeID]int)
+	for _, nodeID := range sampled {
+		counts[nodeID]++
+	}
+	require.Equal(100, counts[goodNode])
+	require.Equal(1, counts[badNode])
+
+	// Sampling more than the scaled weight falls back to the stake weights.
+	sampled, err = reputationVdrs.Sample(200)
+	require.NoError(err)
+	require.Len(sampled, 200)
+
+	_, err = reputationVdrs.Sample(201)
+	require.ErrorIs(err, sampler.ErrOutOfRange)
+}
+
+func TestValidatorsSampleRefresh(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		tracker = newTestTracker(t)
+		vdrs    = validators.NewSet()
+		nodeID0 = ids.GenerateTestNodeID()
+		nodeID1 = ids.GenerateTestNodeID()
+	)
+	tracker.clock.Set(time.Unix(0, 0))
+	require.NoError(vdrs.Add(nodeID0, nil, ids.Empty, 100))
+
+	reputationVdrs := Validators(vdrs, tracker)
+	reputationVdrs.(*validatorSet).clock.Set(time.Unix(0, 0))
+
+	sampled, err := reputationVdrs.Sample(100)
+	require.NoError(err)
+	require.Len(sampled, 100)
+
+	// Changes to the validator set are reflected immediately.
+	require.NoError(vdrs.Add(nodeID1, nil, ids.Empty, 100))
+	sampled, err = reputationVdrs.Sample(200)
+	require.NoError(err)
+	require.Contains(sampled, nodeID1)
+
+	// Changes in score are only reflected once the weights are refreshed.
+	for i := 0; i < 100; i++ {
+		tracker.RegisterEvent(nodeID1, Timeout)
+	}
+	sampled, err = reputationVdrs.Sample(200)
+	require.NoError(err)
+	require.Len(sampled, 200)
+
+	reputationVdrs.(*validatorSet).clock.Set(time.Unix(0, 0).Add(samplerRefreshFrequency))
+	sampled, err = reputationVdrs.Sample(101)
+	require.NoError(err)
+
+	counts := make(map[ids.NodeID]int)
+	for _, nodeID := range sampled {
+		counts[nodeID]++
+	}
+	require.Equal(100, counts[nodeID0])
+	require.Equal(1, counts[nodeID1])
+}
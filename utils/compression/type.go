@@ -4,6 +4,7 @@
 package compression
 
 import (
+	"encoding/json"
 	"errors"
 	"strings"
 )
@@ -57,3 +58,16 @@ func (t Type) MarshalJSON() ([]byte, error) {
 	}
 	return []byte(b.String()), nil
 }
+
+func (t *Type) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	typ, err := TypeFromString(s)
+	if err != nil {
+		return err
+	}
+	*t = typ
+	return nil
+}
@@ -58,3 +58,20 @@ func TestTypeMarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestTypeUnmarshalJSON(t *testing.T) {
+	require := require.New(t)
+
+	for _, compressionType := range []Type{TypeNone, TypeGzip, TypeZstd} {
+		b, err := compressionType.MarshalJSON()
+		require.NoError(err)
+
+		var parsedType Type
+		require.NoError(parsedType.UnmarshalJSON(b))
+		require.Equal(compressionType, parsedType)
+	}
+
+	var parsedType Type
+	err := parsedType.UnmarshalJSON([]byte(`"unknown"`))
+	require.ErrorIs(err, errUnknownCompressionType)
+}
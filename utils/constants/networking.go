@@ -56,6 +56,14 @@ const (
 	DefaultNetworkPeerReadBufferSize        = 8 * units.KiB
 	DefaultNetworkPeerWriteBufferSize       = 8 * units.KiB
 
+	DefaultNetworkOutboundQueueStrictPriority        = false
+	DefaultNetworkOutboundQueueConsensusWeight       = 4
+	DefaultNetworkOutboundQueueConsensusMaxBytes     = 0
+	DefaultNetworkOutboundQueueBootstrappingWeight   = 2
+	DefaultNetworkOutboundQueueBootstrappingMaxBytes = 0
+	DefaultNetworkOutboundQueueApplicationWeight     = 1
+	DefaultNetworkOutboundQueueApplicationMaxBytes   = 0
+
 	DefaultNetworkTCPProxyEnabled = false
 
 	// The PROXY protocol specification recommends setting this value to be at
@@ -70,6 +78,16 @@ const (
 	DefaultBenchlistDuration           = 15 * time.Minute
 	DefaultBenchlistMinFailingDuration = 2*time.Minute + 30*time.Second
 
+	// Reputation
+	DefaultReputationHalflife                = 10 * time.Minute
+	DefaultReputationTimeoutPenalty          = 1.0
+	DefaultReputationInvalidMessagePenalty   = 5.0
+	DefaultReputationBandwidthAbusePenalty   = 0.5
+	DefaultReputationHandshakeFailurePenalty = 10.0
+	DefaultReputationResponseReward          = 0.5
+	DefaultReputationMinInboundScore         = 0
+	DefaultReputationSamplingEnabled         = false
+
 	// Router
 	DefaultAcceptedFrontierGossipFrequency                 = 10 * time.Second
 	DefaultConsensusAppConcurrency                         = 2
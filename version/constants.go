@@ -14,14 +14,14 @@ import (
 
 // RPCChainVMProtocol should be bumped anytime changes are made which require
 // the plugin vm to upgrade to latest avalanchego release to be compatible.
-const RPCChainVMProtocol uint = 28
+const RPCChainVMProtocol uint = 29
 
 // These are globals that describe network upgrades and node versions
 var (
 	Current = &Semantic{
 		Major: 1,
 		Minor: 10,
-		Patch: 12,
+		Patch: 13,
 	}
 	CurrentApp = &Application{
 		Major: Current.Major,
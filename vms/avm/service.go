@@ -588,7 +588,20 @@ func (s *Service) GetAllBalances(_ *http.Request, args *GetAllBalancesArgs, repl
 	addrSet := set.Set[ids.ShortID]{}
 	addrSet.Add(address)
 
-	utxos, err := avax.GetAllUTXOs(s.vm.state, addrSet)
+	// Read the UTXOs from a snapshot, when supported, so that the balances are
+	// consistent even if blocks are accepted while the UTXOs are being read.
+	var utxoReader avax.UTXOReader = s.vm.state
+	snapshot, err := s.vm.state.NewUTXOSnapshot()
+	switch err {
+	case nil:
+		defer snapshot.Release()
+		utxoReader = snapshot
+	case database.ErrSnapshotNotSupported:
+	default:
+		return fmt.Errorf("couldn't snapshot UTXOs: %w", err)
+	}
+
+	utxos, err := avax.GetAllUTXOs(utxoReader, addrSet)
 	if err != nil {
 		return fmt.Errorf("couldn't get address's UTXOs: %w", err)
 	}
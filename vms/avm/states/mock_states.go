@@ -462,6 +462,21 @@ func (mr *MockStateMockRecorder) IsInitialized() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsInitialized", reflect.TypeOf((*MockState)(nil).IsInitialized))
 }
 
+// NewUTXOSnapshot mocks base method.
+func (m *MockState) NewUTXOSnapshot() (UTXOSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewUTXOSnapshot")
+	ret0, _ := ret[0].(UTXOSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewUTXOSnapshot indicates an expected call of NewUTXOSnapshot.
+func (mr *MockStateMockRecorder) NewUTXOSnapshot() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewUTXOSnapshot", reflect.TypeOf((*MockState)(nil).NewUTXOSnapshot))
+}
+
 // Prune mocks base method.
 func (m *MockState) Prune(arg0 sync.Locker, arg1 logging.Logger) error {
 	m.ctrl.T.Helper()
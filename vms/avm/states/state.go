@@ -20,6 +20,7 @@ import (
 	"github.com/ava-labs/avalanchego/cache/metercacher"
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/database/snapshotdb"
 	"github.com/ava-labs/avalanchego/database/versiondb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/choices"
@@ -125,9 +126,25 @@ type State interface {
 	// Checksums returns the current TxChecksum and UTXOChecksum.
 	Checksums() (txChecksum ids.ID, utxoChecksum ids.ID)
 
+	// NewUTXOSnapshot returns a read-only view of the UTXOs written to the
+	// database as of the time of the call. UTXOs that have been added or
+	// deleted, but not yet written, are not included.
+	//
+	// If the underlying database doesn't support snapshots,
+	// [database.ErrSnapshotNotSupported] is returned.
+	NewUTXOSnapshot() (UTXOSnapshot, error)
+
 	Close() error
 }
 
+// UTXOSnapshot is a read-only view of the UTXO set. The snapshot must be
+// released once it is no longer needed.
+type UTXOSnapshot interface {
+	avax.UTXOReader
+
+	Release()
+}
+
 /*
  * VMDB
  * |- utxos
@@ -850,6 +867,39 @@ func (s *state) Checksums() (ids.ID, ids.ID) {
 	return s.txChecksum, s.utxoState.Checksum()
 }
 
+func (s *state) NewUTXOSnapshot() (UTXOSnapshot, error) {
+	snapshot, err := s.db.NewSnapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	// The UTXO state is recreated on top of the snapshot using the same
+	// prefixes as [s.utxoState], so that the snapshot reads the same keys.
+	db := snapshotdb.New(snapshot)
+	utxoState, err := avax.NewUTXOState(
+		prefixdb.New(utxoPrefix, db),
+		s.parser.Codec(),
+		false,
+	)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return &utxoSnapshot{
+		UTXOReader: utxoState,
+		db:         db,
+	}, nil
+}
+
+type utxoSnapshot struct {
+	avax.UTXOReader
+	db database.Database
+}
+
+func (s *utxoSnapshot) Release() {
+	_ = s.db.Close()
+}
+
 func (s *state) initTxChecksum() error {
 	if !s.trackChecksum {
 		return nil
@@ -315,3 +315,61 @@ func TestInitializeChainState(t *testing.T) {
 	require.NoError(err)
 	require.Equal(genesis.ID(), lastAccepted.Parent())
 }
+
+func TestNewUTXOSnapshot(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	vdb := versiondb.New(db)
+	s, err := New(vdb, parser, prometheus.NewRegistry(), trackChecksums)
+	require.NoError(err)
+
+	addr := ids.GenerateTestShortID()
+	newUTXO := func() *avax.UTXO {
+		return &avax.UTXO{
+			UTXOID: avax.UTXOID{
+				TxID: ids.GenerateTestID(),
+			},
+			Asset: avax.Asset{
+				ID: ids.GenerateTestID(),
+			},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 1,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{addr},
+				},
+			},
+		}
+	}
+
+	utxo1 := newUTXO()
+	s.AddUTXO(utxo1)
+	require.NoError(s.Commit())
+
+	snapshot, err := s.NewUTXOSnapshot()
+	require.NoError(err)
+	defer snapshot.Release()
+
+	utxo2 := newUTXO()
+	s.AddUTXO(utxo2)
+	s.DeleteUTXO(utxo1.InputID())
+	require.NoError(s.Commit())
+
+	// The snapshot should not include changes committed after it was taken.
+	utxo, err := snapshot.GetUTXO(utxo1.InputID())
+	require.NoError(err)
+	require.Equal(utxo1.InputID(), utxo.InputID())
+
+	_, err = snapshot.GetUTXO(utxo2.InputID())
+	require.ErrorIs(err, database.ErrNotFound)
+
+	utxoIDs, err := snapshot.UTXOIDs(addr.Bytes(), ids.Empty, 10)
+	require.NoError(err)
+	require.Equal([]ids.ID{utxo1.InputID()}, utxoIDs)
+
+	// The state itself should include the committed changes.
+	utxoIDs, err = s.UTXOIDs(addr.Bytes(), ids.Empty, 10)
+	require.NoError(err)
+	require.Equal([]ids.ID{utxo2.InputID()}, utxoIDs)
+}
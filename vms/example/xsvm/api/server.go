@@ -34,6 +34,7 @@ type Server interface {
 func NewServer(
 	ctx *snow.Context,
 	genesis *genesis.Genesis,
+	blocks database.KeyValueReader,
 	state database.KeyValueReader,
 	chain chain.Chain,
 	builder builder.Builder,
@@ -41,6 +42,7 @@ func NewServer(
 	return &server{
 		ctx:     ctx,
 		genesis: genesis,
+		blocks:  blocks,
 		state:   state,
 		chain:   chain,
 		builder: builder,
@@ -50,6 +52,7 @@ func NewServer(
 type server struct {
 	ctx     *snow.Context
 	genesis *genesis.Genesis
+	blocks  database.KeyValueReader
 	state   database.KeyValueReader
 	chain   chain.Chain
 	builder builder.Builder
@@ -151,7 +154,7 @@ type LastAcceptedReply struct {
 
 func (s *server) LastAccepted(_ *http.Request, _ *struct{}, reply *LastAcceptedReply) error {
 	reply.BlockID = s.chain.LastAccepted()
-	blkBytes, err := state.GetBlock(s.state, reply.BlockID)
+	blkBytes, err := state.GetBlock(s.blocks, reply.BlockID)
 	if err != nil {
 		return err
 	}
@@ -169,7 +172,7 @@ type BlockReply struct {
 }
 
 func (s *server) Block(_ *http.Request, args *BlockArgs, reply *BlockReply) error {
-	blkBytes, err := state.GetBlock(s.state, args.BlockID)
+	blkBytes, err := state.GetBlock(s.blocks, args.BlockID)
 	if err != nil {
 		return err
 	}
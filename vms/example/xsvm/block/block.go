@@ -4,6 +4,8 @@
 package block
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
@@ -11,13 +13,20 @@ import (
 	"github.com/ava-labs/avalanchego/vms/example/xsvm/tx"
 )
 
+var errNonCanonicalVersion = errors.New("non-canonical codec version")
+
 // Stateless blocks are blocks as they are marshalled/unmarshalled and sent over
 // the p2p network. The stateful blocks which can be executed are built from
 // Stateless blocks.
 type Stateless struct {
-	ParentID  ids.ID   `serialize:"true" json:"parentID"`
-	Timestamp int64    `serialize:"true" json:"timestamp"`
-	Height    uint64   `serialize:"true" json:"height"`
+	ParentID  ids.ID `serialize:"true" json:"parentID"`
+	Timestamp int64  `serialize:"true" json:"timestamp"`
+	Height    uint64 `serialize:"true" json:"height"`
+	// StateRoot is the merkle root of the state following this block's
+	// execution. It's [ids.Empty] if the chain doesn't merkleize its state.
+	//
+	// It's only serialized by [MerkleVersion].
+	StateRoot ids.ID   `serializeV1:"true" json:"stateRoot"`
 	Txs       []*tx.Tx `serialize:"true" json:"txs"`
 }
 
@@ -25,13 +34,35 @@ func (b *Stateless) Time() time.Time {
 	return time.Unix(b.Timestamp, 0)
 }
 
+// CodecVersion returns the codec version that [b] is marshalled with. Blocks
+// without a state root are marshalled with [Version], so that they are the
+// same as the blocks from before state roots were added.
+func (b *Stateless) CodecVersion() uint16 {
+	if b.StateRoot == ids.Empty {
+		return Version
+	}
+	return MerkleVersion
+}
+
+func (b *Stateless) Bytes() ([]byte, error) {
+	return Codec.Marshal(b.CodecVersion(), b)
+}
+
 func (b *Stateless) ID() (ids.ID, error) {
-	bytes, err := Codec.Marshal(Version, b)
+	bytes, err := b.Bytes()
 	return hashing.ComputeHash256Array(bytes), err
 }
 
 func Parse(bytes []byte) (*Stateless, error) {
 	blk := &Stateless{}
-	_, err := Codec.Unmarshal(bytes, blk)
-	return blk, err
+	version, err := Codec.Unmarshal(bytes, blk)
+	if err != nil {
+		return nil, err
+	}
+	// Every block has a single valid encoding, so that its ID is the hash of
+	// the bytes it was parsed from.
+	if expectedVersion := blk.CodecVersion(); version != expectedVersion {
+		return nil, fmt.Errorf("%w: expected %d, got %d", errNonCanonicalVersion, expectedVersion, version)
+	}
+	return blk, nil
 }
@@ -0,0 +1,66 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package block
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/example/xsvm/tx"
+)
+
+// Test that a block from before [Stateless.StateRoot] was added is parsed,
+// and that it is marshalled into the same bytes and ID.
+func TestLegacyBlock(t *testing.T) {
+	require := require.New(t)
+
+	legacyBytes, err := hex.DecodeString("000001000000000000000000000000000000000000000000000000000000000000000000000000000002000000000000000300000000")
+	require.NoError(err)
+
+	blk, err := Parse(legacyBytes)
+	require.NoError(err)
+	require.Equal(&Stateless{
+		ParentID:  ids.ID{1},
+		Timestamp: 2,
+		Height:    3,
+		Txs:       []*tx.Tx{},
+	}, blk)
+
+	bytes, err := blk.Bytes()
+	require.NoError(err)
+	require.Equal(legacyBytes, bytes)
+
+	blkID, err := blk.ID()
+	require.NoError(err)
+	require.Equal("FVdQ2chFSsCSpV9Wtf8CcE8KQmiLQYsSQ43dArnVRBeVhwZqY", blkID.String())
+}
+
+func TestStateRoot(t *testing.T) {
+	require := require.New(t)
+
+	blk := &Stateless{
+		ParentID:  ids.ID{1},
+		Timestamp: 2,
+		Height:    3,
+		StateRoot: ids.ID{4},
+		Txs:       []*tx.Tx{},
+	}
+	bytes, err := blk.Bytes()
+	require.NoError(err)
+
+	parsed, err := Parse(bytes)
+	require.NoError(err)
+	require.Equal(blk, parsed)
+
+	// A block without a state root must be marshalled with [Version].
+	blk.StateRoot = ids.Empty
+	bytes, err = Codec.Marshal(MerkleVersion, blk)
+	require.NoError(err)
+
+	_, err = Parse(bytes)
+	require.ErrorIs(err, errNonCanonicalVersion)
+}
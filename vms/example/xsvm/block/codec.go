@@ -5,7 +5,12 @@ package block
 
 import "github.com/ava-labs/avalanchego/vms/example/xsvm/tx"
 
-// Version is the current default codec version
-const Version = tx.Version
+const (
+	// Version is the current default codec version
+	Version = tx.Version
+	// MerkleVersion is the codec version of blocks that commit to a state
+	// root.
+	MerkleVersion = tx.MerkleVersion
+)
 
 var Codec = tx.Codec
@@ -135,5 +135,10 @@ func (b *builder) BuildBlock(ctx context.Context, blockContext *smblock.Context)
 
 		wipBlock.Txs = append(wipBlock.Txs, currentTx)
 	}
+
+	wipBlock.StateRoot, err = preferredBlk.ComputeStateRoot(ctx, currentState)
+	if err != nil {
+		return nil, err
+	}
 	return b.chain.NewBlock(&wipBlock)
 }
@@ -14,9 +14,11 @@ import (
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/utils/maybe"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/example/xsvm/execute"
 	"github.com/ava-labs/avalanchego/vms/example/xsvm/state"
+	"github.com/ava-labs/avalanchego/x/merkledb"
 
 	smblock "github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	xsblock "github.com/ava-labs/avalanchego/vms/example/xsvm/block"
@@ -34,6 +36,7 @@ var (
 	errFutureTimestamp       = errors.New("future timestamp")
 	errTimestampBeforeParent = errors.New("timestamp before parent")
 	errWrongHeight           = errors.New("wrong height")
+	errWrongStateRoot        = errors.New("wrong state root")
 )
 
 type Block interface {
@@ -45,6 +48,11 @@ type Block interface {
 	// block's verification to allow block's descendants verification before
 	// being accepted.
 	State() (database.Database, error)
+
+	// ComputeStateRoot returns the merkle root of the state resulting from
+	// applying [changes] on top of this block's state. If the chain doesn't
+	// merkleize its state, [ids.Empty] is returned.
+	ComputeStateRoot(ctx context.Context, changes *versiondb.Database) (ids.ID, error)
 }
 
 type block struct {
@@ -56,7 +64,10 @@ type block struct {
 	status choices.Status
 	bytes  []byte
 
-	state               *versiondb.Database
+	state *versiondb.Database
+	// view is the merkleized state following this block's acceptance. It's
+	// nil if the chain doesn't merkleize its state.
+	view                merkledb.TrieView
 	verifiedChildrenIDs set.Set[ids.ID]
 }
 
@@ -91,8 +102,8 @@ func (b *block) Verify(ctx context.Context) error {
 	return b.VerifyWithContext(ctx, nil)
 }
 
-func (b *block) Accept(context.Context) error {
-	if err := b.state.Commit(); err != nil {
+func (b *block) Accept(ctx context.Context) error {
+	if err := b.commit(ctx); err != nil {
 		return err
 	}
 
@@ -103,7 +114,7 @@ func (b *block) Accept(context.Context) error {
 		if !exists {
 			return errMissingChild
 		}
-		if err := child.state.SetDatabase(b.chain.acceptedState); err != nil {
+		if err := child.state.SetDatabase(b.chain.executionState()); err != nil {
 			return err
 		}
 	}
@@ -167,9 +178,27 @@ func (b *block) VerifyWithContext(ctx context.Context, blockContext *smblock.Con
 		return err
 	}
 
+	var view merkledb.TrieView
+	if b.chain.merkleState != nil {
+		view, err = parent.newView(ctx, blkState)
+		if err != nil {
+			return err
+		}
+		stateRoot, err := view.GetMerkleRoot(ctx)
+		if err != nil {
+			return err
+		}
+		if stateRoot != b.StateRoot {
+			return errWrongStateRoot
+		}
+	} else if b.StateRoot != ids.Empty {
+		return errWrongStateRoot
+	}
+
 	// Make sure to only state the state the first time we verify this block.
 	if b.state == nil {
 		b.state = blkState
+		b.view = view
 		parent.verifiedChildrenIDs.Add(b.id)
 		b.chain.verifiedBlocks[b.id] = b
 	}
@@ -179,7 +208,7 @@ func (b *block) VerifyWithContext(ctx context.Context, blockContext *smblock.Con
 
 func (b *block) State() (database.Database, error) {
 	if b.id == b.chain.lastAccepted {
-		return b.chain.acceptedState, nil
+		return b.chain.executionState(), nil
 	}
 
 	// States of accepted blocks other than the lastAccepted are undefined.
@@ -195,6 +224,92 @@ func (b *block) State() (database.Database, error) {
 	return b.state, nil
 }
 
+func (b *block) ComputeStateRoot(ctx context.Context, changes *versiondb.Database) (ids.ID, error) {
+	if b.chain.merkleState == nil {
+		return ids.Empty, nil
+	}
+	view, err := b.newView(ctx, changes)
+	if err != nil {
+		return ids.Empty, err
+	}
+	return view.GetMerkleRoot(ctx)
+}
+
+// trie returns the merkleized state following this block's acceptance.
+func (b *block) trie() (merkledb.Trie, error) {
+	if b.id == b.chain.lastAccepted {
+		return b.chain.merkleState, nil
+	}
+
+	// States of accepted blocks other than the lastAccepted are undefined.
+	if b.Status() == choices.Accepted {
+		return nil, errMissingState
+	}
+
+	// We should not be calling trie on an unverified block.
+	if b.view == nil {
+		return nil, errParentNotVerified
+	}
+
+	return b.view, nil
+}
+
+// newView returns a view of the merkleized state resulting from applying the
+// merkleized changes in [changes] on top of this block's state.
+func (b *block) newView(ctx context.Context, changes *versiondb.Database) (merkledb.TrieView, error) {
+	trie, err := b.trie()
+	if err != nil {
+		return nil, err
+	}
+
+	batch, err := changes.CommitBatch()
+	if err != nil {
+		return nil, err
+	}
+	mapOps := make(map[string]maybe.Maybe[[]byte])
+	if err := batch.Replay(&keyFilter{merkleized: true, writer: mapOpsWriter(mapOps)}); err != nil {
+		return nil, err
+	}
+	return trie.NewView(ctx, merkledb.ViewChanges{MapOps: mapOps})
+}
+
+// commit writes this block's changes to the accepted state.
+func (b *block) commit(ctx context.Context) error {
+	if b.view == nil {
+		return b.state.Commit()
+	}
+
+	changes, err := b.state.CommitBatch()
+	if err != nil {
+		return err
+	}
+
+	// The merkleized changes are recorded atomically with the block state so
+	// that they can be committed to the merkleized state on startup if the
+	// node stops before they are committed here.
+	batch := b.chain.acceptedState.NewBatch()
+	if err := changes.Replay(&keyFilter{merkleized: false, writer: batch}); err != nil {
+		return err
+	}
+	if err := changes.Replay(&keyFilter{merkleized: true, writer: pendingWriter{writer: batch}}); err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	// Committing the view moves the views of this block's children onto the
+	// database.
+	if err := b.view.CommitToDB(ctx); err != nil {
+		return err
+	}
+	if err := state.DeletePendingChanges(b.chain.acceptedState); err != nil {
+		return err
+	}
+	b.state.Abort()
+	return nil
+}
+
 func (b *block) calculateStatus() choices.Status {
 	if b.chain.lastAccepted == b.id {
 		return choices.Accepted
@@ -4,11 +4,14 @@
 package chain
 
 import (
+	"context"
+
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/vms/example/xsvm/state"
+	"github.com/ava-labs/avalanchego/x/merkledb"
 
 	xsblock "github.com/ava-labs/avalanchego/vms/example/xsvm/block"
 )
@@ -20,6 +23,11 @@ type Chain interface {
 	SetChainState(state snow.State)
 	GetBlock(blkID ids.ID) (Block, error)
 
+	// ReloadLastAccepted reloads the last accepted block from the database.
+	// It must be called after the last accepted block is changed outside of
+	// the consensus engine, such as after state syncing.
+	ReloadLastAccepted() error
+
 	// Creates a fully verifiable and executable block, which can be processed
 	// by the consensus engine, from a stateless block.
 	NewBlock(blk *xsblock.Stateless) (Block, error)
@@ -28,6 +36,10 @@ type Chain interface {
 type chain struct {
 	chainContext  *snow.Context
 	acceptedState database.Database
+	// merkleState is nil if the chain doesn't merkleize its state. Otherwise,
+	// it holds the accepted merkleized state and [acceptedState] only holds
+	// the block state.
+	merkleState merkledb.MerkleDB
 
 	// chain state as driven by the consensus engine
 	chainState snow.State
@@ -36,26 +48,23 @@ type chain struct {
 	verifiedBlocks map[ids.ID]*block
 }
 
-func New(ctx *snow.Context, db database.Database) (Chain, error) {
-	// Load the last accepted block data. For a newly created VM, this will be
-	// the genesis. It is assumed the genesis was processed and stored
-	// previously during VM initialization.
-	lastAcceptedID, err := state.GetLastAccepted(db)
-	if err != nil {
-		return nil, err
-	}
-
+// New returns a chain whose state is stored in [db]. If [merkleState] is
+// non-nil, the merkleized state is stored in [merkleState] instead.
+func New(
+	ctx context.Context,
+	chainContext *snow.Context,
+	db database.Database,
+	merkleState merkledb.MerkleDB,
+) (Chain, error) {
 	c := &chain{
-		chainContext:  ctx,
+		chainContext:  chainContext,
 		acceptedState: db,
-		lastAccepted:  lastAcceptedID,
+		merkleState:   merkleState,
 	}
-
-	lastAccepted, err := c.getBlock(lastAcceptedID)
-	c.verifiedBlocks = map[ids.ID]*block{
-		lastAcceptedID: lastAccepted,
+	if err := c.commitPendingChanges(ctx); err != nil {
+		return nil, err
 	}
-	return c, err
+	return c, c.ReloadLastAccepted()
 }
 
 func (c *chain) LastAccepted() ids.ID {
@@ -70,6 +79,22 @@ func (c *chain) GetBlock(blkID ids.ID) (Block, error) {
 	return c.getBlock(blkID)
 }
 
+func (c *chain) ReloadLastAccepted() error {
+	// Load the last accepted block data. For a newly created VM, this will be
+	// the genesis. It is assumed the genesis was processed and stored
+	// previously during VM initialization.
+	lastAcceptedID, err := state.GetLastAccepted(c.acceptedState)
+	if err != nil {
+		return err
+	}
+
+	c.lastAccepted = lastAcceptedID
+	c.verifiedBlocks = make(map[ids.ID]*block)
+	lastAccepted, err := c.getBlock(lastAcceptedID)
+	c.verifiedBlocks[lastAcceptedID] = lastAccepted
+	return err
+}
+
 func (c *chain) NewBlock(blk *xsblock.Stateless) (Block, error) {
 	blkID, err := blk.ID()
 	if err != nil {
@@ -80,7 +105,7 @@ func (c *chain) NewBlock(blk *xsblock.Stateless) (Block, error) {
 		return blk, nil
 	}
 
-	blkBytes, err := xsblock.Codec.Marshal(xsblock.Version, blk)
+	blkBytes, err := blk.Bytes()
 	if err != nil {
 		return nil, err
 	}
@@ -93,6 +118,39 @@ func (c *chain) NewBlock(blk *xsblock.Stateless) (Block, error) {
 	}, nil
 }
 
+// commitPendingChanges commits the merkleized changes of the last accepted
+// block that weren't committed to the merkleized state before the node
+// stopped. Committing the changes is idempotent, so they may have already been
+// committed.
+func (c *chain) commitPendingChanges(ctx context.Context) error {
+	if c.merkleState == nil {
+		return nil
+	}
+
+	changes, err := state.GetPendingChanges(c.acceptedState)
+	if err != nil || len(changes) == 0 {
+		return err
+	}
+
+	view, err := c.merkleState.NewView(ctx, merkledb.ViewChanges{MapOps: changes})
+	if err != nil {
+		return err
+	}
+	if err := view.CommitToDB(ctx); err != nil {
+		return err
+	}
+	return state.DeletePendingChanges(c.acceptedState)
+}
+
+// executionState returns the accepted state that blocks are executed on top
+// of.
+func (c *chain) executionState() database.Database {
+	if c.merkleState != nil {
+		return c.merkleState
+	}
+	return c.acceptedState
+}
+
 func (c *chain) getBlock(blkID ids.ID) (*block, error) {
 	if blk, exists := c.verifiedBlocks[blkID]; exists {
 		return blk, nil
@@ -0,0 +1,63 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chain
+
+import (
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils/maybe"
+	"github.com/ava-labs/avalanchego/vms/example/xsvm/state"
+)
+
+var (
+	_ database.KeyValueWriterDeleter = (*keyFilter)(nil)
+	_ database.KeyValueWriterDeleter = mapOpsWriter(nil)
+	_ database.KeyValueWriterDeleter = pendingWriter{}
+)
+
+// keyFilter forwards the writes to keys that are merkleized, or to keys that
+// aren't merkleized, to [writer].
+type keyFilter struct {
+	merkleized bool
+	writer     database.KeyValueWriterDeleter
+}
+
+func (f *keyFilter) Put(key, value []byte) error {
+	if state.IsMerkleized(key) != f.merkleized {
+		return nil
+	}
+	return f.writer.Put(key, value)
+}
+
+func (f *keyFilter) Delete(key []byte) error {
+	if state.IsMerkleized(key) != f.merkleized {
+		return nil
+	}
+	return f.writer.Delete(key)
+}
+
+// mapOpsWriter records writes as merkledb view changes.
+type mapOpsWriter map[string]maybe.Maybe[[]byte]
+
+func (w mapOpsWriter) Put(key, value []byte) error {
+	w[string(key)] = maybe.Some(value)
+	return nil
+}
+
+func (w mapOpsWriter) Delete(key []byte) error {
+	w[string(key)] = maybe.Nothing[[]byte]()
+	return nil
+}
+
+// pendingWriter records writes as pending changes to the merkleized state.
+type pendingWriter struct {
+	writer database.KeyValueWriter
+}
+
+func (w pendingWriter) Put(key, value []byte) error {
+	return state.PutPendingChange(w.writer, key, maybe.Some(value))
+}
+
+func (w pendingWriter) Delete(key []byte) error {
+	return state.PutPendingChange(w.writer, key, maybe.Nothing[[]byte]())
+}
@@ -55,7 +55,7 @@ func createFunc(c *cobra.Command, args []string) error {
 	// Get the P-chain wallet
 	pWallet := wallet.P()
 
-	genesisBytes, err := genesis.Codec.Marshal(genesis.Version, &genesis.Genesis{
+	chainGenesis := &genesis.Genesis{
 		Timestamp: 0,
 		Allocations: []genesis.Allocation{
 			{
@@ -63,7 +63,9 @@ func createFunc(c *cobra.Command, args []string) error {
 				Balance: config.Balance,
 			},
 		},
-	})
+		MerkleState: config.MerkleState,
+	}
+	genesisBytes, err := chainGenesis.Bytes()
 	if err != nil {
 		return err
 	}
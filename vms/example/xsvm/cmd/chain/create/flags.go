@@ -21,6 +21,7 @@ const (
 	BalanceKey    = "balance"
 	NameKey       = "name"
 	PrivateKeyKey = "private-key"
+	MerkleKey     = "merkle-state"
 )
 
 func AddFlags(flags *pflag.FlagSet) {
@@ -30,15 +31,17 @@ func AddFlags(flags *pflag.FlagSet) {
 	flags.Uint64(BalanceKey, math.MaxUint64, "Amount to provide the funded address in the genesis")
 	flags.String(NameKey, "xs", "Name of the chain to create")
 	flags.String(PrivateKeyKey, genesis.EWOQKeyFormattedStr, "Private key to use when creating the new chain")
+	flags.Bool(MerkleKey, false, "Store the chain state in a merkledb to allow nodes to state sync")
 }
 
 type Config struct {
-	URI        string
-	SubnetID   ids.ID
-	Address    ids.ShortID
-	Balance    uint64
-	Name       string
-	PrivateKey *secp256k1.PrivateKey
+	URI         string
+	SubnetID    ids.ID
+	Address     ids.ShortID
+	Balance     uint64
+	Name        string
+	PrivateKey  *secp256k1.PrivateKey
+	MerkleState bool
 }
 
 func ParseFlags(flags *pflag.FlagSet, args []string) (*Config, error) {
@@ -96,12 +99,18 @@ func ParseFlags(flags *pflag.FlagSet, args []string) (*Config, error) {
 		return nil, err
 	}
 
+	merkleState, err := flags.GetBool(MerkleKey)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Config{
-		URI:        uri,
-		SubnetID:   subnetID,
-		Address:    addr,
-		Balance:    balance,
-		Name:       name,
-		PrivateKey: &sk,
+		URI:         uri,
+		SubnetID:    subnetID,
+		Address:     addr,
+		Balance:     balance,
+		Name:        name,
+		PrivateKey:  &sk,
+		MerkleState: merkleState,
 	}, nil
 }
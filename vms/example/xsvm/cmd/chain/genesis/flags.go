@@ -21,6 +21,7 @@ const (
 	AddressKey  = "address"
 	BalanceKey  = "balance"
 	EncodingKey = "encoding"
+	MerkleKey   = "merkle-state"
 
 	binaryEncoding = "binary"
 	hexEncoding    = "hex"
@@ -31,6 +32,7 @@ func AddFlags(flags *pflag.FlagSet) {
 	flags.String(AddressKey, genesis.EWOQKey.Address().String(), "Address to fund in the genesis")
 	flags.Uint64(BalanceKey, math.MaxUint64, "Amount to provide the funded address in the genesis")
 	flags.String(EncodingKey, hexEncoding, fmt.Sprintf("Encoding to use for the genesis. Available values: %s or %s", hexEncoding, binaryEncoding))
+	flags.Bool(MerkleKey, false, "Store the chain state in a merkledb to allow nodes to state sync")
 }
 
 type Config struct {
@@ -68,6 +70,11 @@ func ParseFlags(flags *pflag.FlagSet, args []string) (*Config, error) {
 		return nil, err
 	}
 
+	merkleState, err := flags.GetBool(MerkleKey)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Config{
 		Genesis: &xsgenesis.Genesis{
 			Timestamp: timestamp,
@@ -77,6 +84,7 @@ func ParseFlags(flags *pflag.FlagSet, args []string) (*Config, error) {
 					Balance: balance,
 				},
 			},
+			MerkleState: merkleState,
 		},
 		Encoding: encoding,
 	}, nil
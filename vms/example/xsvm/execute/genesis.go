@@ -4,14 +4,25 @@
 package execute
 
 import (
+	"context"
+
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
-	"github.com/ava-labs/avalanchego/vms/example/xsvm/block"
 	"github.com/ava-labs/avalanchego/vms/example/xsvm/genesis"
 	"github.com/ava-labs/avalanchego/vms/example/xsvm/state"
+	"github.com/ava-labs/avalanchego/x/merkledb"
 )
 
-func Genesis(db database.KeyValueReaderWriterDeleter, chainID ids.ID, g *genesis.Genesis) error {
+// Genesis initializes the chain state with [g]. If [merkleState] is non-nil,
+// the allocations are written to it rather than to [db] and the genesis block
+// commits to its root.
+func Genesis(
+	ctx context.Context,
+	db database.KeyValueReaderWriterDeleter,
+	merkleState merkledb.MerkleDB,
+	chainID ids.ID,
+	g *genesis.Genesis,
+) error {
 	isInitialized, err := state.IsInitialized(db)
 	if err != nil {
 		return err
@@ -20,23 +31,35 @@ func Genesis(db database.KeyValueReaderWriterDeleter, chainID ids.ID, g *genesis
 		return nil
 	}
 
-	blk, err := genesis.Block(g)
-	if err != nil {
-		return err
+	var allocationState database.KeyValueWriterDeleter = db
+	if merkleState != nil {
+		allocationState = merkleState
 	}
-
 	for _, allocation := range g.Allocations {
-		if err := state.SetBalance(db, allocation.Address, chainID, allocation.Balance); err != nil {
+		if err := state.SetBalance(allocationState, allocation.Address, chainID, allocation.Balance); err != nil {
+			return err
+		}
+	}
+
+	var stateRoot ids.ID
+	if merkleState != nil {
+		stateRoot, err = merkleState.GetMerkleRoot(ctx)
+		if err != nil {
 			return err
 		}
 	}
 
+	blk, err := genesis.Block(g, stateRoot)
+	if err != nil {
+		return err
+	}
+
 	blkID, err := blk.ID()
 	if err != nil {
 		return err
 	}
 
-	blkBytes, err := block.Codec.Marshal(block.Version, blk)
+	blkBytes, err := blk.Bytes()
 	if err != nil {
 		return err
 	}
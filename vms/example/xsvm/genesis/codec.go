@@ -5,7 +5,12 @@ package genesis
 
 import "github.com/ava-labs/avalanchego/vms/example/xsvm/block"
 
-// Version is the current default codec version
-const Version = block.Version
+const (
+	// Version is the current default codec version
+	Version = block.Version
+	// MerkleVersion is the codec version of geneses of chains that merkleize
+	// their state.
+	MerkleVersion = block.MerkleVersion
+)
 
 var Codec = block.Codec
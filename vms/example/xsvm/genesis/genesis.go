@@ -4,14 +4,24 @@
 package genesis
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/vms/example/xsvm/block"
 )
 
+var errNonCanonicalVersion = errors.New("non-canonical codec version")
+
 type Genesis struct {
 	Timestamp   int64        `serialize:"true" json:"timestamp"`
 	Allocations []Allocation `serialize:"true" json:"allocations"`
+	// MerkleState stores the chain state in a merkledb, which allows nodes to
+	// state sync.
+	//
+	// It's only serialized by [MerkleVersion].
+	MerkleState bool `serializeV1:"true" json:"merkleState"`
 }
 
 type Allocation struct {
@@ -19,19 +29,44 @@ type Allocation struct {
 	Balance uint64      `serialize:"true" json:"balance"`
 }
 
+// CodecVersion returns the codec version that [g] is marshalled with. Geneses
+// of chains that don't merkleize their state are marshalled with [Version],
+// so that they are the same as the geneses from before [MerkleState] was
+// added.
+func (g *Genesis) CodecVersion() uint16 {
+	if !g.MerkleState {
+		return Version
+	}
+	return MerkleVersion
+}
+
+func (g *Genesis) Bytes() ([]byte, error) {
+	return Codec.Marshal(g.CodecVersion(), g)
+}
+
 func Parse(bytes []byte) (*Genesis, error) {
 	genesis := &Genesis{}
-	_, err := Codec.Unmarshal(bytes, genesis)
-	return genesis, err
+	version, err := Codec.Unmarshal(bytes, genesis)
+	if err != nil {
+		return nil, err
+	}
+	// The genesis has a single valid encoding, so that the ID of the genesis
+	// block is the same on every node.
+	if expectedVersion := genesis.CodecVersion(); version != expectedVersion {
+		return nil, fmt.Errorf("%w: expected %d, got %d", errNonCanonicalVersion, expectedVersion, version)
+	}
+	return genesis, nil
 }
 
-func Block(genesis *Genesis) (*block.Stateless, error) {
-	bytes, err := Codec.Marshal(Version, genesis)
+// Block returns the genesis block, which commits to [stateRoot].
+func Block(genesis *Genesis, stateRoot ids.ID) (*block.Stateless, error) {
+	bytes, err := genesis.Bytes()
 	if err != nil {
 		return nil, err
 	}
 	return &block.Stateless{
 		ParentID:  hashing.ComputeHash256Array(bytes),
 		Timestamp: genesis.Timestamp,
+		StateRoot: stateRoot,
 	}, nil
 }
@@ -4,6 +4,7 @@
 package genesis
 
 import (
+	"encoding/hex"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -25,11 +26,52 @@ func TestGenesis(t *testing.T) {
 			{Address: id, Balance: 1000000000},
 			{Address: id2, Balance: 3000000000},
 		},
+		MerkleState: true,
 	}
-	bytes, err := Codec.Marshal(Version, genesis)
+	bytes, err := genesis.Bytes()
 	require.NoError(err)
 
 	parsed, err := Parse(bytes)
 	require.NoError(err)
 	require.Equal(genesis, *parsed)
 }
+
+// Test that a genesis from before [Genesis.MerkleState] was added is parsed,
+// and that it is marshalled into the same bytes and genesis block.
+func TestLegacyGenesis(t *testing.T) {
+	require := require.New(t)
+
+	legacyBytes, err := hex.DecodeString("000000000000000000040000000105000000000000000000000000000000000000000000000000000006")
+	require.NoError(err)
+
+	genesis, err := Parse(legacyBytes)
+	require.NoError(err)
+	require.Equal(&Genesis{
+		Timestamp: 4,
+		Allocations: []Allocation{
+			{Address: ids.ShortID{5}, Balance: 6},
+		},
+	}, genesis)
+
+	bytes, err := genesis.Bytes()
+	require.NoError(err)
+	require.Equal(legacyBytes, bytes)
+
+	blk, err := Block(genesis, ids.Empty)
+	require.NoError(err)
+	blkID, err := blk.ID()
+	require.NoError(err)
+	require.Equal("2uRA8m8hLiy78L1EteRoNHx2c7ydtafqRE1zQXxsPjShn6sph", blkID.String())
+}
+
+func TestParseNonCanonicalVersion(t *testing.T) {
+	require := require.New(t)
+
+	// A genesis that doesn't merkleize its state must be marshalled with
+	// [Version].
+	bytes, err := Codec.Marshal(MerkleVersion, &Genesis{})
+	require.NoError(err)
+
+	_, err = Parse(bytes)
+	require.ErrorIs(err, errNonCanonicalVersion)
+}
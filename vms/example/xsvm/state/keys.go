@@ -9,8 +9,16 @@ var (
 	addressPrefix  = []byte{0x01}
 	chainPrefix    = []byte{0x02}
 	messagePrefix  = []byte{0x03}
+	pendingPrefix  = []byte{0x04}
 )
 
+// IsMerkleized returns true if [key] is part of the state that is stored in a
+// merkledb when the chain merkleizes its state. The block state isn't
+// merkleized because blocks commit to the merkle root.
+func IsMerkleized(key []byte) bool {
+	return len(key) > 0 && key[0] != blockPrefix[0] && key[0] != pendingPrefix[0]
+}
+
 func Flatten[T any](slices ...[]T) []T {
 	var size int
 	for _, slice := range slices {
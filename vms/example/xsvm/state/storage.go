@@ -9,12 +9,19 @@ import (
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/utils/maybe"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 )
 
+const (
+	deleted byte = iota
+	updated
+)
+
 var (
-	errWrongNonce          = errors.New("wrong nonce")
-	errInsufficientBalance = errors.New("insufficient balance")
+	errInvalidPendingChange = errors.New("invalid pending change")
+	errWrongNonce           = errors.New("wrong nonce")
+	errInsufficientBalance  = errors.New("insufficient balance")
 )
 
 /*
@@ -30,8 +37,15 @@ var (
  * |-. chains
  * | |-- chainID -> balance
  * | '-- chainID + loanID -> nil
- * '-. message
- *   '-- txID -> message bytes
+ * |-. message
+ * | '-- txID -> message bytes
+ * '-. pending
+ *   '-- key -> change
+ *
+ * If the chain merkleizes its state, the address, chain, and message state
+ * are stored in a merkledb rather than in the VMDB. The merkleized changes of
+ * the last accepted block are recorded as pending changes until they are
+ * committed to the merkledb.
  */
 
 // Chain state
@@ -73,6 +87,49 @@ func AddBlock(db database.KeyValueWriter, height uint64, blkID ids.ID, blk []byt
 	return db.Put(idToBlockKey, blk)
 }
 
+// Pending state
+
+// PutPendingChange records that [key] is changed to [value] in the merkleized
+// state. If [value] is Nothing, [key] is deleted.
+func PutPendingChange(db database.KeyValueWriter, key []byte, value maybe.Maybe[[]byte]) error {
+	pendingKey := Flatten(pendingPrefix, key)
+	if value.IsNothing() {
+		return db.Put(pendingKey, []byte{deleted})
+	}
+	return db.Put(pendingKey, Flatten([]byte{updated}, value.Value()))
+}
+
+// GetPendingChanges returns the recorded changes to the merkleized state.
+func GetPendingChanges(db database.Iteratee) (map[string]maybe.Maybe[[]byte], error) {
+	it := db.NewIteratorWithPrefix(pendingPrefix)
+	defer it.Release()
+
+	changes := make(map[string]maybe.Maybe[[]byte])
+	for it.Next() {
+		key := string(it.Key()[len(pendingPrefix):])
+		value := it.Value()
+		switch {
+		case len(value) == 1 && value[0] == deleted:
+			changes[key] = maybe.Nothing[[]byte]()
+		case len(value) > 0 && value[0] == updated:
+			changes[key] = maybe.Some(Flatten(value[1:]))
+		default:
+			return nil, errInvalidPendingChange
+		}
+	}
+	return changes, it.Error()
+}
+
+// DeletePendingChanges removes all the recorded changes to the merkleized
+// state.
+func DeletePendingChanges(db database.Database) error {
+	batch := db.NewBatch()
+	if err := database.AtomicClearPrefix(db, batch, pendingPrefix); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
 // Address state
 
 func GetNonce(db database.KeyValueReader, address ids.ShortID) (uint64, error) {
@@ -0,0 +1,275 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package xsvm
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/example/xsvm/state"
+	"github.com/ava-labs/avalanchego/x/merkledb"
+	"github.com/ava-labs/avalanchego/x/sync"
+
+	smblock "github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	xsblock "github.com/ava-labs/avalanchego/vms/example/xsvm/block"
+)
+
+const (
+	merkleBranchFactor              = merkledb.BranchFactor16
+	merkleEvictionBatchSize         = units.MiB
+	merkleHistoryLength             = 256
+	merkleValueNodeCacheSize        = 4 * units.MiB
+	merkleIntermediateNodeCacheSize = 4 * units.MiB
+
+	syncMetricsNamespace         = "sync"
+	syncResponseCacheSize        = units.MiB
+	syncMaxActiveRequests        = 16
+	syncSimultaneousWorkLimit    = 8
+	syncProgressPersistFrequency = 10 * time.Second
+)
+
+var (
+	_ smblock.StateSummary = (*stateSummary)(nil)
+	_ common.AppHandler    = (*syncAppHandler)(nil)
+
+	// syncSummaryKey is the key under which the summary being synced to is
+	// stored in the sync database.
+	syncSummaryKey     = []byte("summary")
+	syncProgressPrefix = []byte("progress")
+)
+
+// stateSummary is the summary of the state following the acceptance of a
+// block. Its bytes are the block's bytes, and syncing to it downloads the
+// merkleized state with the block's state root.
+type stateSummary struct {
+	vm    *VM
+	id    ids.ID
+	block *xsblock.Stateless
+	bytes []byte
+}
+
+func (s *stateSummary) ID() ids.ID {
+	return s.id
+}
+
+func (s *stateSummary) Height() uint64 {
+	return s.block.Height
+}
+
+func (s *stateSummary) Bytes() []byte {
+	return s.bytes
+}
+
+func (s *stateSummary) Accept(context.Context) (smblock.StateSyncMode, error) {
+	return s.vm.acceptStateSummary(s)
+}
+
+// syncAppHandler serves proofs of the merkleized state to its peers and
+// forwards the responses to its own proof requests to the sync client.
+type syncAppHandler struct {
+	common.AppHandler
+
+	server *sync.NetworkServer
+	client sync.NetworkClient
+}
+
+func (h *syncAppHandler) AppRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, deadline time.Time, request []byte) error {
+	return h.server.AppRequest(ctx, nodeID, requestID, deadline, request)
+}
+
+func (h *syncAppHandler) AppRequestFailed(ctx context.Context, nodeID ids.NodeID, requestID uint32) error {
+	return h.client.AppRequestFailed(ctx, nodeID, requestID)
+}
+
+func (h *syncAppHandler) AppResponse(ctx context.Context, nodeID ids.NodeID, requestID uint32, response []byte) error {
+	return h.client.AppResponse(ctx, nodeID, requestID, response)
+}
+
+func (vm *VM) StateSyncEnabled(context.Context) (bool, error) {
+	return vm.merkleState != nil, nil
+}
+
+func (vm *VM) GetOngoingSyncStateSummary(context.Context) (smblock.StateSummary, error) {
+	if vm.merkleState == nil {
+		return nil, database.ErrNotFound
+	}
+	summaryBytes, err := vm.syncDB.Get(syncSummaryKey)
+	if err != nil {
+		return nil, err
+	}
+	return vm.parseStateSummary(summaryBytes)
+}
+
+func (vm *VM) GetLastStateSummary(context.Context) (smblock.StateSummary, error) {
+	if vm.merkleState == nil {
+		return nil, database.ErrNotFound
+	}
+	return vm.getStateSummary(vm.chain.LastAccepted())
+}
+
+func (vm *VM) ParseStateSummary(_ context.Context, summaryBytes []byte) (smblock.StateSummary, error) {
+	return vm.parseStateSummary(summaryBytes)
+}
+
+// GetStateSummary returns the summary of the state following the acceptance of
+// the block at [height]. Proofs of the state are only served while its root is
+// in the merkledb's history.
+func (vm *VM) GetStateSummary(_ context.Context, height uint64) (smblock.StateSummary, error) {
+	if vm.merkleState == nil {
+		return nil, database.ErrNotFound
+	}
+	blkID, err := state.GetBlockIDByHeight(vm.blockState, height)
+	if err != nil {
+		return nil, err
+	}
+	return vm.getStateSummary(blkID)
+}
+
+func (vm *VM) getStateSummary(blkID ids.ID) (*stateSummary, error) {
+	blkBytes, err := state.GetBlock(vm.blockState, blkID)
+	if err != nil {
+		return nil, err
+	}
+	return vm.parseStateSummary(blkBytes)
+}
+
+func (vm *VM) parseStateSummary(summaryBytes []byte) (*stateSummary, error) {
+	blk, err := xsblock.Parse(summaryBytes)
+	if err != nil {
+		return nil, err
+	}
+	blkID, err := blk.ID()
+	if err != nil {
+		return nil, err
+	}
+	return &stateSummary{
+		vm:    vm,
+		id:    blkID,
+		block: blk,
+		bytes: summaryBytes,
+	}, nil
+}
+
+// acceptStateSummary starts syncing the merkleized state to [summary] in the
+// background. The engine is notified once syncing completes.
+func (vm *VM) acceptStateSummary(summary *stateSummary) (smblock.StateSyncMode, error) {
+	// If the merkleized state was partially synced before a restart, it
+	// doesn't match the last accepted block anymore, so syncing can't be
+	// skipped.
+	isSyncing, err := vm.syncDB.Has(syncSummaryKey)
+	if err != nil {
+		return 0, err
+	}
+	if !isSyncing {
+		lastAccepted, err := vm.chain.GetBlock(vm.chain.LastAccepted())
+		if err != nil {
+			return 0, err
+		}
+		if summary.Height() <= lastAccepted.Height() {
+			vm.chainContext.Log.Info("skipping state sync",
+				zap.Stringer("summaryID", summary.ID()),
+				zap.Uint64("summaryHeight", summary.Height()),
+				zap.Uint64("lastAcceptedHeight", lastAccepted.Height()),
+			)
+			return smblock.StateSyncSkipped, nil
+		}
+	}
+
+	if err := vm.syncDB.Put(syncSummaryKey, summary.bytes); err != nil {
+		return 0, err
+	}
+
+	vm.syncManager, err = sync.NewManager(sync.ManagerConfig{
+		DB:                       vm.merkleState,
+		Client:                   vm.syncClient,
+		SimultaneousWorkLimit:    syncSimultaneousWorkLimit,
+		Log:                      vm.chainContext.Log,
+		TargetRoot:               summary.block.StateRoot,
+		BranchFactor:             merkleBranchFactor,
+		ProgressDB:               prefixdb.New(syncProgressPrefix, vm.syncDB),
+		ProgressPersistFrequency: syncProgressPersistFrequency,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := vm.syncManager.Start(ctx); err != nil {
+		cancel()
+		return 0, err
+	}
+	vm.cancelSync = cancel
+
+	vm.chainContext.Log.Info("state syncing",
+		zap.Stringer("summaryID", summary.ID()),
+		zap.Uint64("summaryHeight", summary.Height()),
+		zap.Stringer("stateRoot", summary.block.StateRoot),
+	)
+	go vm.finishStateSync(ctx, summary)
+	return smblock.StateSyncStatic, nil
+}
+
+// finishStateSync waits for the merkleized state to be synced, marks the
+// summary's block as accepted, and notifies the engine.
+func (vm *VM) finishStateSync(ctx context.Context, summary *stateSummary) {
+	err := vm.syncManager.Wait(ctx)
+
+	vm.chainContext.Lock.Lock()
+	if ctx.Err() != nil {
+		// The VM is shutting down.
+		vm.chainContext.Lock.Unlock()
+		return
+	}
+	if err == nil {
+		err = vm.setLastAcceptedSummary(summary)
+	}
+	if err != nil {
+		vm.chainContext.Log.Error("state sync failed",
+			zap.Stringer("summaryID", summary.ID()),
+			zap.Error(err),
+		)
+		// The error is reported to the engine when it moves on to
+		// bootstrapping.
+		vm.syncErr = err
+	}
+	vm.chainContext.Lock.Unlock()
+
+	select {
+	case vm.engineChan <- common.StateSyncDone:
+	case <-ctx.Done():
+	}
+}
+
+// setLastAcceptedSummary marks the summary's block as the last accepted block.
+//
+// Invariant: The merkleized state has been synced to the summary's state root.
+func (vm *VM) setLastAcceptedSummary(summary *stateSummary) error {
+	if err := state.AddBlock(vm.blockState, summary.Height(), summary.id, summary.bytes); err != nil {
+		return err
+	}
+	if err := state.SetLastAccepted(vm.blockState, summary.id); err != nil {
+		return err
+	}
+	if err := vm.syncDB.Delete(syncSummaryKey); err != nil {
+		return err
+	}
+	return vm.chain.ReloadLastAccepted()
+}
+
+// stopStateSync stops syncing the merkleized state, if it's being synced. The
+// sync progress is persisted so that syncing resumes after a restart.
+func (vm *VM) stopStateSync() {
+	if vm.syncManager == nil {
+		return
+	}
+	vm.cancelSync()
+	vm.syncManager.Close()
+}
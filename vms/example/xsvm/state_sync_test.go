@@ -0,0 +1,242 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package xsvm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/manager"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/avalanchego/vms/example/xsvm/genesis"
+	"github.com/ava-labs/avalanchego/vms/example/xsvm/state"
+	"github.com/ava-labs/avalanchego/vms/example/xsvm/tx"
+
+	smblock "github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+)
+
+const (
+	testNumBlocks    = 5
+	testInitialFunds = 1_000_000
+)
+
+type testVM struct {
+	*VM
+	nodeID     ids.NodeID
+	engineChan chan common.Message
+	sender     *common.SenderTest
+}
+
+func newTestVM(t *testing.T, chainID ids.ID, genesisBytes []byte) *testVM {
+	return newTestVMWithDB(t, chainID, genesisBytes, memdb.New())
+}
+
+// newTestVMWithDB returns an initialized VM whose state is stored in [db].
+func newTestVMWithDB(t *testing.T, chainID ids.ID, genesisBytes []byte, db database.Database) *testVM {
+	require := require.New(t)
+
+	dbManager, err := manager.NewManagerFromDBs([]*manager.VersionedDatabase{
+		{
+			Database: db,
+			Version:  version.Semantic1_0_0,
+		},
+	})
+	require.NoError(err)
+
+	chainContext := snow.DefaultContextTest()
+	chainContext.ChainID = chainID
+	chainContext.NodeID = ids.GenerateTestNodeID()
+
+	vm := &testVM{
+		VM:         &VM{},
+		nodeID:     chainContext.NodeID,
+		engineChan: make(chan common.Message, 1),
+		sender:     &common.SenderTest{T: t},
+	}
+	require.NoError(vm.Initialize(
+		context.Background(),
+		chainContext,
+		dbManager,
+		genesisBytes,
+		nil,
+		nil,
+		vm.engineChan,
+		nil,
+		vm.sender,
+	))
+	return vm
+}
+
+// connect routes the proof requests of [client] to [server], and the
+// responses back to [client].
+func connect(t *testing.T, client, server *testVM) {
+	require := require.New(t)
+
+	client.sender.SendAppRequestF = func(ctx context.Context, nodeIDs set.Set[ids.NodeID], requestID uint32, request []byte) error {
+		go func() {
+			_ = server.AppRequest(ctx, client.nodeID, requestID, time.Now().Add(time.Minute), request)
+		}()
+		return nil
+	}
+	server.sender.SendAppResponseF = func(ctx context.Context, _ ids.NodeID, requestID uint32, response []byte) error {
+		go func() {
+			_ = client.AppResponse(ctx, server.nodeID, requestID, response)
+		}()
+		return nil
+	}
+	require.NoError(client.Connected(context.Background(), server.nodeID, version.CurrentApp))
+}
+
+func TestStateSync(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	key, err := (&secp256k1.Factory{}).NewPrivateKey()
+	require.NoError(err)
+	chainGenesis := &genesis.Genesis{
+		Allocations: []genesis.Allocation{
+			{
+				Address: key.Address(),
+				Balance: testInitialFunds,
+			},
+		},
+		MerkleState: true,
+	}
+	genesisBytes, err := chainGenesis.Bytes()
+	require.NoError(err)
+
+	chainID := ids.GenerateTestID()
+	server := newTestVM(t, chainID, genesisBytes)
+	require.NoError(server.SetState(ctx, snow.NormalOp))
+
+	// Populate the server with blocks that transfer funds to new addresses.
+	recipients := make([]ids.ShortID, testNumBlocks)
+	for i := range recipients {
+		recipients[i] = ids.GenerateTestShortID()
+		transfer, err := tx.Sign(&tx.Transfer{
+			ChainID: chainID,
+			Nonce:   uint64(i),
+			AssetID: chainID,
+			Amount:  uint64(i + 1),
+			To:      recipients[i],
+		}, key)
+		require.NoError(err)
+		require.NoError(server.builder.AddTx(ctx, transfer))
+
+		blk, err := server.BuildBlock(ctx)
+		require.NoError(err)
+		require.NoError(blk.Verify(ctx))
+		require.NoError(blk.Accept(ctx))
+		require.NoError(server.SetPreference(ctx, blk.ID()))
+	}
+
+	client := newTestVM(t, chainID, genesisBytes)
+	connect(t, client, server)
+
+	enabled, err := client.StateSyncEnabled(ctx)
+	require.NoError(err)
+	require.True(enabled)
+
+	_, err = client.GetOngoingSyncStateSummary(ctx)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	serverSummary, err := server.GetLastStateSummary(ctx)
+	require.NoError(err)
+	require.Equal(uint64(testNumBlocks), serverSummary.Height())
+
+	summary, err := client.ParseStateSummary(ctx, serverSummary.Bytes())
+	require.NoError(err)
+	require.Equal(serverSummary.ID(), summary.ID())
+
+	mode, err := summary.Accept(ctx)
+	require.NoError(err)
+	require.Equal(smblock.StateSyncStatic, mode)
+
+	select {
+	case msg := <-client.engineChan:
+		require.Equal(common.StateSyncDone, msg)
+	case <-time.After(30 * time.Second):
+		require.FailNow("timed out waiting for state sync")
+	}
+	require.NoError(client.SetState(ctx, snow.Bootstrapping))
+
+	// The client's last accepted block is the summary's block.
+	lastAcceptedID, err := client.LastAccepted(ctx)
+	require.NoError(err)
+	require.Equal(summary.ID(), lastAcceptedID)
+	blkID, err := client.GetBlockIDAtHeight(ctx, summary.Height())
+	require.NoError(err)
+	require.Equal(summary.ID(), blkID)
+
+	_, err = client.GetOngoingSyncStateSummary(ctx)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// The client has the same state as the server.
+	serverRoot, err := server.merkleState.GetMerkleRoot(ctx)
+	require.NoError(err)
+	clientRoot, err := client.merkleState.GetMerkleRoot(ctx)
+	require.NoError(err)
+	require.Equal(serverRoot, clientRoot)
+	for i, recipient := range recipients {
+		balance, err := state.GetBalance(client.state, recipient, chainID)
+		require.NoError(err)
+		require.Equal(uint64(i+1), balance)
+	}
+
+	// The client can verify and accept the server's following blocks.
+	require.NoError(client.SetState(ctx, snow.NormalOp))
+	transfer, err := tx.Sign(&tx.Transfer{
+		ChainID: chainID,
+		Nonce:   testNumBlocks,
+		AssetID: chainID,
+		Amount:  1,
+		To:      ids.GenerateTestShortID(),
+	}, key)
+	require.NoError(err)
+	require.NoError(server.builder.AddTx(ctx, transfer))
+	serverBlk, err := server.BuildBlock(ctx)
+	require.NoError(err)
+
+	clientBlk, err := client.ParseBlock(ctx, serverBlk.Bytes())
+	require.NoError(err)
+	require.NoError(clientBlk.Verify(ctx))
+	require.NoError(clientBlk.Accept(ctx))
+
+	// A summary at or below the last accepted height isn't synced to.
+	mode, err = summary.Accept(ctx)
+	require.NoError(err)
+	require.Equal(smblock.StateSyncSkipped, mode)
+
+	require.NoError(client.Shutdown(ctx))
+	require.NoError(server.Shutdown(ctx))
+}
+
+func TestStateSyncDisabled(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	genesisBytes, err := (&genesis.Genesis{}).Bytes()
+	require.NoError(err)
+
+	vm := newTestVM(t, ids.GenerateTestID(), genesisBytes)
+
+	enabled, err := vm.StateSyncEnabled(ctx)
+	require.NoError(err)
+	require.False(enabled)
+
+	_, err = vm.GetLastStateSummary(ctx)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	require.NoError(vm.Shutdown(ctx))
+}
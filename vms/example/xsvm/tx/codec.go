@@ -8,16 +8,27 @@ import (
 
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/codec/linearcodec"
+	"github.com/ava-labs/avalanchego/codec/reflectcodec"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
 
-// Version is the current default codec version
-const Version = 0
+const (
+	// Version is the current default codec version
+	Version = 0
+	// MerkleVersion is the codec version of the blocks and geneses of chains
+	// that merkleize their state. It also serializes the fields tagged with
+	// [MerkleTagName], which aren't serialized by [Version] so that the bytes
+	// of chains that don't merkleize their state are unchanged.
+	MerkleVersion = 1
+
+	MerkleTagName = reflectcodec.DefaultTagName + "V1"
+)
 
 var Codec codec.Manager
 
 func init() {
 	c := linearcodec.NewCustomMaxLength(math.MaxInt32)
+	mc := linearcodec.New([]string{reflectcodec.DefaultTagName, MerkleTagName}, math.MaxInt32)
 	Codec = codec.NewManager(math.MaxInt32)
 
 	errs := wrappers.Errs{}
@@ -26,6 +37,11 @@ func init() {
 		c.RegisterType(&Export{}),
 		c.RegisterType(&Import{}),
 		Codec.RegisterCodec(Version, c),
+
+		mc.RegisterType(&Transfer{}),
+		mc.RegisterType(&Export{}),
+		mc.RegisterType(&Import{}),
+		Codec.RegisterCodec(MerkleVersion, mc),
 	)
 	if errs.Errored() {
 		panic(errs.Err)
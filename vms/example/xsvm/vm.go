@@ -10,15 +10,19 @@ import (
 
 	"github.com/gorilla/rpc/v2"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"go.uber.org/zap"
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/manager"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/database/versiondb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms/example/xsvm/api"
@@ -27,6 +31,8 @@ import (
 	"github.com/ava-labs/avalanchego/vms/example/xsvm/execute"
 	"github.com/ava-labs/avalanchego/vms/example/xsvm/genesis"
 	"github.com/ava-labs/avalanchego/vms/example/xsvm/state"
+	"github.com/ava-labs/avalanchego/x/merkledb"
+	"github.com/ava-labs/avalanchego/x/sync"
 
 	smblock "github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	xsblock "github.com/ava-labs/avalanchego/vms/example/xsvm/block"
@@ -35,6 +41,11 @@ import (
 var (
 	_ smblock.ChainVM                      = (*VM)(nil)
 	_ smblock.BuildBlockWithContextChainVM = (*VM)(nil)
+	_ smblock.StateSyncableVM              = (*VM)(nil)
+
+	blockDBPrefix  = []byte("block")
+	merkleDBPrefix = []byte("merkle")
+	syncDBPrefix   = []byte("sync")
 )
 
 type VM struct {
@@ -45,12 +56,29 @@ type VM struct {
 	genesis      *genesis.Genesis
 	engineChan   chan<- common.Message
 
+	// blockState stores the blocks. Unless the chain merkleizes its state, it
+	// also stores the chain state.
+	blockState database.Database
+	// merkleState is nil unless the chain merkleizes its state.
+	merkleState merkledb.MerkleDB
+	// state stores the chain state.
+	state database.Database
+
 	chain   chain.Chain
 	builder builder.Builder
+
+	// The following fields are only set if the chain merkleizes its state.
+	syncDB            database.Database
+	syncNetworkClient sync.NetworkClient
+	syncClient        sync.Client
+	syncManager       *sync.Manager
+	cancelSync        context.CancelFunc
+	// syncErr is the error that caused state syncing to fail, if any.
+	syncErr error
 }
 
 func (vm *VM) Initialize(
-	_ context.Context,
+	ctx context.Context,
 	chainContext *snow.Context,
 	dbManager manager.Manager,
 	genesisBytes []byte,
@@ -58,7 +86,7 @@ func (vm *VM) Initialize(
 	_ []byte,
 	engineChan chan<- common.Message,
 	_ []*common.Fx,
-	_ common.AppSender,
+	appSender common.AppSender,
 ) error {
 	vm.AppHandler = common.NewNoOpAppHandler(chainContext.Log)
 
@@ -74,8 +102,16 @@ func (vm *VM) Initialize(
 		return fmt.Errorf("failed to parse genesis bytes: %w", err)
 	}
 
-	vdb := versiondb.New(vm.db)
-	if err := execute.Genesis(vdb, chainContext.ChainID, g); err != nil {
+	vm.blockState = vm.db
+	vm.state = vm.db
+	if g.MerkleState {
+		if err := vm.initializeMerkleState(ctx, appSender); err != nil {
+			return fmt.Errorf("failed to initialize merkleized state: %w", err)
+		}
+	}
+
+	vdb := versiondb.New(vm.blockState)
+	if err := execute.Genesis(ctx, vdb, vm.merkleState, chainContext.ChainID, g); err != nil {
 		return fmt.Errorf("failed to initialize genesis state: %w", err)
 	}
 	if err := vdb.Commit(); err != nil {
@@ -85,7 +121,7 @@ func (vm *VM) Initialize(
 	vm.genesis = g
 	vm.engineChan = engineChan
 
-	vm.chain, err = chain.New(chainContext, vm.db)
+	vm.chain, err = chain.New(ctx, chainContext, vm.blockState, vm.merkleState)
 	if err != nil {
 		return fmt.Errorf("failed to initialize chain manager: %w", err)
 	}
@@ -98,7 +134,86 @@ func (vm *VM) Initialize(
 	return nil
 }
 
+// initializeMerkleState stores the chain state in a merkledb and serves
+// proofs of it to the peers that are state syncing.
+func (vm *VM) initializeMerkleState(ctx context.Context, appSender common.AppSender) error {
+	registerer := prometheus.NewRegistry()
+	if err := vm.chainContext.Metrics.Register(registerer); err != nil {
+		return err
+	}
+
+	var err error
+	vm.merkleState, err = merkledb.New(
+		ctx,
+		prefixdb.New(merkleDBPrefix, vm.db),
+		merkledb.Config{
+			BranchFactor:              merkleBranchFactor,
+			EvictionBatchSize:         merkleEvictionBatchSize,
+			HistoryLength:             merkleHistoryLength,
+			ValueNodeCacheSize:        merkleValueNodeCacheSize,
+			IntermediateNodeCacheSize: merkleIntermediateNodeCacheSize,
+			Reg:                       registerer,
+			Tracer:                    trace.Noop,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	vm.blockState = prefixdb.New(blockDBPrefix, vm.db)
+	vm.state = vm.merkleState
+	vm.syncDB = prefixdb.New(syncDBPrefix, vm.db)
+
+	serverMetrics, err := sync.NewServerMetrics(syncMetricsNamespace, registerer)
+	if err != nil {
+		return err
+	}
+	syncServer := sync.NewNetworkServer(
+		appSender,
+		vm.merkleState,
+		vm.chainContext.Log,
+		sync.NetworkServerConfig{
+			ResponseCacheSize: syncResponseCacheSize,
+			Metrics:           serverMetrics,
+		},
+	)
+
+	vm.syncNetworkClient, err = sync.NewNetworkClient(
+		appSender,
+		vm.chainContext.NodeID,
+		syncMaxActiveRequests,
+		vm.chainContext.Log,
+		syncMetricsNamespace,
+		registerer,
+	)
+	if err != nil {
+		return err
+	}
+	syncMetrics, err := sync.NewMetrics(syncMetricsNamespace, registerer)
+	if err != nil {
+		return err
+	}
+	vm.syncClient, err = sync.NewClient(&sync.ClientConfig{
+		NetworkClient: vm.syncNetworkClient,
+		Log:           vm.chainContext.Log,
+		Metrics:       syncMetrics,
+		BranchFactor:  merkleBranchFactor,
+	})
+	if err != nil {
+		return err
+	}
+
+	vm.AppHandler = &syncAppHandler{
+		AppHandler: vm.AppHandler,
+		server:     syncServer,
+		client:     vm.syncNetworkClient,
+	}
+	return nil
+}
+
 func (vm *VM) SetState(_ context.Context, state snow.State) error {
+	if vm.syncErr != nil {
+		return vm.syncErr
+	}
 	vm.chain.SetChainState(state)
 	return nil
 }
@@ -107,6 +222,12 @@ func (vm *VM) Shutdown(context.Context) error {
 	if vm.chainContext == nil {
 		return nil
 	}
+	if vm.merkleState != nil {
+		vm.stopStateSync()
+		if err := vm.merkleState.Close(); err != nil {
+			return err
+		}
+	}
 	return vm.db.Close()
 }
 
@@ -125,7 +246,8 @@ func (vm *VM) CreateHandlers(_ context.Context) (map[string]*common.HTTPHandler,
 	api := api.NewServer(
 		vm.chainContext,
 		vm.genesis,
-		vm.db,
+		vm.blockState,
+		vm.state,
 		vm.chain,
 		vm.builder,
 	)
@@ -144,12 +266,18 @@ func (*VM) HealthCheck(context.Context) (interface{}, error) {
 	return http.StatusOK, nil
 }
 
-func (*VM) Connected(context.Context, ids.NodeID, *version.Application) error {
-	return nil
+func (vm *VM) Connected(ctx context.Context, nodeID ids.NodeID, nodeVersion *version.Application) error {
+	if vm.syncNetworkClient == nil {
+		return nil
+	}
+	return vm.syncNetworkClient.Connected(ctx, nodeID, nodeVersion)
 }
 
-func (*VM) Disconnected(context.Context, ids.NodeID) error {
-	return nil
+func (vm *VM) Disconnected(ctx context.Context, nodeID ids.NodeID) error {
+	if vm.syncNetworkClient == nil {
+		return nil
+	}
+	return vm.syncNetworkClient.Disconnected(ctx, nodeID)
 }
 
 func (vm *VM) GetBlock(_ context.Context, blkID ids.ID) (snowman.Block, error) {
@@ -186,5 +314,5 @@ func (*VM) VerifyHeightIndex(context.Context) error {
 }
 
 func (vm *VM) GetBlockIDAtHeight(_ context.Context, height uint64) (ids.ID, error) {
-	return state.GetBlockIDByHeight(vm.db, height)
+	return state.GetBlockIDByHeight(vm.blockState, height)
 }
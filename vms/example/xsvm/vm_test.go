@@ -0,0 +1,150 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package xsvm
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/vms/example/xsvm/genesis"
+	"github.com/ava-labs/avalanchego/vms/example/xsvm/state"
+	"github.com/ava-labs/avalanchego/vms/example/xsvm/tx"
+
+	xsblock "github.com/ava-labs/avalanchego/vms/example/xsvm/block"
+)
+
+var errCrashed = errors.New("crashed")
+
+// crashingDB fails all the writes to keys with [prefix] once [crashed] is set,
+// to simulate the node stopping before those writes are persisted.
+type crashingDB struct {
+	database.Database
+	prefix  []byte
+	crashed bool
+}
+
+func (db *crashingDB) Put(key, value []byte) error {
+	if db.crashed && bytes.HasPrefix(key, db.prefix) {
+		return errCrashed
+	}
+	return db.Database.Put(key, value)
+}
+
+func (db *crashingDB) Delete(key []byte) error {
+	if db.crashed && bytes.HasPrefix(key, db.prefix) {
+		return errCrashed
+	}
+	return db.Database.Delete(key)
+}
+
+func (db *crashingDB) NewBatch() database.Batch {
+	return &crashingBatch{
+		Batch: db.Database.NewBatch(),
+		db:    db,
+	}
+}
+
+type crashingBatch struct {
+	database.Batch
+	db      *crashingDB
+	touched bool
+}
+
+func (b *crashingBatch) Put(key, value []byte) error {
+	b.touched = b.touched || bytes.HasPrefix(key, b.db.prefix)
+	return b.Batch.Put(key, value)
+}
+
+func (b *crashingBatch) Delete(key []byte) error {
+	b.touched = b.touched || bytes.HasPrefix(key, b.db.prefix)
+	return b.Batch.Delete(key)
+}
+
+func (b *crashingBatch) Write() error {
+	if b.db.crashed && b.touched {
+		return errCrashed
+	}
+	return b.Batch.Write()
+}
+
+func TestAcceptCrashBeforeMerkleCommit(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	key, err := (&secp256k1.Factory{}).NewPrivateKey()
+	require.NoError(err)
+	chainGenesis := &genesis.Genesis{
+		Allocations: []genesis.Allocation{
+			{
+				Address: key.Address(),
+				Balance: testInitialFunds,
+			},
+		},
+		MerkleState: true,
+	}
+	genesisBytes, err := chainGenesis.Bytes()
+	require.NoError(err)
+
+	chainID := ids.GenerateTestID()
+	baseDB := memdb.New()
+	db := &crashingDB{
+		Database: baseDB,
+		prefix:   prefixdb.MakePrefix(merkleDBPrefix),
+	}
+	vm := newTestVMWithDB(t, chainID, genesisBytes, db)
+	require.NoError(vm.SetState(ctx, snow.NormalOp))
+
+	recipient := ids.GenerateTestShortID()
+	transfer, err := tx.Sign(&tx.Transfer{
+		ChainID: chainID,
+		AssetID: chainID,
+		Amount:  1,
+		To:      recipient,
+	}, key)
+	require.NoError(err)
+	require.NoError(vm.builder.AddTx(ctx, transfer))
+
+	blk, err := vm.BuildBlock(ctx)
+	require.NoError(err)
+	require.NoError(blk.Verify(ctx))
+
+	// The node stops after the block is written but before its changes are
+	// committed to the merkleized state.
+	db.crashed = true
+	err = blk.Accept(ctx)
+	require.ErrorIs(err, errCrashed)
+
+	lastAcceptedID, err := state.GetLastAccepted(prefixdb.New(blockDBPrefix, baseDB))
+	require.NoError(err)
+	require.Equal(blk.ID(), lastAcceptedID)
+
+	// Restarting the node commits the block's changes to the merkleized state.
+	restarted := newTestVMWithDB(t, chainID, genesisBytes, baseDB)
+
+	lastAcceptedID, err = restarted.LastAccepted(ctx)
+	require.NoError(err)
+	require.Equal(blk.ID(), lastAcceptedID)
+
+	stateless, err := xsblock.Parse(blk.Bytes())
+	require.NoError(err)
+	root, err := restarted.merkleState.GetMerkleRoot(ctx)
+	require.NoError(err)
+	require.Equal(stateless.StateRoot, root)
+
+	balance, err := state.GetBalance(restarted.state, recipient, chainID)
+	require.NoError(err)
+	require.Equal(uint64(1), balance)
+
+	require.NoError(restarted.Shutdown(ctx))
+}
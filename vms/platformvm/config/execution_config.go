@@ -6,6 +6,7 @@ package config
 import (
 	"encoding/json"
 
+	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/utils/units"
 )
 
@@ -18,20 +19,25 @@ var DefaultExecutionConfig = ExecutionConfig{
 	ChainDBCacheSize:             2048,
 	BlockIDCacheSize:             8192,
 	FxOwnerCacheSize:             4 * units.MiB,
+	CachePolicy:                  cache.LRUPolicy,
 	ChecksumsEnabled:             false,
 }
 
 // ExecutionConfig provides execution parameters of PlatformVM
 type ExecutionConfig struct {
-	BlockCacheSize               int  `json:"block-cache-size"`
-	TxCacheSize                  int  `json:"tx-cache-size"`
-	TransformedSubnetTxCacheSize int  `json:"transformed-subnet-tx-cache-size"`
-	RewardUTXOsCacheSize         int  `json:"reward-utxos-cache-size"`
-	ChainCacheSize               int  `json:"chain-cache-size"`
-	ChainDBCacheSize             int  `json:"chain-db-cache-size"`
-	BlockIDCacheSize             int  `json:"block-id-cache-size"`
-	FxOwnerCacheSize             int  `json:"fx-owner-cache-size"`
-	ChecksumsEnabled             bool `json:"checksums-enabled"`
+	BlockCacheSize               int `json:"block-cache-size"`
+	TxCacheSize                  int `json:"tx-cache-size"`
+	TransformedSubnetTxCacheSize int `json:"transformed-subnet-tx-cache-size"`
+	RewardUTXOsCacheSize         int `json:"reward-utxos-cache-size"`
+	ChainCacheSize               int `json:"chain-cache-size"`
+	ChainDBCacheSize             int `json:"chain-db-cache-size"`
+	BlockIDCacheSize             int `json:"block-id-cache-size"`
+	FxOwnerCacheSize             int `json:"fx-owner-cache-size"`
+	// CachePolicy is the eviction policy of the state's caches. [cache.TwoQueuePolicy]
+	// prevents scans, such as during bootstrapping, from evicting the
+	// elements that are accessed repeatedly.
+	CachePolicy      cache.Policy `json:"cache-policy"`
+	ChecksumsEnabled bool         `json:"checksums-enabled"`
 }
 
 // GetExecutionConfig returns an ExecutionConfig
@@ -45,5 +51,8 @@ func GetExecutionConfig(b []byte) (*ExecutionConfig, error) {
 		return &ec, nil
 	}
 
-	return &ec, json.Unmarshal(b, &ec)
+	if err := json.Unmarshal(b, &ec); err != nil {
+		return nil, err
+	}
+	return &ec, ec.CachePolicy.Verify()
 }
@@ -7,6 +7,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/cache"
 )
 
 func TestExecutionConfigUnmarshal(t *testing.T) {
@@ -47,6 +49,7 @@ func TestExecutionConfigUnmarshal(t *testing.T) {
 			"chain-db-cache-size": 7,
 			"block-id-cache-size": 8,
 			"fx-owner-cache-size": 9,
+			"cache-policy": "2q",
 			"checksums-enabled": true
 		}`)
 		ec, err := GetExecutionConfig(b)
@@ -60,8 +63,16 @@ func TestExecutionConfigUnmarshal(t *testing.T) {
 			ChainDBCacheSize:             7,
 			BlockIDCacheSize:             8,
 			FxOwnerCacheSize:             9,
+			CachePolicy:                  cache.TwoQueuePolicy,
 			ChecksumsEnabled:             true,
 		}
 		require.Equal(expected, ec)
 	})
+
+	t.Run("unknown cache policy", func(t *testing.T) {
+		require := require.New(t)
+		b := []byte(`{"cache-policy":"unknown"}`)
+		_, err := GetExecutionConfig(b)
+		require.ErrorIs(err, cache.ErrUnknownPolicy)
+	})
 }
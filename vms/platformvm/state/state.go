@@ -520,19 +520,27 @@ func newState(
 	rewards reward.Calculator,
 	bootstrapped *utils.Atomic[bool],
 ) (*state, error) {
+	baseBlockIDCache, err := cache.NewCacher[uint64, ids.ID](execCfg.CachePolicy, execCfg.BlockIDCacheSize)
+	if err != nil {
+		return nil, err
+	}
 	blockIDCache, err := metercacher.New[uint64, ids.ID](
 		"block_id_cache",
 		metricsReg,
-		&cache.LRU[uint64, ids.ID]{Size: execCfg.BlockIDCacheSize},
+		baseBlockIDCache,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	baseBlockCache, err := cache.NewSizedCacher[ids.ID, block.Block](execCfg.CachePolicy, execCfg.BlockCacheSize, blockSize)
+	if err != nil {
+		return nil, err
+	}
 	blockCache, err := metercacher.New[ids.ID, block.Block](
 		"block_cache",
 		metricsReg,
-		cache.NewSizedLRU[ids.ID, block.Block](execCfg.BlockCacheSize, blockSize),
+		baseBlockCache,
 	)
 	if err != nil {
 		return nil, err
@@ -559,20 +567,28 @@ func newState(
 	flatValidatorWeightDiffsDB := prefixdb.New(flatValidatorWeightDiffsPrefix, validatorsDB)
 	flatValidatorPublicKeyDiffsDB := prefixdb.New(flatValidatorPublicKeyDiffsPrefix, validatorsDB)
 
+	baseTxCache, err := cache.NewSizedCacher[ids.ID, *txAndStatus](execCfg.CachePolicy, execCfg.TxCacheSize, txAndStatusSize)
+	if err != nil {
+		return nil, err
+	}
 	txCache, err := metercacher.New(
 		"tx_cache",
 		metricsReg,
-		cache.NewSizedLRU[ids.ID, *txAndStatus](execCfg.TxCacheSize, txAndStatusSize),
+		baseTxCache,
 	)
 	if err != nil {
 		return nil, err
 	}
 
 	rewardUTXODB := prefixdb.New(rewardUTXOsPrefix, baseDB)
+	baseRewardUTXOsCache, err := cache.NewCacher[ids.ID, []*avax.UTXO](execCfg.CachePolicy, execCfg.RewardUTXOsCacheSize)
+	if err != nil {
+		return nil, err
+	}
 	rewardUTXOsCache, err := metercacher.New[ids.ID, []*avax.UTXO](
 		"reward_utxos_cache",
 		metricsReg,
-		&cache.LRU[ids.ID, []*avax.UTXO]{Size: execCfg.RewardUTXOsCacheSize},
+		baseRewardUTXOsCache,
 	)
 	if err != nil {
 		return nil, err
@@ -587,48 +603,68 @@ func newState(
 	subnetBaseDB := prefixdb.New(subnetPrefix, baseDB)
 
 	subnetOwnerDB := prefixdb.New(subnetOwnerPrefix, baseDB)
+	baseSubnetOwnerCache, err := cache.NewSizedCacher[ids.ID, fxOwnerAndSize](execCfg.CachePolicy, execCfg.FxOwnerCacheSize, func(_ ids.ID, f fxOwnerAndSize) int {
+		return ids.IDLen + f.size
+	})
+	if err != nil {
+		return nil, err
+	}
 	subnetOwnerCache, err := metercacher.New[ids.ID, fxOwnerAndSize](
 		"subnet_owner_cache",
 		metricsReg,
-		cache.NewSizedLRU[ids.ID, fxOwnerAndSize](execCfg.FxOwnerCacheSize, func(_ ids.ID, f fxOwnerAndSize) int {
-			return ids.IDLen + f.size
-		}),
+		baseSubnetOwnerCache,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	baseTransformedSubnetCache, err := cache.NewSizedCacher[ids.ID, *txs.Tx](execCfg.CachePolicy, execCfg.TransformedSubnetTxCacheSize, txSize)
+	if err != nil {
+		return nil, err
+	}
 	transformedSubnetCache, err := metercacher.New(
 		"transformed_subnet_cache",
 		metricsReg,
-		cache.NewSizedLRU[ids.ID, *txs.Tx](execCfg.TransformedSubnetTxCacheSize, txSize),
+		baseTransformedSubnetCache,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	baseSupplyCache, err := cache.NewCacher[ids.ID, *uint64](execCfg.CachePolicy, execCfg.ChainCacheSize)
+	if err != nil {
+		return nil, err
+	}
 	supplyCache, err := metercacher.New[ids.ID, *uint64](
 		"supply_cache",
 		metricsReg,
-		&cache.LRU[ids.ID, *uint64]{Size: execCfg.ChainCacheSize},
+		baseSupplyCache,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	baseChainCache, err := cache.NewCacher[ids.ID, []*txs.Tx](execCfg.CachePolicy, execCfg.ChainCacheSize)
+	if err != nil {
+		return nil, err
+	}
 	chainCache, err := metercacher.New[ids.ID, []*txs.Tx](
 		"chain_cache",
 		metricsReg,
-		&cache.LRU[ids.ID, []*txs.Tx]{Size: execCfg.ChainCacheSize},
+		baseChainCache,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	baseChainDBCache, err := cache.NewCacher[ids.ID, linkeddb.LinkedDB](execCfg.CachePolicy, execCfg.ChainDBCacheSize)
+	if err != nil {
+		return nil, err
+	}
 	chainDBCache, err := metercacher.New[ids.ID, linkeddb.LinkedDB](
 		"chain_db_cache",
 		metricsReg,
-		&cache.LRU[ids.ID, linkeddb.LinkedDB]{Size: execCfg.ChainDBCacheSize},
+		baseChainDBCache,
 	)
 	if err != nil {
 		return nil, err
@@ -1023,6 +1023,7 @@ func initTestRemoteProposerVM(
 		0,
 		DefaultMinBlockDelay,
 		DefaultNumHistoricalBlocks,
+		DefaultCachePolicy,
 		pTestSigner,
 		pTestCert,
 	)
@@ -665,6 +665,7 @@ func TestOptionTimestampValidity(t *testing.T) {
 		0,
 		DefaultMinBlockDelay,
 		DefaultNumHistoricalBlocks,
+		DefaultCachePolicy,
 		pTestSigner,
 		pTestCert,
 	)
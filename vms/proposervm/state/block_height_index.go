@@ -70,10 +70,32 @@ type heightIndex struct {
 }
 
 func NewHeightIndex(db database.Database, commitable versiondb.Commitable) HeightIndex {
+	return newHeightIndex(db, commitable, &cache.LRU[uint64, ids.ID]{Size: cacheSize})
+}
+
+// NewHeightIndexWithPolicy returns a HeightIndex whose cache evicts heights
+// following [cachePolicy].
+func NewHeightIndexWithPolicy(
+	db database.Database,
+	commitable versiondb.Commitable,
+	cachePolicy cache.Policy,
+) (HeightIndex, error) {
+	heightsCache, err := cache.NewCacher[uint64, ids.ID](cachePolicy, cacheSize)
+	if err != nil {
+		return nil, err
+	}
+	return newHeightIndex(db, commitable, heightsCache), nil
+}
+
+func newHeightIndex(
+	db database.Database,
+	commitable versiondb.Commitable,
+	heightsCache cache.Cacher[uint64, ids.ID],
+) *heightIndex {
 	return &heightIndex{
 		Commitable: commitable,
 
-		heightsCache: &cache.LRU[uint64, ids.ID]{Size: cacheSize},
+		heightsCache: heightsCache,
 		heightDB:     prefixdb.New(heightPrefix, db),
 		metadataDB:   prefixdb.New(metadataPrefix, db),
 	}
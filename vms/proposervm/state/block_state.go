@@ -66,14 +66,26 @@ func NewBlockState(db database.Database) BlockState {
 	}
 }
 
-func NewMeteredBlockState(db database.Database, namespace string, metrics prometheus.Registerer) (BlockState, error) {
+// NewMeteredBlockState returns a BlockState whose cache evicts blocks following
+// [cachePolicy].
+func NewMeteredBlockState(
+	db database.Database,
+	namespace string,
+	metrics prometheus.Registerer,
+	cachePolicy cache.Policy,
+) (BlockState, error) {
+	baseCache, err := cache.NewSizedCacher[ids.ID, *blockWrapper](
+		cachePolicy,
+		blockCacheSize,
+		cachedBlockSize,
+	)
+	if err != nil {
+		return nil, err
+	}
 	blkCache, err := metercacher.New[ids.ID, *blockWrapper](
 		fmt.Sprintf("%s_block_cache", namespace),
 		metrics,
-		cache.NewSizedLRU[ids.ID, *blockWrapper](
-			blockCacheSize,
-			cachedBlockSize,
-		),
+		baseCache,
 	)
 
 	return &blockState{
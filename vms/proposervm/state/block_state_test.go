@@ -12,6 +12,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/ids"
@@ -74,11 +75,15 @@ func TestBlockState(t *testing.T) {
 }
 
 func TestMeteredBlockState(t *testing.T) {
-	a := require.New(t)
+	for _, cachePolicy := range []cache.Policy{cache.LRUPolicy, cache.TwoQueuePolicy} {
+		t.Run(string(cachePolicy), func(t *testing.T) {
+			a := require.New(t)
 
-	db := memdb.New()
-	bs, err := NewMeteredBlockState(db, "", prometheus.NewRegistry())
-	a.NoError(err)
+			db := memdb.New()
+			bs, err := NewMeteredBlockState(db, "", prometheus.NewRegistry(), cachePolicy)
+			a.NoError(err)
 
-	testBlockState(a, bs)
+			testBlockState(a, bs)
+		})
+	}
 }
@@ -6,6 +6,7 @@ package state
 import (
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/database/versiondb"
 )
@@ -40,12 +41,24 @@ func New(db *versiondb.Database) State {
 	}
 }
 
-func NewMetered(db *versiondb.Database, namespace string, metrics prometheus.Registerer) (State, error) {
+// NewMetered returns a State whose caches evict elements following
+// [cachePolicy].
+func NewMetered(
+	db *versiondb.Database,
+	namespace string,
+	metrics prometheus.Registerer,
+	cachePolicy cache.Policy,
+) (State, error) {
 	chainDB := prefixdb.New(chainStatePrefix, db)
 	blockDB := prefixdb.New(blockStatePrefix, db)
 	heightDB := prefixdb.New(heightIndexPrefix, db)
 
-	blockState, err := NewMeteredBlockState(blockDB, namespace, metrics)
+	blockState, err := NewMeteredBlockState(blockDB, namespace, metrics, cachePolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	heightIndex, err := NewHeightIndexWithPolicy(heightDB, db, cachePolicy)
 	if err != nil {
 		return nil, err
 	}
@@ -53,6 +66,6 @@ func NewMetered(db *versiondb.Database, namespace string, metrics prometheus.Reg
 	return &state{
 		ChainState:  NewChainState(chainDB),
 		BlockState:  blockState,
-		HeightIndex: NewHeightIndex(heightDB, db),
+		HeightIndex: heightIndex,
 	}, nil
 }
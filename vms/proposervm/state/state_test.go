@@ -10,6 +10,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/database/versiondb"
 )
@@ -26,13 +27,17 @@ func TestState(t *testing.T) {
 }
 
 func TestMeteredState(t *testing.T) {
-	a := require.New(t)
-
-	db := memdb.New()
-	vdb := versiondb.New(db)
-	s, err := NewMetered(vdb, "", prometheus.NewRegistry())
-	a.NoError(err)
-
-	testBlockState(a, s)
-	testChainState(a, s)
+	for _, cachePolicy := range []cache.Policy{cache.LRUPolicy, cache.TwoQueuePolicy} {
+		t.Run(string(cachePolicy), func(t *testing.T) {
+			a := require.New(t)
+
+			db := memdb.New()
+			vdb := versiondb.New(db)
+			s, err := NewMetered(vdb, "", prometheus.NewRegistry(), cachePolicy)
+			a.NoError(err)
+
+			testBlockState(a, s)
+			testChainState(a, s)
+		})
+	}
 }
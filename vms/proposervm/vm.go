@@ -49,6 +49,9 @@ const (
 	// DefaultNumHistoricalBlocks as 0 results in never deleting any historical
 	// blocks.
 	DefaultNumHistoricalBlocks uint64 = 0
+	// DefaultCachePolicy evicts the least recently used blocks from the
+	// state's caches.
+	DefaultCachePolicy = cache.LRUPolicy
 
 	checkIndexedFrequency = 10 * time.Second
 	innerBlkCacheSize     = 64 * units.MiB
@@ -95,6 +98,7 @@ type VM struct {
 	minimumPChainHeight uint64
 	minBlkDelay         time.Duration
 	numHistoricalBlocks uint64
+	cachePolicy         cache.Policy
 	// block signer
 	stakingLeafSigner crypto.Signer
 	// block certificate
@@ -143,6 +147,7 @@ func New(
 	minimumPChainHeight uint64,
 	minBlkDelay time.Duration,
 	numHistoricalBlocks uint64,
+	cachePolicy cache.Policy,
 	stakingLeafSigner crypto.Signer,
 	stakingCertLeaf *staking.Certificate,
 ) *VM {
@@ -159,6 +164,7 @@ func New(
 		minimumPChainHeight: minimumPChainHeight,
 		minBlkDelay:         minBlkDelay,
 		numHistoricalBlocks: numHistoricalBlocks,
+		cachePolicy:         cachePolicy,
 		stakingLeafSigner:   stakingLeafSigner,
 		stakingCertLeaf:     stakingCertLeaf,
 	}
@@ -196,7 +202,7 @@ func (vm *VM) Initialize(
 	rawDB := dbManager.Current().Database
 	prefixDB := prefixdb.New(dbPrefix, rawDB)
 	vm.db = versiondb.New(prefixDB)
-	baseState, err := state.NewMetered(vm.db, "state", registerer)
+	baseState, err := state.NewMetered(vm.db, "state", registerer, vm.cachePolicy)
 	if err != nil {
 		return err
 	}
@@ -49,6 +49,7 @@ func TestProposerVMInitializeShouldFailIfInnerVMCantVerifyItsHeightIndex(t *test
 		0,
 		DefaultMinBlockDelay,
 		DefaultNumHistoricalBlocks,
+		DefaultCachePolicy,
 		pTestSigner,
 		pTestCert,
 	)
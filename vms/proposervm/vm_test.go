@@ -138,6 +138,7 @@ func initTestProposerVM(
 		minPChainHeight,
 		DefaultMinBlockDelay,
 		DefaultNumHistoricalBlocks,
+		DefaultCachePolicy,
 		pTestSigner,
 		pTestCert,
 	)
@@ -879,6 +880,7 @@ func TestExpiredBuildBlock(t *testing.T) {
 		0,
 		DefaultMinBlockDelay,
 		DefaultNumHistoricalBlocks,
+		DefaultCachePolicy,
 		pTestSigner,
 		pTestCert,
 	)
@@ -1222,6 +1224,7 @@ func TestInnerVMRollback(t *testing.T) {
 		0,
 		DefaultMinBlockDelay,
 		DefaultNumHistoricalBlocks,
+		DefaultCachePolicy,
 		pTestSigner,
 		pTestCert,
 	)
@@ -1309,6 +1312,7 @@ func TestInnerVMRollback(t *testing.T) {
 		0,
 		DefaultMinBlockDelay,
 		DefaultNumHistoricalBlocks,
+		DefaultCachePolicy,
 		pTestSigner,
 		pTestCert,
 	)
@@ -1801,6 +1805,7 @@ func TestRejectedHeightNotIndexed(t *testing.T) {
 		0,
 		DefaultMinBlockDelay,
 		DefaultNumHistoricalBlocks,
+		DefaultCachePolicy,
 		pTestSigner,
 		pTestCert,
 	)
@@ -2005,6 +2010,7 @@ func TestRejectedOptionHeightNotIndexed(t *testing.T) {
 		0,
 		DefaultMinBlockDelay,
 		DefaultNumHistoricalBlocks,
+		DefaultCachePolicy,
 		pTestSigner,
 		pTestCert,
 	)
@@ -2165,6 +2171,7 @@ func TestVMInnerBlkCache(t *testing.T) {
 		0,           // minimum P-Chain height
 		DefaultMinBlockDelay,
 		DefaultNumHistoricalBlocks,
+		DefaultCachePolicy,
 		pTestSigner,
 		pTestCert,
 	)
@@ -2398,6 +2405,7 @@ func TestVM_VerifyBlockWithContext(t *testing.T) {
 		0,           // minimum P-Chain height
 		DefaultMinBlockDelay,
 		DefaultNumHistoricalBlocks,
+		DefaultCachePolicy,
 		pTestSigner,
 		pTestCert,
 	)
@@ -2611,6 +2619,7 @@ func TestHistoricalBlockDeletion(t *testing.T) {
 		0,
 		DefaultMinBlockDelay,
 		DefaultNumHistoricalBlocks,
+		DefaultCachePolicy,
 		pTestSigner,
 		pTestCert,
 	)
@@ -2709,6 +2718,7 @@ func TestHistoricalBlockDeletion(t *testing.T) {
 		0,
 		DefaultMinBlockDelay,
 		numHistoricalBlocks,
+		DefaultCachePolicy,
 		pTestSigner,
 		pTestCert,
 	)
@@ -2751,6 +2761,7 @@ func TestHistoricalBlockDeletion(t *testing.T) {
 		0,
 		DefaultMinBlockDelay,
 		newNumHistoricalBlocks,
+		DefaultCachePolicy,
 		pTestSigner,
 		pTestCert,
 	)
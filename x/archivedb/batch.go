@@ -12,7 +12,8 @@ var _ database.Batch = (*batch)(nil)
 // It consumes puts and deletes at a specified height. When committing, an
 // atomic operation is created which registers the modifications at the
 // specified height and updates the last tracked height to be equal to this
-// batch's height.
+// batch's height. If the database retains heights based on their age, the time
+// the height was written is also recorded.
 type batch struct {
 	db     *Database
 	height uint64
@@ -36,6 +37,15 @@ func (c *batch) Write() error {
 		return err
 	}
 
+	// The time each height was written is only needed to support age based
+	// retention.
+	if c.db.retention.Age > 0 {
+		timestamp := uint64(c.db.clock.Unix())
+		if err := database.PutUInt64(batch, newTimestampKey(c.height), timestamp); err != nil {
+			return err
+		}
+	}
+
 	return batch.Write()
 }
 
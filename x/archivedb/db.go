@@ -7,14 +7,20 @@ import (
 	"context"
 	"errors"
 	"io"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/avalanchego/api/health"
 	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 )
 
 var (
 	ErrNotImplemented = errors.New("feature not implemented")
 	ErrInvalidValue   = errors.New("invalid data value")
+	ErrHeightPruned   = errors.New("height has been pruned")
 
 	_ database.Compacter = (*Database)(nil)
 	_ health.Checker     = (*Database)(nil)
@@ -57,21 +63,89 @@ var (
 // foo was deleted at height 1000. When calling `reader.GetHeight(foo)` at
 // height 99 it will return a tuple `("foo's value is bar", 10)` returning the
 // value of `foo` at height 99 (which was set at height 10).
+//
+// If the database was created with a retention policy, versions that are not
+// needed to read the retained heights are periodically pruned. Reading a
+// height below the lowest retained height returns ErrHeightPruned.
 type Database struct {
-	db database.Database
+	db    database.Database
+	clock mockable.Clock
+
+	// lock is held while reading from [db] and while increasing [minHeight],
+	// so that versions are never pruned during a read that relies on them.
+	lock sync.RWMutex
+
+	// minHeightLock guards lazily loading [minHeight] from [db].
+	minHeightLock   sync.Mutex
+	minHeightLoaded bool
+	minHeight       uint64
+
+	// The following fields are only set if the database prunes old heights.
+	retention RetentionConfig
+	log       logging.Logger
+	metrics   *metrics
+	closeOnce sync.Once
+	closing   chan struct{}
+	pruned    chan struct{}
 }
 
+// New returns an archive database that retains every height.
 func New(db database.Database) *Database {
 	return &Database{
 		db: db,
 	}
 }
 
+// NewWithRetention returns an archive database that only retains the heights
+// described by [config]. Superseded versions below the lowest retained height
+// are pruned in the background until the database is closed.
+func NewWithRetention(
+	db database.Database,
+	config RetentionConfig,
+	log logging.Logger,
+	namespace string,
+	reg prometheus.Registerer,
+) (*Database, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+
+	metrics, err := newMetrics(namespace, reg)
+	if err != nil {
+		return nil, err
+	}
+
+	archiveDB := &Database{
+		db:        db,
+		retention: config,
+		log:       log,
+		metrics:   metrics,
+		closing:   make(chan struct{}),
+		pruned:    make(chan struct{}),
+	}
+	minHeight, err := archiveDB.getMinHeight()
+	if err != nil {
+		return nil, err
+	}
+	metrics.minHeight.Set(float64(minHeight))
+
+	go archiveDB.pruneLoop()
+	return archiveDB, nil
+}
+
 // Height returns the last written height.
 func (db *Database) Height() (uint64, error) {
 	return database.GetUInt64(db.db, heightKey)
 }
 
+// MinHeight returns the lowest height that can be read.
+func (db *Database) MinHeight() (uint64, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	return db.getMinHeight()
+}
+
 // Open returns a reader for the state at the given height.
 func (db *Database) Open(height uint64) *Reader {
 	return &Reader{
@@ -101,5 +175,32 @@ func (db *Database) HealthCheck(ctx context.Context) (interface{}, error) {
 }
 
 func (db *Database) Close() error {
+	if db.closing != nil {
+		db.closeOnce.Do(func() {
+			close(db.closing)
+		})
+		<-db.pruned
+	}
 	return db.db.Close()
 }
+
+func (db *Database) getMinHeight() (uint64, error) {
+	db.minHeightLock.Lock()
+	defer db.minHeightLock.Unlock()
+
+	if db.minHeightLoaded {
+		return db.minHeight, nil
+	}
+
+	minHeight, err := database.GetUInt64(db.db, minHeightKey)
+	switch err {
+	case nil:
+	case database.ErrNotFound:
+		minHeight = 0
+	default:
+		return 0, err
+	}
+	db.minHeightLoaded = true
+	db.minHeight = minHeight
+	return minHeight, nil
+}
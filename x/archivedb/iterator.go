@@ -0,0 +1,193 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package archivedb
+
+import (
+	"bytes"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+// maxNextsBeforeSeek is the number of database keys that are stepped over
+// before the underlying iterator is re-created at the target key.
+const maxNextsBeforeSeek = 8
+
+var _ database.Iterator = (*iterator)(nil)
+
+// iterator returns the most recent version, at or below [height], of every
+// user key that starts with [prefix].
+//
+// Database keys are grouped by the length of the user key, so the iterator
+// walks every group and seeks over the keys in each group that don't start
+// with [prefix], as well as over the versions of each user key that are above
+// [height] or superseded.
+type iterator struct {
+	db     *Database
+	height uint64
+	prefix []byte
+
+	// dbIt is nil until the first call to Next.
+	dbIt database.Iterator
+	// nextStart is the database key to continue iterating from. If nil, the
+	// iterator is exhausted.
+	nextStart []byte
+
+	key, value []byte
+	err        error
+	exhausted  bool
+}
+
+func (it *iterator) Next() bool {
+	it.key = nil
+	it.value = nil
+	if it.exhausted {
+		return false
+	}
+
+	it.db.lock.RLock()
+	defer it.db.lock.RUnlock()
+
+	// Versions below the minimum height may be pruned at any time, so the
+	// iterator must stop once its height has been pruned.
+	minHeight, err := it.db.getMinHeight()
+	if err != nil {
+		return it.fail(err)
+	}
+	if it.height < minHeight {
+		return it.fail(ErrHeightPruned)
+	}
+
+	var valid bool
+	if it.dbIt == nil {
+		it.dbIt = it.db.db.NewIterator()
+		valid = it.dbIt.Next()
+	} else {
+		valid = it.seek(it.nextStart)
+	}
+
+	for valid {
+		dbKey := it.dbIt.Key()
+		key, height, err := parseDBKeyFromUser(dbKey)
+		if err != nil {
+			// Metadata keys can never be parsed as user keys.
+			valid = it.dbIt.Next()
+			continue
+		}
+
+		// [group] is the encoded length of the user key, which is shared by
+		// every database key in the group.
+		group := dbKey[:len(dbKey)-len(key)-wrappers.LongLen]
+		if len(key) < len(it.prefix) {
+			// No key in this group is long enough to start with the prefix.
+			valid = it.seek(prefixUpperBound(group))
+			continue
+		}
+		switch cmp := bytes.Compare(key[:len(it.prefix)], it.prefix); {
+		case cmp < 0:
+			valid = it.seek(append(slices.Clone(group), it.prefix...))
+			continue
+		case cmp > 0:
+			valid = it.seek(prefixUpperBound(group))
+			continue
+		case height > it.height:
+			// Skip the versions of this key that are above the height.
+			seekKey, _ := newDBKeyFromUser(key, it.height)
+			valid = it.seek(seekKey)
+			continue
+		}
+
+		// This is the most recent version of the key at or below the height,
+		// so the remaining versions of the key are skipped.
+		it.nextStart = prefixUpperBound(dbKey[:len(dbKey)-wrappers.LongLen])
+		value, exists := parseDBValue(it.dbIt.Value())
+		if exists {
+			it.key = slices.Clone(key)
+			it.value = slices.Clone(value)
+			return true
+		}
+		valid = it.seek(it.nextStart)
+	}
+
+	if err := it.dbIt.Error(); err != nil {
+		return it.fail(err)
+	}
+	it.exhausted = true
+	return false
+}
+
+func (it *iterator) Error() error {
+	if it.err != nil {
+		return it.err
+	}
+	if it.dbIt == nil {
+		return nil
+	}
+	return it.dbIt.Error()
+}
+
+func (it *iterator) Key() []byte {
+	return it.key
+}
+
+func (it *iterator) Value() []byte {
+	return it.value
+}
+
+func (it *iterator) Release() {
+	it.key = nil
+	it.value = nil
+	it.exhausted = true
+	if it.dbIt != nil {
+		it.dbIt.Release()
+	}
+}
+
+// seek moves the underlying iterator to the first database key that is >=
+// [start]. If [start] is nil, the iterator is exhausted. Returns false if
+// there is no such key.
+func (it *iterator) seek(start []byte) bool {
+	if start == nil {
+		return false
+	}
+
+	// Re-creating the underlying iterator is expensive, so nearby keys are
+	// reached by stepping over the keys in between.
+	for i := 0; i < maxNextsBeforeSeek; i++ {
+		if !it.dbIt.Next() {
+			return false
+		}
+		if bytes.Compare(it.dbIt.Key(), start) >= 0 {
+			return true
+		}
+	}
+
+	if err := it.dbIt.Error(); err != nil {
+		return false
+	}
+	it.dbIt.Release()
+	it.dbIt = it.db.db.NewIteratorWithStart(start)
+	return it.dbIt.Next()
+}
+
+func (it *iterator) fail(err error) bool {
+	it.err = err
+	it.exhausted = true
+	return false
+}
+
+// prefixUpperBound returns the smallest key that is greater than every key
+// with the provided [prefix]. If no such key exists, nil is returned.
+func prefixUpperBound(prefix []byte) []byte {
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if prefix[i] != 0xFF {
+			upperBound := slices.Clone(prefix[:i+1])
+			upperBound[i]++
+			return upperBound
+		}
+	}
+	return nil
+}
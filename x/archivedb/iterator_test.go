@@ -0,0 +1,212 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package archivedb
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+)
+
+func TestIteratorWithPrefix(t *testing.T) {
+	require := require.New(t)
+
+	db := New(memdb.New())
+
+	batch := db.NewBatch(1)
+	require.NoError(batch.Put([]byte("a"), []byte("a@1")))
+	require.NoError(batch.Put([]byte("ab"), []byte("ab@1")))
+	require.NoError(batch.Put([]byte("abc"), []byte("abc@1")))
+	require.NoError(batch.Put([]byte("b"), []byte("b@1")))
+	require.NoError(batch.Write())
+
+	batch = db.NewBatch(2)
+	require.NoError(batch.Put([]byte("ab"), []byte("ab@2")))
+	require.NoError(batch.Delete([]byte("abc")))
+	require.NoError(batch.Put([]byte("abd"), []byte("abd@2")))
+	require.NoError(batch.Write())
+
+	batch = db.NewBatch(3)
+	require.NoError(batch.Put([]byte("abc"), []byte("abc@3")))
+	require.NoError(batch.Write())
+
+	tests := []struct {
+		name     string
+		height   uint64
+		prefix   []byte
+		expected map[string]string
+	}{
+		{
+			name:     "before first height",
+			height:   0,
+			prefix:   nil,
+			expected: map[string]string{},
+		},
+		{
+			name:   "all keys",
+			height: 1,
+			prefix: nil,
+			expected: map[string]string{
+				"a":   "a@1",
+				"ab":  "ab@1",
+				"abc": "abc@1",
+				"b":   "b@1",
+			},
+		},
+		{
+			name:   "skips deletions",
+			height: 2,
+			prefix: []byte("ab"),
+			expected: map[string]string{
+				"ab":  "ab@2",
+				"abd": "abd@2",
+			},
+		},
+		{
+			name:   "latest version",
+			height: 3,
+			prefix: []byte("ab"),
+			expected: map[string]string{
+				"ab":  "ab@2",
+				"abc": "abc@3",
+				"abd": "abd@2",
+			},
+		},
+		{
+			name:   "height above last height",
+			height: 10,
+			prefix: []byte("a"),
+			expected: map[string]string{
+				"a":   "a@1",
+				"ab":  "ab@2",
+				"abc": "abc@3",
+				"abd": "abd@2",
+			},
+		},
+		{
+			name:     "no matching keys",
+			height:   3,
+			prefix:   []byte("c"),
+			expected: map[string]string{},
+		},
+	}
+	for _, test := range tests {
+		it := db.Open(test.height).NewIteratorWithPrefix(test.prefix)
+		require.Equal(test.expected, iterate(t, it), test.name)
+		it.Release()
+	}
+}
+
+func TestIteratorWithPrefixRandom(t *testing.T) {
+	require := require.New(t)
+
+	seed := time.Now().UnixNano()
+	t.Log("Seed: ", seed)
+	rand := rand.New(rand.NewSource(seed)) //#nosec G404
+
+	const (
+		numHeights = 50
+		numKeys    = 64
+		numOps     = 10
+	)
+
+	// Long keys are included to cover multi-byte key length encodings.
+	keys := make([][]byte, numKeys)
+	for i := range keys {
+		key := make([]byte, rand.Intn(3)+1)
+		if i%8 == 0 {
+			key = make([]byte, rand.Intn(200)+100)
+		}
+		_, _ = rand.Read(key)
+		key[0] %= 4
+		keys[i] = key
+	}
+
+	db := New(memdb.New())
+	values := make([]map[string]string, numHeights+1)
+	values[0] = map[string]string{}
+	for height := uint64(1); height <= numHeights; height++ {
+		current := make(map[string]string, len(values[height-1]))
+		for key, value := range values[height-1] {
+			current[key] = value
+		}
+
+		batch := db.NewBatch(height)
+		for i := 0; i < numOps; i++ {
+			key := keys[rand.Intn(numKeys)]
+			if rand.Intn(4) == 0 {
+				require.NoError(batch.Delete(key))
+				delete(current, string(key))
+				continue
+			}
+
+			value := make([]byte, rand.Intn(8))
+			_, _ = rand.Read(value)
+			require.NoError(batch.Put(key, value))
+			current[string(key)] = string(value)
+		}
+		require.NoError(batch.Write())
+		values[height] = current
+	}
+
+	for i := 0; i < 100; i++ {
+		height := uint64(rand.Intn(numHeights + 1))
+		prefix := keys[rand.Intn(numKeys)]
+		prefix = prefix[:rand.Intn(len(prefix)+1)]
+
+		expected := map[string]string{}
+		for key, value := range values[height] {
+			if bytes.HasPrefix([]byte(key), prefix) {
+				expected[key] = value
+			}
+		}
+
+		it := db.Open(height).NewIteratorWithPrefix(prefix)
+		require.Equal(expected, iterate(t, it))
+		it.Release()
+	}
+}
+
+func TestIteratorWithPrefixPruned(t *testing.T) {
+	require := require.New(t)
+
+	db := newTestRetentionDB(t, memdb.New(), RetentionConfig{
+		Heights: 1,
+	})
+
+	for height := uint64(1); height <= 2; height++ {
+		batch := db.NewBatch(height)
+		require.NoError(batch.Put([]byte("key"), database.PackUInt64(height)))
+		require.NoError(batch.Write())
+	}
+
+	it := db.Open(1).NewIteratorWithPrefix(nil)
+	defer it.Release()
+
+	require.True(it.Next())
+	require.NoError(db.prune())
+
+	require.False(it.Next())
+	require.ErrorIs(it.Error(), ErrHeightPruned)
+}
+
+// iterate exhausts [it] and returns the key/value pairs it returned.
+func iterate(t *testing.T, it database.Iterator) map[string]string {
+	require := require.New(t)
+
+	values := map[string]string{}
+	for it.Next() {
+		key := string(it.Key())
+		require.NotContains(values, key)
+		values[key] = string(it.Value())
+	}
+	require.NoError(it.Error())
+	return values
+}
@@ -14,7 +14,19 @@ var (
 	ErrParsingKeyLength   = errors.New("failed reading key length")
 	ErrIncorrectKeyLength = errors.New("incorrect key length")
 
-	heightKey = newDBKeyFromMetadata([]byte{})
+	heightKey       = newDBKeyFromMetadata([]byte{})
+	minHeightKey    = newDBKeyFromMetadata([]byte{minHeightPrefix})
+	prunedHeightKey = newDBKeyFromMetadata([]byte{prunedHeightPrefix})
+	pruneFloorKey   = newDBKeyFromMetadata([]byte{pruneFloorPrefix})
+	pruneCursorKey  = newDBKeyFromMetadata([]byte{pruneCursorPrefix})
+)
+
+const (
+	minHeightPrefix byte = iota
+	prunedHeightPrefix
+	timestampPrefix
+	pruneFloorPrefix
+	pruneCursorPrefix
 )
 
 // The requirements of a database key are:
@@ -93,3 +105,15 @@ func newDBKeyFromMetadata(key []byte) []byte {
 	offset += copy(dbKey[offset:], key)
 	return dbKey[:offset]
 }
+
+// newTimestampKey returns the metadata key that stores the time at which
+// [height] was written.
+//
+// Because the height is big endian encoded, timestamp keys are sorted by
+// increasing height.
+func newTimestampKey(height uint64) []byte {
+	key := make([]byte, 1+wrappers.LongLen)
+	key[0] = timestampPrefix
+	binary.BigEndian.PutUint64(key[1:], height)
+	return newDBKeyFromMetadata(key)
+}
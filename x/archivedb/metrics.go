@@ -0,0 +1,44 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package archivedb
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+type metrics struct {
+	minHeight      prometheus.Gauge
+	prunedKeys     prometheus.Counter
+	reclaimedBytes prometheus.Counter
+}
+
+func newMetrics(namespace string, reg prometheus.Registerer) (*metrics, error) {
+	m := &metrics{
+		minHeight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "min_height",
+			Help:      "lowest height that can be read",
+		}),
+		prunedKeys: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "pruned_keys",
+			Help:      "cumulative number of superseded key versions that were pruned",
+		}),
+		reclaimedBytes: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reclaimed_bytes",
+			Help:      "cumulative number of key and value bytes that were pruned",
+		}),
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		reg.Register(m.minHeight),
+		reg.Register(m.prunedKeys),
+		reg.Register(m.reclaimedBytes),
+	)
+	return m, errs.Err
+}
@@ -0,0 +1,337 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package archivedb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils/math"
+)
+
+var (
+	errNoRetentionPolicy    = errors.New("no retention policy specified")
+	errInvalidPruneInterval = errors.New("prune frequency must be positive")
+	errInvalidPruneBatch    = errors.New("prune batch size must be positive")
+	errInvalidPruneScan     = errors.New("prune scan size must be positive")
+)
+
+// RetentionConfig describes the heights of an archive database that must
+// remain readable. A height is retained if any of the configured policies
+// retains it. The most recent height is always retained.
+type RetentionConfig struct {
+	// Heights is the number of most recent heights to retain. If 0, heights
+	// are not retained based on their number.
+	Heights uint64 `json:"heights"`
+	// Age is how long a height is retained after it was written. If 0, heights
+	// are not retained based on their age.
+	Age time.Duration `json:"age"`
+	// PruneFrequency is how often superseded versions are pruned.
+	PruneFrequency time.Duration `json:"pruneFrequency"`
+	// PruneBatchSize is the number of bytes to delete in a single batch while
+	// pruning.
+	PruneBatchSize int `json:"pruneBatchSize"`
+	// PruneScanSize is the number of database entries to examine each time the
+	// database is pruned. Pruning resumes where the previous scan stopped, so
+	// the whole database is pruned over multiple prunes.
+	PruneScanSize int `json:"pruneScanSize"`
+}
+
+func (c RetentionConfig) Verify() error {
+	switch {
+	case c.Heights == 0 && c.Age == 0:
+		return errNoRetentionPolicy
+	case c.PruneFrequency <= 0:
+		return errInvalidPruneInterval
+	case c.PruneBatchSize <= 0:
+		return errInvalidPruneBatch
+	case c.PruneScanSize <= 0:
+		return errInvalidPruneScan
+	default:
+		return nil
+	}
+}
+
+func (db *Database) pruneLoop() {
+	defer close(db.pruned)
+
+	ticker := time.NewTicker(db.retention.PruneFrequency)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := db.prune(); err != nil {
+				db.log.Error("failed to prune archive database",
+					zap.Error(err),
+				)
+			}
+		case <-db.closing:
+			return
+		}
+	}
+}
+
+// prune raises the minimum height to the retention floor and deletes the
+// versions of keys that are no longer needed to read any height at or above
+// it.
+//
+// For every key, the most recent version at or below the floor is kept, even
+// if it is a deletion, so that GetEntry returns the same result for every
+// retained height.
+//
+// Each call examines at most [PruneScanSize] entries. The position and floor
+// of an unfinished pass are persisted, so the next call resumes the pass
+// where it stopped, even after the database is re-opened.
+func (db *Database) prune() error {
+	floor, err := db.raiseMinHeight()
+	if err != nil {
+		return err
+	}
+
+	prunedHeight, err := database.GetUInt64(db.db, prunedHeightKey)
+	switch err {
+	case nil:
+	case database.ErrNotFound:
+		prunedHeight = 0
+	default:
+		return err
+	}
+	if prunedHeight >= floor {
+		return nil
+	}
+
+	// A pass prunes every key to the floor it started with, even if the
+	// floor was raised since.
+	passFloor, err := database.GetUInt64(db.db, pruneFloorKey)
+	switch err {
+	case nil:
+	case database.ErrNotFound:
+		db.log.Info("pruning archive database",
+			zap.Uint64("minHeight", floor),
+		)
+		passFloor = floor
+		if err := database.PutUInt64(db.db, pruneFloorKey, passFloor); err != nil {
+			return err
+		}
+	default:
+		return err
+	}
+
+	cursor, err := db.db.Get(pruneCursorKey)
+	if err != nil && err != database.ErrNotFound {
+		return err
+	}
+
+	finished, err := db.pruneVersions(passFloor, cursor)
+	if err != nil || !finished {
+		return err
+	}
+	// The timestamps may only be partially deleted if DeleteRange fails. They
+	// are deleted again by the next prune, because the pass isn't recorded as
+	// finished until they have all been deleted.
+	if err := db.db.DeleteRange(newTimestampKey(0), newTimestampKey(passFloor)); err != nil {
+		return err
+	}
+
+	batch := db.db.NewBatch()
+	if err := database.PutUInt64(batch, prunedHeightKey, passFloor); err != nil {
+		return err
+	}
+	if err := batch.Delete(pruneFloorKey); err != nil {
+		return err
+	}
+	if err := batch.Delete(pruneCursorKey); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// raiseMinHeight raises the minimum height to the retention floor and returns
+// the resulting minimum height.
+//
+// The minimum height is raised before any versions are deleted so that
+// readers of pruned heights fail rather than read partially pruned state.
+func (db *Database) raiseMinHeight() (uint64, error) {
+	floor, err := db.retentionFloor()
+	if err != nil {
+		return 0, err
+	}
+
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	minHeight, err := db.getMinHeight()
+	if err != nil || floor <= minHeight {
+		return minHeight, err
+	}
+	if err := database.PutUInt64(db.db, minHeightKey, floor); err != nil {
+		return 0, err
+	}
+
+	db.minHeightLock.Lock()
+	db.minHeight = floor
+	db.minHeightLock.Unlock()
+
+	db.metrics.minHeight.Set(float64(floor))
+	return floor, nil
+}
+
+// retentionFloor returns the lowest height that must remain readable.
+func (db *Database) retentionFloor() (uint64, error) {
+	height, err := db.Height()
+	if err == database.ErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	floor := height
+	if keep := db.retention.Heights; keep > 0 {
+		if height >= keep {
+			floor = height - keep + 1
+		} else {
+			floor = 0
+		}
+	}
+	if db.retention.Age > 0 {
+		ageFloor, err := db.ageFloor(height)
+		if err != nil {
+			return 0, err
+		}
+		floor = math.Min(floor, ageFloor)
+	}
+	return floor, nil
+}
+
+// ageFloor returns the height after the last height, below [height], that was
+// written before the retention age. Heights without a recorded timestamp are
+// never considered to be old.
+func (db *Database) ageFloor(height uint64) (uint64, error) {
+	floor, err := db.getMinHeight()
+	if err != nil {
+		return 0, err
+	}
+
+	it := database.NewIteratorWithOptions(db.db, database.IteratorOptions{
+		Start: newTimestampKey(floor),
+		End:   newTimestampKey(height),
+	})
+	defer it.Release()
+
+	cutoff := db.clock.Time().Add(-db.retention.Age)
+	for it.Next() {
+		timestamp, err := database.ParseUInt64(it.Value())
+		if err != nil {
+			return 0, err
+		}
+		if !time.Unix(int64(timestamp), 0).Before(cutoff) {
+			break
+		}
+
+		key := it.Key()
+		floor = binary.BigEndian.Uint64(key[len(key)-8:]) + 1
+	}
+	return math.Min(floor, height), it.Error()
+}
+
+// pruneVersions deletes every version of a key that is older than the most
+// recent version of the key at or below [floor], starting from the database key
+// [start]. Returns false if pruning stopped before reaching the end of the
+// database, either because [PruneScanSize] entries were examined or because the
+// database is closing.
+//
+// Whenever deletions are written, the first version of the key being pruned is
+// persisted as the cursor to resume from. Pruning a key again from its first
+// version is safe, because the version at or below [floor] is never deleted.
+func (db *Database) pruneVersions(floor uint64, start []byte) (bool, error) {
+	it := db.db.NewIteratorWithStart(start)
+	defer it.Release()
+
+	var (
+		batch             = db.db.NewBatch()
+		scannedEntries    int
+		prunedKeys        int
+		reclaimedBytes    int
+		currentKey        []byte
+		currentKeyStart   []byte
+		foundFloorVersion bool
+		writeBatch        = func() error {
+			if currentKeyStart != nil {
+				if err := batch.Put(pruneCursorKey, currentKeyStart); err != nil {
+					return err
+				}
+			}
+			if err := batch.Write(); err != nil {
+				return err
+			}
+			batch.Reset()
+			db.metrics.prunedKeys.Add(float64(prunedKeys))
+			db.metrics.reclaimedBytes.Add(float64(reclaimedBytes))
+			prunedKeys = 0
+			reclaimedBytes = 0
+			return nil
+		}
+	)
+	for it.Next() {
+		scannedEntries++
+		dbKey := it.Key()
+		key, height, err := parseDBKeyFromUser(dbKey)
+		if err != nil {
+			// Metadata keys can never be parsed as user keys.
+			continue
+		}
+
+		// Versions of a key are sorted by decreasing height.
+		if !bytes.Equal(key, currentKey) {
+			currentKeyStart = slices.Clone(dbKey)
+			// The scan only stops between keys, so that every version of a
+			// key is pruned in the same scan.
+			if scannedEntries > db.retention.PruneScanSize {
+				return false, writeBatch()
+			}
+			currentKey = slices.Clone(key)
+			foundFloorVersion = false
+		}
+		if height > floor {
+			continue
+		}
+		if !foundFloorVersion {
+			foundFloorVersion = true
+			continue
+		}
+
+		if err := batch.Delete(dbKey); err != nil {
+			return false, err
+		}
+		prunedKeys++
+		reclaimedBytes += len(dbKey) + len(it.Value())
+		if batch.Size() < db.retention.PruneBatchSize {
+			continue
+		}
+		if err := writeBatch(); err != nil {
+			return false, err
+		}
+
+		// If the database is closing, pruning is resumed after the database is
+		// re-opened.
+		select {
+		case <-db.closing:
+			return false, nil
+		default:
+		}
+	}
+	if err := it.Error(); err != nil {
+		return false, err
+	}
+	return true, writeBatch()
+}
@@ -0,0 +1,322 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package archivedb
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func newTestRetentionDB(t *testing.T, db database.Database, config RetentionConfig) *Database {
+	config.PruneFrequency = time.Hour
+	config.PruneBatchSize = 1
+	if config.PruneScanSize == 0 {
+		config.PruneScanSize = math.MaxInt
+	}
+	archiveDB, err := NewWithRetention(db, config, logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = archiveDB.Close()
+	})
+	return archiveDB
+}
+
+func TestRetentionConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      RetentionConfig
+		expectedErr error
+	}{
+		{
+			name: "no retention policy",
+			config: RetentionConfig{
+				PruneFrequency: time.Minute,
+				PruneBatchSize: 1,
+				PruneScanSize:  1,
+			},
+			expectedErr: errNoRetentionPolicy,
+		},
+		{
+			name: "no prune frequency",
+			config: RetentionConfig{
+				Heights:        1,
+				PruneBatchSize: 1,
+				PruneScanSize:  1,
+			},
+			expectedErr: errInvalidPruneInterval,
+		},
+		{
+			name: "no prune batch size",
+			config: RetentionConfig{
+				Age:            time.Minute,
+				PruneFrequency: time.Minute,
+				PruneScanSize:  1,
+			},
+			expectedErr: errInvalidPruneBatch,
+		},
+		{
+			name: "no prune scan size",
+			config: RetentionConfig{
+				Heights:        1,
+				PruneFrequency: time.Minute,
+				PruneBatchSize: 1,
+			},
+			expectedErr: errInvalidPruneScan,
+		},
+		{
+			name: "valid",
+			config: RetentionConfig{
+				Heights:        1,
+				PruneFrequency: time.Minute,
+				PruneBatchSize: 1,
+				PruneScanSize:  1,
+			},
+			expectedErr: nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.config.Verify()
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestPruneHeights(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db := newTestRetentionDB(t, baseDB, RetentionConfig{
+		Heights: 2,
+	})
+
+	batch := db.NewBatch(1)
+	require.NoError(batch.Put([]byte("key1"), []byte("value1@1")))
+	require.NoError(batch.Put([]byte("key2"), []byte("value2@1")))
+	require.NoError(batch.Put([]byte("key3"), []byte("value3@1")))
+	require.NoError(batch.Write())
+
+	batch = db.NewBatch(2)
+	require.NoError(batch.Put([]byte("key1"), []byte("value1@2")))
+	require.NoError(batch.Delete([]byte("key2")))
+	require.NoError(batch.Write())
+
+	batch = db.NewBatch(3)
+	require.NoError(batch.Put([]byte("key1"), []byte("value1@3")))
+	require.NoError(batch.Write())
+
+	batch = db.NewBatch(4)
+	require.NoError(batch.Put([]byte("key1"), []byte("value1@4")))
+	require.NoError(batch.Write())
+
+	require.NoError(db.prune())
+
+	minHeight, err := db.MinHeight()
+	require.NoError(err)
+	require.Equal(uint64(3), minHeight)
+
+	_, err = db.Open(2).Get([]byte("key1"))
+	require.ErrorIs(err, ErrHeightPruned)
+
+	value, height, exists, err := db.Open(3).GetEntry([]byte("key1"))
+	require.NoError(err)
+	require.True(exists)
+	require.Equal([]byte("value1@3"), value)
+	require.Equal(uint64(3), height)
+
+	value, err = db.Open(4).Get([]byte("key1"))
+	require.NoError(err)
+	require.Equal([]byte("value1@4"), value)
+
+	// The deletion of key2 must be retained to report its height.
+	_, height, exists, err = db.Open(3).GetEntry([]byte("key2"))
+	require.NoError(err)
+	require.False(exists)
+	require.Equal(uint64(2), height)
+
+	value, err = db.Open(4).Get([]byte("key3"))
+	require.NoError(err)
+	require.Equal([]byte("value3@1"), value)
+
+	// key1@1, key1@2 and key2@1 were superseded.
+	for _, version := range []struct {
+		key    []byte
+		height uint64
+	}{
+		{key: []byte("key1"), height: 1},
+		{key: []byte("key1"), height: 2},
+		{key: []byte("key2"), height: 1},
+	} {
+		dbKey, _ := newDBKeyFromUser(version.key, version.height)
+		has, err := baseDB.Has(dbKey)
+		require.NoError(err)
+		require.False(has)
+	}
+	require.Equal(float64(3), testutil.ToFloat64(db.metrics.prunedKeys))
+	require.Positive(testutil.ToFloat64(db.metrics.reclaimedBytes))
+
+	// Pruning again without new heights is a no-op.
+	require.NoError(db.prune())
+	require.Equal(float64(3), testutil.ToFloat64(db.metrics.prunedKeys))
+}
+
+func TestPruneResumes(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db := newTestRetentionDB(t, baseDB, RetentionConfig{
+		Heights:       1,
+		PruneScanSize: 1,
+	})
+
+	keys := [][]byte{
+		[]byte("key1"),
+		[]byte("key2"),
+		[]byte("key3"),
+	}
+	for height := uint64(1); height <= 2; height++ {
+		batch := db.NewBatch(height)
+		for _, key := range keys {
+			require.NoError(batch.Put(key, database.PackUInt64(height)))
+		}
+		require.NoError(batch.Write())
+	}
+
+	// Each prune stops after the versions of the first key it scans, so the
+	// pass takes at least one prune per key.
+	var numPrunes int
+	for {
+		require.NoError(db.prune())
+		numPrunes++
+
+		prunedHeight, err := database.GetUInt64(baseDB, prunedHeightKey)
+		if err == database.ErrNotFound {
+			require.Less(numPrunes, 10)
+			continue
+		}
+		require.NoError(err)
+		require.Equal(uint64(2), prunedHeight)
+		break
+	}
+	require.GreaterOrEqual(numPrunes, len(keys))
+
+	// The state of the pass is removed once it finishes.
+	for _, key := range [][]byte{pruneFloorKey, pruneCursorKey} {
+		has, err := baseDB.Has(key)
+		require.NoError(err)
+		require.False(has)
+	}
+
+	for _, key := range keys {
+		dbKey, _ := newDBKeyFromUser(key, 1)
+		has, err := baseDB.Has(dbKey)
+		require.NoError(err)
+		require.False(has)
+
+		value, err := db.Open(2).Get(key)
+		require.NoError(err)
+		require.Equal(database.PackUInt64(2), value)
+	}
+	require.Equal(float64(len(keys)), testutil.ToFloat64(db.metrics.prunedKeys))
+}
+
+func TestTimestampsOnlyWrittenWithAge(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db := newTestRetentionDB(t, baseDB, RetentionConfig{
+		Heights: 1,
+	})
+	batch := db.NewBatch(1)
+	require.NoError(batch.Put([]byte("key"), []byte("value")))
+	require.NoError(batch.Write())
+
+	has, err := baseDB.Has(newTimestampKey(1))
+	require.NoError(err)
+	require.False(has)
+}
+
+func TestPruneAge(t *testing.T) {
+	require := require.New(t)
+
+	db := newTestRetentionDB(t, memdb.New(), RetentionConfig{
+		Age: 2 * time.Hour,
+	})
+
+	now := time.Unix(1_000_000, 0)
+	for height := uint64(1); height <= 4; height++ {
+		db.clock.Set(now.Add(time.Duration(height) * time.Hour))
+
+		batch := db.NewBatch(height)
+		require.NoError(batch.Put([]byte("key"), database.PackUInt64(height)))
+		require.NoError(batch.Write())
+	}
+
+	// Heights 3 and 4 were written within the last two hours.
+	db.clock.Set(now.Add(4*time.Hour + 30*time.Minute))
+	require.NoError(db.prune())
+
+	minHeight, err := db.MinHeight()
+	require.NoError(err)
+	require.Equal(uint64(3), minHeight)
+
+	_, err = db.Open(2).Get([]byte("key"))
+	require.ErrorIs(err, ErrHeightPruned)
+
+	value, err := db.Open(3).Get([]byte("key"))
+	require.NoError(err)
+	require.Equal(database.PackUInt64(3), value)
+
+	// The timestamps of pruned heights are removed.
+	has, err := db.db.Has(newTimestampKey(2))
+	require.NoError(err)
+	require.False(has)
+
+	// The most recent height is always retained.
+	db.clock.Set(now.Add(24 * time.Hour))
+	require.NoError(db.prune())
+
+	minHeight, err = db.MinHeight()
+	require.NoError(err)
+	require.Equal(uint64(4), minHeight)
+
+	value, err = db.Open(4).Get([]byte("key"))
+	require.NoError(err)
+	require.Equal(database.PackUInt64(4), value)
+}
+
+func TestMinHeightPersisted(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db := newTestRetentionDB(t, baseDB, RetentionConfig{
+		Heights: 1,
+	})
+
+	for height := uint64(1); height <= 3; height++ {
+		batch := db.NewBatch(height)
+		require.NoError(batch.Put([]byte("key"), database.PackUInt64(height)))
+		require.NoError(batch.Write())
+	}
+	require.NoError(db.prune())
+
+	// Databases that don't prune must still respect previous pruning.
+	db = New(baseDB)
+	_, err := db.Open(2).Get([]byte("key"))
+	require.ErrorIs(err, ErrHeightPruned)
+
+	value, err := db.Open(3).Get([]byte("key"))
+	require.NoError(err)
+	require.Equal(database.PackUInt64(3), value)
+}
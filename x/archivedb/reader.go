@@ -3,7 +3,11 @@
 
 package archivedb
 
-import "github.com/ava-labs/avalanchego/database"
+import (
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/avalanchego/database"
+)
 
 var _ database.KeyValueReader = (*Reader)(nil)
 
@@ -34,7 +38,19 @@ func (r *Reader) Get(key []byte) ([]byte, error) {
 // GetEntry retrieves the value of the provided key, the height it was last
 // modified at, and a boolean to indicate if the last modification was an
 // insertion. If the key has never been modified, ErrNotFound will be returned.
+// If the reader's height has been pruned, ErrHeightPruned will be returned.
 func (r *Reader) GetEntry(key []byte) ([]byte, uint64, bool, error) {
+	r.db.lock.RLock()
+	defer r.db.lock.RUnlock()
+
+	minHeight, err := r.db.getMinHeight()
+	if err != nil {
+		return nil, 0, false, err
+	}
+	if r.height < minHeight {
+		return nil, 0, false, ErrHeightPruned
+	}
+
 	it := r.db.db.NewIteratorWithStartAndPrefix(newDBKeyFromUser(key, r.height))
 	defer it.Release()
 
@@ -59,3 +75,18 @@ func (r *Reader) GetEntry(key []byte) ([]byte, uint64, bool, error) {
 	}
 	return value, height, true, nil
 }
+
+// NewIteratorWithPrefix returns an iterator over the value of every key that
+// starts with [prefix], as of the reader's height. Keys that were deleted at
+// or below the reader's height are skipped.
+//
+// Keys are not returned in lexicographic order: keys of equal length are
+// returned in lexicographic order, but keys of different lengths are
+// returned in the order of their encoded length.
+func (r *Reader) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return &iterator{
+		db:     r.db,
+		height: r.height,
+		prefix: slices.Clone(prefix),
+	}
+}
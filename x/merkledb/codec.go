@@ -25,6 +25,12 @@ const (
 	minByteSliceLen      = minVarIntLen
 	minDBNodeLen         = minMaybeByteSliceLen + minVarIntLen
 	minChildLen          = minVarIntLen + minPathLen + ids.IDLen + boolLen
+	minNodeChangeLen     = minPathLen + 2*boolLen
+	minValueChangeLen    = minPathLen + 2*minMaybeByteSliceLen
+	minChangeSummaryLen  = ids.IDLen + 2*minVarIntLen
+	minProofNodeLen      = minPathLen + minMaybeByteSliceLen + minVarIntLen
+	minKeyChangeLen      = minByteSliceLen + minMaybeByteSliceLen
+	minMultiProofLen     = 3 * minVarIntLen
 
 	estimatedKeyLen            = 64
 	estimatedValueLen          = 64
@@ -62,11 +68,19 @@ type encoder interface {
 	encodeDBNode(n *dbNode, factor BranchFactor) []byte
 	// Assumes [hv] is non-nil.
 	encodeHashValues(hv *hashValues) []byte
+	// Assumes [changes] is non-nil.
+	encodeChangeSummary(changes *changeSummary, factor BranchFactor) []byte
+	// Assumes [proof] is non-nil.
+	encodeMultiProof(proof *MultiProof) []byte
 }
 
 type decoder interface {
 	// Assumes [n] is non-nil.
 	decodeDBNode(bytes []byte, n *dbNode, factor BranchFactor) error
+	// Assumes [changes] is non-nil.
+	decodeChangeSummary(bytes []byte, changes *changeSummary, factor BranchFactor, hasher Hasher) error
+	// Assumes [proof] is non-nil.
+	decodeMultiProof(bytes []byte, proof *MultiProof, factor BranchFactor) error
 }
 
 func newCodec() encoderDecoder {
@@ -192,6 +206,253 @@ func (c *codecImpl) decodeDBNode(b []byte, n *dbNode, branchFactor BranchFactor)
 	return nil
 }
 
+func (c *codecImpl) encodeChangeSummary(changes *changeSummary, branchFactor BranchFactor) []byte {
+	var (
+		// Estimate size of [changes] to prevent memory allocations
+		estimatedLen = ids.IDLen + 2*minVarIntLen +
+			len(changes.nodes)*(estimatedCompressedPathLen+2*(ids.IDLen+estimatedValueLen)) +
+			len(changes.values)*(estimatedKeyLen+2*estimatedValueLen)
+		buf = bytes.NewBuffer(make([]byte, 0, estimatedLen))
+	)
+
+	_, _ = buf.Write(changes.rootID[:])
+	c.encodeUint(buf, uint64(len(changes.nodes)))
+	for key, nodeChange := range changes.nodes {
+		c.encodePath(buf, key)
+		c.encodeMaybeNode(buf, nodeChange.before, branchFactor)
+		c.encodeMaybeNode(buf, nodeChange.after, branchFactor)
+	}
+	c.encodeUint(buf, uint64(len(changes.values)))
+	for key, valueChange := range changes.values {
+		c.encodePath(buf, key)
+		c.encodeMaybeByteSlice(buf, valueChange.before)
+		c.encodeMaybeByteSlice(buf, valueChange.after)
+	}
+	return buf.Bytes()
+}
+
+func (c *codecImpl) decodeChangeSummary(b []byte, changes *changeSummary, branchFactor BranchFactor, hasher Hasher) error {
+	if minChangeSummaryLen > len(b) {
+		return io.ErrUnexpectedEOF
+	}
+
+	src := bytes.NewReader(b)
+
+	rootID, err := c.decodeID(src)
+	if err != nil {
+		return err
+	}
+	changes.rootID = rootID
+
+	numNodes, err := c.decodeUint(src)
+	switch {
+	case err != nil:
+		return err
+	case numNodes > uint64(src.Len()/minNodeChangeLen):
+		return io.ErrUnexpectedEOF
+	}
+	changes.nodes = make(map[Path]*change[*node], numNodes)
+	for i := uint64(0); i < numNodes; i++ {
+		key, err := c.decodePath(src, branchFactor)
+		if err != nil {
+			return err
+		}
+		before, err := c.decodeMaybeNode(src, key, hasher)
+		if err != nil {
+			return err
+		}
+		after, err := c.decodeMaybeNode(src, key, hasher)
+		if err != nil {
+			return err
+		}
+		changes.nodes[key] = &change[*node]{
+			before: before,
+			after:  after,
+		}
+	}
+
+	numValues, err := c.decodeUint(src)
+	switch {
+	case err != nil:
+		return err
+	case numValues > uint64(src.Len()/minValueChangeLen):
+		return io.ErrUnexpectedEOF
+	}
+	changes.values = make(map[Path]*change[maybe.Maybe[[]byte]], numValues)
+	for i := uint64(0); i < numValues; i++ {
+		key, err := c.decodePath(src, branchFactor)
+		if err != nil {
+			return err
+		}
+		before, err := c.decodeMaybeByteSlice(src)
+		if err != nil {
+			return err
+		}
+		after, err := c.decodeMaybeByteSlice(src)
+		if err != nil {
+			return err
+		}
+		changes.values[key] = &change[maybe.Maybe[[]byte]]{
+			before: before,
+			after:  after,
+		}
+	}
+
+	if src.Len() != 0 {
+		return errExtraSpace
+	}
+	return nil
+}
+
+func (c *codecImpl) encodeMultiProof(proof *MultiProof) []byte {
+	var (
+		// Estimate size of [proof] to prevent memory allocations
+		estimatedLen = 3*minVarIntLen +
+			len(proof.Path)*(estimatedKeyLen+estimatedValueLen+minVarIntLen+hashValuesChildLen) +
+			len(proof.KeyValues)*(estimatedKeyLen+estimatedValueLen)
+		buf = bytes.NewBuffer(make([]byte, 0, estimatedLen))
+	)
+
+	c.encodeUint(buf, uint64(len(proof.Path)))
+	for i := range proof.Path {
+		node := &proof.Path[i]
+		c.encodePath(buf, node.KeyPath)
+		c.encodeMaybeByteSlice(buf, node.ValueOrHash)
+		c.encodeUint(buf, uint64(len(node.Children)))
+		// Note we insert children in order of increasing index
+		// for determinism.
+		for index := 0; BranchFactor(index) < node.KeyPath.branchFactor; index++ {
+			if childID, ok := node.Children[byte(index)]; ok {
+				c.encodeUint(buf, uint64(index))
+				_, _ = buf.Write(childID[:])
+			}
+		}
+	}
+	c.encodeUint(buf, uint64(len(proof.KeyValues)))
+	for _, keyValue := range proof.KeyValues {
+		c.encodeByteSlice(buf, keyValue.Key)
+		c.encodeMaybeByteSlice(buf, keyValue.Value)
+	}
+	c.encodeByteSlice(buf, []byte(hasherOrDefault(proof.Hasher).Name()))
+	return buf.Bytes()
+}
+
+func (c *codecImpl) decodeMultiProof(b []byte, proof *MultiProof, branchFactor BranchFactor) error {
+	if minMultiProofLen > len(b) {
+		return io.ErrUnexpectedEOF
+	}
+
+	src := bytes.NewReader(b)
+
+	numNodes, err := c.decodeUint(src)
+	switch {
+	case err != nil:
+		return err
+	case numNodes > uint64(src.Len()/minProofNodeLen):
+		return io.ErrUnexpectedEOF
+	}
+	proof.Path = make([]ProofNode, numNodes)
+	for i := range proof.Path {
+		node := &proof.Path[i]
+		if node.KeyPath, err = c.decodePath(src, branchFactor); err != nil {
+			return err
+		}
+		if node.ValueOrHash, err = c.decodeMaybeByteSlice(src); err != nil {
+			return err
+		}
+
+		numChildren, err := c.decodeUint(src)
+		switch {
+		case err != nil:
+			return err
+		case numChildren > uint64(branchFactor):
+			return errTooManyChildren
+		case numChildren > uint64(src.Len()/hashValuesChildLen):
+			return io.ErrUnexpectedEOF
+		}
+		node.Children = make(map[byte]ids.ID, numChildren)
+		var previousChild uint64
+		for j := uint64(0); j < numChildren; j++ {
+			index, err := c.decodeUint(src)
+			if err != nil {
+				return err
+			}
+			if index >= uint64(branchFactor) || (j != 0 && index <= previousChild) {
+				return errChildIndexTooLarge
+			}
+			previousChild = index
+
+			childID, err := c.decodeID(src)
+			if err != nil {
+				return err
+			}
+			node.Children[byte(index)] = childID
+		}
+	}
+
+	numKeyValues, err := c.decodeUint(src)
+	switch {
+	case err != nil:
+		return err
+	case numKeyValues > uint64(src.Len()/minKeyChangeLen):
+		return io.ErrUnexpectedEOF
+	}
+	proof.KeyValues = make([]KeyChange, numKeyValues)
+	for i := range proof.KeyValues {
+		keyValue := &proof.KeyValues[i]
+		if keyValue.Key, err = c.decodeByteSlice(src); err != nil {
+			return err
+		}
+		if keyValue.Value, err = c.decodeMaybeByteSlice(src); err != nil {
+			return err
+		}
+	}
+
+	hasherName, err := c.decodeByteSlice(src)
+	if err != nil {
+		return err
+	}
+	if proof.Hasher, err = HasherFromName(string(hasherName)); err != nil {
+		return err
+	}
+
+	if src.Len() != 0 {
+		return errExtraSpace
+	}
+	return nil
+}
+
+// encodeMaybeNode writes whether [n] is non-nil, followed by its ID and its
+// serialized form if it is.
+func (c *codecImpl) encodeMaybeNode(dst *bytes.Buffer, n *node, branchFactor BranchFactor) {
+	c.encodeBool(dst, n != nil)
+	if n != nil {
+		_, _ = dst.Write(n.id[:])
+		c.encodeByteSlice(dst, c.encodeDBNode(&n.dbNode, branchFactor))
+	}
+}
+
+func (c *codecImpl) decodeMaybeNode(src *bytes.Reader, key Path, hasher Hasher) (*node, error) {
+	if hasNode, err := c.decodeBool(src); err != nil || !hasNode {
+		return nil, err
+	}
+
+	id, err := c.decodeID(src)
+	if err != nil {
+		return nil, err
+	}
+	nodeBytes, err := c.decodeByteSlice(src)
+	if err != nil {
+		return nil, err
+	}
+	n, err := parseNode(hasher, key, nodeBytes)
+	if err != nil {
+		return nil, err
+	}
+	n.id = id
+	return n, nil
+}
+
 func (*codecImpl) encodeBool(dst *bytes.Buffer, value bool) {
 	bytesValue := falseBytes
 	if value {
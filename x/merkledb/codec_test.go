@@ -270,3 +270,120 @@ func TestCodecDecodePathLengthOverflowRegression(t *testing.T) {
 	_, err := codec.decodePath(bytes, BranchFactor16)
 	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
 }
+
+func TestCodecChangeSummary(t *testing.T) {
+	require := require.New(t)
+
+	for _, branchFactor := range branchFactors {
+		newPath := func(b []byte) Path {
+			return NewPath(b, branchFactor)
+		}
+
+		before := newNode(nil, newPath([]byte{1}))
+		before.setValue(SHA256Hasher, maybe.Some([]byte{2}))
+		before.calculateID(SHA256Hasher, &mockMetrics{})
+
+		after := newNode(nil, newPath([]byte{1}))
+		after.setValue(SHA256Hasher, maybe.Some([]byte{3}))
+		after.calculateID(SHA256Hasher, &mockMetrics{})
+
+		changes := &changeSummary{
+			rootID: ids.GenerateTestID(),
+			nodes: map[Path]*change[*node]{
+				newPath([]byte{1}): {
+					before: before,
+					after:  after,
+				},
+				newPath([]byte{2}): {
+					before: nil,
+					after:  after,
+				},
+			},
+			values: map[Path]*change[maybe.Maybe[[]byte]]{
+				newPath([]byte{1}): {
+					before: maybe.Some([]byte{2}),
+					after:  maybe.Some([]byte{3}),
+				},
+				newPath([]byte{2}): {
+					before: maybe.Nothing[[]byte](),
+					after:  maybe.Some([]byte{3}),
+				},
+			},
+		}
+		changesBytes := codec.encodeChangeSummary(changes, branchFactor)
+
+		var gotChanges changeSummary
+		require.NoError(codec.decodeChangeSummary(changesBytes, &gotChanges, branchFactor, SHA256Hasher))
+		require.Equal(changes.rootID, gotChanges.rootID)
+		require.Equal(changes.values, gotChanges.values)
+		require.Len(gotChanges.nodes, len(changes.nodes))
+		for key, nodeChange := range changes.nodes {
+			gotNodeChange := gotChanges.nodes[key]
+			require.NotNil(gotNodeChange)
+			for _, pair := range [][2]*node{
+				{nodeChange.before, gotNodeChange.before},
+				{nodeChange.after, gotNodeChange.after},
+			} {
+				expected, got := pair[0], pair[1]
+				if expected == nil {
+					require.Nil(got)
+					continue
+				}
+				require.Equal(expected.id, got.id)
+				require.Equal(key, got.key)
+				require.Equal(expected.dbNode, got.dbNode)
+			}
+		}
+
+		err := codec.decodeChangeSummary(changesBytes[:len(changesBytes)-1], &gotChanges, branchFactor, SHA256Hasher)
+		require.ErrorIs(err, io.ErrUnexpectedEOF)
+
+		err = codec.decodeChangeSummary(append(changesBytes, 0), &gotChanges, branchFactor, SHA256Hasher)
+		require.ErrorIs(err, errExtraSpace)
+	}
+}
+
+func TestCodecMultiProof(t *testing.T) {
+	require := require.New(t)
+
+	for _, branchFactor := range branchFactors {
+		proof := &MultiProof{
+			Path: []ProofNode{
+				{
+					KeyPath: emptyPath(branchFactor),
+					Children: map[byte]ids.ID{
+						0: ids.GenerateTestID(),
+						1: ids.GenerateTestID(),
+					},
+				},
+				{
+					KeyPath:     NewPath([]byte{1}, branchFactor),
+					ValueOrHash: maybe.Some([]byte{2}),
+					Children:    map[byte]ids.ID{},
+				},
+			},
+			KeyValues: []KeyChange{
+				{
+					Key:   []byte{1},
+					Value: maybe.Some([]byte{2}),
+				},
+				{
+					Key:   []byte{3},
+					Value: maybe.Nothing[[]byte](),
+				},
+			},
+			Hasher: Keccak256Hasher,
+		}
+		proofBytes := codec.encodeMultiProof(proof)
+
+		var gotProof MultiProof
+		require.NoError(codec.decodeMultiProof(proofBytes, &gotProof, branchFactor))
+		require.Equal(proof, &gotProof)
+
+		err := codec.decodeMultiProof(proofBytes[:len(proofBytes)-1], &gotProof, branchFactor)
+		require.ErrorIs(err, io.ErrUnexpectedEOF)
+
+		err = codec.decodeMultiProof(append(proofBytes, 0), &gotProof, branchFactor)
+		require.ErrorIs(err, errExtraSpace)
+	}
+}
@@ -25,20 +25,11 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/avalanchego/utils"
-	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/utils/maybe"
 	"github.com/ava-labs/avalanchego/utils/set"
-	"github.com/ava-labs/avalanchego/utils/units"
 )
 
-const (
-
-	// TODO: name better
-	rebuildViewSizeFractionOfCacheSize   = 50
-	minRebuildViewSizePerCommit          = 1000
-	rebuildIntermediateDeletionWriteSize = units.MiB
-	valueNodePrefixLen                   = 1
-)
+const valueNodePrefixLen = 1
 
 var (
 	rootKey []byte
@@ -49,8 +40,11 @@ var (
 	metadataPrefix         = []byte{0}
 	valueNodePrefix        = []byte{1}
 	intermediateNodePrefix = []byte{2}
+	historyPrefix          = []byte{3}
+	historyRootPrefix      = []byte{4}
 
 	cleanShutdownKey        = []byte(string(metadataPrefix) + "cleanShutdown")
+	hasherKey               = []byte(string(metadataPrefix) + "hasher")
 	hadCleanShutdown        = []byte{1}
 	didNotHaveCleanShutdown = []byte{0}
 
@@ -126,6 +120,13 @@ type MerkleDB interface {
 type Config struct {
 	// BranchFactor determines the number of children each node can have.
 	BranchFactor BranchFactor
+	// Hasher calculates the node IDs and value digests of the trie.
+	// It is recorded in the database, which must always be opened with the
+	// same hasher. A custom hasher must be registered with [RegisterHasher]
+	// for proofs generated by the database to be unmarshalled.
+	//
+	// If nil, [SHA256Hasher] will be used.
+	Hasher Hasher
 
 	// RootGenConcurrency is the number of goroutines to use when
 	// generating a new state root.
@@ -138,6 +139,10 @@ type Config struct {
 	// The number of changes to the database that we store in memory in order to
 	// serve change proofs.
 	HistoryLength uint
+	// The number of changes to the database that we persist to disk in order
+	// to serve change proofs and historical range proofs after a restart.
+	// If 0, changes aren't persisted.
+	PersistedHistoryLength uint
 	// The number of bytes to cache nodes with values.
 	ValueNodeCacheSize uint
 	// The number of bytes to cache nodes without values.
@@ -192,6 +197,22 @@ type merkleDB struct {
 
 	newPath  func(p []byte) Path
 	rootPath Path
+
+	// Calculates the node IDs and value digests of the trie.
+	hasher Hasher
+
+	// True iff the intermediate nodes are being rebuilt after an unclean
+	// shutdown. [rebuildStatus] reports the progress of the rebuild.
+	rebuilding    bool
+	rebuildStatus rebuildStatus
+	// True iff the rebuild was interrupted by [Close] before it completed.
+	rebuildInterrupted bool
+	// Non-nil iff the rebuild failed.
+	rebuildErr error
+	// Interrupts the rebuild.
+	rebuildCancel context.CancelFunc
+	// Closed when the rebuild returns.
+	rebuildDone chan struct{}
 }
 
 // New returns a new merkle database.
@@ -204,7 +225,7 @@ func New(ctx context.Context, db database.Database, config Config) (MerkleDB, er
 }
 
 func newDatabase(
-	ctx context.Context,
+	_ context.Context,
 	db database.Database,
 	config Config,
 	metrics merkleMetrics,
@@ -218,6 +239,11 @@ func newDatabase(
 		return nil, err
 	}
 
+	hasher := hasherOrDefault(config.Hasher)
+	if err := verifyHasherMetadata(db, hasher); err != nil {
+		return nil, err
+	}
+
 	newPath := func(b []byte) Path {
 		return NewPath(b, config.BranchFactor)
 	}
@@ -229,40 +255,44 @@ func newDatabase(
 			return make([]byte, 0, defaultBufferLength)
 		},
 	}
+	history := newTrieHistory(int(config.HistoryLength), newPath)
+	if config.PersistedHistoryLength > 0 {
+		historyDB, err := newHistoryDB(db, int(config.PersistedHistoryLength), config.BranchFactor, hasher)
+		if err != nil {
+			return nil, err
+		}
+		history.persist(historyDB)
+	}
+
+	rebuildDone := make(chan struct{})
+	close(rebuildDone)
+
 	trieDB := &merkleDB{
 		metrics:              metrics,
 		baseDB:               db,
-		valueNodeDB:          newValueNodeDB(db, bufferPool, metrics, int(config.ValueNodeCacheSize), config.BranchFactor),
-		intermediateNodeDB:   newIntermediateNodeDB(db, bufferPool, metrics, int(config.IntermediateNodeCacheSize), int(config.EvictionBatchSize)),
-		history:              newTrieHistory(int(config.HistoryLength), newPath),
+		valueNodeDB:          newValueNodeDB(db, bufferPool, metrics, int(config.ValueNodeCacheSize), config.BranchFactor, hasher),
+		intermediateNodeDB:   newIntermediateNodeDB(db, bufferPool, metrics, int(config.IntermediateNodeCacheSize), int(config.EvictionBatchSize), hasher),
+		history:              history,
 		debugTracer:          getTracerIfEnabled(config.TraceLevel, DebugTrace, config.Tracer),
 		infoTracer:           getTracerIfEnabled(config.TraceLevel, InfoTrace, config.Tracer),
 		childViews:           make([]*trieView, 0, defaultPreallocationSize),
 		calculateNodeIDsSema: semaphore.NewWeighted(int64(rootGenConcurrency)),
 		newPath:              newPath,
 		rootPath:             newPath(rootKey),
+		hasher:               hasher,
+		rebuildCancel:        func() {},
+		rebuildDone:          rebuildDone,
 	}
 
-	root, err := trieDB.initializeRootIfNeeded()
-	if err != nil {
+	if _, err := trieDB.initializeRootIfNeeded(); err != nil {
 		return nil, err
 	}
 
-	// add current root to history (has no changes)
-	trieDB.history.record(&changeSummary{
-		rootID: root,
-		values: map[Path]*change[maybe.Maybe[[]byte]]{},
-		nodes:  map[Path]*change[*node]{},
-	})
-
 	shutdownType, err := trieDB.baseDB.Get(cleanShutdownKey)
+	needsRebuild := false
 	switch err {
 	case nil:
-		if bytes.Equal(shutdownType, didNotHaveCleanShutdown) {
-			if err := trieDB.rebuild(ctx, int(config.ValueNodeCacheSize)); err != nil {
-				return nil, err
-			}
-		}
+		needsRebuild = bytes.Equal(shutdownType, didNotHaveCleanShutdown)
 	case database.ErrNotFound:
 		// If the marker wasn't found then the DB is being created for the first
 		// time and there is nothing to do.
@@ -271,61 +301,43 @@ func newDatabase(
 	}
 
 	// mark that the db has not yet been cleanly closed
-	err = trieDB.baseDB.Put(cleanShutdownKey, didNotHaveCleanShutdown)
-	return trieDB, err
-}
-
-// Deletes every intermediate node and rebuilds them by re-adding every key/value.
-// TODO: make this more efficient by only clearing out the stale portions of the trie.
-func (db *merkleDB) rebuild(ctx context.Context, cacheSize int) error {
-	db.root = newNode(nil, db.rootPath)
+	if err := trieDB.baseDB.Put(cleanShutdownKey, didNotHaveCleanShutdown); err != nil {
+		return nil, err
+	}
 
-	// Delete intermediate nodes.
-	if err := database.ClearPrefix(db.baseDB, intermediateNodePrefix, rebuildIntermediateDeletionWriteSize); err != nil {
-		return err
+	if needsRebuild {
+		trieDB.rebuildInBackground(int(config.ValueNodeCacheSize))
+		return trieDB, nil
 	}
+	return trieDB, trieDB.recordCurrentRoot()
+}
 
-	// Add all key-value pairs back into the database.
-	opsSizeLimit := math.Max(
-		cacheSize/rebuildViewSizeFractionOfCacheSize,
-		minRebuildViewSizePerCommit,
-	)
-	currentOps := make([]database.BatchOp, 0, opsSizeLimit)
-	valueIt := db.NewIterator()
-	defer valueIt.Release()
-	for valueIt.Next() {
-		if len(currentOps) >= opsSizeLimit {
-			view, err := newTrieView(db, db, ViewChanges{BatchOps: currentOps, ConsumeBytes: true})
-			if err != nil {
-				return err
-			}
-			if err := view.commitToDB(ctx); err != nil {
-				return err
-			}
-			currentOps = make([]database.BatchOp, 0, opsSizeLimit)
-			// reset the iterator to prevent memory bloat
-			nextValue := valueIt.Key()
-			valueIt.Release()
-			valueIt = db.NewIteratorWithStart(nextValue)
-			continue
+// verifyHasherMetadata returns an error if [db] was created with a different
+// hasher than [hasher]. If [db] doesn't record its hasher yet, [hasher] is
+// recorded.
+func verifyHasherMetadata(db database.Database, hasher Hasher) error {
+	hasherName, err := db.Get(hasherKey)
+	switch err {
+	case nil:
+		if string(hasherName) != hasher.Name() {
+			return fmt.Errorf("%w: database was created with %q, opened with %q", ErrHasherMismatch, hasherName, hasher.Name())
 		}
-
-		currentOps = append(currentOps, database.BatchOp{
-			Key:   valueIt.Key(),
-			Value: valueIt.Value(),
-		})
-	}
-	if err := valueIt.Error(); err != nil {
+		return nil
+	case database.ErrNotFound:
+	default:
 		return err
 	}
-	view, err := newTrieView(db, db, ViewChanges{BatchOps: currentOps, ConsumeBytes: true})
+
+	// Databases created before the hasher was configurable don't record it
+	// and always used [SHA256Hasher].
+	existing, err := db.Has(cleanShutdownKey)
 	if err != nil {
 		return err
 	}
-	if err := view.commitToDB(ctx); err != nil {
-		return err
+	if existing && hasher.Name() != SHA256Hasher.Name() {
+		return fmt.Errorf("%w: database was created with %q, opened with %q", ErrHasherMismatch, SHA256Hasher.Name(), hasher.Name())
 	}
-	return db.Compact(nil, nil)
+	return db.Put(hasherKey, []byte(hasher.Name()))
 }
 
 func (db *merkleDB) CommitChangeProof(ctx context.Context, proof *ChangeProof) error {
@@ -401,7 +413,9 @@ func (db *merkleDB) Compact(start []byte, limit []byte) error {
 }
 
 func (db *merkleDB) Close() error {
-	db.commitLock.Lock()
+	if !db.interruptRebuild() {
+		db.commitLock.Lock()
+	}
 	defer db.commitLock.Unlock()
 
 	db.lock.Lock()
@@ -418,6 +432,12 @@ func (db *merkleDB) Close() error {
 		return err
 	}
 
+	if db.rebuilding {
+		// The rebuild is resumed from its last checkpoint when the db is
+		// reopened.
+		return nil
+	}
+
 	// Successfully wrote intermediate nodes.
 	return db.baseDB.Put(cleanShutdownKey, hadCleanShutdown)
 }
@@ -554,10 +574,18 @@ func (db *merkleDB) GetMerkleRoot(ctx context.Context) (ids.ID, error) {
 	_, span := db.infoTracer.Start(ctx, "MerkleDB.GetMerkleRoot")
 	defer span.End()
 
+	// The root isn't known until the intermediate nodes are rebuilt.
+	select {
+	case <-db.rebuildDone:
+	case <-ctx.Done():
+		return ids.Empty, ctx.Err()
+	}
+
 	db.lock.RLock()
 	defer db.lock.RUnlock()
 
-	if db.closed {
+	// If the rebuild didn't complete, the db is being closed.
+	if db.closed || db.rebuilding {
 		return ids.Empty, database.ErrClosed
 	}
 
@@ -591,6 +619,28 @@ func (db *merkleDB) getProof(ctx context.Context, key []byte) (*Proof, error) {
 	return view.getProof(ctx, key)
 }
 
+func (db *merkleDB) GetMultiProof(ctx context.Context, keys [][]byte) (*MultiProof, error) {
+	db.commitLock.RLock()
+	defer db.commitLock.RUnlock()
+
+	return db.getMultiProof(ctx, keys)
+}
+
+// Assumes [db.commitLock] is read locked.
+// Assumes [db.lock] is not held
+func (db *merkleDB) getMultiProof(ctx context.Context, keys [][]byte) (*MultiProof, error) {
+	if db.closed {
+		return nil, database.ErrClosed
+	}
+
+	view, err := newTrieView(db, db, ViewChanges{})
+	if err != nil {
+		return nil, err
+	}
+	// Don't need to lock [view] because nobody else has a reference to it.
+	return view.getMultiProof(ctx, keys)
+}
+
 func (db *merkleDB) GetRangeProof(
 	ctx context.Context,
 	start maybe.Maybe[[]byte],
@@ -674,6 +724,7 @@ func (db *merkleDB) GetChangeProof(
 
 	result := &ChangeProof{
 		KeyChanges: make([]KeyChange, 0, len(changedKeys)),
+		Hasher:     db.hasher,
 	}
 
 	for _, key := range changedKeys {
@@ -785,8 +836,13 @@ func (db *merkleDB) HealthCheck(ctx context.Context) (interface{}, error) {
 	db.lock.RLock()
 	defer db.lock.RUnlock()
 
-	if db.closed {
+	switch {
+	case db.rebuildErr != nil:
+		return nil, db.rebuildErr
+	case db.closed:
 		return nil, database.ErrClosed
+	case db.rebuilding:
+		return db.rebuildStatus, errRebuilding
 	}
 	return db.baseDB.HealthCheck(ctx)
 }
@@ -859,6 +915,14 @@ func (db *merkleDB) DeleteContext(ctx context.Context, key []byte) error {
 	return view.commitToDB(ctx)
 }
 
+func (db *merkleDB) DeleteRange(start, limit []byte) error {
+	return db.commitBatch([]database.BatchOp{{
+		Key:         start,
+		DeleteRange: true,
+		Limit:       limit,
+	}})
+}
+
 // Assumes values inside of [ops] are safe to reference after the function
 // returns. Assumes [db.lock] isn't held.
 func (db *merkleDB) commitBatch(ops []database.BatchOp) error {
@@ -869,6 +933,11 @@ func (db *merkleDB) commitBatch(ops []database.BatchOp) error {
 		return database.ErrClosed
 	}
 
+	ops, err := db.resolveRangeDeletions(ops)
+	if err != nil {
+		return err
+	}
+
 	view, err := newTrieView(db, db, ViewChanges{BatchOps: ops, ConsumeBytes: true})
 	if err != nil {
 		return err
@@ -876,6 +945,47 @@ func (db *merkleDB) commitBatch(ops []database.BatchOp) error {
 	return view.commitToDB(context.Background())
 }
 
+// resolveRangeDeletions replaces each range deletion in [ops] with deletions
+// of every key in the range. The keys in the range are the keys in the
+// database along with the keys put by the preceding [ops].
+// Assumes [db.commitLock] is held.
+func (db *merkleDB) resolveRangeDeletions(ops []database.BatchOp) ([]database.BatchOp, error) {
+	resolvedOps := make([]database.BatchOp, 0, len(ops))
+	for _, op := range ops {
+		if !op.DeleteRange {
+			resolvedOps = append(resolvedOps, op)
+			continue
+		}
+
+		for _, previousOp := range resolvedOps {
+			if !previousOp.Delete && database.InRange(previousOp.Key, op.Key, op.Limit) {
+				resolvedOps = append(resolvedOps, database.BatchOp{
+					Key:    previousOp.Key,
+					Delete: true,
+				})
+			}
+		}
+
+		it := db.NewIteratorWithStart(op.Key)
+		for it.Next() {
+			key := it.Key()
+			if !database.InRange(key, op.Key, op.Limit) {
+				break
+			}
+			resolvedOps = append(resolvedOps, database.BatchOp{
+				Key:    slices.Clone(key),
+				Delete: true,
+			})
+		}
+		err := it.Error()
+		it.Release()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return resolvedOps, nil
+}
+
 // commitChanges commits the changes in [trieToCommit] to [db].
 // Assumes [trieToCommit]'s node IDs have been calculated.
 func (db *merkleDB) commitChanges(ctx context.Context, trieToCommit *trieView) error {
@@ -957,8 +1067,7 @@ func (db *merkleDB) commitChanges(ctx context.Context, trieToCommit *trieView) e
 	// Only modify in-memory state after the commit succeeds
 	// so that we don't need to clean up on error.
 	db.root = rootChange.after
-	db.history.record(changes)
-	return nil
+	return db.history.record(changes)
 }
 
 // moveChildViewsToDB removes any child views from the trieToCommit and moves them to the db
@@ -1006,6 +1115,12 @@ func (db *merkleDB) VerifyChangeProof(
 		return ErrNoStartProof
 	}
 
+	// The proof is verified by recalculating the root of this database, so it
+	// must have been generated with the same hasher.
+	if err := verifyHasher(db.hasher, proof.Hasher); err != nil {
+		return err
+	}
+
 	// Make sure the key-value pairs are sorted and in [start, end].
 	if err := verifyKeyChanges(proof.KeyChanges, start, end); err != nil {
 		return err
@@ -1050,6 +1165,7 @@ func (db *merkleDB) VerifyChangeProof(
 	if err := verifyAllChangeProofKeyValuesPresent(
 		ctx,
 		db,
+		db.hasher,
 		proof.StartProof,
 		smallestPath,
 		largestPath,
@@ -1061,6 +1177,7 @@ func (db *merkleDB) VerifyChangeProof(
 	if err := verifyAllChangeProofKeyValuesPresent(
 		ctx,
 		db,
+		db.hasher,
 		proof.EndProof,
 		smallestPath,
 		largestPath,
@@ -1136,6 +1253,15 @@ func (db *merkleDB) invalidateChildrenExcept(exception *trieView) {
 	}
 }
 
+// recordCurrentRoot adds the current root, without any changes, to the history.
+func (db *merkleDB) recordCurrentRoot() error {
+	return db.history.record(&changeSummary{
+		rootID: db.getMerkleRoot(),
+		values: map[Path]*change[maybe.Maybe[[]byte]]{},
+		nodes:  map[Path]*change[*node]{},
+	})
+}
+
 func (db *merkleDB) initializeRootIfNeeded() (ids.ID, error) {
 	// not sure if the root exists or had a value or not
 	// check under both prefixes
@@ -1146,7 +1272,7 @@ func (db *merkleDB) initializeRootIfNeeded() (ids.ID, error) {
 	}
 	if err == nil {
 		// Root already exists, so calculate its id
-		db.root.calculateID(db.metrics)
+		db.root.calculateID(db.hasher, db.metrics)
 		return db.root.id, nil
 	}
 	if err != database.ErrNotFound {
@@ -1157,7 +1283,7 @@ func (db *merkleDB) initializeRootIfNeeded() (ids.ID, error) {
 	db.root = newNode(nil, db.rootPath)
 
 	// update its ID
-	db.root.calculateID(db.metrics)
+	db.root.calculateID(db.hasher, db.metrics)
 
 	if err := db.intermediateNodeDB.Put(db.rootPath, db.root); err != nil {
 		return ids.Empty, err
@@ -0,0 +1,158 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sync"
+
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/sha3"
+
+	"lukechampine.com/blake3"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+var (
+	_ Hasher = sha256Hasher{}
+	_ Hasher = keccak256Hasher{}
+	_ Hasher = blake2b256Hasher{}
+	_ Hasher = blake3Hasher{}
+
+	// SHA256Hasher is the default [Hasher].
+	SHA256Hasher Hasher = sha256Hasher{}
+	// Keccak256Hasher hashes with the legacy Keccak-256 used by Ethereum.
+	Keccak256Hasher Hasher = keccak256Hasher{}
+	// Blake2b256Hasher hashes with BLAKE2b-256.
+	Blake2b256Hasher Hasher = blake2b256Hasher{}
+	// Blake3Hasher hashes with BLAKE3, with a 256-bit output.
+	Blake3Hasher Hasher = blake3Hasher{}
+
+	hashersLock sync.RWMutex
+	hashers     = map[string]Hasher{
+		SHA256Hasher.Name():     SHA256Hasher,
+		Keccak256Hasher.Name():  Keccak256Hasher,
+		Blake2b256Hasher.Name(): Blake2b256Hasher,
+		Blake3Hasher.Name():     Blake3Hasher,
+	}
+
+	ErrUnknownHasher           = errors.New("unknown hasher")
+	ErrHasherMismatch          = errors.New("hasher mismatch")
+	ErrHasherAlreadyRegistered = errors.New("hasher already registered")
+	errEmptyHasherName         = errors.New("hasher name is empty")
+)
+
+// Hasher computes the IDs of nodes and the digests of values in the trie.
+type Hasher interface {
+	// Name uniquely identifies the hash function. It is recorded in the
+	// database metadata and in proofs so that they are verified with the
+	// hash function that generated them.
+	Name() string
+	// Hash returns the [HashLength] byte digest of [b].
+	Hash(b []byte) ids.ID
+}
+
+// RegisterHasher makes [hasher] available to [HasherFromName], so that proofs
+// generated with [hasher] can be unmarshalled and verified. A custom [Hasher]
+// must be registered before proofs that use it are received, such as by
+// x/sync.
+func RegisterHasher(hasher Hasher) error {
+	name := hasher.Name()
+	if len(name) == 0 {
+		return errEmptyHasherName
+	}
+
+	hashersLock.Lock()
+	defer hashersLock.Unlock()
+
+	if _, ok := hashers[name]; ok {
+		return fmt.Errorf("%w: %q", ErrHasherAlreadyRegistered, name)
+	}
+	hashers[name] = hasher
+	return nil
+}
+
+// HasherFromName returns the built-in or registered [Hasher] with the given
+// [name]. An empty [name] refers to [SHA256Hasher], which was used before the
+// hasher was configurable.
+func HasherFromName(name string) (Hasher, error) {
+	if len(name) == 0 {
+		return SHA256Hasher, nil
+	}
+
+	hashersLock.RLock()
+	defer hashersLock.RUnlock()
+
+	hasher, ok := hashers[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownHasher, name)
+	}
+	return hasher, nil
+}
+
+// hasherOrDefault returns [hasher], or [SHA256Hasher] if [hasher] is nil.
+func hasherOrDefault(hasher Hasher) Hasher {
+	if hasher == nil {
+		return SHA256Hasher
+	}
+	return hasher
+}
+
+// verifyHasher returns an error if [actual] isn't the same hash function as
+// [expected]. A nil [Hasher] is treated as [SHA256Hasher].
+func verifyHasher(expected, actual Hasher) error {
+	expectedName := hasherOrDefault(expected).Name()
+	actualName := hasherOrDefault(actual).Name()
+	if expectedName != actualName {
+		return fmt.Errorf("%w: expected %q, got %q", ErrHasherMismatch, expectedName, actualName)
+	}
+	return nil
+}
+
+type sha256Hasher struct{}
+
+func (sha256Hasher) Name() string {
+	return "sha256"
+}
+
+func (sha256Hasher) Hash(b []byte) ids.ID {
+	return sha256.Sum256(b)
+}
+
+type keccak256Hasher struct{}
+
+func (keccak256Hasher) Name() string {
+	return "keccak256"
+}
+
+func (keccak256Hasher) Hash(b []byte) ids.ID {
+	var id ids.ID
+	h := sha3.NewLegacyKeccak256()
+	_, _ = h.Write(b)
+	h.Sum(id[:0])
+	return id
+}
+
+type blake2b256Hasher struct{}
+
+func (blake2b256Hasher) Name() string {
+	return "blake2b256"
+}
+
+func (blake2b256Hasher) Hash(b []byte) ids.ID {
+	return blake2b.Sum256(b)
+}
+
+type blake3Hasher struct{}
+
+func (blake3Hasher) Name() string {
+	return "blake3"
+}
+
+func (blake3Hasher) Hash(b []byte) ids.ID {
+	return blake3.Sum256(b)
+}
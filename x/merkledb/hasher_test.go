@@ -0,0 +1,225 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/maybe"
+)
+
+var _ Hasher = testHasher{}
+
+// testHasher is a custom [Hasher] that isn't built in.
+type testHasher struct{}
+
+func (testHasher) Name() string {
+	return "test"
+}
+
+func (testHasher) Hash(b []byte) ids.ID {
+	return hashing.ComputeHash256Array(append([]byte("test"), b...))
+}
+
+func TestHasherFromName(t *testing.T) {
+	require := require.New(t)
+
+	for _, hasher := range []Hasher{SHA256Hasher, Keccak256Hasher, Blake2b256Hasher, Blake3Hasher} {
+		got, err := HasherFromName(hasher.Name())
+		require.NoError(err)
+		require.Equal(hasher, got)
+	}
+
+	got, err := HasherFromName("")
+	require.NoError(err)
+	require.Equal(SHA256Hasher, got)
+
+	_, err = HasherFromName("md5")
+	require.ErrorIs(err, ErrUnknownHasher)
+}
+
+func TestHashersDiffer(t *testing.T) {
+	require := require.New(t)
+
+	input := []byte("input")
+	digests := make(map[ids.ID]bool)
+	for _, hasher := range []Hasher{SHA256Hasher, Keccak256Hasher, Blake2b256Hasher, Blake3Hasher} {
+		digests[hasher.Hash(input)] = true
+	}
+	require.Len(digests, 4)
+}
+
+func TestBlake3Hasher(t *testing.T) {
+	require := require.New(t)
+
+	// The BLAKE3 digest of the empty input from the reference test vectors.
+	expected, err := hex.DecodeString("af1349b9f5f9a1a6a0404dea36dcc9499bcb25c9adc112b7cc9a93cae41f3262")
+	require.NoError(err)
+	digest := Blake3Hasher.Hash(nil)
+	require.Equal(expected, digest[:])
+}
+
+func TestRegisterHasher(t *testing.T) {
+	require := require.New(t)
+
+	_, err := HasherFromName(testHasher{}.Name())
+	require.ErrorIs(err, ErrUnknownHasher)
+
+	require.NoError(RegisterHasher(testHasher{}))
+	t.Cleanup(func() {
+		hashersLock.Lock()
+		defer hashersLock.Unlock()
+
+		delete(hashers, testHasher{}.Name())
+	})
+
+	got, err := HasherFromName(testHasher{}.Name())
+	require.NoError(err)
+	require.Equal(testHasher{}, got)
+
+	// Names can't be registered twice, including the built-in names.
+	require.ErrorIs(RegisterHasher(testHasher{}), ErrHasherAlreadyRegistered)
+	require.ErrorIs(RegisterHasher(SHA256Hasher), ErrHasherAlreadyRegistered)
+
+	// Proofs generated with the registered hasher can be unmarshalled.
+	config := newDefaultConfig()
+	config.Hasher = testHasher{}
+	db, err := newDatabase(context.Background(), memdb.New(), config, &mockMetrics{})
+	require.NoError(err)
+	require.NoError(db.Put([]byte("key"), []byte("value")))
+	root, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	rangeProof, err := db.GetRangeProof(context.Background(), maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), 10)
+	require.NoError(err)
+
+	var gotRangeProof RangeProof
+	require.NoError(gotRangeProof.UnmarshalProto(rangeProof.ToProto(), config.BranchFactor))
+	require.Equal(testHasher{}, gotRangeProof.Hasher)
+	require.NoError(gotRangeProof.Verify(context.Background(), maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), root))
+}
+
+func TestDatabaseHasher(t *testing.T) {
+	require := require.New(t)
+
+	roots := make(map[string]bool)
+	for _, hasher := range []Hasher{SHA256Hasher, Keccak256Hasher, Blake2b256Hasher, Blake3Hasher} {
+		config := newDefaultConfig()
+		config.Hasher = hasher
+		db, err := newDatabase(context.Background(), memdb.New(), config, &mockMetrics{})
+		require.NoError(err)
+
+		// The value is long enough to be hashed into its digest.
+		key := []byte("key")
+		value := make([]byte, 2*HashLength)
+		require.NoError(db.Put(key, value))
+
+		root, err := db.GetMerkleRoot(context.Background())
+		require.NoError(err)
+		roots[root.String()] = true
+
+		proof, err := db.GetProof(context.Background(), key)
+		require.NoError(err)
+		require.Equal(hasher, proof.Hasher)
+		require.NoError(proof.Verify(context.Background(), root))
+
+		// The proof can't be verified with a different hasher.
+		proof.Hasher = otherHasher(hasher)
+		require.ErrorIs(proof.Verify(context.Background(), root), ErrProofValueDoesntMatch)
+
+		rangeProof, err := db.GetRangeProof(context.Background(), maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), 10)
+		require.NoError(err)
+		require.NoError(rangeProof.Verify(context.Background(), maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), root))
+
+		// The hasher is preserved over the network.
+		var gotRangeProof RangeProof
+		require.NoError(gotRangeProof.UnmarshalProto(rangeProof.ToProto(), config.BranchFactor))
+		require.Equal(hasher, gotRangeProof.Hasher)
+		require.NoError(gotRangeProof.Verify(context.Background(), maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), root))
+	}
+	require.Len(roots, 4)
+}
+
+func TestDatabaseHasherMismatch(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	config := newDefaultConfig()
+	config.Hasher = Keccak256Hasher
+	db, err := newDatabase(context.Background(), baseDB, config, &mockMetrics{})
+	require.NoError(err)
+	require.NoError(db.Put([]byte("key"), []byte("value")))
+	require.NoError(db.Close())
+
+	// The default hasher doesn't match the recorded hasher.
+	_, err = newDatabase(context.Background(), baseDB, newDefaultConfig(), &mockMetrics{})
+	require.ErrorIs(err, ErrHasherMismatch)
+
+	db, err = newDatabase(context.Background(), baseDB, config, &mockMetrics{})
+	require.NoError(err)
+	require.NoError(db.Close())
+}
+
+func TestDatabaseHasherMismatchUnrecorded(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db, err := newDatabase(context.Background(), baseDB, newDefaultConfig(), &mockMetrics{})
+	require.NoError(err)
+	require.NoError(db.Close())
+
+	// Databases created before the hasher was recorded used SHA-256.
+	require.NoError(baseDB.Delete(hasherKey))
+
+	config := newDefaultConfig()
+	config.Hasher = Blake2b256Hasher
+	_, err = newDatabase(context.Background(), baseDB, config, &mockMetrics{})
+	require.ErrorIs(err, ErrHasherMismatch)
+
+	db, err = newDatabase(context.Background(), baseDB, newDefaultConfig(), &mockMetrics{})
+	require.NoError(err)
+	require.NoError(db.Close())
+
+	hasherName, err := baseDB.Get(hasherKey)
+	require.NoError(err)
+	require.Equal(SHA256Hasher.Name(), string(hasherName))
+}
+
+func TestVerifyChangeProofHasherMismatch(t *testing.T) {
+	require := require.New(t)
+
+	db, err := getBasicDB()
+	require.NoError(err)
+
+	config := newDefaultConfig()
+	config.Hasher = Keccak256Hasher
+	otherDB, err := newDatabase(context.Background(), memdb.New(), config, &mockMetrics{})
+	require.NoError(err)
+
+	startRoot, err := otherDB.GetMerkleRoot(context.Background())
+	require.NoError(err)
+	require.NoError(otherDB.Put([]byte("key"), []byte("value")))
+	endRoot, err := otherDB.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	proof, err := otherDB.GetChangeProof(context.Background(), startRoot, endRoot, maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), 10)
+	require.NoError(err)
+
+	err = db.VerifyChangeProof(context.Background(), proof, maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), endRoot)
+	require.ErrorIs(err, ErrHasherMismatch)
+}
+
+func otherHasher(hasher Hasher) Hasher {
+	if hasher == SHA256Hasher {
+		return Keccak256Hasher
+	}
+	return SHA256Hasher
+}
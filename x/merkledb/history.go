@@ -11,6 +11,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/buffer"
+	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/utils/maybe"
 	"github.com/ava-labs/avalanchego/utils/set"
 )
@@ -33,6 +34,10 @@ type trieHistory struct {
 	// Each change is tagged with this monotonic increasing number.
 	nextInsertNumber uint64
 
+	// If non-nil, changes are also persisted to [historyDB] so that they
+	// survive restarts.
+	historyDB *historyDB
+
 	newPath func([]byte) Path
 }
 
@@ -95,62 +100,33 @@ func (th *trieHistory) getValueChanges(
 		return newChangeSummary(maxLength), nil
 	}
 
-	// [endRootChanges] is the last change in the history resulting in [endRoot].
+	// [endRootInsertNumber] is the insert number of the last change in the
+	// history resulting in [endRoot].
 	// TODO when we update to minimum go version 1.20.X, make this return another
 	// wrapped error ErrNoEndRoot. In NetworkServer.HandleChangeProofRequest, if we return
 	// that error, we know we shouldn't try to generate a range proof since we
 	// lack the necessary history.
-	endRootChanges, ok := th.lastChanges[endRoot]
+	endRootInsertNumber, ok, err := th.findRoot(endRoot, th.nextInsertNumber)
+	if err != nil {
+		return nil, err
+	}
 	if !ok {
 		return nil, fmt.Errorf("%w: end root %s not found", ErrInsufficientHistory, endRoot)
 	}
 
 	// Confirm there's a change resulting in [startRoot] before
 	// a change resulting in [endRoot] in the history.
-	// [startRootChanges] is the last appearance of [startRoot].
-	startRootChanges, ok := th.lastChanges[startRoot]
-	if !ok {
-		return nil, fmt.Errorf("%w: start root %s not found", ErrInsufficientHistory, startRoot)
+	// [startRootInsertNumber] is the insert number of the last change
+	// resulting in [startRoot] before [endRoot].
+	startRootInsertNumber, ok, err := th.findRoot(startRoot, endRootInsertNumber)
+	if err != nil {
+		return nil, err
 	}
-
-	var (
-		// The insert number of the last element in [th.history].
-		mostRecentChangeInsertNumber = th.nextInsertNumber - 1
-
-		// The index within [th.history] of its last element.
-		mostRecentChangeIndex = th.history.Len() - 1
-
-		// The difference between the last index in [th.history] and the index of [endRootChanges].
-		endToMostRecentOffset = int(mostRecentChangeInsertNumber - endRootChanges.insertNumber)
-
-		// The index in [th.history] of the latest change resulting in [endRoot].
-		endRootIndex = mostRecentChangeIndex - endToMostRecentOffset
-	)
-
-	if startRootChanges.insertNumber > endRootChanges.insertNumber {
-		// [startRootChanges] happened after [endRootChanges].
-		// However, that is just the *latest* change resulting in [startRoot].
-		// Attempt to find a change resulting in [startRoot] before [endRootChanges].
-		//
-		// Translate the insert number to the index in [th.history] so we can iterate
-		// backward from [endRootChanges].
-		for i := endRootIndex - 1; i >= 0; i-- {
-			changes, _ := th.history.Index(i)
-
-			if changes.rootID == startRoot {
-				// [startRootChanges] is now the last change resulting in
-				// [startRoot] before [endRootChanges].
-				startRootChanges = changes
-				break
-			}
-
-			if i == 0 {
-				return nil, fmt.Errorf(
-					"%w: start root %s not found before end root %s",
-					ErrInsufficientHistory, startRoot, endRoot,
-				)
-			}
-		}
+	if !ok {
+		return nil, fmt.Errorf(
+			"%w: start root %s not found before end root %s",
+			ErrInsufficientHistory, startRoot, endRoot,
+		)
 	}
 
 	var (
@@ -166,19 +142,15 @@ func (th *trieHistory) getValueChanges(
 		// add the changes to keys in [start, end] to [combinedChanges].
 		// Only the key-value pairs with the greatest [maxLength] keys will be kept.
 		combinedChanges = newChangeSummary(maxLength)
-
-		// The difference between the index of [startRootChanges] and [endRootChanges] in [th.history].
-		startToEndOffset = int(endRootChanges.insertNumber - startRootChanges.insertNumber)
-
-		// The index of the last change resulting in [startRoot]
-		// which occurs before [endRootChanges].
-		startRootIndex = endRootIndex - startToEndOffset
 	)
 
-	// For each change after [startRootChanges] up to and including
-	// [endRootChanges], record the change in [combinedChanges].
-	for i := startRootIndex + 1; i <= endRootIndex; i++ {
-		changes, _ := th.history.Index(i)
+	// For each change after [startRootInsertNumber] up to and including
+	// [endRootInsertNumber], record the change in [combinedChanges].
+	for i := startRootInsertNumber + 1; i <= endRootInsertNumber; i++ {
+		changes, err := th.getChange(i)
+		if err != nil {
+			return nil, err
+		}
 
 		// Add the changes from this commit to [combinedChanges].
 		for key, valueChange := range changes.values {
@@ -230,27 +202,30 @@ func (th *trieHistory) getValueChanges(
 // If [start] is Nothing, all keys are considered > [start].
 // If [end] is Nothing, all keys are considered < [end].
 func (th *trieHistory) getChangesToGetToRoot(rootID ids.ID, start maybe.Maybe[[]byte], end maybe.Maybe[[]byte]) (*changeSummary, error) {
-	// [lastRootChange] is the last change in the history resulting in [rootID].
-	lastRootChange, ok := th.lastChanges[rootID]
+	// [lastRootChangeInsertNumber] is the insert number of the last change in
+	// the history resulting in [rootID].
+	lastRootChangeInsertNumber, ok, err := th.findRoot(rootID, th.nextInsertNumber)
+	if err != nil {
+		return nil, err
+	}
 	if !ok {
 		return nil, ErrInsufficientHistory
 	}
 
 	var (
-		startPath                    = maybe.Bind(start, th.newPath)
-		endPath                      = maybe.Bind(end, th.newPath)
-		combinedChanges              = newChangeSummary(defaultPreallocationSize)
-		mostRecentChangeInsertNumber = th.nextInsertNumber - 1
-		mostRecentChangeIndex        = th.history.Len() - 1
-		offset                       = int(mostRecentChangeInsertNumber - lastRootChange.insertNumber)
-		lastRootChangeIndex          = mostRecentChangeIndex - offset
+		startPath       = maybe.Bind(start, th.newPath)
+		endPath         = maybe.Bind(end, th.newPath)
+		combinedChanges = newChangeSummary(defaultPreallocationSize)
 	)
 
 	// Go backward from the most recent change in the history up to but
 	// not including the last change resulting in [rootID].
 	// Record each change in [combinedChanges].
-	for i := mostRecentChangeIndex; i > lastRootChangeIndex; i-- {
-		changes, _ := th.history.Index(i)
+	for i := th.nextInsertNumber - 1; i > lastRootChangeInsertNumber; i-- {
+		changes, err := th.getChange(i)
+		if err != nil {
+			return nil, err
+		}
 
 		for key, changedNode := range changes.nodes {
 			combinedChanges.nodes[key] = &change[*node]{
@@ -276,11 +251,89 @@ func (th *trieHistory) getChangesToGetToRoot(rootID ids.ID, start maybe.Maybe[[]
 	return combinedChanges, nil
 }
 
+// Returns the insert number of the most recent change in the history that
+// resulted in [rootID] and has an insert number less than [before].
+// Returns false if there is no such change.
+func (th *trieHistory) findRoot(rootID ids.ID, before uint64) (uint64, bool, error) {
+	oldestInMemory := th.oldestInMemory()
+	if latestChange, ok := th.lastChanges[rootID]; ok {
+		if latestChange.insertNumber < before {
+			return latestChange.insertNumber, true, nil
+		}
+
+		// Attempt to find a change resulting in [rootID] in memory before
+		// [before].
+		for i := before; i > oldestInMemory; i-- {
+			changes, _ := th.history.Index(int(i - 1 - oldestInMemory))
+			if changes.rootID == rootID {
+				return changes.insertNumber, true, nil
+			}
+		}
+	}
+
+	if th.historyDB == nil {
+		return 0, false, nil
+	}
+	// Every change in memory has been checked, so only older changes need to be
+	// checked on disk.
+	return th.historyDB.findRoot(rootID, math.Min(before, oldestInMemory))
+}
+
+// Returns the change with [insertNumber].
+// Assumes the change is in the history.
+func (th *trieHistory) getChange(insertNumber uint64) (*changeSummaryAndInsertNumber, error) {
+	oldestInMemory := th.oldestInMemory()
+	if insertNumber >= oldestInMemory {
+		changes, _ := th.history.Index(int(insertNumber - oldestInMemory))
+		return changes, nil
+	}
+	return th.historyDB.get(insertNumber)
+}
+
+// Returns the insert number of the oldest change in [th.history].
+// If [th.history] is empty, returns [th.nextInsertNumber].
+func (th *trieHistory) oldestInMemory() uint64 {
+	return th.nextInsertNumber - uint64(th.history.Len())
+}
+
+// persist makes [th] also persist the changes it records to [historyDB], and
+// serve the changes persisted to [historyDB] before [th] was created.
+// Must be called before any changes are recorded.
+func (th *trieHistory) persist(historyDB *historyDB) {
+	th.historyDB = historyDB
+	th.nextInsertNumber = historyDB.next
+}
+
+// clearPersisted deletes every persisted change. The changes in memory are
+// unaffected.
+func (th *trieHistory) clearPersisted() error {
+	if th.historyDB == nil {
+		return nil
+	}
+	return th.historyDB.clear(th.nextInsertNumber)
+}
+
 // record the provided set of changes in the history
-func (th *trieHistory) record(changes *changeSummary) {
+func (th *trieHistory) record(changes *changeSummary) error {
 	// we aren't recording history so noop
+	if th.maxHistoryLen == 0 && th.historyDB == nil {
+		return nil
+	}
+
+	changesAndIndex := &changeSummaryAndInsertNumber{
+		changeSummary: changes,
+		insertNumber:  th.nextInsertNumber,
+	}
+	if th.historyDB != nil {
+		if err := th.historyDB.put(changesAndIndex); err != nil {
+			return err
+		}
+	}
+	th.nextInsertNumber++
+
+	// we aren't recording history in memory
 	if th.maxHistoryLen == 0 {
-		return
+		return nil
 	}
 
 	if th.history.Len() == th.maxHistoryLen {
@@ -295,15 +348,10 @@ func (th *trieHistory) record(changes *changeSummary) {
 		}
 	}
 
-	changesAndIndex := &changeSummaryAndInsertNumber{
-		changeSummary: changes,
-		insertNumber:  th.nextInsertNumber,
-	}
-	th.nextInsertNumber++
-
 	// Add [changes] to the sorted change list.
 	_ = th.history.PushRight(changesAndIndex)
 
 	// Mark that this is the most recent change resulting in [changes.rootID].
 	th.lastChanges[changes.rootID] = changesAndIndex
+	return nil
 }
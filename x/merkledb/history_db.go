@@ -0,0 +1,207 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+var errInvalidHistoryKey = errors.New("invalid history key")
+
+// historyDB persists the most recent changes to the trie so that they can be
+// used to serve proofs after a restart.
+//
+// Each change is stored under [historyPrefix] followed by its insert number.
+// The insert numbers of the changes resulting in each root ID are indexed
+// under [historyRootPrefix] followed by the root ID and the insert number.
+type historyDB struct {
+	// The underlying storage.
+	baseDB database.Database
+
+	branchFactor BranchFactor
+	// Used to calculate the value digests of the persisted nodes.
+	hasher Hasher
+
+	// Maximum number of changes to persist.
+	maxLen int
+
+	// The insert number of the oldest persisted change.
+	// If [oldest] == [next], no changes are persisted.
+	oldest uint64
+	// The insert number of the next change to persist.
+	next uint64
+}
+
+func newHistoryDB(db database.Database, maxLen int, branchFactor BranchFactor, hasher Hasher) (*historyDB, error) {
+	h := &historyDB{
+		baseDB:       db,
+		branchFactor: branchFactor,
+		hasher:       hasher,
+		maxLen:       maxLen,
+	}
+
+	oldestIt := db.NewIteratorWithPrefix(historyPrefix)
+	defer oldestIt.Release()
+
+	if !oldestIt.Next() {
+		return h, oldestIt.Error()
+	}
+	oldest, err := parseHistoryKey(oldestIt.Key())
+	if err != nil {
+		return nil, err
+	}
+
+	newestIt := database.NewIteratorWithOptions(db, database.IteratorOptions{
+		Prefix:  historyPrefix,
+		Reverse: true,
+	})
+	defer newestIt.Release()
+
+	if !newestIt.Next() {
+		return nil, newestIt.Error()
+	}
+	newest, err := parseHistoryKey(newestIt.Key())
+	if err != nil {
+		return nil, err
+	}
+
+	h.oldest = oldest
+	h.next = newest + 1
+	return h, nil
+}
+
+// get returns the change with [insertNumber].
+// Assumes the change is persisted.
+func (h *historyDB) get(insertNumber uint64) (*changeSummaryAndInsertNumber, error) {
+	changesBytes, err := h.baseDB.Get(historyKey(insertNumber))
+	if err != nil {
+		return nil, err
+	}
+
+	changes := &changeSummary{}
+	if err := codec.decodeChangeSummary(changesBytes, changes, h.branchFactor, h.hasher); err != nil {
+		return nil, err
+	}
+	return &changeSummaryAndInsertNumber{
+		changeSummary: changes,
+		insertNumber:  insertNumber,
+	}, nil
+}
+
+// findRoot returns the insert number of the most recent persisted change that
+// resulted in [rootID] and has an insert number less than [before].
+// Returns false if there is no such change.
+func (h *historyDB) findRoot(rootID ids.ID, before uint64) (uint64, bool, error) {
+	it := database.NewIteratorWithOptions(h.baseDB, database.IteratorOptions{
+		Prefix:  historyRootPrefixOf(rootID),
+		End:     historyRootKey(rootID, before),
+		Reverse: true,
+	})
+	defer it.Release()
+
+	if !it.Next() {
+		return 0, false, it.Error()
+	}
+	key := it.Key()
+	if len(key) != len(historyRootPrefix)+ids.IDLen+wrappers.LongLen {
+		return 0, false, errInvalidHistoryKey
+	}
+	return binary.BigEndian.Uint64(key[len(key)-wrappers.LongLen:]), true, nil
+}
+
+// put persists [changes], which must have the next insert number, and deletes
+// the oldest persisted change if more than [maxLen] changes are persisted.
+func (h *historyDB) put(changes *changeSummaryAndInsertNumber) error {
+	batch := h.baseDB.NewBatch()
+	if err := batch.Put(historyKey(changes.insertNumber), codec.encodeChangeSummary(changes.changeSummary, h.branchFactor)); err != nil {
+		return err
+	}
+	if err := batch.Put(historyRootKey(changes.rootID, changes.insertNumber), nil); err != nil {
+		return err
+	}
+
+	oldest := h.oldest
+	if oldest == h.next {
+		oldest = changes.insertNumber
+	}
+	for changes.insertNumber+1-oldest > uint64(h.maxLen) {
+		// Only the root ID of the evicted change is needed, which is the
+		// prefix of the serialized change.
+		oldestBytes, err := h.baseDB.Get(historyKey(oldest))
+		if err != nil {
+			return err
+		}
+		if len(oldestBytes) < ids.IDLen {
+			return io.ErrUnexpectedEOF
+		}
+		oldestRootID, err := ids.ToID(oldestBytes[:ids.IDLen])
+		if err != nil {
+			return err
+		}
+
+		if err := batch.Delete(historyKey(oldest)); err != nil {
+			return err
+		}
+		if err := batch.Delete(historyRootKey(oldestRootID, oldest)); err != nil {
+			return err
+		}
+		oldest++
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	h.oldest = oldest
+	h.next = changes.insertNumber + 1
+	return nil
+}
+
+// clear deletes every persisted change. The next change to be persisted will
+// have the insert number [next].
+func (h *historyDB) clear(next uint64) error {
+	if err := database.ClearPrefix(h.baseDB, historyPrefix, rebuildIntermediateDeletionWriteSize); err != nil {
+		return err
+	}
+	if err := database.ClearPrefix(h.baseDB, historyRootPrefix, rebuildIntermediateDeletionWriteSize); err != nil {
+		return err
+	}
+	h.oldest = next
+	h.next = next
+	return nil
+}
+
+func historyKey(insertNumber uint64) []byte {
+	key := make([]byte, len(historyPrefix)+wrappers.LongLen)
+	copy(key, historyPrefix)
+	binary.BigEndian.PutUint64(key[len(historyPrefix):], insertNumber)
+	return key
+}
+
+func parseHistoryKey(key []byte) (uint64, error) {
+	if len(key) != len(historyPrefix)+wrappers.LongLen {
+		return 0, errInvalidHistoryKey
+	}
+	return binary.BigEndian.Uint64(key[len(historyPrefix):]), nil
+}
+
+func historyRootPrefixOf(rootID ids.ID) []byte {
+	prefix := make([]byte, len(historyRootPrefix)+ids.IDLen)
+	copy(prefix, historyRootPrefix)
+	copy(prefix[len(historyRootPrefix):], rootID[:])
+	return prefix
+}
+
+func historyRootKey(rootID ids.ID, insertNumber uint64) []byte {
+	key := make([]byte, len(historyRootPrefix)+ids.IDLen+wrappers.LongLen)
+	copy(key, historyRootPrefix)
+	copy(key[len(historyRootPrefix):], rootID[:])
+	binary.BigEndian.PutUint64(key[len(historyRootPrefix)+ids.IDLen:], insertNumber)
+	return key
+}
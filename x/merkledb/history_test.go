@@ -9,6 +9,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/stretchr/testify/require"
 
 	"github.com/ava-labs/avalanchego/database/memdb"
@@ -774,3 +776,141 @@ func TestHistoryGetChangesToRoot(t *testing.T) {
 		})
 	}
 }
+
+func TestPersistedHistory(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	config := newDefaultConfig()
+	config.HistoryLength = 1
+	config.PersistedHistoryLength = 10
+
+	db, err := newDB(context.Background(), baseDB, config)
+	require.NoError(err)
+
+	roots := []ids.ID{}
+	for i := 0; i < 5; i++ {
+		batch := db.NewBatch()
+		require.NoError(batch.Put([]byte{byte(i)}, []byte{byte(i)}))
+		require.NoError(batch.Write())
+
+		root, err := db.GetMerkleRoot(context.Background())
+		require.NoError(err)
+		roots = append(roots, root)
+	}
+
+	// Changes that are no longer in memory are read from disk.
+	expectedChangeProof, err := db.GetChangeProof(context.Background(), roots[0], roots[2], maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), 10)
+	require.NoError(err)
+	require.Len(expectedChangeProof.KeyChanges, 2)
+
+	require.NoError(db.Close())
+
+	config.Reg = prometheus.NewRegistry()
+	db, err = newDB(context.Background(), baseDB, config)
+	require.NoError(err)
+
+	changeProof, err := db.GetChangeProof(context.Background(), roots[0], roots[2], maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), 10)
+	require.NoError(err)
+	require.Equal(expectedChangeProof, changeProof)
+
+	rangeProof, err := db.GetRangeProofAtRoot(context.Background(), roots[1], maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), 10)
+	require.NoError(err)
+	require.Len(rangeProof.KeyValues, 2)
+	require.NoError(rangeProof.Verify(context.Background(), maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), roots[1]))
+
+	// Changes committed after the restart extend the persisted history.
+	batch := db.NewBatch()
+	require.NoError(batch.Put([]byte{0}, []byte{5}))
+	require.NoError(batch.Write())
+	root, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	changeProof, err = db.GetChangeProof(context.Background(), roots[0], root, maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), 10)
+	require.NoError(err)
+	require.Equal([]KeyChange{
+		{Key: []byte{1}, Value: maybe.Some([]byte{1})},
+		{Key: []byte{2}, Value: maybe.Some([]byte{2})},
+		{Key: []byte{3}, Value: maybe.Some([]byte{3})},
+		{Key: []byte{4}, Value: maybe.Some([]byte{4})},
+	}, changeProof.KeyChanges[1:])
+	require.Equal([]byte{0}, changeProof.KeyChanges[0].Key)
+	require.Equal(maybe.Some([]byte{5}), changeProof.KeyChanges[0].Value)
+}
+
+func TestPersistedHistoryPruned(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	config := newDefaultConfig()
+	config.HistoryLength = 1
+	config.PersistedHistoryLength = 3
+
+	db, err := newDB(context.Background(), baseDB, config)
+	require.NoError(err)
+
+	roots := []ids.ID{}
+	for i := 0; i < 5; i++ {
+		batch := db.NewBatch()
+		require.NoError(batch.Put([]byte{byte(i)}, []byte{byte(i)}))
+		require.NoError(batch.Write())
+
+		root, err := db.GetMerkleRoot(context.Background())
+		require.NoError(err)
+		roots = append(roots, root)
+	}
+	require.NoError(db.Close())
+
+	config.Reg = prometheus.NewRegistry()
+	db, err = newDB(context.Background(), baseDB, config)
+	require.NoError(err)
+
+	// Reopening the database recorded the current root again, so only the
+	// last 2 committed roots remain.
+	_, err = db.GetChangeProof(context.Background(), roots[2], roots[4], maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), 10)
+	require.ErrorIs(err, ErrInsufficientHistory)
+
+	_, err = db.GetChangeProof(context.Background(), roots[3], roots[4], maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), 10)
+	require.NoError(err)
+
+	// Pruned changes are deleted from disk.
+	has, err := baseDB.Has(historyKey(0))
+	require.NoError(err)
+	require.False(has)
+	has, err = baseDB.Has(historyRootKey(roots[2], 3))
+	require.NoError(err)
+	require.False(has)
+}
+
+func TestPersistedHistoryClearedAfterUncleanShutdown(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	config := newDefaultConfig()
+	config.PersistedHistoryLength = 10
+
+	db, err := newDB(context.Background(), baseDB, config)
+	require.NoError(err)
+
+	startRoot, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	batch := db.NewBatch()
+	require.NoError(batch.Put([]byte("key"), []byte("value")))
+	require.NoError(batch.Write())
+
+	endRoot, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	// Reopen the database without closing it.
+	config.Reg = prometheus.NewRegistry()
+	db, err = newDB(context.Background(), baseDB, config)
+	require.NoError(err)
+
+	root, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+	require.Equal(endRoot, root)
+
+	_, err = db.GetChangeProof(context.Background(), startRoot, endRoot, maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), 10)
+	require.ErrorIs(err, ErrInsufficientHistory)
+}
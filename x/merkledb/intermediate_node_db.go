@@ -31,6 +31,8 @@ type intermediateNodeDB struct {
 	// the number of bytes to evict during an eviction batch
 	evictionBatchSize int
 	metrics           merkleMetrics
+	// Used to calculate the value digests of parsed nodes.
+	hasher Hasher
 }
 
 func newIntermediateNodeDB(
@@ -39,12 +41,14 @@ func newIntermediateNodeDB(
 	metrics merkleMetrics,
 	size int,
 	evictionBatchSize int,
+	hasher Hasher,
 ) *intermediateNodeDB {
 	result := &intermediateNodeDB{
 		metrics:           metrics,
 		baseDB:            db,
 		bufferPool:        bufferPool,
 		evictionBatchSize: evictionBatchSize,
+		hasher:            hasher,
 	}
 	result.nodeCache = newOnEvictCache(
 		size,
@@ -116,7 +120,7 @@ func (db *intermediateNodeDB) Get(key Path) (*node, error) {
 	}
 	db.bufferPool.Put(dbKey)
 
-	return parseNode(key, nodeBytes)
+	return parseNode(db.hasher, key, nodeBytes)
 }
 
 // constructDBKey returns a key that can be used in [db.baseDB].
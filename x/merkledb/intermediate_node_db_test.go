@@ -24,7 +24,7 @@ func Test_IntermediateNodeDB(t *testing.T) {
 	require := require.New(t)
 
 	n := newNode(nil, NewPath([]byte{0x00}, BranchFactor16))
-	n.setValue(maybe.Some([]byte{byte(0x02)}))
+	n.setValue(SHA256Hasher, maybe.Some([]byte{byte(0x02)}))
 	nodeSize := cacheEntrySize(n.key, n)
 
 	// use exact multiple of node size so require.Equal(1, db.nodeCache.fifo.Len()) is correct later
@@ -39,12 +39,13 @@ func Test_IntermediateNodeDB(t *testing.T) {
 		&mockMetrics{},
 		cacheSize,
 		evictionBatchSize,
+		SHA256Hasher,
 	)
 
 	// Put a key-node pair
 	node1Key := NewPath([]byte{0x01}, BranchFactor16)
 	node1 := newNode(nil, node1Key)
-	node1.setValue(maybe.Some([]byte{byte(0x01)}))
+	node1.setValue(SHA256Hasher, maybe.Some([]byte{byte(0x01)}))
 	require.NoError(db.Put(node1Key, node1))
 
 	// Get the key-node pair from cache
@@ -54,7 +55,7 @@ func Test_IntermediateNodeDB(t *testing.T) {
 
 	// Overwrite the key-node pair
 	node1Updated := newNode(nil, node1Key)
-	node1Updated.setValue(maybe.Some([]byte{byte(0x02)}))
+	node1Updated.setValue(SHA256Hasher, maybe.Some([]byte{byte(0x02)}))
 	require.NoError(db.Put(node1Key, node1Updated))
 
 	// Assert the key-node pair was overwritten
@@ -75,7 +76,7 @@ func Test_IntermediateNodeDB(t *testing.T) {
 	for {
 		key := NewPath([]byte{byte(added)}, BranchFactor16)
 		node := newNode(nil, emptyPath(BranchFactor16))
-		node.setValue(maybe.Some([]byte{byte(added)}))
+		node.setValue(SHA256Hasher, maybe.Some([]byte{byte(added)}))
 		newExpectedSize := expectedSize + cacheEntrySize(key, node)
 		if newExpectedSize > cacheSize {
 			// Don't trigger eviction.
@@ -95,7 +96,7 @@ func Test_IntermediateNodeDB(t *testing.T) {
 	// the added key prefix increasing the size tracked by the batch.
 	key := NewPath([]byte{byte(added)}, BranchFactor16)
 	node := newNode(nil, emptyPath(BranchFactor16))
-	node.setValue(maybe.Some([]byte{byte(added)}))
+	node.setValue(SHA256Hasher, maybe.Some([]byte{byte(added)}))
 	require.NoError(db.Put(key, node))
 
 	// Assert cache has expected number of elements
@@ -142,6 +143,7 @@ func FuzzIntermediateNodeDBConstructDBKey(f *testing.F) {
 		&mockMetrics{},
 		cacheSize,
 		evictionBatchSize,
+		SHA256Hasher,
 	)
 	f.Fuzz(func(
 		t *testing.T,
@@ -187,6 +189,7 @@ func Test_IntermediateNodeDB_ConstructDBKey_DirtyBuffer(t *testing.T) {
 		&mockMetrics{},
 		cacheSize,
 		evictionBatchSize,
+		SHA256Hasher,
 	)
 
 	db.bufferPool.Put([]byte{0xFF, 0xFF, 0xFF})
@@ -155,6 +155,21 @@ func (mr *MockMerkleDBMockRecorder) GetMerkleRoot(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMerkleRoot", reflect.TypeOf((*MockMerkleDB)(nil).GetMerkleRoot), arg0)
 }
 
+// GetMultiProof mocks base method.
+func (m *MockMerkleDB) GetMultiProof(arg0 context.Context, arg1 [][]byte) (*MultiProof, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMultiProof", arg0, arg1)
+	ret0, _ := ret[0].(*MultiProof)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMultiProof indicates an expected call of GetMultiProof.
+func (mr *MockMerkleDBMockRecorder) GetMultiProof(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMultiProof", reflect.TypeOf((*MockMerkleDB)(nil).GetMultiProof), arg0, arg1)
+}
+
 // GetProof mocks base method.
 func (m *MockMerkleDB) GetProof(arg0 context.Context, arg1 []byte) (*Proof, error) {
 	m.ctrl.T.Helper()
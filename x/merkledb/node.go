@@ -8,7 +8,6 @@ import (
 	"golang.org/x/exp/slices"
 
 	"github.com/ava-labs/avalanchego/ids"
-	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/maybe"
 )
 
@@ -58,7 +57,8 @@ func newNode(parent *node, key Path) *node {
 }
 
 // Parse [nodeBytes] to a node and set its key to [key].
-func parseNode(key Path, nodeBytes []byte) (*node, error) {
+// [hasher] is used to calculate the digest of the node's value.
+func parseNode(hasher Hasher, key Path, nodeBytes []byte) (*node, error) {
 	n := dbNode{}
 	if err := codec.decodeDBNode(nodeBytes, &n, key.branchFactor); err != nil {
 		return nil, err
@@ -69,7 +69,7 @@ func parseNode(key Path, nodeBytes []byte) (*node, error) {
 		nodeBytes: nodeBytes,
 	}
 
-	result.setValueDigest()
+	result.setValueDigest(hasher)
 	return result, nil
 }
 
@@ -95,7 +95,7 @@ func (n *node) onNodeChanged() {
 }
 
 // Returns and caches the ID of this node.
-func (n *node) calculateID(metrics merkleMetrics) {
+func (n *node) calculateID(hasher Hasher, metrics merkleMetrics) {
 	if n.id != ids.Empty {
 		return
 	}
@@ -106,22 +106,30 @@ func (n *node) calculateID(metrics merkleMetrics) {
 		Value:    n.valueDigest,
 		Key:      n.key,
 	})
-	n.id = hashing.ComputeHash256Array(bytes)
+	n.id = hasher.Hash(bytes)
 }
 
 // Set [n]'s value to [val].
-func (n *node) setValue(val maybe.Maybe[[]byte]) {
+// [hasher] is used to calculate the digest of [val].
+func (n *node) setValue(hasher Hasher, val maybe.Maybe[[]byte]) {
 	n.onNodeChanged()
 	n.value = val
-	n.setValueDigest()
+	n.setValueDigest(hasher)
 }
 
-func (n *node) setValueDigest() {
-	if n.value.IsNothing() || len(n.value.Value()) < HashLength {
-		n.valueDigest = n.value
-	} else {
-		n.valueDigest = maybe.Some(hashing.ComputeHash256(n.value.Value()))
+func (n *node) setValueDigest(hasher Hasher) {
+	n.valueDigest = valueDigest(hasher, n.value)
+}
+
+// valueDigest returns the digest of [value] that is used to calculate the ID
+// of the node containing it. Values shorter than [HashLength] are their own
+// digest.
+func valueDigest(hasher Hasher, value maybe.Maybe[[]byte]) maybe.Maybe[[]byte] {
+	if value.IsNothing() || len(value.Value()) < HashLength {
+		return value
 	}
+	digest := hasher.Hash(value.Value())
+	return maybe.Some(digest[:])
 }
 
 // Adds [child] as a child of [n].
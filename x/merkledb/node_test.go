@@ -18,14 +18,14 @@ func Test_Node_Marshal(t *testing.T) {
 
 	fullpath := NewPath([]byte("key"), BranchFactor16)
 	childNode := newNode(root, fullpath)
-	childNode.setValue(maybe.Some([]byte("value")))
+	childNode.setValue(SHA256Hasher, maybe.Some([]byte("value")))
 	require.NotNil(t, childNode)
 
-	childNode.calculateID(&mockMetrics{})
+	childNode.calculateID(SHA256Hasher, &mockMetrics{})
 	root.addChild(childNode)
 
 	data := root.bytes()
-	rootParsed, err := parseNode(NewPath([]byte(""), BranchFactor16), data)
+	rootParsed, err := parseNode(SHA256Hasher, NewPath([]byte(""), BranchFactor16), data)
 	require.NoError(t, err)
 	require.Len(t, rootParsed.children, 1)
 
@@ -42,25 +42,25 @@ func Test_Node_Marshal_Errors(t *testing.T) {
 
 	fullpath := NewPath([]byte{255}, BranchFactor16)
 	childNode1 := newNode(root, fullpath)
-	childNode1.setValue(maybe.Some([]byte("value1")))
+	childNode1.setValue(SHA256Hasher, maybe.Some([]byte("value1")))
 	require.NotNil(t, childNode1)
 
-	childNode1.calculateID(&mockMetrics{})
+	childNode1.calculateID(SHA256Hasher, &mockMetrics{})
 	root.addChild(childNode1)
 
 	fullpath = NewPath([]byte{237}, BranchFactor16)
 	childNode2 := newNode(root, fullpath)
-	childNode2.setValue(maybe.Some([]byte("value2")))
+	childNode2.setValue(SHA256Hasher, maybe.Some([]byte("value2")))
 	require.NotNil(t, childNode2)
 
-	childNode2.calculateID(&mockMetrics{})
+	childNode2.calculateID(SHA256Hasher, &mockMetrics{})
 	root.addChild(childNode2)
 
 	data := root.bytes()
 
 	for i := 1; i < len(data); i++ {
 		broken := data[:i]
-		_, err := parseNode(NewPath([]byte(""), BranchFactor16), broken)
+		_, err := parseNode(SHA256Hasher, NewPath([]byte(""), BranchFactor16), broken)
 		require.ErrorIs(t, err, io.ErrUnexpectedEOF)
 	}
 }
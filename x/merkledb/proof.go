@@ -6,15 +6,17 @@ package merkledb
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
+	"sort"
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/trace"
-	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/maybe"
 
 	pb "github.com/ava-labs/avalanchego/proto/pb/sync"
@@ -54,6 +56,11 @@ var (
 	ErrNilValue                    = errors.New("value is nil")
 	ErrUnexpectedEndProof          = errors.New("end proof should be empty")
 	ErrInconsistentBranchFactor    = errors.New("all keys in proof nodes should have the same branch factor")
+	ErrNilMultiProof               = errors.New("multi proof is nil")
+	ErrUnsortedProofNodes          = errors.New("proof nodes are not sorted by increasing key")
+	ErrMissingExclusionNode        = errors.New("the proof doesn't include the node that would be an ancestor of an absent key")
+	ErrNilExclusionProof           = errors.New("exclusion proof is nil")
+	ErrKeyPresent                  = errors.New("key is in the trie")
 )
 
 type ProofNode struct {
@@ -135,6 +142,10 @@ type Proof struct {
 	// Nothing if [Key] isn't in the trie.
 	// Otherwise the value corresponding to [Key].
 	Value maybe.Maybe[[]byte]
+
+	// The hasher used to generate the proof.
+	// If nil, [SHA256Hasher] is used.
+	Hasher Hasher
 }
 
 // Returns nil if the trie given in [proof] has root [expectedRootID].
@@ -145,6 +156,7 @@ func (proof *Proof) Verify(ctx context.Context, expectedRootID ids.ID) error {
 	if len(proof.Path) == 0 {
 		return ErrNoProof
 	}
+	hasher := hasherOrDefault(proof.Hasher)
 	if err := verifyProofPath(proof.Path, maybe.Some(proof.Key)); err != nil {
 		return err
 	}
@@ -158,7 +170,7 @@ func (proof *Proof) Verify(ctx context.Context, expectedRootID ids.ID) error {
 	// and thus has a whole number of bytes
 	if !lastNode.KeyPath.hasPartialByte() &&
 		proof.Key == lastNode.KeyPath &&
-		!valueOrHashMatches(proof.Value, lastNode.ValueOrHash) {
+		!valueOrHashMatches(hasher, proof.Value, lastNode.ValueOrHash) {
 		return ErrProofValueDoesntMatch
 	}
 
@@ -166,13 +178,17 @@ func (proof *Proof) Verify(ctx context.Context, expectedRootID ids.ID) error {
 	// then this is an exclusion proof and should prove that [proof.Key] isn't in the trie.
 	// Note length not evenly divisible into bytes can never match the [proof.Key] since it's bytes,
 	// and thus an exact number of bytes.
-	if (lastNode.KeyPath.hasPartialByte() || proof.Key != lastNode.KeyPath) &&
-		proof.Value.HasValue() {
-		return ErrProofValueDoesntMatch
+	if lastNode.KeyPath.hasPartialByte() || proof.Key != lastNode.KeyPath {
+		if proof.Value.HasValue() {
+			return ErrProofValueDoesntMatch
+		}
+		if err := verifyExclusionProofPath(proof.Path, proof.Key); err != nil {
+			return err
+		}
 	}
 
 	// Don't bother locking [view] -- nobody else has a reference to it.
-	view, err := getStandaloneTrieView(ctx, nil, proof.Key.branchFactor)
+	view, err := getStandaloneTrieView(ctx, nil, proof.Key.branchFactor, hasher)
 	if err != nil {
 		return err
 	}
@@ -203,8 +219,9 @@ func (proof *Proof) ToProto() *pb.Proof {
 	}
 
 	pbProof := &pb.Proof{
-		Key:   proof.Key.Bytes(),
-		Value: value,
+		Key:    proof.Key.Bytes(),
+		Value:  value,
+		Hasher: hasherOrDefault(proof.Hasher).Name(),
 	}
 
 	pbProof.Proof = make([]*pb.ProofNode, len(proof.Path))
@@ -225,6 +242,12 @@ func (proof *Proof) UnmarshalProto(pbProof *pb.Proof, bf BranchFactor) error {
 		return ErrInvalidMaybe
 	}
 
+	hasher, err := HasherFromName(pbProof.Hasher)
+	if err != nil {
+		return err
+	}
+	proof.Hasher = hasher
+
 	proof.Key = NewPath(pbProof.Key, bf)
 
 	if !pbProof.Value.IsNothing {
@@ -241,6 +264,384 @@ func (proof *Proof) UnmarshalProto(pbProof *pb.Proof, bf BranchFactor) error {
 	return nil
 }
 
+// ToExclusionProof returns a proof that [proof.Key] isn't in the trie.
+// Returns [ErrKeyPresent] if [proof] proves that [proof.Key] is in the trie.
+func (proof *Proof) ToExclusionProof() (*ExclusionProof, error) {
+	if proof.Value.HasValue() {
+		return nil, ErrKeyPresent
+	}
+	return &ExclusionProof{
+		Key:    proof.Key.Bytes(),
+		Path:   proof.Path,
+		Hasher: proof.Hasher,
+	}, nil
+}
+
+// An ExclusionProof proves that a key isn't in a trie.
+// It can be verified against a root ID without a database.
+type ExclusionProof struct {
+	// The key that isn't in the trie.
+	Key []byte
+
+	// Nodes in the proof path from root --> the node that would be
+	// where [Key] is if it existed, or its closest ancestor.
+	// Must always be non-empty (i.e. have the root node).
+	Path []ProofNode
+
+	// The hasher used to generate the proof.
+	// If nil, [SHA256Hasher] is used.
+	Hasher Hasher
+}
+
+// Returns nil if the trie given in [proof] has root [expectedRootID].
+// That is, this is a valid proof that [proof.Key] doesn't exist
+// in the trie with root [expectedRootID].
+func (proof *ExclusionProof) Verify(ctx context.Context, expectedRootID ids.ID) error {
+	if len(proof.Path) == 0 {
+		return ErrNoProof
+	}
+	p := &Proof{
+		Path:   proof.Path,
+		Key:    NewPath(proof.Key, proof.Path[0].KeyPath.branchFactor),
+		Value:  maybe.Nothing[[]byte](),
+		Hasher: proof.Hasher,
+	}
+	return p.Verify(ctx, expectedRootID)
+}
+
+func (proof *ExclusionProof) ToProto() *pb.ExclusionProof {
+	pbProof := &pb.ExclusionProof{
+		Key:    proof.Key,
+		Proof:  make([]*pb.ProofNode, len(proof.Path)),
+		Hasher: hasherOrDefault(proof.Hasher).Name(),
+	}
+	for i, node := range proof.Path {
+		pbProof.Proof[i] = node.ToProto()
+	}
+	return pbProof
+}
+
+func (proof *ExclusionProof) UnmarshalProto(pbProof *pb.ExclusionProof, bf BranchFactor) error {
+	if pbProof == nil {
+		return ErrNilExclusionProof
+	}
+
+	hasher, err := HasherFromName(pbProof.Hasher)
+	if err != nil {
+		return err
+	}
+	proof.Hasher = hasher
+
+	proof.Key = pbProof.Key
+	proof.Path = make([]ProofNode, len(pbProof.Proof))
+	for i, pbNode := range pbProof.Proof {
+		if err := proof.Path[i].UnmarshalProto(pbNode, bf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// The JSON encoding of an ExclusionProof includes the branch factor of its
+// keys and its hasher so that it can be decoded without any other context.
+type exclusionProofJSON struct {
+	Key          string          `json:"key"`
+	BranchFactor BranchFactor    `json:"branchFactor"`
+	Hasher       string          `json:"hasher"`
+	Proof        []proofNodeJSON `json:"proof"`
+}
+
+type proofNodeJSON struct {
+	KeyLength uint64 `json:"keyLength"`
+	Key       string `json:"key"`
+	// nil if the node has no value.
+	ValueOrHash *string           `json:"valueOrHash"`
+	Children    map[uint32]ids.ID `json:"children"`
+}
+
+// MarshalJSON encodes [proof] with byte slices in hex.
+// Returns [ErrNoProof] if [proof.Path] is empty, since the branch factor
+// can't be determined.
+func (proof *ExclusionProof) MarshalJSON() ([]byte, error) {
+	if len(proof.Path) == 0 {
+		return nil, ErrNoProof
+	}
+
+	key, err := formatting.Encode(formatting.HexNC, proof.Key)
+	if err != nil {
+		return nil, err
+	}
+	proofJSON := exclusionProofJSON{
+		Key:          key,
+		BranchFactor: proof.Path[0].KeyPath.branchFactor,
+		Hasher:       hasherOrDefault(proof.Hasher).Name(),
+		Proof:        make([]proofNodeJSON, len(proof.Path)),
+	}
+	for i, node := range proof.Path {
+		nodeJSON := &proofJSON.Proof[i]
+		nodeJSON.KeyLength = uint64(node.KeyPath.tokensLength)
+		nodeJSON.Key, err = formatting.Encode(formatting.HexNC, node.KeyPath.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		if node.ValueOrHash.HasValue() {
+			valueOrHash, err := formatting.Encode(formatting.HexNC, node.ValueOrHash.Value())
+			if err != nil {
+				return nil, err
+			}
+			nodeJSON.ValueOrHash = &valueOrHash
+		}
+		nodeJSON.Children = make(map[uint32]ids.ID, len(node.Children))
+		for index, childID := range node.Children {
+			nodeJSON.Children[uint32(index)] = childID
+		}
+	}
+	return json.Marshal(proofJSON)
+}
+
+// UnmarshalJSON decodes a proof encoded by [MarshalJSON].
+func (proof *ExclusionProof) UnmarshalJSON(b []byte) error {
+	var proofJSON exclusionProofJSON
+	if err := json.Unmarshal(b, &proofJSON); err != nil {
+		return err
+	}
+	if err := proofJSON.BranchFactor.Valid(); err != nil {
+		return err
+	}
+
+	// The nodes are converted to their proto representation so that they are
+	// validated the same way as proofs received over the network.
+	pbProof := &pb.ExclusionProof{
+		Proof:  make([]*pb.ProofNode, len(proofJSON.Proof)),
+		Hasher: proofJSON.Hasher,
+	}
+	var err error
+	pbProof.Key, err = formatting.Decode(formatting.HexNC, proofJSON.Key)
+	if err != nil {
+		return err
+	}
+	for i, nodeJSON := range proofJSON.Proof {
+		pbNode := &pb.ProofNode{
+			Key: &pb.Path{
+				Length: nodeJSON.KeyLength,
+			},
+			ValueOrHash: &pb.MaybeBytes{
+				IsNothing: nodeJSON.ValueOrHash == nil,
+			},
+			Children: make(map[uint32][]byte, len(nodeJSON.Children)),
+		}
+		pbNode.Key.Value, err = formatting.Decode(formatting.HexNC, nodeJSON.Key)
+		if err != nil {
+			return err
+		}
+		if nodeJSON.ValueOrHash != nil {
+			pbNode.ValueOrHash.Value, err = formatting.Decode(formatting.HexNC, *nodeJSON.ValueOrHash)
+			if err != nil {
+				return err
+			}
+		}
+		for index, childID := range nodeJSON.Children {
+			childID := childID
+			pbNode.Children[index] = childID[:]
+		}
+		pbProof.Proof[i] = pbNode
+	}
+	return proof.UnmarshalProto(pbProof, proofJSON.BranchFactor)
+}
+
+// A proof of the values of a set of keys, which may be unrelated.
+// Nodes shared by the proofs of multiple keys are only included once.
+type MultiProof struct {
+	// The union of the proofs of each key in [KeyValues], sorted by
+	// increasing key path.
+	// Must always be non-empty (i.e. have the root node).
+	Path []ProofNode
+
+	// The proven keys, sorted by increasing key.
+	// Each value is Nothing if the key isn't in the trie.
+	KeyValues []KeyChange
+
+	// The hasher used to generate the proof.
+	// If nil, [SHA256Hasher] is used.
+	Hasher Hasher
+}
+
+// Returns nil if the trie given in [proof] has root [expectedRootID].
+// That is, this is a valid proof that each key in [proof.KeyValues]
+// has the given value, or doesn't exist if the value is Nothing,
+// in the trie with root [expectedRootID].
+func (proof *MultiProof) Verify(ctx context.Context, expectedRootID ids.ID) error {
+	// Make sure the proof is well-formed.
+	if len(proof.Path) == 0 {
+		return ErrNoProof
+	}
+	if err := verifyKeyChanges(proof.KeyValues, maybe.Nothing[[]byte](), maybe.Nothing[[]byte]()); err != nil {
+		return err
+	}
+
+	hasher := hasherOrDefault(proof.Hasher)
+	branchFactor := proof.Path[0].KeyPath.branchFactor
+	nodes := make(map[Path]*ProofNode, len(proof.Path))
+	for i := range proof.Path {
+		node := &proof.Path[i]
+		if node.KeyPath.branchFactor != branchFactor {
+			return ErrInconsistentBranchFactor
+		}
+		if i > 0 && !proof.Path[i-1].KeyPath.Less(node.KeyPath) {
+			return ErrUnsortedProofNodes
+		}
+		// Because the interface only supports []byte keys,
+		// a key with a partial byte can't have a value.
+		if node.KeyPath.hasPartialByte() && node.ValueOrHash.HasValue() {
+			return ErrPartialByteLengthWithValue
+		}
+		nodes[node.KeyPath] = node
+	}
+
+	for _, keyValue := range proof.KeyValues {
+		keyPath := NewPath(keyValue.Key, branchFactor)
+
+		// If there is a proof node for the key, its value must match.
+		if node, ok := nodes[keyPath]; ok {
+			if !valueOrHashMatches(hasher, keyValue.Value, node.ValueOrHash) {
+				return ErrProofValueDoesntMatch
+			}
+			continue
+		}
+
+		// Otherwise, this must prove that the key isn't in the trie.
+		if keyValue.Value.HasValue() {
+			return ErrProofValueDoesntMatch
+		}
+
+		// Find the node closest to where the key would be if it existed.
+		closestNode, ok := closestProofNode(nodes, keyPath)
+		if !ok {
+			return ErrProofNodeNotForKey
+		}
+
+		// If the closest node has a child at the index where the key would be,
+		// the child must be included to show that the key isn't below it.
+		nextIndex := keyPath.Token(closestNode.KeyPath.tokensLength)
+		if _, ok := closestNode.Children[nextIndex]; !ok {
+			continue
+		}
+		childPrefix := closestNode.KeyPath.Append(nextIndex)
+		childIndex := sort.Search(len(proof.Path), func(i int) bool {
+			return !proof.Path[i].KeyPath.Less(childPrefix)
+		})
+		if childIndex == len(proof.Path) || !proof.Path[childIndex].KeyPath.HasPrefix(childPrefix) {
+			return ErrMissingExclusionNode
+		}
+	}
+
+	// Don't bother locking [view] -- nobody else has a reference to it.
+	view, err := getStandaloneTrieView(ctx, nil, branchFactor, hasher)
+	if err != nil {
+		return err
+	}
+	if err := addMultiProofPathInfo(view, proof.Path); err != nil {
+		return err
+	}
+
+	gotRootID, err := view.GetMerkleRoot(ctx)
+	if err != nil {
+		return err
+	}
+	if expectedRootID != gotRootID {
+		return fmt.Errorf("%w:[%s], expected:[%s]", ErrInvalidProof, gotRootID, expectedRootID)
+	}
+	return nil
+}
+
+func (proof *MultiProof) ToProto() *pb.MultiProof {
+	pbProof := &pb.MultiProof{
+		Proof:     make([]*pb.ProofNode, len(proof.Path)),
+		KeyValues: make([]*pb.KeyChange, len(proof.KeyValues)),
+		Hasher:    hasherOrDefault(proof.Hasher).Name(),
+	}
+	for i, node := range proof.Path {
+		pbProof.Proof[i] = node.ToProto()
+	}
+	for i, kv := range proof.KeyValues {
+		pbProof.KeyValues[i] = &pb.KeyChange{
+			Key: kv.Key,
+			Value: &pb.MaybeBytes{
+				Value:     kv.Value.Value(),
+				IsNothing: kv.Value.IsNothing(),
+			},
+		}
+	}
+	return pbProof
+}
+
+func (proof *MultiProof) UnmarshalProto(pbProof *pb.MultiProof, bf BranchFactor) error {
+	if pbProof == nil {
+		return ErrNilMultiProof
+	}
+
+	hasher, err := HasherFromName(pbProof.Hasher)
+	if err != nil {
+		return err
+	}
+	proof.Hasher = hasher
+
+	proof.Path = make([]ProofNode, len(pbProof.Proof))
+	for i, pbNode := range pbProof.Proof {
+		if err := proof.Path[i].UnmarshalProto(pbNode, bf); err != nil {
+			return err
+		}
+	}
+
+	proof.KeyValues = make([]KeyChange, len(pbProof.KeyValues))
+	for i, kv := range pbProof.KeyValues {
+		if kv.Value == nil {
+			return ErrNilMaybeBytes
+		}
+
+		if kv.Value.IsNothing && len(kv.Value.Value) != 0 {
+			return ErrInvalidMaybe
+		}
+
+		value := maybe.Nothing[[]byte]()
+		if !kv.Value.IsNothing {
+			value = maybe.Some(kv.Value.Value)
+		}
+		proof.KeyValues[i] = KeyChange{
+			Key:   kv.Key,
+			Value: value,
+		}
+	}
+
+	return nil
+}
+
+// Bytes returns the canonical serialization of [proof].
+func (proof *MultiProof) Bytes() []byte {
+	return codec.encodeMultiProof(proof)
+}
+
+// ParseMultiProof returns the multi proof serialized in [b] by
+// [MultiProof.Bytes]. The keys in the proof must have branch factor [bf].
+func ParseMultiProof(b []byte, bf BranchFactor) (*MultiProof, error) {
+	proof := &MultiProof{}
+	if err := codec.decodeMultiProof(b, proof, bf); err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+// Returns the node in [nodes] with the longest key that is a prefix of [key].
+// Returns false if no node in [nodes] has a key that is a prefix of [key].
+func closestProofNode(nodes map[Path]*ProofNode, key Path) (*ProofNode, bool) {
+	for length := key.tokensLength; length >= 0; length-- {
+		if node, ok := nodes[key.Take(length)]; ok {
+			return node, true
+		}
+	}
+	return nil, false
+}
+
 type KeyValue struct {
 	Key   []byte
 	Value []byte
@@ -270,6 +671,10 @@ type RangeProof struct {
 	// This proof proves that the key-value pairs in [KeyValues] are in the trie.
 	// Sorted by increasing key.
 	KeyValues []KeyValue
+
+	// The hasher used to generate the proof.
+	// If nil, [SHA256Hasher] is used.
+	Hasher Hasher
 }
 
 // Verify returns nil iff all the following hold:
@@ -301,6 +706,8 @@ func (proof *RangeProof) Verify(
 		return ErrNoEndProof
 	}
 
+	hasher := hasherOrDefault(proof.Hasher)
+
 	// determine branch factor based on proof paths
 	var branchFactor BranchFactor
 	if len(proof.StartProof) > 0 {
@@ -348,6 +755,7 @@ func (proof *RangeProof) Verify(
 		return err
 	}
 	if err := verifyAllRangeProofKeyValuesPresent(
+		hasher,
 		proof.StartProof,
 		smallestProvenPath,
 		largestProvenPath,
@@ -362,6 +770,7 @@ func (proof *RangeProof) Verify(
 		return err
 	}
 	if err := verifyAllRangeProofKeyValuesPresent(
+		hasher,
 		proof.EndProof,
 		smallestProvenPath,
 		largestProvenPath,
@@ -380,7 +789,7 @@ func (proof *RangeProof) Verify(
 	}
 
 	// Don't need to lock [view] because nobody else has a reference to it.
-	view, err := getStandaloneTrieView(ctx, ops, branchFactor)
+	view, err := getStandaloneTrieView(ctx, ops, branchFactor, hasher)
 	if err != nil {
 		return err
 	}
@@ -441,6 +850,7 @@ func (proof *RangeProof) ToProto() *pb.RangeProof {
 		StartProof: startProof,
 		EndProof:   endProof,
 		KeyValues:  keyValues,
+		Hasher:     hasherOrDefault(proof.Hasher).Name(),
 	}
 }
 
@@ -449,6 +859,12 @@ func (proof *RangeProof) UnmarshalProto(pbProof *pb.RangeProof, bf BranchFactor)
 		return ErrNilRangeProof
 	}
 
+	hasher, err := HasherFromName(pbProof.Hasher)
+	if err != nil {
+		return err
+	}
+	proof.Hasher = hasher
+
 	proof.StartProof = make([]ProofNode, len(pbProof.StartProof))
 	for i, protoNode := range pbProof.StartProof {
 		if err := proof.StartProof[i].UnmarshalProto(protoNode, bf); err != nil {
@@ -476,7 +892,7 @@ func (proof *RangeProof) UnmarshalProto(pbProof *pb.RangeProof, bf BranchFactor)
 
 // Verify that all non-intermediate nodes in [proof] which have keys
 // in [[start], [end]] have the value given for that key in [keysValues].
-func verifyAllRangeProofKeyValuesPresent(proof []ProofNode, start maybe.Maybe[Path], end maybe.Maybe[Path], keysValues map[Path][]byte) error {
+func verifyAllRangeProofKeyValuesPresent(hasher Hasher, proof []ProofNode, start maybe.Maybe[Path], end maybe.Maybe[Path], keysValues map[Path][]byte) error {
 	for i := 0; i < len(proof); i++ {
 		var (
 			node     = proof[i]
@@ -490,7 +906,7 @@ func verifyAllRangeProofKeyValuesPresent(proof []ProofNode, start maybe.Maybe[Pa
 				// We didn't get a key-value pair for this key, but the proof node has a value.
 				return ErrProofNodeHasUnincludedValue
 			}
-			if ok && !valueOrHashMatches(maybe.Some(value), node.ValueOrHash) {
+			if ok && !valueOrHashMatches(hasher, maybe.Some(value), node.ValueOrHash) {
 				// We got a key-value pair for this key, but the value in the proof
 				// node doesn't match the value we got for this key.
 				return ErrProofValueDoesntMatch
@@ -565,6 +981,10 @@ type ChangeProof struct {
 	// [kv0, kv1] (For some kv1 < start)
 	// [kv1, kv2, kv3, kv4, kv5, kv6] (For some kv6 > end)
 	KeyChanges []KeyChange
+
+	// The hasher used to generate the proof.
+	// If nil, [SHA256Hasher] is used.
+	Hasher Hasher
 }
 
 func (proof *ChangeProof) ToProto() *pb.ChangeProof {
@@ -593,6 +1013,7 @@ func (proof *ChangeProof) ToProto() *pb.ChangeProof {
 		StartProof: startProof,
 		EndProof:   endProof,
 		KeyChanges: keyChanges,
+		Hasher:     hasherOrDefault(proof.Hasher).Name(),
 	}
 }
 
@@ -601,6 +1022,12 @@ func (proof *ChangeProof) UnmarshalProto(pbProof *pb.ChangeProof, bf BranchFacto
 		return ErrNilChangeProof
 	}
 
+	hasher, err := HasherFromName(pbProof.Hasher)
+	if err != nil {
+		return err
+	}
+	proof.Hasher = hasher
+
 	proof.StartProof = make([]ProofNode, len(pbProof.StartProof))
 	for i, protoNode := range pbProof.StartProof {
 		if err := proof.StartProof[i].UnmarshalProto(protoNode, bf); err != nil {
@@ -644,6 +1071,7 @@ func (proof *ChangeProof) UnmarshalProto(pbProof *pb.ChangeProof, bf BranchFacto
 func verifyAllChangeProofKeyValuesPresent(
 	ctx context.Context,
 	db MerkleDB,
+	hasher Hasher,
 	proof []ProofNode,
 	start maybe.Maybe[Path],
 	end maybe.Maybe[Path],
@@ -673,7 +1101,7 @@ func verifyAllChangeProofKeyValuesPresent(
 					value = maybe.Some(dbValue)
 				}
 			}
-			if !valueOrHashMatches(value, node.ValueOrHash) {
+			if !valueOrHashMatches(hasher, value, node.ValueOrHash) {
 				return ErrProofValueDoesntMatch
 			}
 		}
@@ -791,9 +1219,38 @@ func verifyProofPath(proof []ProofNode, keyPath maybe.Maybe[Path]) error {
 	return nil
 }
 
+// Returns nil iff [proof] shows that there is no node with key [keyPath].
+// Assumes [proof] is non-empty, passes [verifyProofPath] for [keyPath],
+// and that its last node's key isn't [keyPath].
+// That is, either the last node is the closest ancestor of [keyPath] and has
+// no child where [keyPath] would be, or the last node is that child.
+func verifyExclusionProofPath(proof []ProofNode, keyPath Path) error {
+	lastNode := proof[len(proof)-1]
+	if keyPath.HasStrictPrefix(lastNode.KeyPath) {
+		nextIndex := keyPath.Token(lastNode.KeyPath.tokensLength)
+		if _, ok := lastNode.Children[nextIndex]; ok {
+			return ErrMissingExclusionNode
+		}
+		return nil
+	}
+
+	// The root is a prefix of every key, so if the last node isn't a prefix
+	// of [keyPath], there is a node before it.
+	if len(proof) < 2 {
+		return ErrProofNodeNotForKey
+	}
+	closestNode := proof[len(proof)-2]
+	nextIndex := keyPath.Token(closestNode.KeyPath.tokensLength)
+	if !lastNode.KeyPath.HasPrefix(closestNode.KeyPath.Append(nextIndex)) {
+		return ErrProofNodeNotForKey
+	}
+	return nil
+}
+
 // Returns true if [value] and [valueDigest] match.
 // [valueOrHash] should be the [ValueOrHash] field of a [ProofNode].
-func valueOrHashMatches(value maybe.Maybe[[]byte], valueOrHash maybe.Maybe[[]byte]) bool {
+// [hasher] is used to calculate the digest of [value].
+func valueOrHashMatches(hasher Hasher, value maybe.Maybe[[]byte], valueOrHash maybe.Maybe[[]byte]) bool {
 	var (
 		valueIsNothing  = value.IsNothing()
 		digestIsNothing = valueOrHash.IsNothing()
@@ -809,8 +1266,8 @@ func valueOrHashMatches(value maybe.Maybe[[]byte], valueOrHash maybe.Maybe[[]byt
 	case len(value.Value()) < HashLength:
 		return bytes.Equal(value.Value(), valueOrHash.Value())
 	default:
-		valueHash := hashing.ComputeHash256(value.Value())
-		return bytes.Equal(valueHash, valueOrHash.Value())
+		valueHash := hasher.Hash(value.Value())
+		return bytes.Equal(valueHash[:], valueOrHash.Value())
 	}
 }
 
@@ -880,8 +1337,49 @@ func addPathInfo(
 	return nil
 }
 
+// Adds each node in [proofPath], which must be sorted by increasing key path,
+// to [t]. For each proof node, adds the children that aren't the ancestor of
+// another proof node.
+// Assumes [t.lock] is held.
+func addMultiProofPathInfo(t *trieView, proofPath []ProofNode) error {
+	// Nodes are added in decreasing order so that a node's descendants in
+	// the proof are added before it.
+	for i := len(proofPath) - 1; i >= 0; i-- {
+		proofNode := proofPath[i]
+		keyPath := proofNode.KeyPath
+
+		// load the node associated with the key or create a new one
+		// pass nothing because we are going to overwrite the value digest below
+		n, err := t.insert(keyPath, maybe.Nothing[[]byte]())
+		if err != nil {
+			return err
+		}
+		// We overwrite the valueDigest to be the hash provided in the proof
+		// node because we may not know the pre-image of the valueDigest.
+		n.valueDigest = proofNode.ValueOrHash
+
+		// Children that were created by adding a descendant in the proof
+		// must not be overwritten, since their IDs are calculated from the
+		// proof rather than taken from it.
+		for index, childID := range proofNode.Children {
+			if _, ok := n.children[index]; ok {
+				continue
+			}
+			// We didn't set the other values on the child entry, but it doesn't matter.
+			// We only need the IDs to be correct so that the calculated hash is correct.
+			n.setChildEntry(
+				index,
+				child{
+					id:             childID,
+					compressedPath: emptyPath(keyPath.branchFactor),
+				})
+		}
+	}
+	return nil
+}
+
 // getStandaloneTrieView returns a new view that has nothing in it besides the changes due to [ops]
-func getStandaloneTrieView(ctx context.Context, ops []database.BatchOp, factor BranchFactor) (*trieView, error) {
+func getStandaloneTrieView(ctx context.Context, ops []database.BatchOp, factor BranchFactor, hasher Hasher) (*trieView, error) {
 	db, err := newDatabase(
 		ctx,
 		memdb.New(),
@@ -891,6 +1389,7 @@ func getStandaloneTrieView(ctx context.Context, ops []database.BatchOp, factor B
 			ValueNodeCacheSize:        verificationCacheSize,
 			IntermediateNodeCacheSize: verificationCacheSize,
 			BranchFactor:              factor,
+			Hasher:                    hasher,
 		},
 		&mockMetrics{},
 	)
@@ -6,6 +6,7 @@ package merkledb
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"math/rand"
 	"testing"
 	"time"
@@ -95,6 +96,16 @@ func Test_Proof_Verify_Bad_Data(t *testing.T) {
 			},
 			expectedErr: ErrProofValueDoesntMatch,
 		},
+		{
+			name: "exclusion proof missing node",
+			malform: func(proof *Proof) {
+				// remove the value node, which is the child of the last
+				// remaining node where the key is
+				proof.Path = proof.Path[:len(proof.Path)-1]
+				proof.Value = maybe.Nothing[[]byte]()
+			},
+			expectedErr: ErrMissingExclusionNode,
+		},
 	}
 
 	for _, tt := range tests {
@@ -121,14 +132,14 @@ func Test_Proof_Verify_Bad_Data(t *testing.T) {
 func Test_Proof_ValueOrHashMatches(t *testing.T) {
 	require := require.New(t)
 
-	require.True(valueOrHashMatches(maybe.Some([]byte{0}), maybe.Some([]byte{0})))
-	require.False(valueOrHashMatches(maybe.Nothing[[]byte](), maybe.Some(hashing.ComputeHash256([]byte{0}))))
-	require.True(valueOrHashMatches(maybe.Nothing[[]byte](), maybe.Nothing[[]byte]()))
+	require.True(valueOrHashMatches(SHA256Hasher, maybe.Some([]byte{0}), maybe.Some([]byte{0})))
+	require.False(valueOrHashMatches(SHA256Hasher, maybe.Nothing[[]byte](), maybe.Some(hashing.ComputeHash256([]byte{0}))))
+	require.True(valueOrHashMatches(SHA256Hasher, maybe.Nothing[[]byte](), maybe.Nothing[[]byte]()))
 
-	require.False(valueOrHashMatches(maybe.Some([]byte{0}), maybe.Nothing[[]byte]()))
-	require.False(valueOrHashMatches(maybe.Nothing[[]byte](), maybe.Some([]byte{0})))
-	require.False(valueOrHashMatches(maybe.Nothing[[]byte](), maybe.Some(hashing.ComputeHash256([]byte{1}))))
-	require.False(valueOrHashMatches(maybe.Some(hashing.ComputeHash256([]byte{0})), maybe.Nothing[[]byte]()))
+	require.False(valueOrHashMatches(SHA256Hasher, maybe.Some([]byte{0}), maybe.Nothing[[]byte]()))
+	require.False(valueOrHashMatches(SHA256Hasher, maybe.Nothing[[]byte](), maybe.Some([]byte{0})))
+	require.False(valueOrHashMatches(SHA256Hasher, maybe.Nothing[[]byte](), maybe.Some(hashing.ComputeHash256([]byte{1}))))
+	require.False(valueOrHashMatches(SHA256Hasher, maybe.Some(hashing.ComputeHash256([]byte{0})), maybe.Nothing[[]byte]()))
 }
 
 func Test_RangeProof_Extra_Value(t *testing.T) {
@@ -287,6 +298,322 @@ func Test_Proof(t *testing.T) {
 	require.ErrorIs(err, ErrInvalidProof)
 }
 
+func Test_ExclusionProof(t *testing.T) {
+	require := require.New(t)
+
+	db, err := getBasicDB()
+	require.NoError(err)
+
+	writeBasicBatch(t, db)
+
+	ctx := context.Background()
+	expectedRootID, err := db.GetMerkleRoot(ctx)
+	require.NoError(err)
+
+	for _, key := range [][]byte{{}, {5}, {2, 1}, {255, 255}} {
+		proof, err := db.GetProof(ctx, key)
+		require.NoError(err)
+
+		exclusionProof, err := proof.ToExclusionProof()
+		require.NoError(err)
+		require.Equal(key, exclusionProof.Key)
+		require.NoError(exclusionProof.Verify(ctx, expectedRootID))
+
+		err = exclusionProof.Verify(ctx, ids.GenerateTestID())
+		require.ErrorIs(err, ErrInvalidProof)
+
+		var protoProof ExclusionProof
+		require.NoError(protoProof.UnmarshalProto(exclusionProof.ToProto(), BranchFactor16))
+		require.NoError(protoProof.Verify(ctx, expectedRootID))
+
+		proofJSON, err := json.Marshal(exclusionProof)
+		require.NoError(err)
+		var jsonProof ExclusionProof
+		require.NoError(json.Unmarshal(proofJSON, &jsonProof))
+		require.NoError(jsonProof.Verify(ctx, expectedRootID))
+	}
+}
+
+func Test_ExclusionProof_Present_Key(t *testing.T) {
+	require := require.New(t)
+
+	db, err := getBasicDB()
+	require.NoError(err)
+
+	writeBasicBatch(t, db)
+
+	ctx := context.Background()
+	expectedRootID, err := db.GetMerkleRoot(ctx)
+	require.NoError(err)
+
+	proof, err := db.GetProof(ctx, []byte{2})
+	require.NoError(err)
+
+	_, err = proof.ToExclusionProof()
+	require.ErrorIs(err, ErrKeyPresent)
+
+	exclusionProof := &ExclusionProof{
+		Key:  []byte{2},
+		Path: proof.Path,
+	}
+	err = exclusionProof.Verify(ctx, expectedRootID)
+	require.ErrorIs(err, ErrProofValueDoesntMatch)
+
+	exclusionProof.Path = proof.Path[:len(proof.Path)-1]
+	err = exclusionProof.Verify(ctx, expectedRootID)
+	require.ErrorIs(err, ErrMissingExclusionNode)
+}
+
+func Test_ExclusionProof_Invalid_Encoding(t *testing.T) {
+	require := require.New(t)
+
+	_, err := json.Marshal(&ExclusionProof{})
+	require.ErrorIs(err, ErrNoProof)
+
+	var proof ExclusionProof
+	err = json.Unmarshal([]byte(`{"key":"0x","branchFactor":3,"proof":[]}`), &proof)
+	require.ErrorIs(err, errInvalidBranchFactor)
+
+	err = proof.UnmarshalProto(nil, BranchFactor16)
+	require.ErrorIs(err, ErrNilExclusionProof)
+}
+
+func Test_MultiProof(t *testing.T) {
+	require := require.New(t)
+
+	db, err := getBasicDB()
+	require.NoError(err)
+
+	ctx := context.Background()
+	for _, key := range []string{"key0", "key1", "key10", "key2", "other"} {
+		require.NoError(db.PutContext(ctx, []byte(key), []byte("value_"+key)))
+	}
+	expectedRootID, err := db.GetMerkleRoot(ctx)
+	require.NoError(err)
+
+	// Keys are unsorted and include duplicates and keys that aren't in the db.
+	proof, err := db.GetMultiProof(ctx, [][]byte{
+		[]byte("key2"),
+		[]byte("key1"),
+		[]byte("key"),
+		[]byte("key3"),
+		[]byte("key1"),
+		[]byte("o"),
+	})
+	require.NoError(err)
+	require.Equal(
+		[]KeyChange{
+			{Key: []byte("key"), Value: maybe.Nothing[[]byte]()},
+			{Key: []byte("key1"), Value: maybe.Some([]byte("value_key1"))},
+			{Key: []byte("key2"), Value: maybe.Some([]byte("value_key2"))},
+			{Key: []byte("key3"), Value: maybe.Nothing[[]byte]()},
+			{Key: []byte("o"), Value: maybe.Nothing[[]byte]()},
+		},
+		proof.KeyValues,
+	)
+	require.NoError(proof.Verify(ctx, expectedRootID))
+
+	// Nodes shared by the proofs of multiple keys are only included once.
+	numNodes := 0
+	for _, keyValue := range proof.KeyValues {
+		keyProof, err := db.GetProof(ctx, keyValue.Key)
+		require.NoError(err)
+		numNodes += len(keyProof.Path)
+	}
+	require.Less(len(proof.Path), numNodes)
+
+	// A proof of no keys proves the root.
+	emptyProof, err := db.GetMultiProof(ctx, nil)
+	require.NoError(err)
+	require.Len(emptyProof.Path, 1)
+	require.Empty(emptyProof.KeyValues)
+	require.NoError(emptyProof.Verify(ctx, expectedRootID))
+
+	err = proof.Verify(ctx, ids.GenerateTestID())
+	require.ErrorIs(err, ErrInvalidProof)
+}
+
+func Test_MultiProof_Verify_Bad_Data(t *testing.T) {
+	type test struct {
+		name        string
+		malform     func(proof *MultiProof)
+		expectedErr error
+	}
+
+	tests := []test{
+		{
+			name:        "happyPath",
+			malform:     func(proof *MultiProof) {},
+			expectedErr: nil,
+		},
+		{
+			name: "empty",
+			malform: func(proof *MultiProof) {
+				proof.Path = nil
+			},
+			expectedErr: ErrNoProof,
+		},
+		{
+			name: "unsorted proof nodes",
+			malform: func(proof *MultiProof) {
+				proof.Path[0], proof.Path[1] = proof.Path[1], proof.Path[0]
+			},
+			expectedErr: ErrUnsortedProofNodes,
+		},
+		{
+			name: "duplicate proof nodes",
+			malform: func(proof *MultiProof) {
+				proof.Path[1] = proof.Path[0]
+			},
+			expectedErr: ErrUnsortedProofNodes,
+		},
+		{
+			name: "unsorted keys",
+			malform: func(proof *MultiProof) {
+				proof.KeyValues[0], proof.KeyValues[1] = proof.KeyValues[1], proof.KeyValues[0]
+			},
+			expectedErr: ErrNonIncreasingValues,
+		},
+		{
+			name: "wrong value",
+			malform: func(proof *MultiProof) {
+				proof.KeyValues[0].Value = maybe.Some([]byte{1})
+			},
+			expectedErr: ErrProofValueDoesntMatch,
+		},
+		{
+			name: "value for absent key",
+			malform: func(proof *MultiProof) {
+				proof.KeyValues = append(proof.KeyValues, KeyChange{
+					Key:   []byte{255, 255, 255},
+					Value: maybe.Some([]byte{1}),
+				})
+			},
+			expectedErr: ErrProofValueDoesntMatch,
+		},
+		{
+			name: "wrong value or hash",
+			malform: func(proof *MultiProof) {
+				proof.Path[0].ValueOrHash = maybe.Some([]byte{1})
+			},
+			expectedErr: ErrInvalidProof,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			db, err := getBasicDB()
+			require.NoError(err)
+
+			writeBasicBatch(t, db)
+
+			proof, err := db.GetMultiProof(context.Background(), [][]byte{{0}, {1}, {2}, {3}})
+			require.NoError(err)
+			require.NotNil(proof)
+
+			tt.malform(proof)
+
+			err = proof.Verify(context.Background(), db.getMerkleRoot())
+			require.ErrorIs(err, tt.expectedErr)
+		})
+	}
+}
+
+func Test_MultiProof_Missing_Exclusion_Node(t *testing.T) {
+	require := require.New(t)
+
+	db, err := getBasicDB()
+	require.NoError(err)
+
+	ctx := context.Background()
+	require.NoError(db.PutContext(ctx, []byte{0x00}, []byte{1}))
+	require.NoError(db.PutContext(ctx, []byte{0x11, 0x11}, []byte{2}))
+	require.NoError(db.PutContext(ctx, []byte{0x11, 0x12}, []byte{3}))
+	expectedRootID, err := db.GetMerkleRoot(ctx)
+	require.NoError(err)
+
+	// [0x10] isn't in the trie, but the child of the root at index 1 is
+	// [0x11], which is included to prove it.
+	proof, err := db.GetMultiProof(ctx, [][]byte{{0x10}})
+	require.NoError(err)
+	require.Len(proof.Path, 2)
+	require.NoError(proof.Verify(ctx, expectedRootID))
+
+	proof.Path = proof.Path[:1]
+	err = proof.Verify(ctx, expectedRootID)
+	require.ErrorIs(err, ErrMissingExclusionNode)
+}
+
+func Test_MultiProof_Random(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Now().UnixNano()
+	t.Logf("seed: %d", now)
+	r := rand.New(rand.NewSource(now)) // #nosec G404
+
+	db, err := getBasicDB()
+	require.NoError(err)
+
+	ctx := context.Background()
+	values := make(map[string][]byte)
+	for i := 0; i < 500; i++ {
+		key := make([]byte, r.Intn(4))
+		_, _ = r.Read(key)
+		value := make([]byte, r.Intn(64))
+		_, _ = r.Read(value)
+		values[string(key)] = value
+		require.NoError(db.PutContext(ctx, key, value))
+	}
+	expectedRootID, err := db.GetMerkleRoot(ctx)
+	require.NoError(err)
+
+	for i := 0; i < 25; i++ {
+		keys := make([][]byte, r.Intn(32))
+		for j := range keys {
+			keys[j] = make([]byte, r.Intn(4))
+			_, _ = r.Read(keys[j])
+		}
+
+		proof, err := db.GetMultiProof(ctx, keys)
+		require.NoError(err)
+		require.NoError(proof.Verify(ctx, expectedRootID))
+		for _, keyValue := range proof.KeyValues {
+			value, ok := values[string(keyValue.Key)]
+			require.Equal(ok, keyValue.Value.HasValue())
+			require.Equal(value, keyValue.Value.Value())
+		}
+
+		var unmarshaledProof MultiProof
+		require.NoError(unmarshaledProof.UnmarshalProto(proof.ToProto(), BranchFactor16))
+		require.NoError(unmarshaledProof.Verify(ctx, expectedRootID))
+
+		parsedProof, err := ParseMultiProof(proof.Bytes(), BranchFactor16)
+		require.NoError(err)
+		require.NoError(parsedProof.Verify(ctx, expectedRootID))
+	}
+}
+
+func TestMultiProofUnmarshalProtoNil(t *testing.T) {
+	var proof MultiProof
+	err := proof.UnmarshalProto(nil, BranchFactor16)
+	require.ErrorIs(t, err, ErrNilMultiProof)
+}
+
+func TestMultiProofUnmarshalProtoNilValue(t *testing.T) {
+	var proof MultiProof
+	err := proof.UnmarshalProto(
+		&pb.MultiProof{
+			KeyValues: []*pb.KeyChange{
+				{Key: []byte{1}},
+			},
+		},
+		BranchFactor16,
+	)
+	require.ErrorIs(t, err, ErrNilMaybeBytes)
+}
+
 func Test_RangeProof_Syntactic_Verify(t *testing.T) {
 	type test struct {
 		name        string
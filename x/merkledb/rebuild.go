@@ -0,0 +1,399 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+
+	"golang.org/x/exp/maps"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const (
+	// TODO: name better
+	rebuildViewSizeFractionOfCacheSize   = 50
+	minRebuildViewSizePerCommit          = 1000
+	rebuildIntermediateDeletionWriteSize = units.MiB
+)
+
+var (
+	// rebuildCheckpointKey records the progress of a rebuild so that it can be
+	// resumed after the database is reopened.
+	rebuildCheckpointKey = []byte(string(metadataPrefix) + "rebuildCheckpoint")
+
+	errRebuilding               = errors.New("rebuilding intermediate nodes")
+	errInvalidRebuildCheckpoint = errors.New("invalid rebuild checkpoint")
+)
+
+// rebuildStatus is reported by [merkleDB.HealthCheck] while the intermediate
+// nodes are being rebuilt.
+type rebuildStatus struct {
+	// KeysReused is the number of keys whose intermediate nodes were rebuilt
+	// before the database was reopened.
+	KeysReused uint64 `json:"keysReused"`
+	// KeysRebuilt is the number of keys whose intermediate nodes were rebuilt
+	// since the database was opened.
+	KeysRebuilt uint64 `json:"keysRebuilt"`
+}
+
+// rebuildInBackground rebuilds the intermediate nodes in a new goroutine.
+// Values can be read during the rebuild, but operations that read or modify
+// the trie wait until it completes.
+// Assumes the database isn't being accessed concurrently.
+func (db *merkleDB) rebuildInBackground(cacheSize int) {
+	ctx, cancel := context.WithCancel(context.Background())
+	db.rebuildCancel = cancel
+	db.rebuildDone = make(chan struct{})
+	db.rebuilding = true
+
+	// Released once the intermediate nodes have been rebuilt.
+	db.commitLock.Lock()
+	go func() {
+		defer close(db.rebuildDone)
+
+		err := db.rebuild(ctx, cacheSize)
+
+		db.lock.Lock()
+		defer db.lock.Unlock()
+
+		switch {
+		case err == nil:
+			db.commitLock.Unlock()
+		case errors.Is(err, context.Canceled):
+			// [db.Close] interrupted the rebuild and takes over
+			// [db.commitLock].
+			db.rebuildInterrupted = true
+		default:
+			// The trie is incomplete, so the database can't be used.
+			db.rebuildErr = err
+			db.closed = true
+			db.valueNodeDB.Close()
+			db.commitLock.Unlock()
+		}
+	}()
+}
+
+// interruptRebuild stops the background rebuild, if there is one, and waits
+// for it to return.
+// Returns true iff the rebuild was interrupted before it completed, in which
+// case the caller holds [db.commitLock].
+// Assumes [db.commitLock] and [db.lock] aren't held.
+func (db *merkleDB) interruptRebuild() bool {
+	db.rebuildCancel()
+	<-db.rebuildDone
+
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	interrupted := db.rebuildInterrupted
+	db.rebuildInterrupted = false
+	return interrupted
+}
+
+// Rebuilds the intermediate nodes by re-adding every key/value.
+// Progress is checkpointed after every batch of keys. If a checkpoint of a
+// previous rebuild exists, the trie it describes is verified and reused, and
+// only the keys after it are re-added.
+// Returns [ctx.Err()] if [ctx] is cancelled, in which case the rebuild can be
+// resumed from its last checkpoint.
+// Assumes [db.commitLock] is held.
+func (db *merkleDB) rebuild(ctx context.Context, cacheSize int) error {
+	db.lock.Lock()
+	db.rebuilding = true
+	db.rebuildStatus = rebuildStatus{}
+	db.lock.Unlock()
+
+	start, err := db.initializeRebuild()
+	if err != nil {
+		return err
+	}
+
+	// Add all key-value pairs after [start] back into the database.
+	opsSizeLimit := math.Max(
+		cacheSize/rebuildViewSizeFractionOfCacheSize,
+		minRebuildViewSizePerCommit,
+	)
+	currentOps := make([]database.BatchOp, 0, opsSizeLimit)
+	valueIt := db.NewIteratorWithStart(start)
+	defer func() {
+		valueIt.Release()
+	}()
+	for valueIt.Next() {
+		if len(currentOps) >= opsSizeLimit {
+			if err := db.commitRebuildOps(ctx, currentOps); err != nil {
+				return err
+			}
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			currentOps = make([]database.BatchOp, 0, opsSizeLimit)
+			// reset the iterator to prevent memory bloat
+			nextValue := valueIt.Key()
+			valueIt.Release()
+			valueIt = db.NewIteratorWithStart(nextValue)
+			continue
+		}
+
+		currentOps = append(currentOps, database.BatchOp{
+			Key:   valueIt.Key(),
+			Value: valueIt.Value(),
+		})
+	}
+	if err := valueIt.Error(); err != nil {
+		return err
+	}
+	if err := db.commitRebuildOps(ctx, currentOps); err != nil {
+		return err
+	}
+
+	db.lock.Lock()
+	err = db.finishRebuild()
+	db.lock.Unlock()
+	if err != nil {
+		return err
+	}
+	return db.Compact(nil, nil)
+}
+
+// initializeRebuild sets the trie to the one described by the rebuild
+// checkpoint, if it exists and is valid. Otherwise, every intermediate node is
+// deleted and the trie is reset to only contain the root.
+// Returns the key to continue the rebuild from.
+// Assumes [db.commitLock] is held.
+// Assumes [db.lock] isn't held.
+func (db *merkleDB) initializeRebuild() ([]byte, error) {
+	lastKey, err := db.restoreRebuildCheckpoint()
+	switch {
+	case err == nil:
+		// The smallest key after [lastKey].
+		return append(lastKey, 0), nil
+	case err != database.ErrNotFound && !errors.Is(err, errInvalidRebuildCheckpoint):
+		return nil, err
+	}
+
+	if err := db.baseDB.Delete(rebuildCheckpointKey); err != nil {
+		return nil, err
+	}
+
+	// Delete intermediate nodes.
+	if err := database.ClearPrefix(db.baseDB, intermediateNodePrefix, rebuildIntermediateDeletionWriteSize); err != nil {
+		return nil, err
+	}
+
+	// The root keeps its value so that it can be read during the rebuild.
+	root := newNode(nil, db.rootPath)
+	rootValueNode, err := db.valueNodeDB.Get(db.rootPath)
+	switch err {
+	case nil:
+		root.setValue(db.hasher, rootValueNode.value)
+	case database.ErrNotFound:
+	default:
+		return nil, err
+	}
+	root.calculateID(db.hasher, db.metrics)
+
+	db.lock.Lock()
+	db.root = root
+	db.lock.Unlock()
+	return nil, nil
+}
+
+// commitRebuildOps inserts [ops] into the trie and checkpoints the progress of
+// the rebuild.
+// Assumes [db.commitLock] is held.
+// Assumes [db.lock] isn't held.
+func (db *merkleDB) commitRebuildOps(ctx context.Context, ops []database.BatchOp) error {
+	if len(ops) == 0 {
+		return nil
+	}
+	lastKey := ops[len(ops)-1].Key
+
+	view, err := newTrieView(db, db, ViewChanges{BatchOps: ops, ConsumeBytes: true})
+	if err != nil {
+		return err
+	}
+	if err := view.commitToDB(ctx); err != nil {
+		return err
+	}
+
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	// The checkpoint may only reference intermediate nodes that are on disk.
+	if err := db.intermediateNodeDB.Flush(); err != nil {
+		return err
+	}
+	db.rebuildStatus.KeysRebuilt += uint64(len(ops))
+
+	rootID := db.getMerkleRoot()
+	checkpoint := make([]byte, ids.IDLen+wrappers.LongLen+len(lastKey))
+	copy(checkpoint, rootID[:])
+	binary.BigEndian.PutUint64(checkpoint[ids.IDLen:], db.rebuildStatus.KeysReused+db.rebuildStatus.KeysRebuilt)
+	copy(checkpoint[ids.IDLen+wrappers.LongLen:], lastKey)
+	return db.baseDB.Put(rebuildCheckpointKey, checkpoint)
+}
+
+// finishRebuild marks the rebuild as complete.
+// Assumes [db.lock] is held.
+func (db *merkleDB) finishRebuild() error {
+	// The changes recorded while rebuilding don't follow from the persisted
+	// changes, so the persisted changes can't be used to serve proofs.
+	if err := db.history.clearPersisted(); err != nil {
+		return err
+	}
+	if err := db.recordCurrentRoot(); err != nil {
+		return err
+	}
+	if err := db.baseDB.Delete(rebuildCheckpointKey); err != nil {
+		return err
+	}
+	db.rebuilding = false
+	return nil
+}
+
+// restoreRebuildCheckpoint sets the trie to the one that was committed when
+// the rebuild checkpoint was written.
+//
+// Every key in that trie is at most the last key in the checkpoint, so the
+// subtrees to the left of the path to the last key weren't modified after the
+// checkpoint was written. Their roots are verified against the IDs their
+// parents reference and reused as is. The nodes on the path to the last key
+// are restored by removing the children added after the checkpoint was
+// written, and the resulting root ID is verified against the checkpoint.
+//
+// Returns the last key in the restored trie.
+// Returns [database.ErrNotFound] if there is no checkpoint.
+// Returns [errInvalidRebuildCheckpoint] if the trie can't be restored.
+// Assumes [db.commitLock] is held.
+// Assumes [db.lock] isn't held.
+func (db *merkleDB) restoreRebuildCheckpoint() ([]byte, error) {
+	checkpoint, err := db.baseDB.Get(rebuildCheckpointKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(checkpoint) < ids.IDLen+wrappers.LongLen {
+		return nil, errInvalidRebuildCheckpoint
+	}
+	rootID, err := ids.ToID(checkpoint[:ids.IDLen])
+	if err != nil {
+		return nil, err
+	}
+	numKeys := binary.BigEndian.Uint64(checkpoint[ids.IDLen:])
+	lastKey := checkpoint[ids.IDLen+wrappers.LongLen:]
+
+	// The root is stored as a value node iff the empty key has a value.
+	root, err := db.valueNodeDB.Get(db.rootPath)
+	if err == database.ErrNotFound {
+		root, err = db.intermediateNodeDB.Get(db.rootPath)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	restored := make([]*node, 0, defaultPreallocationSize)
+	root, err = db.restoreCheckpointNode(root, db.newPath(lastKey), &restored)
+	if err != nil {
+		return nil, err
+	}
+	if root.id != rootID {
+		return nil, errInvalidRebuildCheckpoint
+	}
+
+	// Intermediate nodes that aren't part of the restored trie were created by
+	// keys after [lastKey], so they are overwritten as the rebuild continues.
+	valueNodeBatch := db.valueNodeDB.NewBatch()
+	for _, n := range restored {
+		if n.hasValue() {
+			valueNodeBatch.Put(n.key, n)
+			continue
+		}
+		if err := db.intermediateNodeDB.Put(n.key, n); err != nil {
+			return nil, err
+		}
+	}
+	if err := valueNodeBatch.Write(); err != nil {
+		return nil, err
+	}
+
+	db.lock.Lock()
+	db.root = root
+	db.rebuildStatus.KeysReused = numKeys
+	db.lock.Unlock()
+	return lastKey, nil
+}
+
+// restoreCheckpointNode returns a copy of [n], whose key is a prefix of
+// [lastKey], without the children that were added after the rebuild
+// checkpoint was written. The restored node and its restored descendants are
+// appended to [restored].
+// If [n] was added after the checkpoint was written, its restored child on the
+// path to [lastKey] is returned instead.
+// Assumes [db.commitLock] is held.
+func (db *merkleDB) restoreCheckpointNode(n *node, lastKey Path, restored *[]*node) (*node, error) {
+	n = n.clone()
+	n.onNodeChanged()
+
+	if n.key == lastKey {
+		if !n.hasValue() {
+			return nil, errInvalidRebuildCheckpoint
+		}
+		// Every descendant of [lastKey] is after it.
+		maps.Clear(n.children)
+		n.calculateID(db.hasher, db.metrics)
+		*restored = append(*restored, n)
+		return n, nil
+	}
+
+	token := lastKey.Token(n.key.tokensLength)
+	for index, entry := range n.children {
+		switch {
+		case index > token:
+			delete(n.children, index)
+		case index < token:
+			childNode, err := db.getNode(n.key.Append(index).Extend(entry.compressedPath), entry.hasValue)
+			if err != nil {
+				return nil, err
+			}
+			childNode.calculateID(db.hasher, db.metrics)
+			if childNode.id != entry.id {
+				return nil, errInvalidRebuildCheckpoint
+			}
+		}
+	}
+
+	entry, ok := n.children[token]
+	if !ok {
+		return nil, errInvalidRebuildCheckpoint
+	}
+	childKey := n.key.Append(token).Extend(entry.compressedPath)
+	if !lastKey.HasPrefix(childKey) {
+		return nil, errInvalidRebuildCheckpoint
+	}
+	childNode, err := db.getNode(childKey, entry.hasValue)
+	if err != nil {
+		return nil, err
+	}
+	childNode, err = db.restoreCheckpointNode(childNode, lastKey, restored)
+	if err != nil {
+		return nil, err
+	}
+
+	// [n] only had a single child when the checkpoint was written, so it
+	// wasn't part of the trie.
+	if n.key != db.rootPath && !n.hasValue() && len(n.children) == 1 {
+		return childNode, nil
+	}
+
+	n.addChild(childNode)
+	n.calculateID(db.hasher, db.metrics)
+	*restored = append(*restored, n)
+	return n, nil
+}
@@ -0,0 +1,263 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+const rebuildTestNumKeys = 5 * minRebuildViewSizePerCommit
+
+var _ database.Database = (*blockingDeleteDB)(nil)
+
+// blockingDeleteDB blocks deletions of [key] until [unblock] is closed.
+type blockingDeleteDB struct {
+	database.Database
+	key         []byte
+	blockedOnce sync.Once
+	blocked     chan struct{}
+	unblock     chan struct{}
+}
+
+func (db *blockingDeleteDB) Delete(key []byte) error {
+	if bytes.Equal(key, db.key) {
+		db.blockedOnce.Do(func() {
+			close(db.blocked)
+		})
+		<-db.unblock
+	}
+	return db.Database.Delete(key)
+}
+
+func newRebuildTestConfig() Config {
+	config := newDefaultConfig()
+	// Rebuild [minRebuildViewSizePerCommit] keys at a time.
+	config.ValueNodeCacheSize = minRebuildViewSizePerCommit
+	config.IntermediateNodeCacheSize = minRebuildViewSizePerCommit
+	return config
+}
+
+// newRebuildTestDB returns a database, which wasn't closed, with
+// [rebuildTestNumKeys] keys and its sorted keys.
+func newRebuildTestDB(t *testing.T, baseDB database.Database) (*merkleDB, [][]byte) {
+	require := require.New(t)
+
+	db, err := newDB(context.Background(), baseDB, newRebuildTestConfig())
+	require.NoError(err)
+
+	keys := make([][]byte, rebuildTestNumKeys)
+	ops := make([]database.BatchOp, rebuildTestNumKeys)
+	for i := range ops {
+		keys[i] = []byte(strconv.Itoa(i))
+		ops[i] = database.BatchOp{
+			Key:   keys[i],
+			Value: hashing.ComputeHash256(keys[i]),
+		}
+	}
+	view, err := db.NewView(context.Background(), ViewChanges{BatchOps: ops})
+	require.NoError(err)
+	require.NoError(view.CommitToDB(context.Background()))
+
+	slices.SortFunc(keys, func(a, b []byte) bool {
+		return bytes.Compare(a, b) < 0
+	})
+	return db, keys
+}
+
+func TestRebuildResumesFromCheckpoint(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db, keys := newRebuildTestDB(t, baseDB)
+	root, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	// Interrupt the rebuild after the first checkpoint.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(db.rebuild(ctx, int(newRebuildTestConfig().ValueNodeCacheSize)), context.Canceled)
+	require.Equal(rebuildStatus{KeysRebuilt: minRebuildViewSizePerCommit}, db.rebuildStatus)
+
+	// Write some of the nodes of the next batch to disk without checkpointing
+	// them, as if the database crashed during the next batch.
+	nextKeys := keys[minRebuildViewSizePerCommit : 2*minRebuildViewSizePerCommit]
+	ops := make([]database.BatchOp, len(nextKeys))
+	for i, key := range nextKeys {
+		ops[i] = database.BatchOp{
+			Key:   key,
+			Value: hashing.ComputeHash256(key),
+		}
+	}
+	view, err := newTrieView(db, db, ViewChanges{BatchOps: ops})
+	require.NoError(err)
+	require.NoError(view.commitToDB(context.Background()))
+	nodeCache := &db.intermediateNodeDB.nodeCache
+	nodeCache.lock.Lock()
+	require.NoError(nodeCache.resize(nodeCache.currentSize / 2))
+	nodeCache.lock.Unlock()
+
+	config := newRebuildTestConfig()
+	config.Reg = prometheus.NewRegistry()
+	db, err = newDB(context.Background(), baseDB, config)
+	require.NoError(err)
+
+	rebuiltRoot, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+	require.Equal(root, rebuiltRoot)
+
+	db.lock.RLock()
+	require.Equal(
+		rebuildStatus{
+			KeysReused:  minRebuildViewSizePerCommit,
+			KeysRebuilt: rebuildTestNumKeys - minRebuildViewSizePerCommit,
+		},
+		db.rebuildStatus,
+	)
+	db.lock.RUnlock()
+
+	// The checkpoint is removed once the rebuild completes.
+	has, err := baseDB.Has(rebuildCheckpointKey)
+	require.NoError(err)
+	require.False(has)
+}
+
+func TestRebuildInvalidCheckpoint(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db, _ := newRebuildTestDB(t, baseDB)
+	root, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(db.rebuild(ctx, int(newRebuildTestConfig().ValueNodeCacheSize)), context.Canceled)
+
+	// Corrupt the root ID of the checkpoint.
+	checkpoint, err := baseDB.Get(rebuildCheckpointKey)
+	require.NoError(err)
+	checkpoint[0]++
+	require.NoError(baseDB.Put(rebuildCheckpointKey, checkpoint))
+
+	config := newRebuildTestConfig()
+	config.Reg = prometheus.NewRegistry()
+	db, err = newDB(context.Background(), baseDB, config)
+	require.NoError(err)
+
+	rebuiltRoot, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+	require.Equal(root, rebuiltRoot)
+
+	// The rebuild started over.
+	db.lock.RLock()
+	require.Equal(rebuildStatus{KeysRebuilt: rebuildTestNumKeys}, db.rebuildStatus)
+	db.lock.RUnlock()
+}
+
+func TestRebuildInBackground(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := &blockingDeleteDB{
+		Database: memdb.New(),
+		key:      rebuildCheckpointKey,
+		blocked:  make(chan struct{}),
+		unblock:  make(chan struct{}),
+	}
+	db, keys := newRebuildTestDB(t, baseDB)
+	root, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	config := newRebuildTestConfig()
+	config.Reg = prometheus.NewRegistry()
+	db, err = newDB(context.Background(), baseDB, config)
+	require.NoError(err)
+	<-baseDB.blocked
+
+	// Values can be read during the rebuild.
+	for _, key := range keys {
+		value, err := db.Get(key)
+		require.NoError(err)
+		require.Equal(hashing.ComputeHash256(key), value)
+	}
+
+	status, err := db.HealthCheck(context.Background())
+	require.ErrorIs(err, errRebuilding)
+	require.Equal(rebuildStatus{}, status)
+
+	// The root isn't known until the rebuild completes.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = db.GetMerkleRoot(ctx)
+	require.ErrorIs(err, context.Canceled)
+
+	close(baseDB.unblock)
+
+	rebuiltRoot, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+	require.Equal(root, rebuiltRoot)
+
+	_, err = db.HealthCheck(context.Background())
+	require.NoError(err)
+	require.NoError(db.Close())
+}
+
+func TestRebuildCloseDuringRebuild(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := &blockingDeleteDB{
+		Database: memdb.New(),
+		key:      rebuildCheckpointKey,
+		blocked:  make(chan struct{}),
+		unblock:  make(chan struct{}),
+	}
+	db, _ := newRebuildTestDB(t, baseDB)
+	root, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	config := newRebuildTestConfig()
+	config.Reg = prometheus.NewRegistry()
+	db, err = newDB(context.Background(), baseDB, config)
+	require.NoError(err)
+	<-baseDB.blocked
+
+	// Interrupt the rebuild before its first checkpoint.
+	db.rebuildCancel()
+	close(baseDB.unblock)
+	require.NoError(db.Close())
+
+	_, err = db.GetMerkleRoot(context.Background())
+	require.ErrorIs(err, database.ErrClosed)
+	_, err = db.HealthCheck(context.Background())
+	require.ErrorIs(err, database.ErrClosed)
+
+	// The interrupted rebuild resumes when the database is reopened.
+	shutdownType, err := baseDB.Get(cleanShutdownKey)
+	require.NoError(err)
+	require.Equal(didNotHaveCleanShutdown, shutdownType)
+
+	config.Reg = prometheus.NewRegistry()
+	db, err = newDB(context.Background(), baseDB, config)
+	require.NoError(err)
+
+	rebuiltRoot, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+	require.Equal(root, rebuiltRoot)
+
+	db.lock.RLock()
+	require.Equal(minRebuildViewSizePerCommit, int(db.rebuildStatus.KeysReused))
+	db.lock.RUnlock()
+}
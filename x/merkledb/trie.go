@@ -20,6 +20,11 @@ type ProofGetter interface {
 	// GetProof generates a proof of the value associated with a particular key,
 	// or a proof of its absence from the trie
 	GetProof(ctx context.Context, bytesPath []byte) (*Proof, error)
+
+	// GetMultiProof generates a proof of the values associated with [keys],
+	// or of their absence from the trie. The returned proof's key-values are
+	// sorted by increasing key with duplicates removed.
+	GetMultiProof(ctx context.Context, keys [][]byte) (*MultiProof, error)
 }
 
 type ReadOnlyTrie interface {
@@ -304,7 +304,7 @@ func Test_Trie_WriteToDB(t *testing.T) {
 	rawBytes, err := dbTrie.baseDB.Get(prefixedKey)
 	require.NoError(err)
 
-	node, err := parseNode(NewPath(key, BranchFactor16), rawBytes)
+	node, err := parseNode(SHA256Hasher, NewPath(key, BranchFactor16), rawBytes)
 	require.NoError(err)
 	require.Equal([]byte("value"), node.value.Value())
 }
@@ -14,6 +14,7 @@ import (
 
 	oteltrace "go.opentelemetry.io/otel/trace"
 
+	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
 
 	"github.com/ava-labs/avalanchego/database"
@@ -161,6 +162,10 @@ func newTrieView(
 	}
 
 	for _, op := range changes.BatchOps {
+		if op.DeleteRange {
+			return nil, database.ErrRangeDeleteNotSupported
+		}
+
 		key := op.Key
 		if !changes.ConsumeBytes {
 			key = slices.Clone(op.Key)
@@ -313,7 +318,7 @@ func (t *trieView) calculateNodeIDsHelper(n *node) {
 	}
 
 	// The IDs [n]'s descendants are up to date so we can calculate [n]'s ID.
-	n.calculateID(t.db.metrics)
+	n.calculateID(t.db.hasher, t.db.metrics)
 }
 
 // GetProof returns a proof that [bytesPath] is in or not in trie [t].
@@ -334,7 +339,8 @@ func (t *trieView) getProof(ctx context.Context, key []byte) (*Proof, error) {
 	defer span.End()
 
 	proof := &Proof{
-		Key: t.db.newPath(key),
+		Key:    t.db.newPath(key),
+		Hasher: t.db.hasher,
 	}
 
 	proofPath, err := t.getPathTo(proof.Key)
@@ -380,6 +386,59 @@ func (t *trieView) getProof(ctx context.Context, key []byte) (*Proof, error) {
 	return proof, nil
 }
 
+// GetMultiProof returns a proof of the values of [keys] in trie [t].
+func (t *trieView) GetMultiProof(ctx context.Context, keys [][]byte) (*MultiProof, error) {
+	_, span := t.db.infoTracer.Start(ctx, "MerkleDB.trieview.GetMultiProof")
+	defer span.End()
+
+	if err := t.calculateNodeIDs(ctx); err != nil {
+		return nil, err
+	}
+
+	return t.getMultiProof(ctx, keys)
+}
+
+// Returns a proof of the values of [keys] in trie [t].
+func (t *trieView) getMultiProof(ctx context.Context, keys [][]byte) (*MultiProof, error) {
+	_, span := t.db.infoTracer.Start(ctx, "MerkleDB.trieview.getMultiProof")
+	defer span.End()
+
+	sortedKeys := slices.Clone(keys)
+	slices.SortFunc(sortedKeys, func(a, b []byte) bool {
+		return bytes.Compare(a, b) < 0
+	})
+	sortedKeys = slices.CompactFunc(sortedKeys, bytes.Equal)
+
+	// The root is always included, even if there are no keys.
+	proofNodes := map[Path]ProofNode{
+		t.root.key: t.root.asProofNode(),
+	}
+	proof := &MultiProof{
+		KeyValues: make([]KeyChange, len(sortedKeys)),
+		Hasher:    t.db.hasher,
+	}
+	for i, key := range sortedKeys {
+		keyProof, err := t.getProof(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		proof.KeyValues[i] = KeyChange{
+			Key:   slices.Clone(key),
+			Value: keyProof.Value,
+		}
+		// Nodes shared with the proofs of other keys are only included once.
+		for _, node := range keyProof.Path {
+			proofNodes[node.KeyPath] = node
+		}
+	}
+
+	proof.Path = maps.Values(proofNodes)
+	slices.SortFunc(proof.Path, func(a, b ProofNode) bool {
+		return a.KeyPath.Less(b.KeyPath)
+	})
+	return proof, nil
+}
+
 // GetRangeProof returns a range proof for (at least part of) the key range [start, end].
 // The returned proof's [KeyValues] has at most [maxLength] values.
 // [maxLength] must be > 0.
@@ -404,7 +463,9 @@ func (t *trieView) GetRangeProof(
 		return nil, err
 	}
 
-	var result RangeProof
+	result := RangeProof{
+		Hasher: t.db.hasher,
+	}
 
 	result.KeyValues = make([]KeyValue, 0, initKeyValuesSize)
 	it := t.NewIteratorWithStart(start.Value())
@@ -639,7 +700,7 @@ func (t *trieView) remove(key Path) error {
 		}
 	}
 
-	nodeToDelete.setValue(maybe.Nothing[[]byte]())
+	nodeToDelete.setValue(t.db.hasher, maybe.Nothing[[]byte]())
 	if err := t.recordNodeChange(nodeToDelete); err != nil {
 		return err
 	}
@@ -847,7 +908,7 @@ func (t *trieView) insert(
 
 	// a node with that exact path already exists so update its value
 	if closestNode.key == key {
-		closestNode.setValue(value)
+		closestNode.setValue(t.db.hasher, value)
 		// closestNode was already marked as changed in the ancestry loop above
 		return closestNode, nil
 	}
@@ -865,7 +926,7 @@ func (t *trieView) insert(
 			closestNode,
 			key,
 		)
-		newNode.setValue(value)
+		newNode.setValue(t.db.hasher, value)
 		return newNode, t.recordNewNode(newNode)
 	}
 
@@ -894,7 +955,7 @@ func (t *trieView) insert(
 
 	if key.tokensLength == branchNode.key.tokensLength {
 		// the branch node has exactly the key to be inserted as its key, so set the value on the branch node
-		branchNode.setValue(value)
+		branchNode.setValue(t.db.hasher, value)
 	} else {
 		// the key to be inserted is a child of the branch node
 		// create a new node and add the value to it
@@ -902,7 +963,7 @@ func (t *trieView) insert(
 			branchNode,
 			key,
 		)
-		newNode.setValue(value)
+		newNode.setValue(t.db.hasher, value)
 		if err := t.recordNewNode(newNode); err != nil {
 			return nil, err
 		}
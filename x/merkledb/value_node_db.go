@@ -29,6 +29,8 @@ type valueNodeDB struct {
 
 	closed       utils.Atomic[bool]
 	branchFactor BranchFactor
+	// Used to calculate the value digests of parsed nodes.
+	hasher Hasher
 }
 
 func newValueNodeDB(
@@ -37,6 +39,7 @@ func newValueNodeDB(
 	metrics merkleMetrics,
 	cacheSize int,
 	branchFactor BranchFactor,
+	hasher Hasher,
 ) *valueNodeDB {
 	return &valueNodeDB{
 		metrics:      metrics,
@@ -44,6 +47,7 @@ func newValueNodeDB(
 		bufferPool:   bufferPool,
 		nodeCache:    cache.NewSizedLRU(cacheSize, cacheEntrySize),
 		branchFactor: branchFactor,
+		hasher:       hasher,
 	}
 }
 
@@ -89,7 +93,7 @@ func (db *valueNodeDB) Get(key Path) (*node, error) {
 		return nil, err
 	}
 
-	return parseNode(key, nodeBytes)
+	return parseNode(db.hasher, key, nodeBytes)
 }
 
 // Batch of database operations
@@ -170,7 +174,7 @@ func (i *iterator) Next() bool {
 	i.db.metrics.DatabaseNodeRead()
 	key := i.nodeIter.Key()
 	key = key[valueNodePrefixLen:]
-	n, err := parseNode(NewPath(key, i.db.branchFactor), i.nodeIter.Value())
+	n, err := parseNode(i.db.hasher, NewPath(key, i.db.branchFactor), i.nodeIter.Value())
 	if err != nil {
 		i.err = err
 		return false
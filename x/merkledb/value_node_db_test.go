@@ -29,6 +29,7 @@ func TestValueNodeDB(t *testing.T) {
 		&mockMetrics{},
 		size,
 		BranchFactor16,
+		SHA256Hasher,
 	)
 
 	// Getting a key that doesn't exist should return an error.
@@ -125,6 +126,7 @@ func TestValueNodeDBIterator(t *testing.T) {
 		&mockMetrics{},
 		cacheSize,
 		BranchFactor16,
+		SHA256Hasher,
 	)
 
 	// Put key-node pairs.
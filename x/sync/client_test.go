@@ -68,7 +68,7 @@ func sendRangeProofRequest(
 		sender = common.NewMockSender(ctrl)
 
 		// Serves the range proof.
-		server = NewNetworkServer(sender, serverDB, logging.NoLog{})
+		server = NewNetworkServer(sender, serverDB, logging.NoLog{}, NetworkServerConfig{Metrics: &mockMetrics{}})
 
 		clientNodeID, serverNodeID = ids.GenerateTestNodeID(), ids.GenerateTestNodeID()
 
@@ -387,7 +387,7 @@ func sendChangeProofRequest(
 		sender = common.NewMockSender(ctrl)
 
 		// Serves the change proof.
-		server = NewNetworkServer(sender, serverDB, logging.NoLog{})
+		server = NewNetworkServer(sender, serverDB, logging.NoLog{}, NetworkServerConfig{Metrics: &mockMetrics{}})
 
 		clientNodeID, serverNodeID = ids.GenerateTestNodeID(), ids.GenerateTestNodeID()
 
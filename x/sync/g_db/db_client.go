@@ -128,6 +128,21 @@ func (c *DBClient) GetProof(ctx context.Context, key []byte) (*merkledb.Proof, e
 	return &proof, nil
 }
 
+func (c *DBClient) GetMultiProof(ctx context.Context, keys [][]byte) (*merkledb.MultiProof, error) {
+	resp, err := c.client.GetMultiProof(ctx, &pb.GetMultiProofRequest{
+		Keys: keys,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var proof merkledb.MultiProof
+	if err := proof.UnmarshalProto(resp.Proof, c.branchFactor); err != nil {
+		return nil, err
+	}
+	return &proof, nil
+}
+
 func (c *DBClient) GetRangeProofAtRoot(
 	ctx context.Context,
 	rootID ids.ID,
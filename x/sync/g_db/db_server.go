@@ -152,6 +152,20 @@ func (s *DBServer) GetProof(
 	}, nil
 }
 
+func (s *DBServer) GetMultiProof(
+	ctx context.Context,
+	req *pb.GetMultiProofRequest,
+) (*pb.GetMultiProofResponse, error) {
+	proof, err := s.db.GetMultiProof(ctx, req.Keys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetMultiProofResponse{
+		Proof: proof.ToProto(),
+	}, nil
+}
+
 func (s *DBServer) GetRangeProof(
 	ctx context.Context,
 	req *pb.GetRangeProofRequest,
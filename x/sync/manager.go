@@ -9,13 +9,16 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"go.uber.org/zap"
 	"golang.org/x/exp/slices"
 
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/maybe"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/x/merkledb"
 
 	pb "github.com/ava-labs/avalanchego/proto/pb/sync"
@@ -76,6 +79,12 @@ type Manager struct {
 	// Namely, the number of goroutines executing [doWork].
 	// [workLock] must be held when accessing [processingWorkItems].
 	processingWorkItems int
+	// The work items currently being processed.
+	// A work item is removed once its result has been added to
+	// [unprocessedWork] or [processedWork], so that every range is in
+	// exactly one of [processingWork], [unprocessedWork] and [processedWork].
+	// [workLock] must be held when accessing [processingWork].
+	processingWork set.Set[*workItem]
 	// [workLock] must be held while accessing [unprocessedWork].
 	unprocessedWork *workHeap
 	// Signalled when:
@@ -86,6 +95,9 @@ type Manager struct {
 	unprocessedWorkCond sync.Cond
 	// [workLock] must be held while accessing [processedWork].
 	processedWork *workHeap
+	// The last time the progress was persisted to [config.ProgressDB].
+	// [workLock] must be held when accessing [lastProgressPersist].
+	lastProgressPersist time.Time
 
 	// When this is closed:
 	// - [closed] is true.
@@ -114,6 +126,12 @@ type ManagerConfig struct {
 	Log                   logging.Logger
 	TargetRoot            ids.ID
 	BranchFactor          merkledb.BranchFactor
+	// If non-nil, the sync progress is persisted to [ProgressDB] so that
+	// syncing resumes from where it left off after a restart.
+	ProgressDB database.Database
+	// The minimum amount of time between persisting the progress while
+	// syncing. The progress is always persisted when the Manager is closed.
+	ProgressPersistFrequency time.Duration
 }
 
 func NewManager(config ManagerConfig) (*Manager, error) {
@@ -153,9 +171,16 @@ func (m *Manager) Start(ctx context.Context) error {
 
 	m.config.Log.Info("starting sync", zap.Stringer("target root", m.config.TargetRoot))
 
-	// Add work item to fetch the entire key range.
-	// Note that this will be the first work item to be processed.
-	m.unprocessedWork.Insert(newWorkItem(ids.Empty, maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), lowPriority))
+	resumed, err := m.resumeProgress()
+	if err != nil {
+		return err
+	}
+	if !resumed {
+		// Add work item to fetch the entire key range.
+		// Note that this will be the first work item to be processed.
+		m.unprocessedWork.Insert(newWorkItem(ids.Empty, maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), lowPriority))
+	}
+	m.lastProgressPersist = time.Now()
 
 	m.syncing = true
 	ctx, m.cancelCtx = context.WithCancel(ctx)
@@ -199,6 +224,7 @@ func (m *Manager) sync(ctx context.Context) {
 		default:
 			m.processingWorkItems++
 			work := m.unprocessedWork.GetWork()
+			m.processingWork.Add(work)
 			go m.doWork(ctx, work)
 		}
 	}
@@ -216,6 +242,16 @@ func (m *Manager) Close() {
 // [workLock] must be held
 func (m *Manager) close() {
 	m.closeOnce.Do(func() {
+		// Record the progress before the heaps are closed so that syncing
+		// can be resumed. If syncing completed, there is nothing to resume.
+		if m.syncing {
+			if m.Error() == nil && m.unprocessedWork.Len() == 0 && m.processingWork.Len() == 0 {
+				m.clearProgress()
+			} else {
+				m.persistProgress()
+			}
+		}
+
 		// Don't process any more work items.
 		// Drop currently processing work items.
 		if m.cancelCtx != nil {
@@ -240,6 +276,7 @@ func (m *Manager) doWork(ctx context.Context, work *workItem) {
 		defer m.workLock.Unlock()
 
 		m.processingWorkItems--
+		m.processingWork.Remove(work)
 		m.unprocessedWorkCond.Signal()
 	}()
 
@@ -603,6 +640,89 @@ func (m *Manager) getTargetRoot() ids.ID {
 	return m.config.TargetRoot
 }
 
+// resumeProgress adds the work persisted in [m.config.ProgressDB], if any, to
+// [m.unprocessedWork]. Returns true if there was progress to resume.
+//
+// Ranges that were already committed are re-added with their local root ID,
+// so they are verified with change proofs rather than downloaded again.
+// If the target root hasn't changed, their local root ID is the target root,
+// so they are only verified when the final root is checked.
+// Assumes [m.workLock] is held.
+func (m *Manager) resumeProgress() (bool, error) {
+	if m.config.ProgressDB == nil {
+		return false, nil
+	}
+
+	progressBytes, err := m.config.ProgressDB.Get(progressKey)
+	if err == database.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	p, err := parseProgress(progressBytes)
+	if err != nil {
+		// Syncing from scratch overwrites every key in the database, so it is
+		// safe to ignore the corrupted progress.
+		m.config.Log.Warn("failed to parse sync progress, syncing from scratch",
+			zap.Error(err),
+		)
+		return false, nil
+	}
+
+	for _, item := range p.unprocessed {
+		m.unprocessedWork.Insert(item)
+	}
+	for _, item := range p.processed {
+		item.priority = highPriority
+		m.unprocessedWork.Insert(item)
+	}
+
+	m.config.Log.Info("resuming sync",
+		zap.Stringer("previous target root", p.targetRoot),
+		zap.Int("numUnprocessed", len(p.unprocessed)),
+		zap.Int("numProcessed", len(p.processed)),
+	)
+	return true, nil
+}
+
+// persistProgress writes the current work to [m.config.ProgressDB].
+// Work items being processed are persisted as unprocessed.
+// Failing to persist the progress doesn't prevent syncing, so errors are
+// only logged.
+// Assumes [m.workLock] is held, which prevents [m.config.TargetRoot] from
+// being updated.
+func (m *Manager) persistProgress() {
+	if m.config.ProgressDB == nil {
+		return
+	}
+
+	p := &progress{
+		targetRoot:  m.config.TargetRoot,
+		unprocessed: append(m.unprocessedWork.Items(), m.processingWork.List()...),
+		processed:   m.processedWork.Items(),
+	}
+	if err := m.config.ProgressDB.Put(progressKey, p.Bytes()); err != nil {
+		m.config.Log.Warn("failed to persist sync progress", zap.Error(err))
+		return
+	}
+	m.lastProgressPersist = time.Now()
+}
+
+// clearProgress deletes the progress in [m.config.ProgressDB] once syncing
+// has completed.
+// Assumes [m.workLock] is held.
+func (m *Manager) clearProgress() {
+	if m.config.ProgressDB == nil {
+		return
+	}
+
+	if err := m.config.ProgressDB.Delete(progressKey); err != nil {
+		m.config.Log.Warn("failed to clear sync progress", zap.Error(err))
+	}
+}
+
 // Record that there was a fatal error and begin shutting down.
 func (m *Manager) setError(err error) {
 	m.errLock.Lock()
@@ -629,6 +749,7 @@ func (m *Manager) setError(err error) {
 //
 // Assumes [m.workLock] is not held.
 func (m *Manager) completeWorkItem(ctx context.Context, work *workItem, largestHandledKey maybe.Maybe[[]byte], rootID ids.ID, proofOfLargestKey []merkledb.ProofNode) {
+	var remainingWork *workItem
 	if !maybe.Equal(largestHandledKey, work.end, bytes.Equal) {
 		// The largest handled key isn't equal to the end of the work item.
 		// Find the start of the next key range to fetch.
@@ -648,7 +769,7 @@ func (m *Manager) completeWorkItem(ctx context.Context, work *workItem, largestH
 			largestHandledKey = work.end
 		} else {
 			// the full range wasn't completed, so enqueue a new work item for the range [nextStartKey, workItem.end]
-			m.enqueueWork(newWorkItem(work.localRootID, nextStartKey, work.end, work.priority))
+			remainingWork = newWorkItem(work.localRootID, nextStartKey, work.end, work.priority)
 			largestHandledKey = nextStartKey
 		}
 	}
@@ -658,17 +779,31 @@ func (m *Manager) completeWorkItem(ctx context.Context, work *workItem, largestH
 	m.syncTargetLock.RLock()
 	defer m.syncTargetLock.RUnlock()
 
+	// All the results of [work] are added to the heaps while holding
+	// [workLock] so that persisted progress never contains overlapping ranges.
+	m.workLock.Lock()
+	defer func() {
+		m.workLock.Unlock()
+		m.unprocessedWorkCond.Signal()
+	}()
+
+	m.processingWork.Remove(work)
+	if remainingWork != nil {
+		m.insertWork(remainingWork)
+	}
+
 	stale := m.config.TargetRoot != rootID
 	if stale {
 		// the root has changed, so reinsert with high priority
-		m.enqueueWork(newWorkItem(rootID, work.start, largestHandledKey, highPriority))
+		m.insertWork(newWorkItem(rootID, work.start, largestHandledKey, highPriority))
 	} else {
-		m.workLock.Lock()
-		defer m.workLock.Unlock()
-
 		m.processedWork.MergeInsert(newWorkItem(rootID, work.start, largestHandledKey, work.priority))
 	}
 
+	if time.Since(m.lastProgressPersist) >= m.config.ProgressPersistFrequency {
+		m.persistProgress()
+	}
+
 	// completed the range [work.start, lastKey], log and record in the completed work heap
 	m.config.Log.Debug("completed range",
 		zap.Stringer("start", work.start),
@@ -681,14 +816,9 @@ func (m *Manager) completeWorkItem(ctx context.Context, work *workItem, largestH
 // Queue the given key range to be fetched and applied.
 // If there are sufficiently few unprocessed/processing work items,
 // splits the range into two items and queues them both.
-// Assumes [m.workLock] is not held.
-func (m *Manager) enqueueWork(work *workItem) {
-	m.workLock.Lock()
-	defer func() {
-		m.workLock.Unlock()
-		m.unprocessedWorkCond.Signal()
-	}()
-
+// Assumes [m.workLock] is held.
+// The caller is responsible for signalling [m.unprocessedWorkCond].
+func (m *Manager) insertWork(work *workItem) {
 	if m.processingWorkItems+m.unprocessedWork.Len() > 2*m.config.SimultaneousWorkLimit {
 		// There are too many work items already, don't split the range
 		m.unprocessedWork.Insert(work)
@@ -12,8 +12,11 @@ import (
 )
 
 var (
-	_ SyncMetrics = (*mockMetrics)(nil)
-	_ SyncMetrics = (*metrics)(nil)
+	_ SyncMetrics   = (*mockMetrics)(nil)
+	_ SyncMetrics   = (*metrics)(nil)
+	_ ServerMetrics = (*mockMetrics)(nil)
+	_ ServerMetrics = (*serverMetrics)(nil)
+	_ ServerMetrics = noopServerMetrics{}
 )
 
 type SyncMetrics interface {
@@ -22,11 +25,31 @@ type SyncMetrics interface {
 	RequestSucceeded()
 }
 
+// ServerMetrics tracks the requests handled by a NetworkServer.
+type ServerMetrics interface {
+	RequestThrottled()
+	ResponseCacheHit()
+	ResponseCacheMiss()
+}
+
+// noopServerMetrics is used by a NetworkServer that isn't given metrics.
+type noopServerMetrics struct{}
+
+func (noopServerMetrics) RequestThrottled() {}
+
+func (noopServerMetrics) ResponseCacheHit() {}
+
+func (noopServerMetrics) ResponseCacheMiss() {}
+
 type mockMetrics struct {
 	lock              sync.Mutex
 	requestsFailed    int
 	requestsMade      int
 	requestsSucceeded int
+
+	requestsThrottled   int
+	responseCacheHits   int
+	responseCacheMisses int
 }
 
 func (m *mockMetrics) RequestFailed() {
@@ -50,6 +73,27 @@ func (m *mockMetrics) RequestSucceeded() {
 	m.requestsSucceeded++
 }
 
+func (m *mockMetrics) RequestThrottled() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.requestsThrottled++
+}
+
+func (m *mockMetrics) ResponseCacheHit() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.responseCacheHits++
+}
+
+func (m *mockMetrics) ResponseCacheMiss() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.responseCacheMisses++
+}
+
 type metrics struct {
 	requestsFailed    prometheus.Counter
 	requestsMade      prometheus.Counter
@@ -94,3 +138,48 @@ func (m *metrics) RequestMade() {
 func (m *metrics) RequestSucceeded() {
 	m.requestsSucceeded.Inc()
 }
+
+type serverMetrics struct {
+	requestsThrottled   prometheus.Counter
+	responseCacheHits   prometheus.Counter
+	responseCacheMisses prometheus.Counter
+}
+
+func NewServerMetrics(namespace string, reg prometheus.Registerer) (ServerMetrics, error) {
+	m := serverMetrics{
+		requestsThrottled: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "requests_throttled",
+			Help:      "cumulative amount of proof requests dropped because the requesting node exceeded its quota",
+		}),
+		responseCacheHits: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "response_cache_hits",
+			Help:      "cumulative amount of proof requests served from the response cache",
+		}),
+		responseCacheMisses: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "response_cache_misses",
+			Help:      "cumulative amount of proof requests that weren't in the response cache",
+		}),
+	}
+	errs := wrappers.Errs{}
+	errs.Add(
+		reg.Register(m.requestsThrottled),
+		reg.Register(m.responseCacheHits),
+		reg.Register(m.responseCacheMisses),
+	)
+	return &m, errs.Err
+}
+
+func (m *serverMetrics) RequestThrottled() {
+	m.requestsThrottled.Inc()
+}
+
+func (m *serverMetrics) ResponseCacheHit() {
+	m.responseCacheHits.Inc()
+}
+
+func (m *serverMetrics) ResponseCacheMiss() {
+	m.responseCacheMisses.Inc()
+}
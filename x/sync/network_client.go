@@ -201,7 +201,7 @@ func (c *networkClient) RequestAny(
 	}
 	defer c.activeRequests.Release(1)
 
-	nodeID, ok := c.peers.GetAnyPeer(minVersion)
+	nodeID, ok := c.peers.GetAnyPeer(ctx, minVersion)
 	if !ok {
 		return ids.EmptyNodeID, nil, fmt.Errorf(
 			"no peers found matching version %s out of %d peers",
@@ -278,17 +278,15 @@ func (c *networkClient) request(
 
 	select {
 	case <-ctx.Done():
-		c.peers.TrackBandwidth(nodeID, 0)
+		c.peers.RegisterFailure(nodeID)
 		return nil, ctx.Err()
 	case response = <-handler.responseChan:
-		elapsedSeconds := time.Since(startTime).Seconds()
-		bandwidth := float64(len(response))/elapsedSeconds + epsilon
-		c.peers.TrackBandwidth(nodeID, bandwidth)
 	}
 	if handler.failed {
-		c.peers.TrackBandwidth(nodeID, 0)
+		c.peers.RegisterFailure(nodeID)
 		return nil, errRequestFailed
 	}
+	c.peers.RegisterResponse(nodeID, time.Since(startTime), len(response))
 
 	c.log.Debug("received response from peer",
 		zap.Stringer("nodeID", nodeID),
@@ -299,7 +297,7 @@ func (c *networkClient) request(
 }
 
 func (c *networkClient) Connected(
-	_ context.Context,
+	ctx context.Context,
 	nodeID ids.NodeID,
 	nodeVersion *version.Application,
 ) error {
@@ -309,17 +307,17 @@ func (c *networkClient) Connected(
 	}
 
 	c.log.Debug("adding new peer", zap.Stringer("nodeID", nodeID))
-	c.peers.Connected(nodeID, nodeVersion)
+	c.peers.Connected(ctx, nodeID, nodeVersion)
 	return nil
 }
 
-func (c *networkClient) Disconnected(_ context.Context, nodeID ids.NodeID) error {
+func (c *networkClient) Disconnected(ctx context.Context, nodeID ids.NodeID) error {
 	if nodeID == c.myNodeID {
 		c.log.Debug("skipping deregistering self as peer")
 		return nil
 	}
 
 	c.log.Debug("disconnecting peer", zap.Stringer("nodeID", nodeID))
-	c.peers.Disconnected(nodeID)
+	c.peers.Disconnected(ctx, nodeID)
 	return nil
 }
@@ -16,7 +16,9 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/hashing"
@@ -24,6 +26,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/utils/maybe"
 	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/x/merkledb"
 
 	pb "github.com/ava-labs/avalanchego/proto/pb/sync"
@@ -40,6 +43,8 @@ const (
 	estimatedMessageOverhead = 4 * units.KiB
 	maxByteSizeLimit         = constants.DefaultMaxMessageSize - estimatedMessageOverhead
 	endProofSizeBufferAmount = 2 * units.KiB
+	// Size, in bytes, of a [responseCacheKey] excluding its keys.
+	responseCacheKeyOverhead = 2*ids.IDLen + 2*wrappers.BoolLen + 2*wrappers.IntLen
 )
 
 var (
@@ -53,22 +58,102 @@ var (
 	errInvalidEndKey        = errors.New("end key is Nothing but has value")
 	errInvalidBounds        = errors.New("start key is greater than end key")
 	errInvalidRootHash      = fmt.Errorf("root hash must have length %d", hashing.HashLen)
+	errNoKeys               = errors.New("keys must be non-empty")
+	errInvalidNumKeys       = fmt.Errorf("number of keys must be at most %d", maxKeyValuesLimit)
 )
 
+type NetworkServerConfig struct {
+	// Maximum size, in bytes, of the recently served responses to cache.
+	// If 0, responses aren't cached.
+	ResponseCacheSize int
+	// Limits the number of requests handled from each node.
+	// If nil, requests aren't throttled.
+	Throttler p2p.Throttler
+	// Tracks the handled requests.
+	// If nil, metrics aren't tracked.
+	Metrics ServerMetrics
+}
+
 type NetworkServer struct {
 	appSender common.AppSender // Used to respond to peer requests via AppResponse.
 	db        DB
 	log       logging.Logger
+	throttler p2p.Throttler
+	metrics   ServerMetrics
+
+	// Proofs of a given root are immutable, so recently sent responses are
+	// cached to avoid regenerating them when many nodes sync the same root.
+	responseCache cache.Cacher[responseCacheKey, []byte]
 }
 
-func NewNetworkServer(appSender common.AppSender, db DB, log logging.Logger) *NetworkServer {
+func NewNetworkServer(appSender common.AppSender, db DB, log logging.Logger, config NetworkServerConfig) *NetworkServer {
+	var responseCache cache.Cacher[responseCacheKey, []byte] = &cache.Empty[responseCacheKey, []byte]{}
+	if config.ResponseCacheSize > 0 {
+		responseCache = cache.NewSizedLRU[responseCacheKey, []byte](config.ResponseCacheSize, responseCacheEntrySize)
+	}
+	metrics := config.Metrics
+	if metrics == nil {
+		metrics = noopServerMetrics{}
+	}
 	return &NetworkServer{
-		appSender: appSender,
-		db:        db,
-		log:       log,
+		appSender:     appSender,
+		db:            db,
+		log:           log,
+		throttler:     config.Throttler,
+		metrics:       metrics,
+		responseCache: responseCache,
 	}
 }
 
+// responseCacheKey identifies the response to a proof request.
+// For range proofs, [startRoot] is [ids.Empty].
+type responseCacheKey struct {
+	startRoot  ids.ID
+	endRoot    ids.ID
+	start      string
+	hasStart   bool
+	end        string
+	hasEnd     bool
+	keyLimit   uint32
+	bytesLimit uint32
+}
+
+func newResponseCacheKey(
+	startRoot ids.ID,
+	endRoot ids.ID,
+	start maybe.Maybe[[]byte],
+	end maybe.Maybe[[]byte],
+	keyLimit uint32,
+	bytesLimit uint32,
+) responseCacheKey {
+	return responseCacheKey{
+		startRoot:  startRoot,
+		endRoot:    endRoot,
+		start:      string(start.Value()),
+		hasStart:   start.HasValue(),
+		end:        string(end.Value()),
+		hasEnd:     end.HasValue(),
+		keyLimit:   keyLimit,
+		bytesLimit: bytesLimit,
+	}
+}
+
+func responseCacheEntrySize(key responseCacheKey, response []byte) int {
+	return responseCacheKeyOverhead + len(key.start) + len(key.end) + len(response)
+}
+
+// getCachedResponse returns the response to the request identified by [key]
+// if it was recently sent.
+func (s *NetworkServer) getCachedResponse(key responseCacheKey) ([]byte, bool) {
+	response, ok := s.responseCache.Get(key)
+	if ok {
+		s.metrics.ResponseCacheHit()
+	} else {
+		s.metrics.ResponseCacheMiss()
+	}
+	return response, ok
+}
+
 // AppRequest is called by avalanchego -> VM when there is an incoming AppRequest from a peer.
 // Returns a non-nil error iff we fail to send an app message. This is a fatal error.
 // Sends a response back to the sender if length of response returned by the handler > 0.
@@ -90,6 +175,15 @@ func (s *NetworkServer) AppRequest(
 		)
 		return nil
 	}
+	if s.throttler != nil && !s.throttler.Handle(nodeID) {
+		s.log.Debug(
+			"dropping AppRequest from throttled node",
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint32("requestID", requestID),
+		)
+		s.metrics.RequestThrottled()
+		return nil
+	}
 	s.log.Debug(
 		"processing AppRequest from node",
 		zap.Stringer("nodeID", nodeID),
@@ -122,6 +216,8 @@ func (s *NetworkServer) AppRequest(
 		err = s.HandleChangeProofRequest(ctx, nodeID, requestID, req.ChangeProofRequest)
 	case *pb.Request_RangeProofRequest:
 		err = s.HandleRangeProofRequest(ctx, nodeID, requestID, req.RangeProofRequest)
+	case *pb.Request_MultiProofRequest:
+		err = s.HandleMultiProofRequest(ctx, nodeID, requestID, req.MultiProofRequest)
 	default:
 		s.log.Debug(
 			"unknown AppRequest type",
@@ -195,6 +291,11 @@ func (s *NetworkServer) HandleChangeProofRequest(
 		return err
 	}
 
+	cacheKey := newResponseCacheKey(startRoot, endRoot, start, end, keyLimit, uint32(bytesLimit))
+	if proofBytes, ok := s.getCachedResponse(cacheKey); ok {
+		return s.sendAppResponse(ctx, nodeID, requestID, proofBytes)
+	}
+
 	for keyLimit > 0 {
 		changeProof, err := s.db.GetChangeProof(ctx, startRoot, endRoot, start, end, int(keyLimit))
 		if err != nil {
@@ -225,18 +326,10 @@ func (s *NetworkServer) HandleChangeProofRequest(
 			if err != nil {
 				return err
 			}
-
-			if err := s.appSender.SendAppResponse(ctx, nodeID, requestID, proofBytes); err != nil {
-				s.log.Fatal(
-					"failed to send app response",
-					zap.Stringer("nodeID", nodeID),
-					zap.Uint32("requestID", requestID),
-					zap.Int("responseLen", len(proofBytes)),
-					zap.Error(err),
-				)
-				return fmt.Errorf("%w: %w", errAppSendFailed, err)
+			if proofBytes != nil {
+				s.responseCache.Put(cacheKey, proofBytes)
 			}
-			return nil
+			return s.sendAppResponse(ctx, nodeID, requestID, proofBytes)
 		}
 
 		// We generated a change proof. See if it's small enough.
@@ -250,17 +343,8 @@ func (s *NetworkServer) HandleChangeProofRequest(
 		}
 
 		if len(proofBytes) < bytesLimit {
-			if err := s.appSender.SendAppResponse(ctx, nodeID, requestID, proofBytes); err != nil {
-				s.log.Fatal(
-					"failed to send app response",
-					zap.Stringer("nodeID", nodeID),
-					zap.Uint32("requestID", requestID),
-					zap.Int("responseLen", len(proofBytes)),
-					zap.Error(err),
-				)
-				return fmt.Errorf("%w: %w", errAppSendFailed, err)
-			}
-			return nil
+			s.responseCache.Put(cacheKey, proofBytes)
+			return s.sendAppResponse(ctx, nodeID, requestID, proofBytes)
 		}
 
 		// The proof was too large. Try to shrink it.
@@ -292,6 +376,22 @@ func (s *NetworkServer) HandleRangeProofRequest(
 	req.KeyLimit = math.Min(req.KeyLimit, maxKeyValuesLimit)
 	req.BytesLimit = math.Min(req.BytesLimit, maxByteSizeLimit)
 
+	root, err := ids.ToID(req.RootHash)
+	if err != nil {
+		return err
+	}
+	cacheKey := newResponseCacheKey(
+		ids.Empty,
+		root,
+		maybeBytesToMaybe(req.StartKey),
+		maybeBytesToMaybe(req.EndKey),
+		req.KeyLimit,
+		req.BytesLimit,
+	)
+	if proofBytes, ok := s.getCachedResponse(cacheKey); ok {
+		return s.sendAppResponse(ctx, nodeID, requestID, proofBytes)
+	}
+
 	proofBytes, err := getRangeProof(
 		ctx,
 		s.db,
@@ -303,12 +403,73 @@ func (s *NetworkServer) HandleRangeProofRequest(
 	if err != nil {
 		return err
 	}
-	if err := s.appSender.SendAppResponse(ctx, nodeID, requestID, proofBytes); err != nil {
+	if proofBytes != nil {
+		s.responseCache.Put(cacheKey, proofBytes)
+	}
+	return s.sendAppResponse(ctx, nodeID, requestID, proofBytes)
+}
+
+// Generates a proof of the values of the requested keys and sends it to [nodeID].
+// If the proof of every key is too large, only a prefix of the keys is proven.
+// If [errAppSendFailed] is returned, this should be considered fatal.
+func (s *NetworkServer) HandleMultiProofRequest(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	requestID uint32,
+	req *pb.SyncGetMultiProofRequest,
+) error {
+	if err := validateMultiProofRequest(req); err != nil {
+		s.log.Debug(
+			"dropping invalid multi proof request",
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint32("requestID", requestID),
+			zap.Stringer("req", req),
+			zap.Error(err),
+		)
+		return nil // drop request
+	}
+
+	// override limits if they exceed caps
+	var (
+		keys       = req.Keys
+		bytesLimit = math.Min(int(req.BytesLimit), maxByteSizeLimit)
+	)
+
+	for len(keys) > 0 {
+		multiProof, err := s.db.GetMultiProof(ctx, keys)
+		if err != nil {
+			return err
+		}
+
+		proofBytes, err := proto.Marshal(multiProof.ToProto())
+		if err != nil {
+			return err
+		}
+
+		if len(proofBytes) < bytesLimit {
+			return s.sendAppResponse(ctx, nodeID, requestID, proofBytes)
+		}
+
+		// The proof was too large. Try to shrink it.
+		keys = keys[:len(keys)/2]
+	}
+	return ErrMinProofSizeIsTooLarge
+}
+
+// Sends [response] to [nodeID].
+// If [errAppSendFailed] is returned, this should be considered fatal.
+func (s *NetworkServer) sendAppResponse(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	requestID uint32,
+	response []byte,
+) error {
+	if err := s.appSender.SendAppResponse(ctx, nodeID, requestID, response); err != nil {
 		s.log.Fatal(
 			"failed to send app response",
 			zap.Stringer("nodeID", nodeID),
 			zap.Uint32("requestID", requestID),
-			zap.Int("responseLen", len(proofBytes)),
+			zap.Int("responseLen", len(response)),
 			zap.Error(err),
 		)
 		return fmt.Errorf("%w: %w", errAppSendFailed, err)
@@ -423,3 +584,17 @@ func validateRangeProofRequest(req *pb.SyncGetRangeProofRequest) error {
 		return nil
 	}
 }
+
+// Returns nil iff [req] is well-formed.
+func validateMultiProofRequest(req *pb.SyncGetMultiProofRequest) error {
+	switch {
+	case req.BytesLimit == 0:
+		return errInvalidBytesLimit
+	case len(req.Keys) == 0:
+		return errNoKeys
+	case len(req.Keys) > maxKeyValuesLimit:
+		return errInvalidNumKeys
+	default:
+		return nil
+	}
+}
@@ -17,8 +17,10 @@ import (
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/x/merkledb"
 
 	pb "github.com/ava-labs/avalanchego/proto/pb/sync"
@@ -120,7 +122,7 @@ func Test_Server_GetRangeProof(t *testing.T) {
 					return nil
 				},
 			).AnyTimes()
-			handler := NewNetworkServer(sender, smallTrieDB, logging.NoLog{})
+			handler := NewNetworkServer(sender, smallTrieDB, logging.NoLog{}, NetworkServerConfig{Metrics: &mockMetrics{}})
 			err := handler.HandleRangeProofRequest(context.Background(), test.nodeID, 0, test.request)
 			require.ErrorIs(err, test.expectedErr)
 			if test.expectedErr != nil {
@@ -145,6 +147,114 @@ func Test_Server_GetRangeProof(t *testing.T) {
 	}
 }
 
+func Test_Server_GetMultiProof(t *testing.T) {
+	now := time.Now().UnixNano()
+	t.Logf("seed: %d", now)
+	r := rand.New(rand.NewSource(now)) // #nosec G404
+
+	trieDB, keys, err := generateTrieWithMinKeyLen(t, r, defaultRequestKeyLimit, 1)
+	require.NoError(t, err)
+	trieRoot, err := trieDB.GetMerkleRoot(context.Background())
+	require.NoError(t, err)
+
+	// Include a key that isn't in the trie.
+	requestedKeys := append([][]byte{{}}, keys[:64]...)
+
+	tests := map[string]struct {
+		request     *pb.SyncGetMultiProofRequest
+		expectedErr error
+		proofNil    bool
+	}{
+		"proof too large": {
+			request: &pb.SyncGetMultiProofRequest{
+				Keys:       requestedKeys,
+				BytesLimit: 100,
+			},
+			proofNil:    true,
+			expectedErr: ErrMinProofSizeIsTooLarge,
+		},
+		"byteslimit is 0": {
+			request: &pb.SyncGetMultiProofRequest{
+				Keys:       requestedKeys,
+				BytesLimit: 0,
+			},
+			proofNil: true,
+		},
+		"no keys": {
+			request: &pb.SyncGetMultiProofRequest{
+				BytesLimit: defaultRequestByteSizeLimit,
+			},
+			proofNil: true,
+		},
+		"too many keys": {
+			request: &pb.SyncGetMultiProofRequest{
+				Keys:       make([][]byte, maxKeyValuesLimit+1),
+				BytesLimit: defaultRequestByteSizeLimit,
+			},
+			proofNil: true,
+		},
+		"all keys": {
+			request: &pb.SyncGetMultiProofRequest{
+				Keys:       requestedKeys,
+				BytesLimit: defaultRequestByteSizeLimit,
+			},
+		},
+		"some keys": {
+			request: &pb.SyncGetMultiProofRequest{
+				Keys:       requestedKeys,
+				BytesLimit: 4096,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+			sender := common.NewMockSender(ctrl)
+			var (
+				proof      *merkledb.MultiProof
+				proofBytes []byte
+			)
+			sender.EXPECT().SendAppResponse(
+				gomock.Any(), // ctx
+				gomock.Any(), // nodeID
+				gomock.Any(), // requestID
+				gomock.Any(), // responseBytes
+			).DoAndReturn(
+				func(_ context.Context, _ ids.NodeID, requestID uint32, responseBytes []byte) error {
+					// grab a copy of the proof so we can inspect it later
+					if !test.proofNil {
+						var proofProto pb.MultiProof
+						require.NoError(proto.Unmarshal(responseBytes, &proofProto))
+
+						var p merkledb.MultiProof
+						require.NoError(p.UnmarshalProto(&proofProto, merkledb.BranchFactor16))
+						proof = &p
+						proofBytes = responseBytes
+					}
+					return nil
+				},
+			).AnyTimes()
+			handler := NewNetworkServer(sender, trieDB, logging.NoLog{}, NetworkServerConfig{Metrics: &mockMetrics{}})
+			err := handler.HandleMultiProofRequest(context.Background(), ids.EmptyNodeID, 0, test.request)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+			if test.proofNil {
+				require.Nil(proof)
+				return
+			}
+			require.NotNil(proof)
+			require.NotEmpty(proof.KeyValues)
+			require.LessOrEqual(len(proof.KeyValues), len(test.request.Keys))
+			require.LessOrEqual(len(proofBytes), int(test.request.BytesLimit))
+			require.NoError(proof.Verify(context.Background(), trieRoot))
+		})
+	}
+}
+
 func Test_Server_GetChangeProof(t *testing.T) {
 	now := time.Now().UnixNano()
 	t.Logf("seed: %d", now)
@@ -305,7 +415,7 @@ func Test_Server_GetChangeProof(t *testing.T) {
 				},
 			).AnyTimes()
 
-			handler := NewNetworkServer(sender, trieDB, logging.NoLog{})
+			handler := NewNetworkServer(sender, trieDB, logging.NoLog{}, NetworkServerConfig{Metrics: &mockMetrics{}})
 			err := handler.HandleChangeProofRequest(context.Background(), test.nodeID, 0, test.request)
 			require.ErrorIs(err, test.expectedErr)
 			if test.expectedErr != nil {
@@ -388,7 +498,7 @@ func TestAppRequestErrAppSendFailed(t *testing.T) {
 					gomock.Any(),
 				).Return(&merkledb.ChangeProof{}, nil).Times(1)
 
-				return NewNetworkServer(sender, db, logging.NoLog{})
+				return NewNetworkServer(sender, db, logging.NoLog{}, NetworkServerConfig{Metrics: &mockMetrics{}})
 			},
 			expectedErr: errAppSendFailed,
 		},
@@ -423,7 +533,7 @@ func TestAppRequestErrAppSendFailed(t *testing.T) {
 					gomock.Any(),
 				).Return(&merkledb.RangeProof{}, nil).Times(1)
 
-				return NewNetworkServer(sender, db, logging.NoLog{})
+				return NewNetworkServer(sender, db, logging.NoLog{}, NetworkServerConfig{Metrics: &mockMetrics{}})
 			},
 			expectedErr: errAppSendFailed,
 		},
@@ -449,3 +559,164 @@ func TestAppRequestErrAppSendFailed(t *testing.T) {
 		})
 	}
 }
+
+func TestNetworkServerResponseCache(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	rootID := ids.GenerateTestID()
+	request := &pb.SyncGetRangeProofRequest{
+		RootHash:   rootID[:],
+		StartKey:   &pb.MaybeBytes{Value: []byte{1}},
+		EndKey:     &pb.MaybeBytes{Value: []byte{2}},
+		KeyLimit:   100,
+		BytesLimit: defaultRequestByteSizeLimit,
+	}
+
+	var responses [][]byte
+	sender := common.NewMockSender(ctrl)
+	sender.EXPECT().SendAppResponse(
+		gomock.Any(), // ctx
+		gomock.Any(), // nodeID
+		gomock.Any(), // requestID
+		gomock.Any(), // responseBytes
+	).DoAndReturn(
+		func(_ context.Context, _ ids.NodeID, _ uint32, responseBytes []byte) error {
+			responses = append(responses, responseBytes)
+			return nil
+		},
+	).Times(3)
+
+	// The proof is only generated once for each distinct request.
+	db := merkledb.NewMockMerkleDB(ctrl)
+	db.EXPECT().GetRangeProofAtRoot(
+		gomock.Any(),
+		rootID,
+		gomock.Any(),
+		gomock.Any(),
+		gomock.Any(),
+	).Return(&merkledb.RangeProof{
+		KeyValues: []merkledb.KeyValue{
+			{Key: []byte{1}, Value: []byte{1}},
+		},
+	}, nil).Times(2)
+
+	metrics := &mockMetrics{}
+	handler := NewNetworkServer(sender, db, logging.NoLog{}, NetworkServerConfig{
+		ResponseCacheSize: units.MiB,
+		Metrics:           metrics,
+	})
+
+	ctx := context.Background()
+	require.NoError(handler.HandleRangeProofRequest(ctx, ids.GenerateTestNodeID(), 0, proto.Clone(request).(*pb.SyncGetRangeProofRequest)))
+	require.NoError(handler.HandleRangeProofRequest(ctx, ids.GenerateTestNodeID(), 1, proto.Clone(request).(*pb.SyncGetRangeProofRequest)))
+	require.Equal(1, metrics.responseCacheHits)
+	require.Equal(1, metrics.responseCacheMisses)
+	require.Len(responses, 2)
+	require.Equal(responses[0], responses[1])
+
+	// A request with a different limit isn't served from the cache.
+	request.KeyLimit = 50
+	require.NoError(handler.HandleRangeProofRequest(ctx, ids.GenerateTestNodeID(), 2, request))
+	require.Equal(1, metrics.responseCacheHits)
+	require.Equal(2, metrics.responseCacheMisses)
+}
+
+func TestNetworkServerThrottling(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	rootID := ids.GenerateTestID()
+	requestBytes, err := proto.Marshal(&pb.Request{
+		Message: &pb.Request_RangeProofRequest{
+			RangeProofRequest: &pb.SyncGetRangeProofRequest{
+				RootHash:   rootID[:],
+				KeyLimit:   100,
+				BytesLimit: defaultRequestByteSizeLimit,
+			},
+		},
+	})
+	require.NoError(err)
+
+	sender := common.NewMockSender(ctrl)
+	sender.EXPECT().SendAppResponse(
+		gomock.Any(), // ctx
+		gomock.Any(), // nodeID
+		gomock.Any(), // requestID
+		gomock.Any(), // responseBytes
+	).Return(nil).Times(2)
+
+	db := merkledb.NewMockMerkleDB(ctrl)
+	db.EXPECT().GetRangeProofAtRoot(
+		gomock.Any(),
+		gomock.Any(),
+		gomock.Any(),
+		gomock.Any(),
+		gomock.Any(),
+	).Return(&merkledb.RangeProof{}, nil).Times(2)
+
+	metrics := &mockMetrics{}
+	handler := NewNetworkServer(sender, db, logging.NoLog{}, NetworkServerConfig{
+		Throttler: p2p.NewSlidingWindowThrottler(time.Minute, 1),
+		Metrics:   metrics,
+	})
+
+	var (
+		ctx      = context.Background()
+		deadline = time.Now().Add(10 * time.Second)
+		nodeID0  = ids.GenerateTestNodeID()
+		nodeID1  = ids.GenerateTestNodeID()
+	)
+	require.NoError(handler.AppRequest(ctx, nodeID0, 0, deadline, requestBytes))
+	require.NoError(handler.AppRequest(ctx, nodeID0, 1, deadline, requestBytes))
+	require.Equal(1, metrics.requestsThrottled)
+
+	// Each node has its own budget.
+	require.NoError(handler.AppRequest(ctx, nodeID1, 2, deadline, requestBytes))
+	require.Equal(1, metrics.requestsThrottled)
+}
+
+func TestNetworkServerZeroValueConfig(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	rootID := ids.GenerateTestID()
+	requestBytes, err := proto.Marshal(&pb.Request{
+		Message: &pb.Request_RangeProofRequest{
+			RangeProofRequest: &pb.SyncGetRangeProofRequest{
+				RootHash:   rootID[:],
+				KeyLimit:   100,
+				BytesLimit: defaultRequestByteSizeLimit,
+			},
+		},
+	})
+	require.NoError(err)
+
+	sender := common.NewMockSender(ctrl)
+	sender.EXPECT().SendAppResponse(
+		gomock.Any(), // ctx
+		gomock.Any(), // nodeID
+		gomock.Any(), // requestID
+		gomock.Any(), // responseBytes
+	).Return(nil).Times(2)
+
+	db := merkledb.NewMockMerkleDB(ctrl)
+	db.EXPECT().GetRangeProofAtRoot(
+		gomock.Any(),
+		gomock.Any(),
+		gomock.Any(),
+		gomock.Any(),
+		gomock.Any(),
+	).Return(&merkledb.RangeProof{}, nil).Times(2)
+
+	// Without a cache, throttler, or metrics every request is served.
+	handler := NewNetworkServer(sender, db, logging.NoLog{}, NetworkServerConfig{})
+
+	var (
+		ctx      = context.Background()
+		deadline = time.Now().Add(10 * time.Second)
+		nodeID   = ids.GenerateTestNodeID()
+	)
+	require.NoError(handler.AppRequest(ctx, nodeID, 0, deadline, requestBytes))
+	require.NoError(handler.AppRequest(ctx, nodeID, 1, deadline, requestBytes))
+}
@@ -4,6 +4,7 @@
 package sync
 
 import (
+	"context"
 	"math/rand"
 	"sync"
 	"time"
@@ -15,6 +16,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/utils/set"
@@ -29,21 +31,16 @@ const (
 	// peers with known good response bandwidth.
 	desiredMinResponsivePeers = 20
 	newPeerConnectFactor      = 0.1
-
-	// The probability that, when we select a peer, we select randomly rather
-	// than based on their performance.
-	randomPeerProbability = 0.2
 )
 
 // information we track on a given peer
 type peerInfo struct {
-	version   *version.Application
-	bandwidth math.Averager
+	version *version.Application
 }
 
-// Tracks the bandwidth of responses coming from peers,
-// preferring to contact peers with known good bandwidth, connecting
-// to new peers with an exponentially decaying probability.
+// Tracks the responses coming from peers, preferring to contact peers with
+// known good performance, connecting to new peers with an exponentially
+// decaying probability.
 type peerTracker struct {
 	// Lock to protect concurrent access to the peer tracker
 	lock sync.Mutex
@@ -54,8 +51,9 @@ type peerTracker struct {
 	trackedPeers set.Set[ids.NodeID]
 	// Peers that we're connected to that responded to the last request they were sent.
 	responsivePeers set.Set[ids.NodeID]
-	// Max heap that contains the average bandwidth of peers.
-	bandwidthHeap          math.AveragerHeap
+	// Samples peers weighted by the latency, failure rate and bandwidth of
+	// their responses.
+	performance            *p2p.PeerTracker
 	averageBandwidth       math.Averager
 	log                    logging.Logger
 	numTrackedPeers        prometheus.Gauge
@@ -72,7 +70,7 @@ func newPeerTracker(
 		peers:            make(map[ids.NodeID]*peerInfo),
 		trackedPeers:     make(set.Set[ids.NodeID]),
 		responsivePeers:  make(set.Set[ids.NodeID]),
-		bandwidthHeap:    math.NewMaxAveragerHeap(),
+		performance:      p2p.NewPeerTracker(),
 		averageBandwidth: math.NewAverager(0, bandwidthHalflife, time.Now()),
 		log:              log,
 		numTrackedPeers: prometheus.NewGauge(
@@ -137,9 +135,8 @@ func (p *peerTracker) shouldTrackNewPeer() bool {
 
 // Returns a peer that we're connected to.
 // If we should track more peers, returns a random peer with version >= [minVersion], if any exist.
-// Otherwise, with probability [randomPeerProbability] returns a random peer from [p.responsivePeers].
-// With probability [1-randomPeerProbability] returns the peer in [p.bandwidthHeap] with the highest bandwidth.
-func (p *peerTracker) GetAnyPeer(minVersion *version.Application) (ids.NodeID, bool) {
+// Otherwise, returns a peer sampled by [p.performance].
+func (p *peerTracker) GetAnyPeer(ctx context.Context, minVersion *version.Application) (ids.NodeID, bool) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
 
@@ -162,24 +159,14 @@ func (p *peerTracker) GetAnyPeer(minVersion *version.Application) (ids.NodeID, b
 		}
 	}
 
-	var (
-		nodeID ids.NodeID
-		ok     bool
-	)
-	useRand := rand.Float64() < randomPeerProbability // #nosec G404
-	if useRand {
-		nodeID, ok = p.responsivePeers.Peek()
-	} else {
-		nodeID, _, ok = p.bandwidthHeap.Pop()
-	}
-	if !ok {
-		// if no nodes found in the bandwidth heap, return a tracked node at random
-		return p.trackedPeers.Peek()
+	sampled := p.performance.Sample(ctx, 1)
+	if len(sampled) != 1 {
+		return ids.EmptyNodeID, false
 	}
+	nodeID := sampled[0]
 	p.log.Debug(
-		"peer tracking: popping peer",
+		"peer tracking: sampled peer",
 		zap.Stringer("nodeID", nodeID),
-		zap.Bool("random", useRand),
 	)
 	return nodeID, true
 }
@@ -193,41 +180,45 @@ func (p *peerTracker) TrackPeer(nodeID ids.NodeID) {
 	p.numTrackedPeers.Set(float64(p.trackedPeers.Len()))
 }
 
-// Record that we observed that [nodeID]'s bandwidth is [bandwidth].
-// Adds the peer's bandwidth averager to the bandwidth heap.
-func (p *peerTracker) TrackBandwidth(nodeID ids.NodeID, bandwidth float64) {
+// Record that [nodeID] responded with [responseSize] bytes [latency] after it
+// was sent a request.
+func (p *peerTracker) RegisterResponse(nodeID ids.NodeID, latency time.Duration, responseSize int) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
 
-	peer := p.peers[nodeID]
-	if peer == nil {
+	if _, ok := p.peers[nodeID]; !ok {
 		// we're not connected to this peer, nothing to do here
-		p.log.Debug("tracking bandwidth for untracked peer", zap.Stringer("nodeID", nodeID))
+		p.log.Debug("tracking response for untracked peer", zap.Stringer("nodeID", nodeID))
 		return
 	}
 
-	now := time.Now()
-	if peer.bandwidth == nil {
-		peer.bandwidth = math.NewAverager(bandwidth, bandwidthHalflife, now)
-	} else {
-		peer.bandwidth.Observe(bandwidth, now)
-	}
-	p.bandwidthHeap.Add(nodeID, peer.bandwidth)
+	p.performance.RegisterResponse(nodeID, latency, responseSize)
+	p.responsivePeers.Add(nodeID)
+	p.numResponsivePeers.Set(float64(p.responsivePeers.Len()))
 
-	if bandwidth == 0 {
-		p.responsivePeers.Remove(nodeID)
-	} else {
-		p.responsivePeers.Add(nodeID)
-		// TODO danlaine: shouldn't we add the observation of 0
-		// to the average bandwidth in the if statement?
-		p.averageBandwidth.Observe(bandwidth, now)
-		p.averageBandwidthMetric.Set(p.averageBandwidth.Read())
+	bandwidth := float64(responseSize)/latency.Seconds() + epsilon
+	p.averageBandwidth.Observe(bandwidth, time.Now())
+	p.averageBandwidthMetric.Set(p.averageBandwidth.Read())
+}
+
+// Record that [nodeID] failed to respond to a request.
+func (p *peerTracker) RegisterFailure(nodeID ids.NodeID) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if _, ok := p.peers[nodeID]; !ok {
+		// we're not connected to this peer, nothing to do here
+		p.log.Debug("tracking failure for untracked peer", zap.Stringer("nodeID", nodeID))
+		return
 	}
+
+	p.performance.RegisterFailure(nodeID)
+	p.responsivePeers.Remove(nodeID)
 	p.numResponsivePeers.Set(float64(p.responsivePeers.Len()))
 }
 
 // Connected should be called when [nodeID] connects to this node
-func (p *peerTracker) Connected(nodeID ids.NodeID, nodeVersion *version.Application) {
+func (p *peerTracker) Connected(ctx context.Context, nodeID ids.NodeID, nodeVersion *version.Application) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
 
@@ -236,6 +227,7 @@ func (p *peerTracker) Connected(nodeID ids.NodeID, nodeVersion *version.Applicat
 		p.peers[nodeID] = &peerInfo{
 			version: nodeVersion,
 		}
+		_ = p.performance.Connected(ctx, nodeID, nodeVersion)
 		return
 	}
 
@@ -244,8 +236,7 @@ func (p *peerTracker) Connected(nodeID ids.NodeID, nodeVersion *version.Applicat
 	// that we have already marked as Connected.
 	if nodeVersion.Compare(peer.version) != 0 {
 		p.peers[nodeID] = &peerInfo{
-			version:   nodeVersion,
-			bandwidth: peer.bandwidth,
+			version: nodeVersion,
 		}
 		p.log.Warn(
 			"updating node version of already connected peer",
@@ -262,11 +253,11 @@ func (p *peerTracker) Connected(nodeID ids.NodeID, nodeVersion *version.Applicat
 }
 
 // Disconnected should be called when [nodeID] disconnects from this node
-func (p *peerTracker) Disconnected(nodeID ids.NodeID) {
+func (p *peerTracker) Disconnected(ctx context.Context, nodeID ids.NodeID) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
 
-	p.bandwidthHeap.Remove(nodeID)
+	_ = p.performance.Disconnected(ctx, nodeID)
 	p.trackedPeers.Remove(nodeID)
 	p.numTrackedPeers.Set(float64(p.trackedPeers.Len()))
 	p.responsivePeers.Remove(nodeID)
@@ -0,0 +1,136 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sync
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/maybe"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+// minWorkItemLen is the minimum number of bytes a serialized work item takes:
+// the start and end maybes, the priority and the local root ID.
+const minWorkItemLen = 2*wrappers.BoolLen + wrappers.ByteLen + ids.IDLen
+
+var (
+	// progressKey is the key under which the sync progress is persisted in
+	// [ManagerConfig.ProgressDB].
+	progressKey = []byte("progress")
+
+	errInvalidProgress = errors.New("invalid sync progress")
+)
+
+// progress is a snapshot of a Manager's work that can be persisted so that
+// syncing can be resumed after a restart.
+type progress struct {
+	// The root being synced to when the snapshot was taken.
+	targetRoot ids.ID
+	// Ranges that haven't been committed to the database yet.
+	// This includes the ranges that were being processed.
+	unprocessed []*workItem
+	// Ranges that have been committed to the database.
+	processed []*workItem
+}
+
+func (p *progress) Bytes() []byte {
+	size := ids.IDLen + 2*wrappers.IntLen + workItemsLen(p.unprocessed) + workItemsLen(p.processed)
+	packer := wrappers.Packer{
+		Bytes:   make([]byte, 0, size),
+		MaxSize: size,
+	}
+	packer.PackFixedBytes(p.targetRoot[:])
+	packWorkItems(&packer, p.unprocessed)
+	packWorkItems(&packer, p.processed)
+	return packer.Bytes
+}
+
+func parseProgress(b []byte) (*progress, error) {
+	packer := wrappers.Packer{Bytes: b}
+	p := &progress{}
+	copy(p.targetRoot[:], packer.UnpackFixedBytes(ids.IDLen))
+
+	var err error
+	if p.unprocessed, err = unpackWorkItems(&packer); err != nil {
+		return nil, err
+	}
+	if p.processed, err = unpackWorkItems(&packer); err != nil {
+		return nil, err
+	}
+	if packer.Errored() {
+		return nil, packer.Err
+	}
+	if packer.Offset != len(b) {
+		return nil, errInvalidProgress
+	}
+	return p, nil
+}
+
+// workItemsLen returns the number of bytes [items] take when serialized,
+// excluding the number of items.
+func workItemsLen(items []*workItem) int {
+	size := len(items) * minWorkItemLen
+	for _, item := range items {
+		if item.start.HasValue() {
+			size += wrappers.IntLen + len(item.start.Value())
+		}
+		if item.end.HasValue() {
+			size += wrappers.IntLen + len(item.end.Value())
+		}
+	}
+	return size
+}
+
+func packWorkItems(packer *wrappers.Packer, items []*workItem) {
+	packer.PackInt(uint32(len(items)))
+	for _, item := range items {
+		packMaybeBytes(packer, item.start)
+		packMaybeBytes(packer, item.end)
+		packer.PackByte(byte(item.priority))
+		packer.PackFixedBytes(item.localRootID[:])
+	}
+}
+
+func unpackWorkItems(packer *wrappers.Packer) ([]*workItem, error) {
+	numItems := int(packer.UnpackInt())
+	if packer.Errored() {
+		return nil, packer.Err
+	}
+	// Don't allocate more items than could possibly be encoded.
+	if numItems > (len(packer.Bytes)-packer.Offset)/minWorkItemLen {
+		return nil, errInvalidProgress
+	}
+
+	items := make([]*workItem, numItems)
+	for i := range items {
+		start := unpackMaybeBytes(packer)
+		end := unpackMaybeBytes(packer)
+		priority := priority(packer.UnpackByte())
+		var localRootID ids.ID
+		copy(localRootID[:], packer.UnpackFixedBytes(ids.IDLen))
+		if packer.Errored() {
+			return nil, packer.Err
+		}
+		if priority < lowPriority || priority > highPriority {
+			return nil, errInvalidProgress
+		}
+		items[i] = newWorkItem(localRootID, start, end, priority)
+	}
+	return items, nil
+}
+
+func packMaybeBytes(packer *wrappers.Packer, m maybe.Maybe[[]byte]) {
+	packer.PackBool(m.HasValue())
+	if m.HasValue() {
+		packer.PackBytes(m.Value())
+	}
+}
+
+func unpackMaybeBytes(packer *wrappers.Packer) maybe.Maybe[[]byte] {
+	if !packer.UnpackBool() {
+		return maybe.Nothing[[]byte]()
+	}
+	return maybe.Some(packer.UnpackBytes())
+}
@@ -0,0 +1,73 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sync
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/maybe"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+func TestProgressBytes(t *testing.T) {
+	require := require.New(t)
+
+	expected := &progress{
+		targetRoot: ids.GenerateTestID(),
+		unprocessed: []*workItem{
+			newWorkItem(ids.Empty, maybe.Nothing[[]byte](), maybe.Some([]byte{1}), lowPriority),
+			newWorkItem(ids.GenerateTestID(), maybe.Some([]byte{}), maybe.Nothing[[]byte](), highPriority),
+		},
+		processed: []*workItem{
+			newWorkItem(ids.GenerateTestID(), maybe.Some([]byte{1}), maybe.Some([]byte{2, 3}), medPriority),
+		},
+	}
+
+	progressBytes := expected.Bytes()
+	p, err := parseProgress(progressBytes)
+	require.NoError(err)
+	require.Equal(expected.targetRoot, p.targetRoot)
+	require.Len(p.unprocessed, len(expected.unprocessed))
+	for i, item := range expected.unprocessed {
+		requireWorkItemEqual(t, item, p.unprocessed[i])
+	}
+	require.Len(p.processed, len(expected.processed))
+	for i, item := range expected.processed {
+		requireWorkItemEqual(t, item, p.processed[i])
+	}
+
+	// Trailing bytes are invalid.
+	_, err = parseProgress(append(progressBytes, 0))
+	require.ErrorIs(err, errInvalidProgress)
+
+	// Truncated progress is invalid.
+	_, err = parseProgress(progressBytes[:len(progressBytes)-1])
+	require.ErrorIs(err, wrappers.ErrInsufficientLength)
+}
+
+func TestParseProgressInvalidPriority(t *testing.T) {
+	require := require.New(t)
+
+	p := &progress{
+		unprocessed: []*workItem{
+			newWorkItem(ids.Empty, maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), highPriority+1),
+		},
+	}
+	_, err := parseProgress(p.Bytes())
+	require.ErrorIs(err, errInvalidProgress)
+}
+
+func requireWorkItemEqual(t *testing.T, expected, actual *workItem) {
+	require := require.New(t)
+
+	require.Equal(expected.localRootID, actual.localRootID)
+	require.Equal(expected.priority, actual.priority)
+	require.Equal(expected.start.HasValue(), actual.start.HasValue())
+	require.Equal(expected.start.Value(), actual.start.Value())
+	require.Equal(expected.end.HasValue(), actual.end.HasValue())
+	require.Equal(expected.end.Value(), actual.end.Value())
+}
@@ -6,6 +6,7 @@ package sync
 import (
 	"bytes"
 	"context"
+	"errors"
 	"math/rand"
 	"testing"
 	"time"
@@ -910,6 +911,101 @@ func Test_Sync_Result_Correct_Root_With_Sync_Restart(t *testing.T) {
 	require.Equal(syncRoot, newRoot)
 }
 
+func Test_Sync_Resume_From_Persisted_Progress(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	now := time.Now().UnixNano()
+	t.Logf("seed: %d", now)
+	r := rand.New(rand.NewSource(now)) // #nosec G404
+	dbToSync, err := generateTrie(t, r, 3*maxKeyValuesLimit)
+	require.NoError(err)
+	syncRoot, err := dbToSync.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	db, err := merkledb.New(
+		context.Background(),
+		memdb.New(),
+		newDefaultDBConfig(),
+	)
+	require.NoError(err)
+
+	progressDB := memdb.New()
+	syncer, err := NewManager(ManagerConfig{
+		DB:                    db,
+		Client:                newCallthroughSyncClient(ctrl, dbToSync),
+		TargetRoot:            syncRoot,
+		SimultaneousWorkLimit: 5,
+		Log:                   logging.NoLog{},
+		BranchFactor:          merkledb.BranchFactor16,
+		ProgressDB:            progressDB,
+	})
+	require.NoError(err)
+	require.NoError(syncer.Start(context.Background()))
+
+	// Wait until we've processed some work before stopping.
+	require.Eventually(
+		func() bool {
+			syncer.workLock.Lock()
+			defer syncer.workLock.Unlock()
+
+			return syncer.processedWork.Len() > 0
+		},
+		5*time.Second,
+		5*time.Millisecond,
+	)
+	syncer.Close()
+
+	progressBytes, err := progressDB.Get(progressKey)
+	require.NoError(err)
+	p, err := parseProgress(progressBytes)
+	require.NoError(err)
+	require.Equal(syncRoot, p.targetRoot)
+	require.NotEmpty(p.processed)
+
+	// Ranges that were already committed must not be downloaded again.
+	errRedownloadedRange := errors.New("redownloaded a committed range")
+	client := NewMockClient(ctrl)
+	client.EXPECT().GetRangeProof(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, request *pb.SyncGetRangeProofRequest) (*merkledb.RangeProof, error) {
+			start := maybeBytesToMaybe(request.StartKey)
+			for _, item := range p.processed {
+				afterStart := item.start.IsNothing() ||
+					(start.HasValue() && bytes.Compare(item.start.Value(), start.Value()) <= 0)
+				beforeEnd := item.end.IsNothing() ||
+					start.IsNothing() ||
+					bytes.Compare(start.Value(), item.end.Value()) < 0
+				if afterStart && beforeEnd {
+					return nil, errRedownloadedRange
+				}
+			}
+			return dbToSync.GetRangeProof(ctx, start, maybeBytesToMaybe(request.EndKey), int(request.KeyLimit))
+		},
+	).AnyTimes()
+
+	newSyncer, err := NewManager(ManagerConfig{
+		DB:                    db,
+		Client:                client,
+		TargetRoot:            syncRoot,
+		SimultaneousWorkLimit: 5,
+		Log:                   logging.NoLog{},
+		BranchFactor:          merkledb.BranchFactor16,
+		ProgressDB:            progressDB,
+	})
+	require.NoError(err)
+	require.NoError(newSyncer.Start(context.Background()))
+	require.NoError(newSyncer.Wait(context.Background()))
+
+	newRoot, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+	require.Equal(syncRoot, newRoot)
+
+	// The progress is deleted once syncing completes.
+	has, err := progressDB.Has(progressKey)
+	require.NoError(err)
+	require.False(has)
+}
+
 func Test_Sync_Error_During_Sync(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
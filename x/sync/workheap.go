@@ -166,6 +166,16 @@ func (wh *workHeap) remove(item *heapItem) {
 	wh.sortedItems.Delete(item)
 }
 
+// Items returns the work items in the heap sorted by range start.
+func (wh *workHeap) Items() []*workItem {
+	items := make([]*workItem, 0, wh.Len())
+	wh.sortedItems.Ascend(func(item *heapItem) bool {
+		items = append(items, item.workItem)
+		return true
+	})
+	return items
+}
+
 func (wh *workHeap) Len() int {
 	return wh.innerHeap.Len()
 }